@@ -83,6 +83,12 @@ func toAdminAPIErr(ctx context.Context, err error) APIError {
 			Description:    e.Message,
 			HTTPStatusCode: e.StatusCode,
 		}
+	case decomError:
+		apiErr = APIError{
+			Code:           "XMinioDecommissionFailed",
+			Description:    e.Err,
+			HTTPStatusCode: http.StatusBadRequest,
+		}
 	default:
 		switch {
 		case errors.Is(err, errConfigNotFound):
@@ -116,6 +122,51 @@ func toAdminAPIErr(ctx context.Context, err error) APIError {
 				HTTPStatusCode: http.StatusConflict,
 			}
 
+		case errors.Is(err, errDecommissionAlreadyRunning):
+			apiErr = APIError{
+				Code:           "XMinioDecommissionNotAllowed",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case errors.Is(err, errDecommissionComplete):
+			apiErr = APIError{
+				Code:           "XMinioDecommissionNotAllowed",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case errors.Is(err, errDecommissionLastPool):
+			apiErr = APIError{
+				Code:           "XMinioDecommissionNotAllowed",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case errors.Is(err, errDecommissionNotStarted):
+			apiErr = APIError{
+				Code:           "XMinioDecommissionNotStarted",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case errors.Is(err, errDecommissionRebalanceAlreadyRunning):
+			apiErr = APIError{
+				Code:           "XMinioDecommissionNotAllowed",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case errors.Is(err, errRebalanceAlreadyRunning),
+			errors.Is(err, errRebalanceDecommissionAlreadyRunning),
+			errors.Is(err, errRebalanceNotNeeded):
+			apiErr = APIError{
+				Code:           "XMinioRebalanceNotAllowed",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case errors.Is(err, errRebalanceNotStarted):
+			apiErr = APIError{
+				Code:           "XMinioRebalanceNotStarted",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusNotFound,
+			}
+
 		// Tier admin API errors
 		case errors.Is(err, madmin.ErrTierNameEmpty):
 			apiErr = APIError{
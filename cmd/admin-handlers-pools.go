@@ -0,0 +1,365 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// poolsFromRequest validates the admin request and returns the
+// erasure server pools along with the index of the requested pool.
+func poolsFromRequest(w http.ResponseWriter, r *http.Request, action iampolicy.AdminAction, needPool bool) (*erasureServerPools, int) {
+	ctx := r.Context()
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, action)
+	if objectAPI == nil {
+		return nil, -1
+	}
+
+	// Legalize this API only in erasure coded multi-pool setups.
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil, -1
+	}
+
+	if !needPool {
+		return pools, -1
+	}
+
+	v := mux.Vars(r)["pool"]
+	idx := pools.GetPoolIdx(v)
+	if idx == -1 {
+		// We didn't find any matching pools, invalid input
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errInvalidArgument), r.URL)
+		return nil, -1
+	}
+	return pools, idx
+}
+
+// StartDecommission - POST /minio/admin/v3/pools/decommission?pool=http://server{1...4}/disk{1...4}
+// ----------
+// Suspends writes on the given pool and starts moving all of its
+// data to the remaining pools.
+func (a adminAPIHandlers) StartDecommission(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartDecommission")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	pools, idx := poolsFromRequest(w, r, iampolicy.DecommissionAdminAction, true)
+	if pools == nil {
+		return
+	}
+
+	if pools.SinglePool() {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	if err := pools.Decommission(r.Context(), idx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// CancelDecommission - POST /minio/admin/v3/pools/cancel?pool=http://server{1...4}/disk{1...4}
+// ----------
+// Cancels an on-going decommission, the pool accepts writes again.
+func (a adminAPIHandlers) CancelDecommission(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelDecommission")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	pools, idx := poolsFromRequest(w, r, iampolicy.DecommissionAdminAction, true)
+	if pools == nil {
+		return
+	}
+
+	if err := pools.DecommissionCancel(ctx, idx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// StatusPool - GET /minio/admin/v3/pools/status?pool=http://server{1...4}/disk{1...4}
+// ----------
+// Returns the current status of a pool, including any decommission progress.
+func (a adminAPIHandlers) StatusPool(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StatusPool")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	pools, idx := poolsFromRequest(w, r, iampolicy.ServerInfoAdminAction, true)
+	if pools == nil {
+		return
+	}
+
+	status, err := pools.Status(r.Context(), idx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	logger.LogIf(r.Context(), json.NewEncoder(w).Encode(&status))
+}
+
+// ListPools - GET /minio/admin/v3/pools/list
+// ----------
+// Returns the status of all the pools of this deployment.
+func (a adminAPIHandlers) ListPools(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListPools")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	pools, _ := poolsFromRequest(w, r, iampolicy.ServerInfoAdminAction, false)
+	if pools == nil {
+		return
+	}
+
+	poolsStatus := make([]PoolStatus, len(pools.serverPools))
+	for idx := range pools.serverPools {
+		status, err := pools.Status(r.Context(), idx)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, fmt.Errorf("unable to fetch status of pool %d: %w", idx+1, err)), r.URL)
+			return
+		}
+		poolsStatus[idx] = status
+	}
+
+	logger.LogIf(r.Context(), json.NewEncoder(w).Encode(poolsStatus))
+}
+
+// RebalanceStart - POST /minio/admin/v3/rebalance/start?band=5
+// ----------
+// Starts moving data from the pools with the least free space to the
+// remaining pools, until the free space of every pool is within 'band'
+// percent of the free space of the whole deployment.
+func (a adminAPIHandlers) RebalanceStart(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStart")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	pools, _ := poolsFromRequest(w, r, iampolicy.DecommissionAdminAction, false)
+	if pools == nil {
+		return
+	}
+
+	if pools.SinglePool() {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	band := defaultRebalanceBand
+	if v := r.URL.Query().Get("band"); v != "" {
+		percent, err := strconv.ParseFloat(v, 64)
+		if err != nil || percent <= 0 || percent >= 100 {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errInvalidArgument), r.URL)
+			return
+		}
+		band = percent / 100
+	}
+
+	id, err := pools.initRebalanceMeta(ctx, band)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify the peers, the rebalance is run by the
+	// first node of the first pool.
+	globalNotificationSys.LoadRebalanceMeta(ctx, true)
+	pools.resumeRebalance()
+
+	b, err := json.Marshal(struct {
+		ID string `json:"id"`
+	}{ID: id})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, b)
+}
+
+// RebalanceStatus - GET /minio/admin/v3/rebalance/status
+// ----------
+// Returns the progress of the ongoing or the last rebalance.
+func (a adminAPIHandlers) RebalanceStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	pools, _ := poolsFromRequest(w, r, iampolicy.ServerInfoAdminAction, false)
+	if pools == nil {
+		return
+	}
+
+	rs, err := rebalanceStatus(ctx, pools)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	logger.LogIf(ctx, json.NewEncoder(w).Encode(rs))
+}
+
+// RebalanceStop - POST /minio/admin/v3/rebalance/stop
+// ----------
+// Stops the ongoing rebalance, the data moved so far stays on the
+// pools it was moved to.
+func (a adminAPIHandlers) RebalanceStop(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStop")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	pools, _ := poolsFromRequest(w, r, iampolicy.DecommissionAdminAction, false)
+	if pools == nil {
+		return
+	}
+
+	// Stop the rebalance routines on all the nodes
+	// before marking the rebalance as stopped.
+	globalNotificationSys.StopRebalance(ctx)
+	logger.LogIf(ctx, pools.StopRebalance())
+
+	if err := pools.markRebalanceStopped(ctx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	globalNotificationSys.LoadRebalanceMeta(ctx, false)
+}
+
+// rebalPoolProgress contains the rebalance progress of a pool.
+type rebalPoolProgress struct {
+	NumObjects  uint64        `json:"objects"`
+	NumVersions uint64        `json:"versions"`
+	NumFailed   uint64        `json:"failed"`
+	Bytes       uint64        `json:"bytes"`
+	Bucket      string        `json:"bucket"`
+	Object      string        `json:"object"`
+	Elapsed     time.Duration `json:"elapsed"`
+	ETA         time.Duration `json:"eta"`
+}
+
+// rebalancePoolStatus contains the rebalance status of a pool.
+type rebalancePoolStatus struct {
+	ID       int               `json:"id"`      // Pool index (zero-based)
+	CmdLine  string            `json:"cmdline"` // Pool arguments as provided on the command line
+	Status   string            `json:"status"`  // Active if rebalance is running, empty otherwise
+	Used     float64           `json:"used"`    // Percentage used space
+	Progress rebalPoolProgress `json:"progress,omitempty"`
+}
+
+// rebalanceAdminStatus holds rebalance status related information exported
+// to the admin API.
+type rebalanceAdminStatus struct {
+	ID              string                `json:"id"` // identifies the ongoing rebalance operation by a uuid
+	PercentFreeGoal float64               `json:"percentFreeGoal"`
+	Band            float64               `json:"band"`
+	Pools           []rebalancePoolStatus `json:"pools"` // contains all pools, including inactive
+	StoppedAt       time.Time             `json:"stoppedAt,omitempty"`
+}
+
+// rebalanceStatus returns the status of the ongoing or the last rebalance.
+func rebalanceStatus(ctx context.Context, z *erasureServerPools) (r rebalanceAdminStatus, err error) {
+	z.rebalMu.RLock()
+	running := z.rebalMeta != nil && z.rebalMeta.cancel != nil
+	z.rebalMu.RUnlock()
+
+	// Only the node running the rebalance has the latest
+	// progress in memory, others read it from the drives.
+	if !running {
+		if err = z.loadRebalanceMeta(ctx); err != nil {
+			return r, err
+		}
+	}
+
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+
+	meta := z.rebalMeta
+	if meta == nil {
+		return r, errRebalanceNotStarted
+	}
+
+	r = rebalanceAdminStatus{
+		ID:              meta.ID,
+		PercentFreeGoal: meta.PercentFreeGoal,
+		Band:            meta.Band,
+		StoppedAt:       meta.StoppedAt,
+		Pools:           make([]rebalancePoolStatus, len(meta.PoolStats)),
+	}
+
+	now := UTCNow()
+	for idx, ps := range meta.PoolStats {
+		used := 1.0
+		if ps.InitCapacity > 0 {
+			used = float64(ps.InitCapacity-ps.InitFreeSpace) / float64(ps.InitCapacity)
+		}
+		if pi, err := z.getDecommissionPoolSpaceInfo(idx); err == nil && pi.Total > 0 {
+			used = float64(pi.Used) / float64(pi.Total)
+		}
+
+		r.Pools[idx] = rebalancePoolStatus{
+			ID:      idx,
+			CmdLine: z.serverPools[idx].cmdLine,
+			Status:  ps.Info.Status.String(),
+			Used:    used,
+		}
+		if !ps.Participating {
+			continue
+		}
+
+		elapsed := now.Sub(ps.Info.StartTime)
+		if !ps.Info.EndTime.IsZero() {
+			elapsed = ps.Info.EndTime.Sub(ps.Info.StartTime)
+		}
+
+		// Estimate the remaining time from the number of bytes
+		// to be moved for the pool to reach the free space goal.
+		var eta time.Duration
+		if ps.Info.Status == rebalStarted && ps.Bytes > 0 {
+			toMove := (meta.PercentFreeGoal-meta.Band)*float64(ps.InitCapacity) - float64(ps.InitFreeSpace)
+			if remaining := toMove - float64(ps.Bytes); remaining > 0 {
+				eta = time.Duration(remaining / float64(ps.Bytes) * float64(elapsed))
+			}
+		}
+
+		r.Pools[idx].Progress = rebalPoolProgress{
+			NumObjects:  ps.NumObjects,
+			NumVersions: ps.NumVersions,
+			NumFailed:   ps.NumFailed,
+			Bytes:       ps.Bytes,
+			Bucket:      ps.Bucket,
+			Object:      ps.Object,
+			Elapsed:     elapsed,
+			ETA:         eta,
+		}
+	}
+	return r, nil
+}
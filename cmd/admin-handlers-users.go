@@ -25,7 +25,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"sort"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/auth"
@@ -1064,6 +1066,105 @@ func (a adminAPIHandlers) DeleteServiceAccount(w http.ResponseWriter, r *http.Re
 	writeSuccessNoContent(w)
 }
 
+// scimTokenResponse is the response of SCIMTokenHandler.
+type scimTokenResponse struct {
+	ID         string    `json:"id"`
+	Token      string    `json:"token"`
+	Expiration time.Time `json:"expiration"`
+}
+
+// SCIMTokenHandler - POST /minio/admin/v3/scim/token?expiry={duration}
+//
+// Issues a bearer token for the SCIM provisioning API on behalf of the
+// requesting user. The SCIM API grants the same admin actions as the
+// user's policies, the token is revoked with SCIMRevokeTokenHandler
+// by its ID, or by changing the secret key.
+func (a adminAPIHandlers) SCIMTokenHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMToken")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.CreateUserAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if globalIAMSys.usersSysType != MinIOUsersSysType {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Tokens are signed with the secret key of a long-term user only.
+	if cred.IsTemp() || cred.IsServiceAccount() {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+		return
+	}
+
+	expiry := defaultSCIMJWTExpiry
+	if v := r.URL.Query().Get("expiry"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 || d > maxSCIMJWTExpiry {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		expiry = d
+	}
+
+	tokenID := mustGetUUID()
+	expiration := UTCNow().Add(expiry)
+	token, err := authenticateSCIM(cred.AccessKey, cred.SecretKey, tokenID, expiration)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(scimTokenResponse{
+		ID:         tokenID,
+		Token:      token,
+		Expiration: expiration,
+	})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	encryptedData, err := madmin.EncryptData(cred.SecretKey, data)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, encryptedData)
+}
+
+// SCIMRevokeTokenHandler - DELETE /minio/admin/v3/scim/token?id={id}
+//
+// Revokes the SCIM bearer token with the given ID, requests with the
+// token are rejected on every server from now on.
+func (a adminAPIHandlers) SCIMRevokeTokenHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMRevokeToken")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.CreateUserAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	tokenID := r.URL.Query().Get("id")
+	if _, err := uuid.Parse(tokenID); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	if err := revokeSCIMToken(ctx, objectAPI, tokenID); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
+
 // AccountInfoHandler returns usage
 func (a adminAPIHandlers) AccountInfoHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "AccountInfo")
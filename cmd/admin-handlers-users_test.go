@@ -18,8 +18,12 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
@@ -138,8 +142,11 @@ func runAllIAMTests(suite *TestSuiteIAM, c *check) {
 	suite.TestUserCreate(c)
 	suite.TestPolicyCreate(c)
 	suite.TestCannedPolicies(c)
+	suite.TestPolicyMultiMapping(c)
 	suite.TestGroupAddRemove(c)
 	suite.TestServiceAccountOps(c)
+	suite.TestBucketLoggingTarget(c)
+	suite.TestSCIMProvisioning(c)
 	suite.TearDownSuite(c)
 }
 
@@ -400,6 +407,62 @@ func (s *TestSuiteIAM) TestCannedPolicies(c *check) {
 
 }
 
+func (s *TestSuiteIAM) TestPolicyMultiMapping(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err := s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+
+	// 1. Associate multiple policies to a user and a group.
+	policies := "readonly,diagnostics"
+	err = s.adm.SetPolicy(ctx, policies, accessKey, false)
+	if err != nil {
+		c.Fatalf("Unable to set policies: %v", err)
+	}
+	uinfo, err := s.adm.GetUserInfo(ctx, accessKey)
+	if err != nil {
+		c.Fatalf("Unable to get user info: %v", err)
+	}
+	if uinfo.PolicyName != policies {
+		c.Fatalf("expected policies %s, got %s", policies, uinfo.PolicyName)
+	}
+
+	group := "multipolicygroup"
+	err = s.adm.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
+		Group:   group,
+		Members: []string{accessKey},
+	})
+	if err != nil {
+		c.Fatalf("Unable to add user to group: %v", err)
+	}
+	err = s.adm.SetPolicy(ctx, policies, group, true)
+	if err != nil {
+		c.Fatalf("Unable to set group policies: %v", err)
+	}
+	gdesc, err := s.adm.GetGroupDescription(ctx, group)
+	if err != nil {
+		c.Fatalf("Unable to get group: %v", err)
+	}
+	if gdesc.Policy != policies {
+		c.Fatalf("expected group policies %s, got %s", policies, gdesc.Policy)
+	}
+
+	// 2. Check that every policy of the mapping must exist.
+	err = s.adm.SetPolicy(ctx, "readonly,nosuchpolicy", accessKey, false)
+	if err == nil {
+		c.Fatalf("mapping of a missing policy was accepted")
+	}
+
+	err = s.adm.RemoveUser(ctx, accessKey)
+	if err != nil {
+		c.Fatalf("user could not be deleted: %v", err)
+	}
+}
+
 func (s *TestSuiteIAM) TestGroupAddRemove(c *check) {
 	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
 	defer cancel()
@@ -750,6 +813,174 @@ func (s *TestSuiteIAM) TestServiceAccountOps(c *check) {
 	}
 }
 
+func (s *TestSuiteIAM) scimRequest(c *check, token, method, path string, body interface{}, expectStatus int, v interface{}) {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			c.Fatalf("unable to marshal SCIM request: %v", err)
+		}
+	}
+	req, err := http.NewRequest(method, s.endPoint+scimPathPrefix+path, bytes.NewReader(data))
+	if err != nil {
+		c.Fatalf("unable to create SCIM request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", string(mimeSCIM))
+	resp, err := s.TestSuiteCommon.client.Do(req)
+	if err != nil {
+		c.Fatalf("SCIM request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != expectStatus {
+		c.Fatalf("%s %s: expected status %d, got %d", method, path, expectStatus, resp.StatusCode)
+	}
+	if v != nil {
+		if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+			c.Fatalf("unable to decode SCIM response: %v", err)
+		}
+	}
+}
+
+func (s *TestSuiteIAM) TestSCIMProvisioning(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	req, err := newTestSignedRequestV4(http.MethodPost, s.endPoint+adminPathPrefix+adminAPIVersionPrefix+"/scim/token?expiry=1h",
+		0, nil, s.accessKey, s.secretKey, nil)
+	if err != nil {
+		c.Fatalf("unable to create SCIM token request: %v", err)
+	}
+	resp, err := s.TestSuiteCommon.client.Do(req)
+	if err != nil {
+		c.Fatalf("SCIM token request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		c.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	data, err := madmin.DecryptData(s.secretKey, resp.Body)
+	if err != nil {
+		c.Fatalf("unable to decrypt SCIM token: %v", err)
+	}
+	var tokenResp scimTokenResponse
+	if err = json.Unmarshal(data, &tokenResp); err != nil {
+		c.Fatalf("unable to decode SCIM token: %v", err)
+	}
+	token := tokenResp.Token
+
+	// 1. Requests without a valid token are rejected.
+	s.scimRequest(c, "invalid", http.MethodGet, "/Users", nil, http.StatusUnauthorized, nil)
+
+	// 2. Provision a disabled user with a policy.
+	accessKey, secretKey := mustGenerateCredentials(c)
+	inactive := false
+	var user scimUser
+	s.scimRequest(c, token, http.MethodPost, "/Users", scimUser{
+		UserName: accessKey,
+		Password: secretKey,
+		Active:   &inactive,
+		Roles:    newSCIMValues([]string{"readonly"}),
+	}, http.StatusCreated, &user)
+	if user.ID != accessKey {
+		c.Fatalf("expected user %s, got %s", accessKey, user.ID)
+	}
+	s.scimRequest(c, token, http.MethodPost, "/Users", scimUser{UserName: accessKey}, http.StatusConflict, nil)
+
+	info, err := s.adm.GetUserInfo(ctx, accessKey)
+	if err != nil {
+		c.Fatalf("unable to get user info: %v", err)
+	}
+	if info.Status != madmin.AccountDisabled || info.PolicyName != "readonly" {
+		c.Fatalf("unexpected user info: %#v", info)
+	}
+
+	// 3. Enable the user and add a policy with a patch.
+	s.scimRequest(c, token, http.MethodPatch, "/Users/"+accessKey, scimPatchRequest{
+		Schemas: []string{scimPatchOpSchema},
+		Operations: []scimPatchOperation{
+			{Op: "replace", Path: "active", Value: json.RawMessage(`true`)},
+			{Op: "add", Path: "roles", Value: json.RawMessage(`[{"value":"diagnostics"}]`)},
+		},
+	}, http.StatusOK, &user)
+	if user.Active == nil || !*user.Active || len(user.Roles) != 2 {
+		c.Fatalf("unexpected user: %#v", user)
+	}
+
+	var list scimListResponse
+	s.scimRequest(c, token, http.MethodGet, "/Users?filter="+url.QueryEscape(fmt.Sprintf("userName eq %q", accessKey)), nil, http.StatusOK, &list)
+	if list.TotalResults != 1 {
+		c.Fatalf("expected a single user, got %d", list.TotalResults)
+	}
+
+	// 3.1 Access keys of other identities can not be provisioned.
+	svcCred, err := s.adm.AddServiceAccount(ctx, madmin.AddServiceAccountReq{TargetUser: accessKey})
+	if err != nil {
+		c.Fatalf("unable to create service account: %v", err)
+	}
+	s.scimRequest(c, token, http.MethodPost, "/Users", scimUser{UserName: svcCred.AccessKey}, http.StatusConflict, nil)
+	s.scimRequest(c, token, http.MethodPost, "/Users", scimUser{UserName: s.accessKey}, http.StatusConflict, nil)
+	svcInfo, err := s.adm.InfoServiceAccount(ctx, svcCred.AccessKey)
+	if err != nil {
+		c.Fatalf("service account was replaced: %v", err)
+	}
+	if svcInfo.ParentUser != accessKey {
+		c.Fatalf("expected service account of %s, got %s", accessKey, svcInfo.ParentUser)
+	}
+
+	// 4. Provision a group with the user as member.
+	group := "scimgroup"
+	var g scimGroup
+	s.scimRequest(c, token, http.MethodPost, "/Groups", scimGroup{
+		DisplayName: group,
+		Members:     newSCIMValues([]string{accessKey}),
+		Policy:      &scimGroupPolicy{Policies: []string{"readwrite"}},
+	}, http.StatusCreated, &g)
+
+	desc, err := s.adm.GetGroupDescription(ctx, group)
+	if err != nil {
+		c.Fatalf("unable to get group: %v", err)
+	}
+	if len(desc.Members) != 1 || desc.Members[0] != accessKey || desc.Policy != "readwrite" {
+		c.Fatalf("unexpected group: %#v", desc)
+	}
+
+	// 5. Remove the member and delete the group and the user.
+	g = scimGroup{}
+	s.scimRequest(c, token, http.MethodPatch, "/Groups/"+group, scimPatchRequest{
+		Schemas: []string{scimPatchOpSchema},
+		Operations: []scimPatchOperation{
+			{Op: "remove", Path: fmt.Sprintf("members[value eq %q]", accessKey)},
+		},
+	}, http.StatusOK, &g)
+	if len(g.Members) != 0 {
+		c.Fatalf("expected no members, got %v", g.Members)
+	}
+	s.scimRequest(c, token, http.MethodDelete, "/Groups/"+group, nil, http.StatusNoContent, nil)
+	s.scimRequest(c, token, http.MethodGet, "/Groups/"+group, nil, http.StatusNotFound, nil)
+
+	s.scimRequest(c, token, http.MethodDelete, "/Users/"+accessKey, nil, http.StatusNoContent, nil)
+	if _, err = s.adm.GetUserInfo(ctx, accessKey); err == nil {
+		c.Fatalf("user %s was not deleted", accessKey)
+	}
+
+	// 6. A revoked token is rejected.
+	req, err = newTestSignedRequestV4(http.MethodDelete, s.endPoint+adminPathPrefix+adminAPIVersionPrefix+"/scim/token?id="+tokenResp.ID,
+		0, nil, s.accessKey, s.secretKey, nil)
+	if err != nil {
+		c.Fatalf("unable to create SCIM token revoke request: %v", err)
+	}
+	revokeResp, err := s.TestSuiteCommon.client.Do(req)
+	if err != nil {
+		c.Fatalf("SCIM token revoke request failed: %v", err)
+	}
+	revokeResp.Body.Close()
+	if revokeResp.StatusCode != http.StatusNoContent {
+		c.Fatalf("expected status %d, got %d", http.StatusNoContent, revokeResp.StatusCode)
+	}
+	s.scimRequest(c, token, http.MethodGet, "/Users", nil, http.StatusUnauthorized, nil)
+}
+
 func (c *check) mustNotListObjects(ctx context.Context, client *minio.Client, bucket string) {
 	res := client.ListObjects(ctx, bucket, minio.ListObjectsOptions{})
 	v, ok := <-res
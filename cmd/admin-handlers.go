@@ -19,10 +19,13 @@ package cmd
 
 import (
 	"context"
+	"crypto/ed25519"
 	crand "crypto/rand"
 	"crypto/subtle"
 	"crypto/tls"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/crc32"
 	"io"
@@ -43,10 +46,12 @@ import (
 	"github.com/klauspost/compress/zip"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/dsync"
+	"github.com/minio/minio/internal/event/journal"
 	"github.com/minio/minio/internal/handlers"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/logger/message/audit"
 	"github.com/minio/minio/internal/logger/message/log"
 	iampolicy "github.com/minio/pkg/iam/policy"
 	xnet "github.com/minio/pkg/net"
@@ -1213,6 +1218,129 @@ func (a adminAPIHandlers) ConsoleLogHandler(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// AuditVerifyHandler - POST /minio/admin/v3/audit/verify
+// ----------
+// Verifies the hash chains of the audit entries in the request body, the
+// body is a stream of JSON encoded audit entries as stored by an audit
+// target. Signatures of earlier signing keys are verified with the base64
+// encoded public keys given as publicKey query parameters. Responds with a report of gaps, duplicates, modified entries
+// and invalid signatures found per chain.
+func (a adminAPIHandlers) AuditVerifyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AuditVerify")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Entries signed with earlier keys are verified with the public
+	// keys given in the request, along with the current key.
+	var pubs []ed25519.PublicKey
+	if globalAuditChainKey != nil {
+		pubs = append(pubs, globalAuditChainKey.Public().(ed25519.PublicKey))
+	}
+	for _, v := range r.URL.Query()["publicKey"] {
+		pub, err := base64.StdEncoding.DecodeString(v)
+		if err != nil || len(pub) != ed25519.PublicKeySize {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		pubs = append(pubs, ed25519.PublicKey(pub))
+	}
+	if len(pubs) == 0 {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errors.New("audit chain signing key is not available")), r.URL)
+		return
+	}
+
+	report, err := audit.VerifyStream(r.Body, pubs...)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, reportJSON)
+}
+
+// EventHistoryHandler - GET /minio/admin/v3/events/history?bucket={bucket}&seq={seq}&node={node}&since={time}&limit={limit}
+// ----------
+// Pages through the event journal of a bucket, returns up to limit
+// events after the given position or starting at the given RFC3339
+// time. The returned nextSeq and nextNode are passed as seq and node
+// to read the next page.
+func (a adminAPIHandlers) EventHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "EventHistory")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TraceAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := r.Form.Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if globalEventJournalSys == nil || !globalEventJournalSys.Enabled(bucket) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest,
+			errors.New("event journal is not enabled for this bucket")), r.URL)
+		return
+	}
+
+	from, _, err := parseEventJournalPosition(r.Form)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+
+	limit := 100
+	if v := r.Form.Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > maxObjectList {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+
+	records, err := globalEventJournalSys.Read(ctx, objectAPI, bucket, from, limit)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	history := struct {
+		Records  []journal.Record `json:"records"`
+		NextSeq  uint64           `json:"nextSeq"`
+		NextNode string           `json:"nextNode,omitempty"`
+	}{
+		Records:  records,
+		NextSeq:  from.Seq,
+		NextNode: from.Node,
+	}
+	if len(records) > 0 {
+		history.NextSeq = records[len(records)-1].Seq
+		history.NextNode = records[len(records)-1].Node
+	} else if from.Node == "" && from.Seq > 0 {
+		// Without a node the position is before the sequence number.
+		history.NextSeq = from.Seq - 1
+	}
+
+	historyJSON, err := json.Marshal(history)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, historyJSON)
+}
+
 // KMSCreateKeyHandler - POST /minio/admin/v3/kms/key/create?key-id=<master-key-id>
 func (a adminAPIHandlers) KMSCreateKeyHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "KMSCreateKey")
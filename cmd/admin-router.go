@@ -80,6 +80,22 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 
 			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/background-heal/status").HandlerFunc(gz(httpTraceAll(adminAPI.BackgroundHealStatusHandler)))
 
+			/// Pool operations
+
+			// List pools and their decommission status.
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/pools/list").HandlerFunc(gz(httpTraceAll(adminAPI.ListPools)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/pools/status").HandlerFunc(gz(httpTraceAll(adminAPI.StatusPool))).Queries("pool", "{pool:.*}")
+			// Start and cancel decommission of a pool.
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/decommission").HandlerFunc(gz(httpTraceAll(adminAPI.StartDecommission))).Queries("pool", "{pool:.*}")
+			// Misspelled path used by older madmin clients.
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/decomission").HandlerFunc(gz(httpTraceAll(adminAPI.StartDecommission))).Queries("pool", "{pool:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/cancel").HandlerFunc(gz(httpTraceAll(adminAPI.CancelDecommission))).Queries("pool", "{pool:.*}")
+
+			// Rebalance data across the pools.
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rebalance/start").HandlerFunc(gz(httpTraceAll(adminAPI.RebalanceStart)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/rebalance/status").HandlerFunc(gz(httpTraceAll(adminAPI.RebalanceStatus)))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rebalance/stop").HandlerFunc(gz(httpTraceAll(adminAPI.RebalanceStop)))
+
 			/// Health operations
 
 		}
@@ -168,6 +184,12 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		// Set Group Status
 		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-group-status").HandlerFunc(gz(httpTraceHdrs(adminAPI.SetGroupStatus))).Queries("group", "{group:.*}").Queries("status", "{status:.*}")
 
+		// Issue a SCIM provisioning token
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/scim/token").HandlerFunc(gz(httpTraceHdrs(adminAPI.SCIMTokenHandler)))
+
+		// Revoke a SCIM provisioning token
+		adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/scim/token").HandlerFunc(gz(httpTraceHdrs(adminAPI.SCIMRevokeTokenHandler))).Queries("id", "{id:.*}")
+
 		if globalIsDistErasure || globalIsErasure {
 			// GetBucketQuotaConfig
 			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-quota").HandlerFunc(
@@ -176,6 +198,17 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-quota").HandlerFunc(
 				gz(httpTraceHdrs(adminAPI.PutBucketQuotaConfigHandler))).Queries("bucket", "{bucket:.*}")
 
+			// Bucket transform operations
+			// GetBucketTransformConfig
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-transform").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.GetBucketTransformConfigHandler))).Queries("bucket", "{bucket:.*}")
+			// PutBucketTransformConfig
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-transform").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.PutBucketTransformConfigHandler))).Queries("bucket", "{bucket:.*}")
+			// RemoveBucketTransformConfig
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/remove-bucket-transform").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.RemoveBucketTransformConfigHandler))).Queries("bucket", "{bucket:.*}")
+
 			// Bucket replication operations
 			// GetBucketTargetHandler
 			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/list-remote-targets").HandlerFunc(
@@ -222,6 +255,12 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		// Console Logs
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/log").HandlerFunc(gz(httpTraceAll(adminAPI.ConsoleLogHandler)))
 
+		// Audit log chain verification
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/audit/verify").HandlerFunc(gz(httpTraceHdrs(adminAPI.AuditVerifyHandler)))
+
+		// Bucket event history
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/events/history").HandlerFunc(gz(httpTraceHdrs(adminAPI.EventHistoryHandler)))
+
 		// -- KMS APIs --
 		//
 		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/kms/status").HandlerFunc(gz(httpTraceAll(adminAPI.KMSStatusHandler)))
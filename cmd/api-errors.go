@@ -112,6 +112,7 @@ const (
 	ErrNoSuchBucketSSEConfig
 	ErrNoSuchCORSConfiguration
 	ErrNoSuchWebsiteConfiguration
+	ErrInvalidTargetBucketForLogging
 	ErrReplicationConfigurationNotFoundError
 	ErrRemoteDestinationNotFoundError
 	ErrReplicationDestinationMissingLock
@@ -281,6 +282,11 @@ const (
 	ErrAdminBucketQuotaExceeded
 	ErrAdminNoSuchQuotaConfiguration
 
+	// Bucket transform error codes
+	ErrAdminNoSuchTransformConfiguration
+	ErrTransformRangeNotSupported
+	ErrTransformFailed
+
 	ErrHealNotImplemented
 	ErrHealNoSuchProcess
 	ErrHealInvalidClientToken
@@ -847,6 +853,11 @@ var errorCodes = errorCodeMap{
 		Description:    "The specified bucket does not have a website configuration",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrInvalidTargetBucketForLogging: {
+		Code:           "InvalidTargetBucketForLogging",
+		Description:    "The target bucket for logging does not exist",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrReplicationConfigurationNotFoundError: {
 		Code:           "ReplicationConfigurationNotFoundError",
 		Description:    "The replication configuration was not found",
@@ -1249,6 +1260,21 @@ var errorCodes = errorCodeMap{
 		Description:    "The quota configuration does not exist",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrAdminNoSuchTransformConfiguration: {
+		Code:           "XMinioAdminNoSuchTransformConfiguration",
+		Description:    "The transform configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrTransformRangeNotSupported: {
+		Code:           "XMinioTransformRangeNotSupported",
+		Description:    "The transformer configured on this bucket does not support ranged requests",
+		HTTPStatusCode: http.StatusNotImplemented,
+	},
+	ErrTransformFailed: {
+		Code:           "XMinioTransformFailed",
+		Description:    "The transformer configured on this bucket failed to process the object",
+		HTTPStatusCode: http.StatusBadGateway,
+	},
 	ErrInsecureClientRequest: {
 		Code:           "XMinioInsecureClientRequest",
 		Description:    "Cannot respond to plain-text request from TLS-encrypted server",
@@ -2004,12 +2030,20 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchLifecycleConfiguration
 	case BucketSSEConfigNotFound:
 		apiErr = ErrNoSuchBucketSSEConfig
+	case BucketCorsNotFound:
+		apiErr = ErrNoSuchCORSConfiguration
+	case BucketWebsiteNotFound:
+		apiErr = ErrNoSuchWebsiteConfiguration
 	case BucketTaggingNotFound:
 		apiErr = ErrBucketTaggingNotFound
 	case BucketObjectLockConfigNotFound:
 		apiErr = ErrObjectLockConfigurationNotFound
 	case BucketQuotaConfigNotFound:
 		apiErr = ErrAdminNoSuchQuotaConfiguration
+	case BucketTransformNotFound:
+		apiErr = ErrAdminNoSuchTransformConfiguration
+	case BucketTransformFailed:
+		apiErr = ErrTransformFailed
 	case BucketReplicationConfigNotFound:
 		apiErr = ErrReplicationConfigurationNotFoundError
 	case BucketRemoteDestinationNotFound:
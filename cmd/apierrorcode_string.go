@@ -47,255 +47,259 @@ func _() {
 	_ = x[ErrNoSuchBucketSSEConfig-36]
 	_ = x[ErrNoSuchCORSConfiguration-37]
 	_ = x[ErrNoSuchWebsiteConfiguration-38]
-	_ = x[ErrReplicationConfigurationNotFoundError-39]
-	_ = x[ErrRemoteDestinationNotFoundError-40]
-	_ = x[ErrReplicationDestinationMissingLock-41]
-	_ = x[ErrRemoteTargetNotFoundError-42]
-	_ = x[ErrReplicationRemoteConnectionError-43]
-	_ = x[ErrReplicationBandwidthLimitError-44]
-	_ = x[ErrBucketRemoteIdenticalToSource-45]
-	_ = x[ErrBucketRemoteAlreadyExists-46]
-	_ = x[ErrBucketRemoteLabelInUse-47]
-	_ = x[ErrBucketRemoteArnTypeInvalid-48]
-	_ = x[ErrBucketRemoteArnInvalid-49]
-	_ = x[ErrBucketRemoteRemoveDisallowed-50]
-	_ = x[ErrRemoteTargetNotVersionedError-51]
-	_ = x[ErrReplicationSourceNotVersionedError-52]
-	_ = x[ErrReplicationNeedsVersioningError-53]
-	_ = x[ErrReplicationBucketNeedsVersioningError-54]
-	_ = x[ErrReplicationNoMatchingRuleError-55]
-	_ = x[ErrObjectRestoreAlreadyInProgress-56]
-	_ = x[ErrNoSuchKey-57]
-	_ = x[ErrNoSuchUpload-58]
-	_ = x[ErrInvalidVersionID-59]
-	_ = x[ErrNoSuchVersion-60]
-	_ = x[ErrNotImplemented-61]
-	_ = x[ErrPreconditionFailed-62]
-	_ = x[ErrRequestTimeTooSkewed-63]
-	_ = x[ErrSignatureDoesNotMatch-64]
-	_ = x[ErrMethodNotAllowed-65]
-	_ = x[ErrInvalidPart-66]
-	_ = x[ErrInvalidPartOrder-67]
-	_ = x[ErrAuthorizationHeaderMalformed-68]
-	_ = x[ErrMalformedPOSTRequest-69]
-	_ = x[ErrPOSTFileRequired-70]
-	_ = x[ErrSignatureVersionNotSupported-71]
-	_ = x[ErrBucketNotEmpty-72]
-	_ = x[ErrAllAccessDisabled-73]
-	_ = x[ErrMalformedPolicy-74]
-	_ = x[ErrMissingFields-75]
-	_ = x[ErrMissingCredTag-76]
-	_ = x[ErrCredMalformed-77]
-	_ = x[ErrInvalidRegion-78]
-	_ = x[ErrInvalidServiceS3-79]
-	_ = x[ErrInvalidServiceSTS-80]
-	_ = x[ErrInvalidRequestVersion-81]
-	_ = x[ErrMissingSignTag-82]
-	_ = x[ErrMissingSignHeadersTag-83]
-	_ = x[ErrMalformedDate-84]
-	_ = x[ErrMalformedPresignedDate-85]
-	_ = x[ErrMalformedCredentialDate-86]
-	_ = x[ErrMalformedCredentialRegion-87]
-	_ = x[ErrMalformedExpires-88]
-	_ = x[ErrNegativeExpires-89]
-	_ = x[ErrAuthHeaderEmpty-90]
-	_ = x[ErrExpiredPresignRequest-91]
-	_ = x[ErrRequestNotReadyYet-92]
-	_ = x[ErrUnsignedHeaders-93]
-	_ = x[ErrMissingDateHeader-94]
-	_ = x[ErrInvalidQuerySignatureAlgo-95]
-	_ = x[ErrInvalidQueryParams-96]
-	_ = x[ErrBucketAlreadyOwnedByYou-97]
-	_ = x[ErrInvalidDuration-98]
-	_ = x[ErrBucketAlreadyExists-99]
-	_ = x[ErrMetadataTooLarge-100]
-	_ = x[ErrUnsupportedMetadata-101]
-	_ = x[ErrMaximumExpires-102]
-	_ = x[ErrSlowDown-103]
-	_ = x[ErrInvalidPrefixMarker-104]
-	_ = x[ErrBadRequest-105]
-	_ = x[ErrKeyTooLongError-106]
-	_ = x[ErrInvalidBucketObjectLockConfiguration-107]
-	_ = x[ErrObjectLockConfigurationNotFound-108]
-	_ = x[ErrObjectLockConfigurationNotAllowed-109]
-	_ = x[ErrNoSuchObjectLockConfiguration-110]
-	_ = x[ErrObjectLocked-111]
-	_ = x[ErrInvalidRetentionDate-112]
-	_ = x[ErrPastObjectLockRetainDate-113]
-	_ = x[ErrUnknownWORMModeDirective-114]
-	_ = x[ErrBucketTaggingNotFound-115]
-	_ = x[ErrObjectLockInvalidHeaders-116]
-	_ = x[ErrInvalidTagDirective-117]
-	_ = x[ErrInvalidEncryptionMethod-118]
-	_ = x[ErrInsecureSSECustomerRequest-119]
-	_ = x[ErrSSEMultipartEncrypted-120]
-	_ = x[ErrSSEEncryptedObject-121]
-	_ = x[ErrInvalidEncryptionParameters-122]
-	_ = x[ErrInvalidSSECustomerAlgorithm-123]
-	_ = x[ErrInvalidSSECustomerKey-124]
-	_ = x[ErrMissingSSECustomerKey-125]
-	_ = x[ErrMissingSSECustomerKeyMD5-126]
-	_ = x[ErrSSECustomerKeyMD5Mismatch-127]
-	_ = x[ErrInvalidSSECustomerParameters-128]
-	_ = x[ErrIncompatibleEncryptionMethod-129]
-	_ = x[ErrKMSNotConfigured-130]
-	_ = x[ErrNoAccessKey-131]
-	_ = x[ErrInvalidToken-132]
-	_ = x[ErrEventNotification-133]
-	_ = x[ErrARNNotification-134]
-	_ = x[ErrRegionNotification-135]
-	_ = x[ErrOverlappingFilterNotification-136]
-	_ = x[ErrFilterNameInvalid-137]
-	_ = x[ErrFilterNamePrefix-138]
-	_ = x[ErrFilterNameSuffix-139]
-	_ = x[ErrFilterValueInvalid-140]
-	_ = x[ErrOverlappingConfigs-141]
-	_ = x[ErrUnsupportedNotification-142]
-	_ = x[ErrContentSHA256Mismatch-143]
-	_ = x[ErrReadQuorum-144]
-	_ = x[ErrWriteQuorum-145]
-	_ = x[ErrStorageFull-146]
-	_ = x[ErrRequestBodyParse-147]
-	_ = x[ErrObjectExistsAsDirectory-148]
-	_ = x[ErrInvalidObjectName-149]
-	_ = x[ErrInvalidObjectNamePrefixSlash-150]
-	_ = x[ErrInvalidResourceName-151]
-	_ = x[ErrServerNotInitialized-152]
-	_ = x[ErrOperationTimedOut-153]
-	_ = x[ErrClientDisconnected-154]
-	_ = x[ErrOperationMaxedOut-155]
-	_ = x[ErrInvalidRequest-156]
-	_ = x[ErrTransitionStorageClassNotFoundError-157]
-	_ = x[ErrInvalidStorageClass-158]
-	_ = x[ErrBackendDown-159]
-	_ = x[ErrMalformedJSON-160]
-	_ = x[ErrAdminNoSuchUser-161]
-	_ = x[ErrAdminNoSuchGroup-162]
-	_ = x[ErrAdminGroupNotEmpty-163]
-	_ = x[ErrAdminNoSuchPolicy-164]
-	_ = x[ErrAdminInvalidArgument-165]
-	_ = x[ErrAdminInvalidAccessKey-166]
-	_ = x[ErrAdminInvalidSecretKey-167]
-	_ = x[ErrAdminConfigNoQuorum-168]
-	_ = x[ErrAdminConfigTooLarge-169]
-	_ = x[ErrAdminConfigBadJSON-170]
-	_ = x[ErrAdminConfigDuplicateKeys-171]
-	_ = x[ErrAdminCredentialsMismatch-172]
-	_ = x[ErrInsecureClientRequest-173]
-	_ = x[ErrObjectTampered-174]
-	_ = x[ErrSiteReplicationInvalidRequest-175]
-	_ = x[ErrSiteReplicationPeerResp-176]
-	_ = x[ErrSiteReplicationBackendIssue-177]
-	_ = x[ErrSiteReplicationServiceAccountError-178]
-	_ = x[ErrSiteReplicationBucketConfigError-179]
-	_ = x[ErrSiteReplicationBucketMetaError-180]
-	_ = x[ErrSiteReplicationIAMError-181]
-	_ = x[ErrAdminBucketQuotaExceeded-182]
-	_ = x[ErrAdminNoSuchQuotaConfiguration-183]
-	_ = x[ErrHealNotImplemented-184]
-	_ = x[ErrHealNoSuchProcess-185]
-	_ = x[ErrHealInvalidClientToken-186]
-	_ = x[ErrHealMissingBucket-187]
-	_ = x[ErrHealAlreadyRunning-188]
-	_ = x[ErrHealOverlappingPaths-189]
-	_ = x[ErrIncorrectContinuationToken-190]
-	_ = x[ErrEmptyRequestBody-191]
-	_ = x[ErrUnsupportedFunction-192]
-	_ = x[ErrInvalidExpressionType-193]
-	_ = x[ErrBusy-194]
-	_ = x[ErrUnauthorizedAccess-195]
-	_ = x[ErrExpressionTooLong-196]
-	_ = x[ErrIllegalSQLFunctionArgument-197]
-	_ = x[ErrInvalidKeyPath-198]
-	_ = x[ErrInvalidCompressionFormat-199]
-	_ = x[ErrInvalidFileHeaderInfo-200]
-	_ = x[ErrInvalidJSONType-201]
-	_ = x[ErrInvalidQuoteFields-202]
-	_ = x[ErrInvalidRequestParameter-203]
-	_ = x[ErrInvalidDataType-204]
-	_ = x[ErrInvalidTextEncoding-205]
-	_ = x[ErrInvalidDataSource-206]
-	_ = x[ErrInvalidTableAlias-207]
-	_ = x[ErrMissingRequiredParameter-208]
-	_ = x[ErrObjectSerializationConflict-209]
-	_ = x[ErrUnsupportedSQLOperation-210]
-	_ = x[ErrUnsupportedSQLStructure-211]
-	_ = x[ErrUnsupportedSyntax-212]
-	_ = x[ErrUnsupportedRangeHeader-213]
-	_ = x[ErrLexerInvalidChar-214]
-	_ = x[ErrLexerInvalidOperator-215]
-	_ = x[ErrLexerInvalidLiteral-216]
-	_ = x[ErrLexerInvalidIONLiteral-217]
-	_ = x[ErrParseExpectedDatePart-218]
-	_ = x[ErrParseExpectedKeyword-219]
-	_ = x[ErrParseExpectedTokenType-220]
-	_ = x[ErrParseExpected2TokenTypes-221]
-	_ = x[ErrParseExpectedNumber-222]
-	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-223]
-	_ = x[ErrParseExpectedTypeName-224]
-	_ = x[ErrParseExpectedWhenClause-225]
-	_ = x[ErrParseUnsupportedToken-226]
-	_ = x[ErrParseUnsupportedLiteralsGroupBy-227]
-	_ = x[ErrParseExpectedMember-228]
-	_ = x[ErrParseUnsupportedSelect-229]
-	_ = x[ErrParseUnsupportedCase-230]
-	_ = x[ErrParseUnsupportedCaseClause-231]
-	_ = x[ErrParseUnsupportedAlias-232]
-	_ = x[ErrParseUnsupportedSyntax-233]
-	_ = x[ErrParseUnknownOperator-234]
-	_ = x[ErrParseMissingIdentAfterAt-235]
-	_ = x[ErrParseUnexpectedOperator-236]
-	_ = x[ErrParseUnexpectedTerm-237]
-	_ = x[ErrParseUnexpectedToken-238]
-	_ = x[ErrParseUnexpectedKeyword-239]
-	_ = x[ErrParseExpectedExpression-240]
-	_ = x[ErrParseExpectedLeftParenAfterCast-241]
-	_ = x[ErrParseExpectedLeftParenValueConstructor-242]
-	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-243]
-	_ = x[ErrParseExpectedArgumentDelimiter-244]
-	_ = x[ErrParseCastArity-245]
-	_ = x[ErrParseInvalidTypeParam-246]
-	_ = x[ErrParseEmptySelect-247]
-	_ = x[ErrParseSelectMissingFrom-248]
-	_ = x[ErrParseExpectedIdentForGroupName-249]
-	_ = x[ErrParseExpectedIdentForAlias-250]
-	_ = x[ErrParseUnsupportedCallWithStar-251]
-	_ = x[ErrParseNonUnaryAgregateFunctionCall-252]
-	_ = x[ErrParseMalformedJoin-253]
-	_ = x[ErrParseExpectedIdentForAt-254]
-	_ = x[ErrParseAsteriskIsNotAloneInSelectList-255]
-	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-256]
-	_ = x[ErrParseInvalidContextForWildcardInSelectList-257]
-	_ = x[ErrIncorrectSQLFunctionArgumentType-258]
-	_ = x[ErrValueParseFailure-259]
-	_ = x[ErrEvaluatorInvalidArguments-260]
-	_ = x[ErrIntegerOverflow-261]
-	_ = x[ErrLikeInvalidInputs-262]
-	_ = x[ErrCastFailed-263]
-	_ = x[ErrInvalidCast-264]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-265]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-266]
-	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-267]
-	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-268]
-	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-269]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-270]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-271]
-	_ = x[ErrEvaluatorBindingDoesNotExist-272]
-	_ = x[ErrMissingHeaders-273]
-	_ = x[ErrInvalidColumnIndex-274]
-	_ = x[ErrAdminConfigNotificationTargetsFailed-275]
-	_ = x[ErrAdminProfilerNotEnabled-276]
-	_ = x[ErrInvalidDecompressedSize-277]
-	_ = x[ErrAddUserInvalidArgument-278]
-	_ = x[ErrAdminAccountNotEligible-279]
-	_ = x[ErrAccountNotEligible-280]
-	_ = x[ErrAdminServiceAccountNotFound-281]
-	_ = x[ErrPostPolicyConditionInvalidFormat-282]
+	_ = x[ErrInvalidTargetBucketForLogging-39]
+	_ = x[ErrReplicationConfigurationNotFoundError-40]
+	_ = x[ErrRemoteDestinationNotFoundError-41]
+	_ = x[ErrReplicationDestinationMissingLock-42]
+	_ = x[ErrRemoteTargetNotFoundError-43]
+	_ = x[ErrReplicationRemoteConnectionError-44]
+	_ = x[ErrReplicationBandwidthLimitError-45]
+	_ = x[ErrBucketRemoteIdenticalToSource-46]
+	_ = x[ErrBucketRemoteAlreadyExists-47]
+	_ = x[ErrBucketRemoteLabelInUse-48]
+	_ = x[ErrBucketRemoteArnTypeInvalid-49]
+	_ = x[ErrBucketRemoteArnInvalid-50]
+	_ = x[ErrBucketRemoteRemoveDisallowed-51]
+	_ = x[ErrRemoteTargetNotVersionedError-52]
+	_ = x[ErrReplicationSourceNotVersionedError-53]
+	_ = x[ErrReplicationNeedsVersioningError-54]
+	_ = x[ErrReplicationBucketNeedsVersioningError-55]
+	_ = x[ErrReplicationNoMatchingRuleError-56]
+	_ = x[ErrObjectRestoreAlreadyInProgress-57]
+	_ = x[ErrNoSuchKey-58]
+	_ = x[ErrNoSuchUpload-59]
+	_ = x[ErrInvalidVersionID-60]
+	_ = x[ErrNoSuchVersion-61]
+	_ = x[ErrNotImplemented-62]
+	_ = x[ErrPreconditionFailed-63]
+	_ = x[ErrRequestTimeTooSkewed-64]
+	_ = x[ErrSignatureDoesNotMatch-65]
+	_ = x[ErrMethodNotAllowed-66]
+	_ = x[ErrInvalidPart-67]
+	_ = x[ErrInvalidPartOrder-68]
+	_ = x[ErrAuthorizationHeaderMalformed-69]
+	_ = x[ErrMalformedPOSTRequest-70]
+	_ = x[ErrPOSTFileRequired-71]
+	_ = x[ErrSignatureVersionNotSupported-72]
+	_ = x[ErrBucketNotEmpty-73]
+	_ = x[ErrAllAccessDisabled-74]
+	_ = x[ErrMalformedPolicy-75]
+	_ = x[ErrMissingFields-76]
+	_ = x[ErrMissingCredTag-77]
+	_ = x[ErrCredMalformed-78]
+	_ = x[ErrInvalidRegion-79]
+	_ = x[ErrInvalidServiceS3-80]
+	_ = x[ErrInvalidServiceSTS-81]
+	_ = x[ErrInvalidRequestVersion-82]
+	_ = x[ErrMissingSignTag-83]
+	_ = x[ErrMissingSignHeadersTag-84]
+	_ = x[ErrMalformedDate-85]
+	_ = x[ErrMalformedPresignedDate-86]
+	_ = x[ErrMalformedCredentialDate-87]
+	_ = x[ErrMalformedCredentialRegion-88]
+	_ = x[ErrMalformedExpires-89]
+	_ = x[ErrNegativeExpires-90]
+	_ = x[ErrAuthHeaderEmpty-91]
+	_ = x[ErrExpiredPresignRequest-92]
+	_ = x[ErrRequestNotReadyYet-93]
+	_ = x[ErrUnsignedHeaders-94]
+	_ = x[ErrMissingDateHeader-95]
+	_ = x[ErrInvalidQuerySignatureAlgo-96]
+	_ = x[ErrInvalidQueryParams-97]
+	_ = x[ErrBucketAlreadyOwnedByYou-98]
+	_ = x[ErrInvalidDuration-99]
+	_ = x[ErrBucketAlreadyExists-100]
+	_ = x[ErrMetadataTooLarge-101]
+	_ = x[ErrUnsupportedMetadata-102]
+	_ = x[ErrMaximumExpires-103]
+	_ = x[ErrSlowDown-104]
+	_ = x[ErrInvalidPrefixMarker-105]
+	_ = x[ErrBadRequest-106]
+	_ = x[ErrKeyTooLongError-107]
+	_ = x[ErrInvalidBucketObjectLockConfiguration-108]
+	_ = x[ErrObjectLockConfigurationNotFound-109]
+	_ = x[ErrObjectLockConfigurationNotAllowed-110]
+	_ = x[ErrNoSuchObjectLockConfiguration-111]
+	_ = x[ErrObjectLocked-112]
+	_ = x[ErrInvalidRetentionDate-113]
+	_ = x[ErrPastObjectLockRetainDate-114]
+	_ = x[ErrUnknownWORMModeDirective-115]
+	_ = x[ErrBucketTaggingNotFound-116]
+	_ = x[ErrObjectLockInvalidHeaders-117]
+	_ = x[ErrInvalidTagDirective-118]
+	_ = x[ErrInvalidEncryptionMethod-119]
+	_ = x[ErrInsecureSSECustomerRequest-120]
+	_ = x[ErrSSEMultipartEncrypted-121]
+	_ = x[ErrSSEEncryptedObject-122]
+	_ = x[ErrInvalidEncryptionParameters-123]
+	_ = x[ErrInvalidSSECustomerAlgorithm-124]
+	_ = x[ErrInvalidSSECustomerKey-125]
+	_ = x[ErrMissingSSECustomerKey-126]
+	_ = x[ErrMissingSSECustomerKeyMD5-127]
+	_ = x[ErrSSECustomerKeyMD5Mismatch-128]
+	_ = x[ErrInvalidSSECustomerParameters-129]
+	_ = x[ErrIncompatibleEncryptionMethod-130]
+	_ = x[ErrKMSNotConfigured-131]
+	_ = x[ErrNoAccessKey-132]
+	_ = x[ErrInvalidToken-133]
+	_ = x[ErrEventNotification-134]
+	_ = x[ErrARNNotification-135]
+	_ = x[ErrRegionNotification-136]
+	_ = x[ErrOverlappingFilterNotification-137]
+	_ = x[ErrFilterNameInvalid-138]
+	_ = x[ErrFilterNamePrefix-139]
+	_ = x[ErrFilterNameSuffix-140]
+	_ = x[ErrFilterValueInvalid-141]
+	_ = x[ErrOverlappingConfigs-142]
+	_ = x[ErrUnsupportedNotification-143]
+	_ = x[ErrContentSHA256Mismatch-144]
+	_ = x[ErrReadQuorum-145]
+	_ = x[ErrWriteQuorum-146]
+	_ = x[ErrStorageFull-147]
+	_ = x[ErrRequestBodyParse-148]
+	_ = x[ErrObjectExistsAsDirectory-149]
+	_ = x[ErrInvalidObjectName-150]
+	_ = x[ErrInvalidObjectNamePrefixSlash-151]
+	_ = x[ErrInvalidResourceName-152]
+	_ = x[ErrServerNotInitialized-153]
+	_ = x[ErrOperationTimedOut-154]
+	_ = x[ErrClientDisconnected-155]
+	_ = x[ErrOperationMaxedOut-156]
+	_ = x[ErrInvalidRequest-157]
+	_ = x[ErrTransitionStorageClassNotFoundError-158]
+	_ = x[ErrInvalidStorageClass-159]
+	_ = x[ErrBackendDown-160]
+	_ = x[ErrMalformedJSON-161]
+	_ = x[ErrAdminNoSuchUser-162]
+	_ = x[ErrAdminNoSuchGroup-163]
+	_ = x[ErrAdminGroupNotEmpty-164]
+	_ = x[ErrAdminNoSuchPolicy-165]
+	_ = x[ErrAdminInvalidArgument-166]
+	_ = x[ErrAdminInvalidAccessKey-167]
+	_ = x[ErrAdminInvalidSecretKey-168]
+	_ = x[ErrAdminConfigNoQuorum-169]
+	_ = x[ErrAdminConfigTooLarge-170]
+	_ = x[ErrAdminConfigBadJSON-171]
+	_ = x[ErrAdminConfigDuplicateKeys-172]
+	_ = x[ErrAdminCredentialsMismatch-173]
+	_ = x[ErrInsecureClientRequest-174]
+	_ = x[ErrObjectTampered-175]
+	_ = x[ErrSiteReplicationInvalidRequest-176]
+	_ = x[ErrSiteReplicationPeerResp-177]
+	_ = x[ErrSiteReplicationBackendIssue-178]
+	_ = x[ErrSiteReplicationServiceAccountError-179]
+	_ = x[ErrSiteReplicationBucketConfigError-180]
+	_ = x[ErrSiteReplicationBucketMetaError-181]
+	_ = x[ErrSiteReplicationIAMError-182]
+	_ = x[ErrAdminBucketQuotaExceeded-183]
+	_ = x[ErrAdminNoSuchQuotaConfiguration-184]
+	_ = x[ErrAdminNoSuchTransformConfiguration-185]
+	_ = x[ErrTransformRangeNotSupported-186]
+	_ = x[ErrTransformFailed-187]
+	_ = x[ErrHealNotImplemented-188]
+	_ = x[ErrHealNoSuchProcess-189]
+	_ = x[ErrHealInvalidClientToken-190]
+	_ = x[ErrHealMissingBucket-191]
+	_ = x[ErrHealAlreadyRunning-192]
+	_ = x[ErrHealOverlappingPaths-193]
+	_ = x[ErrIncorrectContinuationToken-194]
+	_ = x[ErrEmptyRequestBody-195]
+	_ = x[ErrUnsupportedFunction-196]
+	_ = x[ErrInvalidExpressionType-197]
+	_ = x[ErrBusy-198]
+	_ = x[ErrUnauthorizedAccess-199]
+	_ = x[ErrExpressionTooLong-200]
+	_ = x[ErrIllegalSQLFunctionArgument-201]
+	_ = x[ErrInvalidKeyPath-202]
+	_ = x[ErrInvalidCompressionFormat-203]
+	_ = x[ErrInvalidFileHeaderInfo-204]
+	_ = x[ErrInvalidJSONType-205]
+	_ = x[ErrInvalidQuoteFields-206]
+	_ = x[ErrInvalidRequestParameter-207]
+	_ = x[ErrInvalidDataType-208]
+	_ = x[ErrInvalidTextEncoding-209]
+	_ = x[ErrInvalidDataSource-210]
+	_ = x[ErrInvalidTableAlias-211]
+	_ = x[ErrMissingRequiredParameter-212]
+	_ = x[ErrObjectSerializationConflict-213]
+	_ = x[ErrUnsupportedSQLOperation-214]
+	_ = x[ErrUnsupportedSQLStructure-215]
+	_ = x[ErrUnsupportedSyntax-216]
+	_ = x[ErrUnsupportedRangeHeader-217]
+	_ = x[ErrLexerInvalidChar-218]
+	_ = x[ErrLexerInvalidOperator-219]
+	_ = x[ErrLexerInvalidLiteral-220]
+	_ = x[ErrLexerInvalidIONLiteral-221]
+	_ = x[ErrParseExpectedDatePart-222]
+	_ = x[ErrParseExpectedKeyword-223]
+	_ = x[ErrParseExpectedTokenType-224]
+	_ = x[ErrParseExpected2TokenTypes-225]
+	_ = x[ErrParseExpectedNumber-226]
+	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-227]
+	_ = x[ErrParseExpectedTypeName-228]
+	_ = x[ErrParseExpectedWhenClause-229]
+	_ = x[ErrParseUnsupportedToken-230]
+	_ = x[ErrParseUnsupportedLiteralsGroupBy-231]
+	_ = x[ErrParseExpectedMember-232]
+	_ = x[ErrParseUnsupportedSelect-233]
+	_ = x[ErrParseUnsupportedCase-234]
+	_ = x[ErrParseUnsupportedCaseClause-235]
+	_ = x[ErrParseUnsupportedAlias-236]
+	_ = x[ErrParseUnsupportedSyntax-237]
+	_ = x[ErrParseUnknownOperator-238]
+	_ = x[ErrParseMissingIdentAfterAt-239]
+	_ = x[ErrParseUnexpectedOperator-240]
+	_ = x[ErrParseUnexpectedTerm-241]
+	_ = x[ErrParseUnexpectedToken-242]
+	_ = x[ErrParseUnexpectedKeyword-243]
+	_ = x[ErrParseExpectedExpression-244]
+	_ = x[ErrParseExpectedLeftParenAfterCast-245]
+	_ = x[ErrParseExpectedLeftParenValueConstructor-246]
+	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-247]
+	_ = x[ErrParseExpectedArgumentDelimiter-248]
+	_ = x[ErrParseCastArity-249]
+	_ = x[ErrParseInvalidTypeParam-250]
+	_ = x[ErrParseEmptySelect-251]
+	_ = x[ErrParseSelectMissingFrom-252]
+	_ = x[ErrParseExpectedIdentForGroupName-253]
+	_ = x[ErrParseExpectedIdentForAlias-254]
+	_ = x[ErrParseUnsupportedCallWithStar-255]
+	_ = x[ErrParseNonUnaryAgregateFunctionCall-256]
+	_ = x[ErrParseMalformedJoin-257]
+	_ = x[ErrParseExpectedIdentForAt-258]
+	_ = x[ErrParseAsteriskIsNotAloneInSelectList-259]
+	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-260]
+	_ = x[ErrParseInvalidContextForWildcardInSelectList-261]
+	_ = x[ErrIncorrectSQLFunctionArgumentType-262]
+	_ = x[ErrValueParseFailure-263]
+	_ = x[ErrEvaluatorInvalidArguments-264]
+	_ = x[ErrIntegerOverflow-265]
+	_ = x[ErrLikeInvalidInputs-266]
+	_ = x[ErrCastFailed-267]
+	_ = x[ErrInvalidCast-268]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-269]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-270]
+	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-271]
+	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-272]
+	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-273]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-274]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-275]
+	_ = x[ErrEvaluatorBindingDoesNotExist-276]
+	_ = x[ErrMissingHeaders-277]
+	_ = x[ErrInvalidColumnIndex-278]
+	_ = x[ErrAdminConfigNotificationTargetsFailed-279]
+	_ = x[ErrAdminProfilerNotEnabled-280]
+	_ = x[ErrInvalidDecompressedSize-281]
+	_ = x[ErrAddUserInvalidArgument-282]
+	_ = x[ErrAdminAccountNotEligible-283]
+	_ = x[ErrAccountNotEligible-284]
+	_ = x[ErrAdminServiceAccountNotFound-285]
+	_ = x[ErrPostPolicyConditionInvalidFormat-286]
 }
 
-const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationNoSuchBucketSSEConfigNoSuchCORSConfigurationNoSuchWebsiteConfigurationReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationNoMatchingRuleErrorObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidOverlappingConfigsUnsupportedNotificationContentSHA256MismatchReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryInvalidObjectNameInvalidObjectNamePrefixSlashInvalidResourceNameServerNotInitializedOperationTimedOutClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminConfigDuplicateKeysAdminCredentialsMismatchInsecureClientRequestObjectTamperedSiteReplicationInvalidRequestSiteReplicationPeerRespSiteReplicationBackendIssueSiteReplicationServiceAccountErrorSiteReplicationBucketConfigErrorSiteReplicationBucketMetaErrorSiteReplicationIAMErrorAdminBucketQuotaExceededAdminNoSuchQuotaConfigurationHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundPostPolicyConditionInvalidFormat"
+const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationNoSuchBucketSSEConfigNoSuchCORSConfigurationNoSuchWebsiteConfigurationInvalidTargetBucketForLoggingReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationNoMatchingRuleErrorObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidOverlappingConfigsUnsupportedNotificationContentSHA256MismatchReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryInvalidObjectNameInvalidObjectNamePrefixSlashInvalidResourceNameServerNotInitializedOperationTimedOutClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminConfigDuplicateKeysAdminCredentialsMismatchInsecureClientRequestObjectTamperedSiteReplicationInvalidRequestSiteReplicationPeerRespSiteReplicationBackendIssueSiteReplicationServiceAccountErrorSiteReplicationBucketConfigErrorSiteReplicationBucketMetaErrorSiteReplicationIAMErrorAdminBucketQuotaExceededAdminNoSuchQuotaConfigurationAdminNoSuchTransformConfigurationTransformRangeNotSupportedTransformFailedHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundPostPolicyConditionInvalidFormat"
 
-var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 142, 154, 176, 196, 222, 236, 257, 274, 289, 312, 329, 347, 364, 388, 403, 424, 442, 454, 474, 491, 514, 535, 547, 565, 586, 614, 635, 658, 684, 721, 751, 784, 809, 841, 871, 900, 925, 947, 973, 995, 1023, 1052, 1086, 1117, 1154, 1184, 1214, 1223, 1235, 1251, 1264, 1278, 1296, 1316, 1337, 1353, 1364, 1380, 1408, 1428, 1444, 1472, 1486, 1503, 1518, 1531, 1545, 1558, 1571, 1587, 1604, 1625, 1639, 1660, 1673, 1695, 1718, 1743, 1759, 1774, 1789, 1810, 1828, 1843, 1860, 1885, 1903, 1926, 1941, 1960, 1976, 1995, 2009, 2017, 2036, 2046, 2061, 2097, 2128, 2161, 2190, 2202, 2222, 2246, 2270, 2291, 2315, 2334, 2357, 2383, 2404, 2422, 2449, 2476, 2497, 2518, 2542, 2567, 2595, 2623, 2639, 2650, 2662, 2679, 2694, 2712, 2741, 2758, 2774, 2790, 2808, 2826, 2849, 2870, 2880, 2891, 2902, 2918, 2941, 2958, 2986, 3005, 3025, 3042, 3060, 3077, 3091, 3126, 3145, 3156, 3169, 3184, 3200, 3218, 3235, 3255, 3276, 3297, 3316, 3335, 3353, 3377, 3401, 3422, 3436, 3465, 3488, 3515, 3549, 3581, 3611, 3634, 3658, 3687, 3705, 3722, 3744, 3761, 3779, 3799, 3825, 3841, 3860, 3881, 3885, 3903, 3920, 3946, 3960, 3984, 4005, 4020, 4038, 4061, 4076, 4095, 4112, 4129, 4153, 4180, 4203, 4226, 4243, 4265, 4281, 4301, 4320, 4342, 4363, 4383, 4405, 4429, 4448, 4490, 4511, 4534, 4555, 4586, 4605, 4627, 4647, 4673, 4694, 4716, 4736, 4760, 4783, 4802, 4822, 4844, 4867, 4898, 4936, 4977, 5007, 5021, 5042, 5058, 5080, 5110, 5136, 5164, 5197, 5215, 5238, 5273, 5313, 5355, 5387, 5404, 5429, 5444, 5461, 5471, 5482, 5520, 5574, 5620, 5672, 5720, 5763, 5807, 5835, 5849, 5867, 5903, 5926, 5949, 5971, 5994, 6012, 6039, 6071}
+var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 142, 154, 176, 196, 222, 236, 257, 274, 289, 312, 329, 347, 364, 388, 403, 424, 442, 454, 474, 491, 514, 535, 547, 565, 586, 614, 635, 658, 684, 713, 750, 780, 813, 838, 870, 900, 929, 954, 976, 1002, 1024, 1052, 1081, 1115, 1146, 1183, 1213, 1243, 1252, 1264, 1280, 1293, 1307, 1325, 1345, 1366, 1382, 1393, 1409, 1437, 1457, 1473, 1501, 1515, 1532, 1547, 1560, 1574, 1587, 1600, 1616, 1633, 1654, 1668, 1689, 1702, 1724, 1747, 1772, 1788, 1803, 1818, 1839, 1857, 1872, 1889, 1914, 1932, 1955, 1970, 1989, 2005, 2024, 2038, 2046, 2065, 2075, 2090, 2126, 2157, 2190, 2219, 2231, 2251, 2275, 2299, 2320, 2344, 2363, 2386, 2412, 2433, 2451, 2478, 2505, 2526, 2547, 2571, 2596, 2624, 2652, 2668, 2679, 2691, 2708, 2723, 2741, 2770, 2787, 2803, 2819, 2837, 2855, 2878, 2899, 2909, 2920, 2931, 2947, 2970, 2987, 3015, 3034, 3054, 3071, 3089, 3106, 3120, 3155, 3174, 3185, 3198, 3213, 3229, 3247, 3264, 3284, 3305, 3326, 3345, 3364, 3382, 3406, 3430, 3451, 3465, 3494, 3517, 3544, 3578, 3610, 3640, 3663, 3687, 3716, 3749, 3775, 3790, 3808, 3825, 3847, 3864, 3882, 3902, 3928, 3944, 3963, 3984, 3988, 4006, 4023, 4049, 4063, 4087, 4108, 4123, 4141, 4164, 4179, 4198, 4215, 4232, 4256, 4283, 4306, 4329, 4346, 4368, 4384, 4404, 4423, 4445, 4466, 4486, 4508, 4532, 4551, 4593, 4614, 4637, 4658, 4689, 4708, 4730, 4750, 4776, 4797, 4819, 4839, 4863, 4886, 4905, 4925, 4947, 4970, 5001, 5039, 5080, 5110, 5124, 5145, 5161, 5183, 5213, 5239, 5267, 5300, 5318, 5341, 5376, 5416, 5458, 5490, 5507, 5532, 5547, 5564, 5574, 5585, 5623, 5677, 5723, 5775, 5823, 5866, 5910, 5938, 5952, 5970, 6006, 6029, 6052, 6074, 6097, 6115, 6142, 6174}
 
 func (i APIErrorCode) String() string {
 	if i < 0 || i >= APIErrorCode(len(_APIErrorCode_index)-1) {
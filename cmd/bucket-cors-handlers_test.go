@@ -0,0 +1,99 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/internal/bucket/cors"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func TestBucketCorsHandler(t *testing.T) {
+	config, err := cors.ParseConfig(strings.NewReader(`<CORSConfiguration>
+	<CORSRule>
+		<AllowedOrigin>https://*.example.com</AllowedOrigin>
+		<AllowedMethod>GET</AllowedMethod>
+		<AllowedMethod>PUT</AllowedMethod>
+		<AllowedHeader>x-amz-*</AllowedHeader>
+		<ExposeHeader>ETag</ExposeHeader>
+		<MaxAgeSeconds>300</MaxAgeSeconds>
+	</CORSRule>
+	<CORSRule>
+		<AllowedOrigin>*</AllowedOrigin>
+		<AllowedMethod>HEAD</AllowedMethod>
+	</CORSRule>
+</CORSConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := bucketCorsHandler(config, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	testCases := []struct {
+		method        string
+		origin        string
+		reqMethod     string
+		reqHeaders    string
+		expectedCode  int
+		expectedAllow string
+		expectedCreds string
+	}{
+		// Preflight allowed by the first rule.
+		{http.MethodOptions, "https://www.example.com", http.MethodPut, "X-Amz-Date, x-amz-content-sha256", http.StatusOK, "https://www.example.com", "true"},
+		// Preflight with a header not allowed by any rule.
+		{http.MethodOptions, "https://www.example.com", http.MethodPut, "Authorization", http.StatusForbidden, "", ""},
+		// Preflight from an origin not allowed by any rule.
+		{http.MethodOptions, "https://www.example.org", http.MethodGet, "", http.StatusForbidden, "", ""},
+		// Preflight allowed by the wildcard origin rule.
+		{http.MethodOptions, "https://www.example.org", http.MethodHead, "", http.StatusOK, "*", ""},
+		// Actual request matching the first rule.
+		{http.MethodGet, "https://www.example.com", "", "", http.StatusNoContent, "https://www.example.com", "true"},
+		// Actual request not matching any rule is still served.
+		{http.MethodDelete, "https://www.example.com", "", "", http.StatusNoContent, "", ""},
+	}
+
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(testCase.method, "http://localhost:9000/bucket/object", nil)
+		req.Header.Set(xhttp.Origin, testCase.origin)
+		if testCase.reqMethod != "" {
+			req.Header.Set(xhttp.AccessControlRequestMethod, testCase.reqMethod)
+		}
+		if testCase.reqHeaders != "" {
+			req.Header.Set(xhttp.AccessControlRequestHeaders, testCase.reqHeaders)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedCode, rec.Code)
+		}
+		if got := rec.Header().Get(xhttp.AccessControlAllowOrigin); got != testCase.expectedAllow {
+			t.Errorf("Test %d: expected allow origin %q, got %q", i+1, testCase.expectedAllow, got)
+		}
+		if got := rec.Header().Get(xhttp.AccessControlAllowCredentials); got != testCase.expectedCreds {
+			t.Errorf("Test %d: expected allow credentials %q, got %q", i+1, testCase.expectedCreds, got)
+		}
+	}
+}
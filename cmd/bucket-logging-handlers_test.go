@@ -0,0 +1,118 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/minio/madmin-go"
+	minio "github.com/minio/minio-go/v7"
+)
+
+func (s *TestSuiteIAM) TestBucketLoggingTarget(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	bucket, targetBucket := getRandomBucketName(), getRandomBucketName()
+	for _, b := range []string{bucket, targetBucket} {
+		if err := s.client.MakeBucket(ctx, b, minio.MakeBucketOptions{}); err != nil {
+			c.Fatalf("bucket create error: %v", err)
+		}
+	}
+
+	// 1. Create a user allowed to configure logging of its own bucket only.
+	policy := "loggingpolicy"
+	policyBytes := []byte(fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": [
+    "s3:PutBucketPolicy",
+    "s3:GetBucketPolicy"
+   ],
+   "Resource": [
+    "arn:aws:s3:::%s"
+   ]
+  }
+ ]
+}`, bucket))
+	err := s.adm.AddCannedPolicy(ctx, policy, policyBytes)
+	if err != nil {
+		c.Fatalf("policy add error: %v", err)
+	}
+
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err = s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+	err = s.adm.SetPolicy(ctx, policy, accessKey, false)
+	if err != nil {
+		c.Fatalf("Unable to set policy: %v", err)
+	}
+
+	putBucketLogging := func(expectStatus int) {
+		config := []byte(fmt.Sprintf(`<BucketLoggingStatus><LoggingEnabled><TargetBucket>%s</TargetBucket><TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`, targetBucket))
+		req, err := newTestSignedRequestV4(http.MethodPut, s.endPoint+SlashSeparator+bucket+"?logging=",
+			int64(len(config)), bytes.NewReader(config), accessKey, secretKey, nil)
+		if err != nil {
+			c.Fatalf("unable to create request: %v", err)
+		}
+		resp, err := s.TestSuiteCommon.client.Do(req)
+		if err != nil {
+			c.Fatalf("PutBucketLogging failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != expectStatus {
+			c.Fatalf("PutBucketLogging: expected status %d, got %d", expectStatus, resp.StatusCode)
+		}
+	}
+
+	// 2. Logging to a bucket the user can not write to is rejected.
+	putBucketLogging(http.StatusForbidden)
+
+	// 3. Allow the user to write under the target prefix.
+	targetPolicy := "loggingtargetpolicy"
+	targetPolicyBytes := []byte(fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": [
+    "s3:PutObject"
+   ],
+   "Resource": [
+    "arn:aws:s3:::%s/access/*"
+   ]
+  }
+ ]
+}`, targetBucket))
+	err = s.adm.AddCannedPolicy(ctx, targetPolicy, targetPolicyBytes)
+	if err != nil {
+		c.Fatalf("policy add error: %v", err)
+	}
+	err = s.adm.SetPolicy(ctx, policy+","+targetPolicy, accessKey, false)
+	if err != nil {
+		c.Fatalf("Unable to set policy: %v", err)
+	}
+	putBucketLogging(http.StatusOK)
+}
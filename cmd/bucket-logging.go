@@ -0,0 +1,222 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/logging"
+	"github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/logger/message/audit"
+	"github.com/minio/pkg/env"
+)
+
+const (
+	// Interval at which queued access log records are delivered
+	// to their target buckets.
+	bucketLoggingFlushInterval = 5 * time.Minute
+
+	// Environment variable to override the directory of the access
+	// log queue, defaults to a directory under the config dir.
+	bucketLoggingQueueDirEnv = "MINIO_BUCKET_LOGGING_QUEUE_DIR"
+)
+
+// bucketLoggingSys delivers S3 server access logs of buckets with
+// logging enabled. Records are generated from the audit entries of
+// incoming requests and queued on local disk until they are written
+// as objects to the target bucket.
+type bucketLoggingSys struct {
+	queue *logging.Queue
+
+	// number of records dropped since the last flush because
+	// the queue reached its size limit, updated atomically.
+	dropped uint64
+}
+
+// newBucketLoggingSys - creates new bucket logging system.
+func newBucketLoggingSys() *bucketLoggingSys {
+	return &bucketLoggingSys{}
+}
+
+// Init - opens the access log queue and starts delivering queued
+// records, records left behind by a previous run are delivered first.
+func (sys *bucketLoggingSys) Init(ctx context.Context, objAPI ObjectLayer) {
+	queueDir := env.Get(bucketLoggingQueueDirEnv, filepath.Join(globalConfigDir.Get(), "bucket-logging"))
+	queue := logging.NewQueue(queueDir, 0)
+	if err := queue.Open(); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to open bucket logging queue at %s, bucket logging will be unavailable: %w", queueDir, err))
+		return
+	}
+	sys.queue = queue
+	logger.SetAccessLogger(sys)
+	go sys.run(ctx, objAPI)
+}
+
+// Enabled - returns true if access logging is enabled for the bucket.
+func (sys *bucketLoggingSys) Enabled(bucket string) bool {
+	config, err := globalBucketMetadataSys.GetLoggingConfig(bucket)
+	return err == nil && config.Enabled()
+}
+
+// Log - queues the access log record of a request.
+func (sys *bucketLoggingSys) Log(entry audit.Entry, r *http.Request) {
+	config, err := globalBucketMetadataSys.GetLoggingConfig(entry.API.Bucket)
+	if err != nil || !config.Enabled() {
+		return
+	}
+
+	record := logging.NewRecord(entry, r, globalMinioDefaultOwnerID)
+	err = sys.queue.Put(logging.Entry{
+		TargetBucket: config.LoggingEnabled.TargetBucket,
+		TargetPrefix: config.LoggingEnabled.TargetPrefix,
+		Record:       record.String(),
+	})
+	if err == logging.ErrQueueLimitExceeded {
+		// Dropped records are logged by the next flush.
+		atomic.AddUint64(&sys.dropped, 1)
+		return
+	}
+	logger.LogOnceIf(GlobalContext, err, bucketLoggingQueueDirEnv)
+}
+
+func (sys *bucketLoggingSys) run(ctx context.Context, objAPI ObjectLayer) {
+	ticker := time.NewTicker(bucketLoggingFlushInterval)
+	defer ticker.Stop()
+
+	// Deliver the records queued before a restart right away.
+	sys.flush(ctx, objAPI)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.LogIf(ctx, sys.queue.Close())
+			return
+		case <-ticker.C:
+			sys.flush(ctx, objAPI)
+		}
+	}
+}
+
+// flush - delivers all the sealed segments of the queue, records
+// which could not be delivered are queued again for the next flush.
+func (sys *bucketLoggingSys) flush(ctx context.Context, objAPI ObjectLayer) {
+	segments, err := sys.queue.Rotate()
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	for _, segment := range segments {
+		entries, err := sys.queue.Get(segment)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+
+		failed := deliverAccessLogs(ctx, objAPI, entries)
+		for i := range failed {
+			if err = sys.queue.Put(failed[i]); err != nil {
+				// The segment is removed below, the records
+				// which could not be queued again are lost.
+				if err == logging.ErrQueueLimitExceeded {
+					atomic.AddUint64(&sys.dropped, uint64(len(failed)-i))
+				} else {
+					logger.LogIf(ctx, err)
+				}
+				break
+			}
+		}
+		logger.LogIf(ctx, sys.queue.Del(segment))
+	}
+
+	if dropped := atomic.SwapUint64(&sys.dropped, 0); dropped > 0 {
+		logger.LogIf(ctx, fmt.Errorf("%d access log records dropped since the last flush: %w", dropped, logging.ErrQueueLimitExceeded))
+	}
+}
+
+// deliverAccessLogs - writes the records of each target bucket and
+// prefix as a single log object, returns the entries which could not
+// be delivered.
+func deliverAccessLogs(ctx context.Context, objAPI ObjectLayer, entries []logging.Entry) (failed []logging.Entry) {
+	type target struct {
+		bucket, prefix string
+	}
+
+	var targets []target
+	batches := make(map[target][]logging.Entry)
+	for _, entry := range entries {
+		t := target{entry.TargetBucket, entry.TargetPrefix}
+		if _, ok := batches[t]; !ok {
+			targets = append(targets, t)
+		}
+		batches[t] = append(batches[t], entry)
+	}
+
+	for _, t := range targets {
+		var buf bytes.Buffer
+		for _, entry := range batches[t] {
+			buf.WriteString(entry.Record)
+			buf.WriteByte('\n')
+		}
+		if err := putAccessLogObject(ctx, objAPI, t.bucket, accessLogObjectName(t.prefix), buf.Bytes()); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to deliver access logs to bucket %s: %w", t.bucket, err))
+			// Records destined to a deleted target bucket are dropped.
+			if _, ok := err.(BucketNotFound); !ok {
+				failed = append(failed, batches[t]...)
+			}
+		}
+	}
+	return failed
+}
+
+// accessLogObjectName - returns the name of a new log object in the
+// format TargetPrefixYYYY-mm-DD-HH-MM-SS-UniqueString used by AWS S3.
+func accessLogObjectName(prefix string) string {
+	var unique [8]byte
+	if _, err := rand.Read(unique[:]); err != nil {
+		logger.CriticalIf(GlobalContext, err)
+	}
+	return prefix + UTCNow().Format("2006-01-02-15-04-05") + "-" + strings.ToUpper(hex.EncodeToString(unique[:]))
+}
+
+func putAccessLogObject(ctx context.Context, objAPI ObjectLayer, bucket, object string, data []byte) error {
+	hashReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", getSHA256Hash(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	_, err = objAPI.PutObject(ctx, bucket, object, NewPutObjReader(hashReader), ObjectOptions{
+		Versioned:        globalBucketVersioningSys.Enabled(bucket),
+		VersionSuspended: globalBucketVersioningSys.Suspended(bucket),
+		UserDefined: map[string]string{
+			xhttp.ContentType: "text/plain",
+		},
+	})
+	return err
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/internal/bucket/logging"
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+func TestBucketLoggingDelivery(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketLoggingDelivery)
+}
+
+func testBucketLoggingDelivery(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+
+	for _, bucket := range []string{"source", "logs"} {
+		if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+
+	loggingConfig := []byte(`<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`)
+	if err := globalBucketMetadataSys.Update("source", bucketLoggingConfig, loggingConfig); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	queueDir, err := ioutil.TempDir("", "minio-bucket-logging")
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	defer os.RemoveAll(queueDir)
+
+	sys := &bucketLoggingSys{queue: logging.NewQueue(queueDir, 0)}
+	if err = sys.queue.Open(); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	defer sys.queue.Close()
+
+	if !sys.Enabled("source") || sys.Enabled("logs") {
+		t.Fatalf("%s: expected access logging to be enabled only for the source bucket", instanceType)
+	}
+
+	for _, object := range []string{"object-1", "object-2"} {
+		entry := audit.Entry{RequestID: object}
+		entry.API.Bucket = "source"
+		entry.API.Object = object
+		entry.API.StatusCode = http.StatusOK
+		sys.Log(entry, httptest.NewRequest(http.MethodGet, "/source/"+object, nil))
+	}
+
+	// Records of a deleted target bucket are dropped.
+	if err = sys.queue.Put(logging.Entry{TargetBucket: "deleted", Record: "dropped"}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	sys.flush(ctx, obj)
+
+	result, err := obj.ListObjects(ctx, "logs", "access/", "", "", 10)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(result.Objects) != 1 {
+		t.Fatalf("%s: expected 1 log object, got %d", instanceType, len(result.Objects))
+	}
+
+	var buf bytes.Buffer
+	if err = GetObject(ctx, obj, "logs", result.Objects[0].Name, 0, result.Objects[0].Size, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("%s: expected 2 log records, got %d", instanceType, len(lines))
+	}
+	for i, object := range []string{"object-1", "object-2"} {
+		if !strings.Contains(lines[i], " REST.GET.OBJECT "+object+" ") {
+			t.Errorf("%s: unexpected log record %q", instanceType, lines[i])
+		}
+	}
+
+	// Nothing is left to be delivered.
+	segments, err := sys.queue.Rotate()
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	for _, segment := range segments {
+		entries, err := sys.queue.Get(segment)
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		if len(entries) != 0 {
+			t.Fatalf("%s: expected no queued records, got %d", instanceType, len(entries))
+		}
+	}
+}
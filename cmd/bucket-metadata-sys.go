@@ -26,11 +26,15 @@ import (
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/internal/bucket/cors"
 	bucketsse "github.com/minio/minio/internal/bucket/encryption"
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/bucket/logging"
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
 	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/bucket/transform"
 	"github.com/minio/minio/internal/bucket/versioning"
+	"github.com/minio/minio/internal/bucket/website"
 	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
@@ -117,6 +121,14 @@ func (sys *BucketMetadataSys) Update(bucket string, configFile string, configDat
 		meta.LifecycleConfigXML = configData
 	case bucketSSEConfig:
 		meta.EncryptionConfigXML = configData
+	case bucketCorsConfig:
+		meta.CorsConfigXML = configData
+	case bucketLoggingConfig:
+		meta.LoggingConfigXML = configData
+	case bucketWebsiteConfig:
+		meta.WebsiteConfigXML = configData
+	case bucketTransformConfigFile:
+		meta.TransformConfigJSON = configData
 	case bucketTaggingConfig:
 		meta.TaggingConfigXML = configData
 	case bucketQuotaConfigFile:
@@ -297,6 +309,70 @@ func (sys *BucketMetadataSys) GetSSEConfig(bucket string) (*bucketsse.BucketSSEC
 	return meta.sseConfig, nil
 }
 
+// GetCorsConfig returns configured CORS config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetCorsConfig(bucket string) (*cors.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketCorsNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.corsConfig == nil {
+		return nil, BucketCorsNotFound{Bucket: bucket}
+	}
+	return meta.corsConfig, nil
+}
+
+// GetLoggingConfig returns configured bucket logging config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetLoggingConfig(bucket string) (*logging.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketLoggingNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.loggingConfig == nil {
+		return nil, BucketLoggingNotFound{Bucket: bucket}
+	}
+	return meta.loggingConfig, nil
+}
+
+// GetTransformConfig returns configured bucket transform config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetTransformConfig(bucket string) (*transform.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketTransformNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.transformConfig == nil {
+		return nil, BucketTransformNotFound{Bucket: bucket}
+	}
+	return meta.transformConfig, nil
+}
+
+// GetWebsiteConfig returns configured bucket website config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetWebsiteConfig(bucket string) (*website.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketWebsiteNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.websiteConfig == nil {
+		return nil, BucketWebsiteNotFound{Bucket: bucket}
+	}
+	return meta.websiteConfig, nil
+}
+
 // GetPolicyConfig returns configured bucket policy
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetPolicyConfig(bucket string) (*policy.Policy, error) {
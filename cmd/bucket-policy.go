@@ -27,6 +27,7 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	miniogopolicy "github.com/minio/minio-go/v7/pkg/policy"
+	xldap "github.com/minio/minio/internal/config/identity/ldap"
 	"github.com/minio/minio/internal/handlers"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
@@ -169,6 +170,14 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 
 	// JWT specific values
 	for k, v := range claims {
+		// LDAP user attributes, available as "${ldap:<attribute>}"
+		if strings.HasPrefix(k, ldapAttributePrefix) {
+			attr := strings.TrimPrefix(k, ldapAttributePrefix)
+			if values := claimStrings(v); len(values) > 0 && !xldap.IsReservedConditionAttribute(attr) {
+				args[attr] = values
+			}
+			continue
+		}
 		vStr, ok := v.(string)
 		if ok {
 			// Special case for AD/LDAP STS users
@@ -185,6 +194,26 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 	return args
 }
 
+// claimStrings returns the values of a claim holding a string or a
+// list of strings.
+func claimStrings(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			if s, ok := value.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
 // PolicyToBucketAccessPolicy converts a MinIO policy into a minio-go policy data structure.
 func PolicyToBucketAccessPolicy(bucketPolicy *policy.Policy) (*miniogopolicy.BucketAccessPolicy, error) {
 	// Return empty BucketAccessPolicy for empty bucket policy.
@@ -0,0 +1,158 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/minio/minio/internal/bucket/transform"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/rest"
+)
+
+// Query parameters describing the request context sent to a bucket
+// transformer, they are signed by the bearer token of the request.
+const (
+	transformQueryBucket    = "bucket"
+	transformQueryObject    = "object"
+	transformQueryVersionID = "versionId"
+	transformQueryETag      = "etag"
+)
+
+// transformClients - REST clients of the bucket transformers, one
+// per transformer endpoint, shared by all the requests.
+var transformClients = struct {
+	sync.Mutex
+	transport *http.Transport
+	clients   map[string]*rest.Client
+}{
+	clients: make(map[string]*rest.Client),
+}
+
+// transformClient - returns the REST client of the transformer
+// endpoint. Requests are authenticated by the caller since the
+// bearer token is issued for the access key of each request.
+func transformClient(config *transform.Config) *rest.Client {
+	transformClients.Lock()
+	defer transformClients.Unlock()
+
+	if client, ok := transformClients.clients[config.Endpoint]; ok {
+		return client
+	}
+	if transformClients.transport == nil {
+		transformClients.transport = NewGatewayHTTPTransport()
+	}
+	client := rest.NewClient(config.URL(), transformClients.transport, func(string) string {
+		return ""
+	})
+	client.NoMetrics = true
+	transformClients.clients[config.Endpoint] = client
+	return client
+}
+
+// transformReader - object content returned by a bucket transformer
+// along with its response headers.
+type transformReader struct {
+	io.Reader
+	header        http.Header
+	contentLength int64
+	statusCode    int
+}
+
+// setHeaders - replaces the object headers which do not apply to
+// the transformed content with the ones returned by the transformer.
+func (t *transformReader) setHeaders(w http.ResponseWriter) {
+	h := w.Header()
+	h.Del(xhttp.ETag)
+	h.Del(xhttp.ContentLength)
+	if t.contentLength >= 0 {
+		h.Set(xhttp.ContentLength, strconv.FormatInt(t.contentLength, 10))
+	}
+	if contentType := t.header.Get(xhttp.ContentType); contentType != "" {
+		h.Set(xhttp.ContentType, contentType)
+	}
+	if contentRange := t.header.Get(xhttp.ContentRange); contentRange != "" && t.statusCode == http.StatusPartialContent {
+		h.Set(xhttp.ContentRange, contentRange)
+	}
+}
+
+// transformGetObjectNInfo - returns a GetObjectNInfo which streams
+// the whole object through the transformer configured on the bucket.
+// Ranges are forwarded to the transformer, which is expected to apply
+// them to the transformed content.
+func transformGetObjectNInfo(getObjectNInfo func(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (*GetObjectReader, error),
+	config *transform.Config, accessKey string) func(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (*GetObjectReader, error) {
+	return func(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (*GetObjectReader, error) {
+		gr, err := getObjectNInfo(ctx, bucket, object, nil, h, lockType, opts)
+		if err != nil {
+			return gr, err
+		}
+
+		size, err := gr.ObjInfo.GetActualSize()
+		if err != nil {
+			gr.Close()
+			return nil, err
+		}
+
+		values := make(url.Values)
+		values.Set(transformQueryBucket, bucket)
+		values.Set(transformQueryObject, object)
+		values.Set(transformQueryVersionID, gr.ObjInfo.VersionID)
+		values.Set(transformQueryETag, gr.ObjInfo.ETag)
+
+		header := make(http.Header)
+		if gr.ObjInfo.ContentType != "" {
+			header.Set(xhttp.ContentType, gr.ObjInfo.ContentType)
+		}
+		if rs != nil {
+			header.Set(xhttp.Range, h.Get(xhttp.Range))
+		}
+
+		// The audience is the query string, as for the tokens
+		// issued by the REST client itself.
+		token, err := authenticateNode(accessKey, config.Secret, values.Encode())
+		if err != nil {
+			gr.Close()
+			return nil, BucketTransformFailed{Bucket: bucket, Object: object, Err: err}
+		}
+		header.Set(xhttp.Authorization, "Bearer "+token)
+
+		resp, err := transformClient(config).CallWithHeader(ctx, "", values, header, gr, size)
+		if err != nil {
+			gr.Close()
+			return nil, BucketTransformFailed{Bucket: bucket, Object: object, Err: err}
+		}
+
+		tr := &transformReader{
+			Reader:        resp.Body,
+			header:        resp.Header,
+			contentLength: resp.ContentLength,
+			statusCode:    resp.StatusCode,
+		}
+		return NewGetObjectReaderFromReader(tr, gr.ObjInfo, ObjectOptions{}, func() {
+			gr.Close()
+		}, func() {
+			resp.Body.Close()
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jwtreq "github.com/golang-jwt/jwt/v4/request"
+	"github.com/minio/minio/internal/bucket/transform"
+	xhttp "github.com/minio/minio/internal/http"
+	xjwt "github.com/minio/minio/internal/jwt"
+)
+
+func TestBucketTransform(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketTransform)
+}
+
+func testBucketTransform(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+
+	bucket, object, content := "transform", "greeting.txt", "hello world"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	_, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte(content)), int64(len(content)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	const secret = "transform-secret"
+	transformer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, err := jwtreq.AuthorizationHeaderExtractor.ExtractToken(r)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		claims := xjwt.NewStandardClaims()
+		if err = xjwt.ParseWithStandardClaims(token, claims, []byte(secret)); err != nil ||
+			claims.AccessKey != "reader" || claims.Audience != r.URL.RawQuery {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Query().Get(transformQueryBucket) != bucket || r.URL.Query().Get(transformQueryObject) != object {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		data = bytes.ToUpper(data)
+		w.Header().Set(xhttp.ContentType, "text/plain")
+		if r.Header.Get(xhttp.Range) == "bytes=0-4" {
+			w.Header().Set(xhttp.ContentRange, fmt.Sprintf("bytes 0-4/%d", len(data)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(data[:5])
+			return
+		}
+		w.Write(data)
+	}))
+	defer transformer.Close()
+
+	testCases := []struct {
+		secret        string
+		rangeHeader   string
+		expectedErr   bool
+		expectedCode  int
+		expectedBody  string
+		expectedRange string
+	}{
+		// Whole object.
+		{secret, "", false, http.StatusOK, "HELLO WORLD", ""},
+		// Range forwarded to the transformer.
+		{secret, "bytes=0-4", false, http.StatusPartialContent, "HELLO", "bytes 0-4/11"},
+		// Request context signed with the wrong secret.
+		{"other-secret", "", true, 0, "", ""},
+	}
+
+	for i, tc := range testCases {
+		config := &transform.Config{Endpoint: transformer.URL, Secret: tc.secret, SupportsRange: true}
+		getObjectNInfo := transformGetObjectNInfo(obj.GetObjectNInfo, config, "reader")
+
+		h := make(http.Header)
+		var rs *HTTPRangeSpec
+		if tc.rangeHeader != "" {
+			h.Set(xhttp.Range, tc.rangeHeader)
+			if rs, err = parseRequestRangeSpec(tc.rangeHeader); err != nil {
+				t.Fatalf("%s: Test %d: %v", instanceType, i+1, err)
+			}
+		}
+
+		gr, err := getObjectNInfo(ctx, bucket, object, rs, h, readLock, ObjectOptions{})
+		if tc.expectedErr {
+			if _, ok := err.(BucketTransformFailed); !ok {
+				t.Errorf("%s: Test %d: expected BucketTransformFailed, got %v", instanceType, i+1, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: Test %d: %v", instanceType, i+1, err)
+		}
+
+		tr, ok := gr.Reader.(*transformReader)
+		if !ok {
+			t.Fatalf("%s: Test %d: expected transformed reader", instanceType, i+1)
+		}
+		if tr.statusCode != tc.expectedCode {
+			t.Errorf("%s: Test %d: expected status %d, got %d", instanceType, i+1, tc.expectedCode, tr.statusCode)
+		}
+
+		rec := httptest.NewRecorder()
+		rec.Header().Set(xhttp.ETag, "\"etag\"")
+		tr.setHeaders(rec)
+		if rec.Header().Get(xhttp.ETag) != "" {
+			t.Errorf("%s: Test %d: expected object ETag to be removed", instanceType, i+1)
+		}
+		if rec.Header().Get(xhttp.ContentType) != "text/plain" {
+			t.Errorf("%s: Test %d: expected transformed content type, got %q", instanceType, i+1, rec.Header().Get(xhttp.ContentType))
+		}
+		if rec.Header().Get(xhttp.ContentRange) != tc.expectedRange {
+			t.Errorf("%s: Test %d: expected content range %q, got %q", instanceType, i+1, tc.expectedRange, rec.Header().Get(xhttp.ContentRange))
+		}
+
+		data, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatalf("%s: Test %d: %v", instanceType, i+1, err)
+		}
+		if string(data) != tc.expectedBody {
+			t.Errorf("%s: Test %d: expected body %q, got %q", instanceType, i+1, tc.expectedBody, string(data))
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+	xnet "github.com/minio/pkg/net"
+)
+
+// websiteBucketFromHost returns the bucket of a request sent to one of
+// the website domains, i.e. <bucket>.<website-domain>.
+func websiteBucketFromHost(host string) (string, bool) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	for _, domain := range globalWebsiteDomainNames {
+		if strings.HasSuffix(host, "."+domain) {
+			return strings.TrimSuffix(host, "."+domain), true
+		}
+	}
+	return "", false
+}
+
+// setWebsiteHandler serves the requests sent to the website domains
+// as bucket websites, all other requests are passed on to the handler.
+func setWebsiteHandler(h http.Handler) http.Handler {
+	websiteHandler := addCustomHeaders(http.HandlerFunc(websiteHandler))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := websiteBucketFromHost(r.Host); ok {
+			websiteHandler.ServeHTTP(w, r)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// websiteHandler serves bucket websites, the bucket is taken from the
+// host for the website domains and from the first path component
+// otherwise, which is only possible on the dedicated website listener.
+func websiteHandler(w http.ResponseWriter, r *http.Request) {
+	if bucket, ok := websiteBucketFromHost(r.Host); ok {
+		serveBucketWebsite(w, r, bucket, strings.TrimPrefix(r.URL.Path, SlashSeparator), "")
+		return
+	}
+	bucket, key := path2BucketObject(r.URL.Path)
+	if bucket != "" && key == "" && !strings.HasSuffix(r.URL.Path, SlashSeparator) {
+		// Relative links of the index document need the trailing slash.
+		http.Redirect(w, r, SlashSeparator+bucket+SlashSeparator, http.StatusFound)
+		return
+	}
+	serveBucketWebsite(w, r, bucket, key, SlashSeparator+bucket)
+}
+
+// serveBucketWebsite serves the website of a bucket, only objects which
+// are allowed to be read anonymously by the bucket policy are served.
+// root is the path of the website root on the host.
+func serveBucketWebsite(w http.ResponseWriter, r *http.Request, bucket, key, root string) {
+	ctx := newContext(r, w, "WebsiteGetObject")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil || globalBucketMetadataSys == nil {
+		writeWebsiteError(w, r, bucket, key, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeWebsiteError(w, r, bucket, key, errorCodes.ToAPIErr(ErrMethodNotAllowed))
+		return
+	}
+
+	if bucket == "" || isMinioMetaBucketName(bucket) {
+		writeWebsiteError(w, r, bucket, key, errorCodes.ToAPIErr(ErrNoSuchBucket))
+		return
+	}
+
+	config, err := globalBucketMetadataSys.GetWebsiteConfig(bucket)
+	if err != nil {
+		writeWebsiteError(w, r, bucket, key, toAPIError(ctx, err))
+		return
+	}
+
+	protocol := "http"
+	if r.TLS != nil {
+		protocol = "https"
+	}
+
+	if config.RedirectAllRequestsTo != nil {
+		http.Redirect(w, r, config.RedirectAllRequestsTo.Location(protocol, key), http.StatusMovedPermanently)
+		return
+	}
+
+	if rule := config.Route(key, 0); rule != nil {
+		location, statusCode := rule.Location(protocol, r.Host+root, key)
+		http.Redirect(w, r, location, statusCode)
+		return
+	}
+
+	objKey, isIndex := config.IndexKey(key)
+	apiErr := serveWebsiteObject(ctx, objAPI, w, r, bucket, objKey, http.StatusOK)
+	if apiErr == noError {
+		return
+	}
+
+	// A key without a trailing slash may still refer to a directory
+	// with an index document, redirect to the directory in that case.
+	// Keys not readable anonymously are also looked up as directories
+	// since their existence is not known.
+	if !isIndex && (apiErr.HTTPStatusCode == http.StatusNotFound || apiErr.HTTPStatusCode == http.StatusForbidden) {
+		if indexKey, _ := config.IndexKey(key + SlashSeparator); websiteObjectAllowed(r, bucket, indexKey) {
+			if _, err = objAPI.GetObjectInfo(ctx, bucket, indexKey, ObjectOptions{}); err == nil {
+				http.Redirect(w, r, root+SlashSeparator+key+SlashSeparator, http.StatusFound)
+				return
+			}
+		}
+	}
+
+	if rule := config.Route(key, apiErr.HTTPStatusCode); rule != nil {
+		location, statusCode := rule.Location(protocol, r.Host+root, key)
+		http.Redirect(w, r, location, statusCode)
+		return
+	}
+
+	if config.ErrorDocument != nil {
+		if serveWebsiteObject(ctx, objAPI, w, r, bucket, config.ErrorDocument.Key, apiErr.HTTPStatusCode) == noError {
+			return
+		}
+	}
+
+	writeWebsiteError(w, r, bucket, objKey, apiErr)
+}
+
+// websiteObjectAllowed returns true if the bucket policy allows the
+// object to be read anonymously.
+func websiteObjectAllowed(r *http.Request, bucket, object string) bool {
+	return globalPolicySys.IsAllowed(policy.Args{
+		Action:          policy.GetObjectAction,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, "", "", nil),
+		IsOwner:         false,
+		ObjectName:      object,
+	})
+}
+
+// serveWebsiteObject writes an object with the given status code,
+// the error preventing the object from being served is returned.
+func serveWebsiteObject(ctx context.Context, objAPI ObjectLayer, w http.ResponseWriter, r *http.Request, bucket, object string, statusCode int) APIError {
+	if !websiteObjectAllowed(r, bucket, object) {
+		return errorCodes.ToAPIErr(ErrAccessDenied)
+	}
+
+	opts := ObjectOptions{}
+	gr, err := objAPI.GetObjectNInfo(ctx, bucket, object, nil, r.Header, readLock, opts)
+	if err != nil {
+		return toAPIError(ctx, err)
+	}
+	defer gr.Close()
+
+	if err = setObjectHeaders(w, gr.ObjInfo, nil, opts); err != nil {
+		return toAPIError(ctx, err)
+	}
+
+	w.WriteHeader(statusCode)
+	if r.Method == http.MethodGet {
+		if _, err = io.Copy(w, gr); err != nil && !xnet.IsNetworkOrHostDown(err, true) {
+			logger.LogIf(ctx, err)
+		}
+	}
+	return noError
+}
+
+// writeWebsiteError writes an error page in the HTML format used by
+// the website endpoints of AWS S3.
+func writeWebsiteError(w http.ResponseWriter, r *http.Request, bucket, key string, apiErr APIError) {
+	status := fmt.Sprintf("%d %s", apiErr.HTTPStatusCode, http.StatusText(apiErr.HTTPStatusCode))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<html>\n<head><title>%s</title></head>\n<body>\n<h1>%s</h1>\n<ul>\n", status, status)
+	fmt.Fprintf(&b, "<li>Code: %s</li>\n", html.EscapeString(apiErr.Code))
+	fmt.Fprintf(&b, "<li>Message: %s</li>\n", html.EscapeString(apiErr.Description))
+	if bucket != "" {
+		fmt.Fprintf(&b, "<li>BucketName: %s</li>\n", html.EscapeString(bucket))
+	}
+	if key != "" && apiErr.HTTPStatusCode == http.StatusNotFound {
+		fmt.Fprintf(&b, "<li>Key: %s</li>\n", html.EscapeString(key))
+	}
+	fmt.Fprintf(&b, "<li>RequestId: %s</li>\n", html.EscapeString(w.Header().Get(xhttp.AmzRequestID)))
+	b.WriteString("</ul>\n<hr/>\n</body>\n</html>\n")
+
+	w.Header().Set(xhttp.ContentType, "text/html; charset=utf-8")
+	w.WriteHeader(apiErr.HTTPStatusCode)
+	if r.Method != http.MethodHead {
+		io.WriteString(w, b.String())
+	}
+}
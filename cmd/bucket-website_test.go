@@ -0,0 +1,125 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBucketWebsite(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketWebsite)
+}
+
+func testBucketWebsite(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+
+	bucket := "site"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	objects := map[string]string{
+		"index.html":      "home",
+		"docs/index.html": "docs",
+		"error.html":      "oops",
+		"secret.html":     "secret",
+	}
+	for name, content := range objects {
+		_, err := obj.PutObject(ctx, bucket, name, mustGetPutObjReader(t, bytes.NewReader([]byte(content)), int64(len(content)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+
+	policyData := []byte(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::site/index.html","arn:aws:s3:::site/docs/*","arn:aws:s3:::site/error.html"]}]}`)
+	if err := globalBucketMetadataSys.Update(bucket, bucketPolicyConfig, policyData); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	websiteData := []byte(`<WebsiteConfiguration>
+		<IndexDocument><Suffix>index.html</Suffix></IndexDocument>
+		<ErrorDocument><Key>error.html</Key></ErrorDocument>
+		<RoutingRules><RoutingRule>
+			<Condition><KeyPrefixEquals>old/</KeyPrefixEquals></Condition>
+			<Redirect><ReplaceKeyPrefixWith>docs/</ReplaceKeyPrefixWith></Redirect>
+		</RoutingRule></RoutingRules>
+	</WebsiteConfiguration>`)
+	if err := globalBucketMetadataSys.Update(bucket, bucketWebsiteConfig, websiteData); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	defer func(domains []string) { globalWebsiteDomainNames = domains }(globalWebsiteDomainNames)
+	globalWebsiteDomainNames = []string{"website.example.com"}
+
+	handler := setWebsiteHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	testCases := []struct {
+		method           string
+		host             string
+		path             string
+		handler          http.Handler
+		expectedCode     int
+		expectedBody     string
+		expectedLocation string
+	}{
+		// Index documents.
+		{http.MethodGet, "site.website.example.com", "/", handler, http.StatusOK, "home", ""},
+		{http.MethodGet, "site.website.example.com", "/docs/", handler, http.StatusOK, "docs", ""},
+		{http.MethodHead, "site.website.example.com", "/docs/", handler, http.StatusOK, "", ""},
+		// Directory without a trailing slash.
+		{http.MethodGet, "site.website.example.com", "/docs", handler, http.StatusFound, "", "/docs/"},
+		// Error document returned with the error status code.
+		{http.MethodGet, "site.website.example.com", "/docs/missing.html", handler, http.StatusNotFound, "oops", ""},
+		{http.MethodGet, "site.website.example.com", "/secret.html", handler, http.StatusForbidden, "oops", ""},
+		// Routing rule.
+		{http.MethodGet, "site.website.example.com", "/old/a.html", handler, http.StatusMovedPermanently, "", "http://site.website.example.com/docs/a.html"},
+		// Only reads are allowed.
+		{http.MethodPut, "site.website.example.com", "/index.html", handler, http.StatusMethodNotAllowed, "", ""},
+		// Bucket without a website configuration.
+		{http.MethodGet, "other.website.example.com", "/", handler, http.StatusNotFound, "", ""},
+		// Requests to other hosts are passed on.
+		{http.MethodGet, "localhost:9000", "/site/index.html", handler, http.StatusTeapot, "", ""},
+		// Dedicated listener with path style requests.
+		{http.MethodGet, "localhost:9080", "/site/", http.HandlerFunc(websiteHandler), http.StatusOK, "home", ""},
+		{http.MethodGet, "localhost:9080", "/site", http.HandlerFunc(websiteHandler), http.StatusFound, "", "/site/"},
+		{http.MethodGet, "localhost:9080", "/site/docs", http.HandlerFunc(websiteHandler), http.StatusFound, "", "/site/docs/"},
+	}
+
+	for i, tc := range testCases {
+		req := httptest.NewRequest(tc.method, "http://"+tc.host+tc.path, nil)
+		rec := httptest.NewRecorder()
+		tc.handler.ServeHTTP(rec, req)
+
+		if rec.Code != tc.expectedCode {
+			t.Errorf("%s: Test %d: expected status %d, got %d", instanceType, i+1, tc.expectedCode, rec.Code)
+		}
+		if tc.expectedBody != "" && rec.Body.String() != tc.expectedBody {
+			t.Errorf("%s: Test %d: expected body %q, got %q", instanceType, i+1, tc.expectedBody, rec.Body.String())
+		}
+		if location := rec.Header().Get("Location"); !strings.HasSuffix(location, tc.expectedLocation) {
+			t.Errorf("%s: Test %d: expected location %q, got %q", instanceType, i+1, tc.expectedLocation, location)
+		}
+	}
+}
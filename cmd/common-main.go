@@ -488,6 +488,24 @@ func handleCommonEnvVars() {
 		}
 	}
 
+	websiteDomains := env.Get(config.EnvWebsiteDomain, "")
+	if len(websiteDomains) != 0 {
+		for _, domainName := range strings.Split(websiteDomains, config.ValueSeparator) {
+			if _, ok := dns2.IsDomainName(domainName); !ok {
+				logger.Fatal(config.ErrInvalidDomainValue(nil).Msg("Unknown value `%s`", domainName),
+					"Invalid MINIO_WEBSITE_DOMAIN value in environment variable")
+			}
+			globalWebsiteDomainNames = append(globalWebsiteDomainNames, domainName)
+		}
+	}
+
+	if websiteAddr := env.Get(config.EnvWebsiteAddress, ""); websiteAddr != "" {
+		if _, _, err = net.SplitHostPort(websiteAddr); err != nil {
+			logger.Fatal(config.ErrInvalidAddressFlag(err), "Invalid MINIO_WEBSITE_ADDRESS value in environment variable")
+		}
+		globalWebsiteAddr = websiteAddr
+	}
+
 	publicIPs := env.Get(config.EnvPublicIPs, "")
 	if len(publicIPs) != 0 {
 		minioEndpoints := strings.Split(publicIPs, config.ValueSeparator)
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"sync"
 
@@ -41,11 +42,16 @@ import (
 	"github.com/minio/minio/internal/config/storageclass"
 	"github.com/minio/minio/internal/config/subnet"
 	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/event/journal"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/logger/target/file"
 	"github.com/minio/minio/internal/logger/target/http"
 	"github.com/minio/minio/internal/logger/target/kafka"
+	"github.com/minio/minio/internal/logger/target/otlp"
+	"github.com/minio/minio/internal/logger/target/syslog"
 	"github.com/minio/pkg/env"
 )
 
@@ -64,13 +70,19 @@ func initHelp() {
 		config.LoggerWebhookSubSys:  logger.DefaultKVS,
 		config.AuditWebhookSubSys:   logger.DefaultAuditWebhookKVS,
 		config.AuditKafkaSubSys:     logger.DefaultAuditKafkaKVS,
+		config.AuditSyslogSubSys:    logger.DefaultAuditSyslogKVS,
+		config.AuditOTLPSubSys:      logger.DefaultAuditOTLPKVS,
+		config.AuditFileSubSys:      logger.DefaultAuditFileKVS,
+		config.AuditChainSubSys:     logger.DefaultAuditChainKVS,
 		config.HealSubSys:           heal.DefaultKVS,
 		config.ScannerSubSys:        scanner.DefaultKVS,
+		config.EventJournalSubSys:   journal.DefaultKVS,
 		config.SubnetSubSys:         subnet.DefaultKVS,
 	}
 	for k, v := range notify.DefaultNotificationKVS {
 		kvs[k] = v
 	}
+	kvs[config.NotifySubSys] = notify.DefaultDeliveryKVS
 	if globalIsErasure {
 		kvs[config.StorageClassSubSys] = storageclass.DefaultKVS
 	}
@@ -95,8 +107,9 @@ func initHelp() {
 			Description: "federate multiple clusters for IAM and Bucket DNS",
 		},
 		config.HelpKV{
-			Key:         config.IdentityOpenIDSubSys,
-			Description: "enable OpenID SSO support",
+			Key:             config.IdentityOpenIDSubSys,
+			Description:     "enable OpenID SSO support",
+			MultipleTargets: true,
 		},
 		config.HelpKV{
 			Key:         config.IdentityLDAPSubSys,
@@ -122,6 +135,10 @@ func initHelp() {
 			Key:         config.ScannerSubSys,
 			Description: "manage namespace scanning for usage calculation, lifecycle, healing and more",
 		},
+		config.HelpKV{
+			Key:         config.EventJournalSubSys,
+			Description: "journal bucket events to resume listening and page through event history",
+		},
 		config.HelpKV{
 			Key:             config.LoggerWebhookSubSys,
 			Description:     "send server logs to webhook endpoints",
@@ -137,6 +154,29 @@ func initHelp() {
 			Description:     "send audit logs to kafka endpoints",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.AuditSyslogSubSys,
+			Description:     "send audit logs to syslog servers",
+			MultipleTargets: true,
+		},
+		config.HelpKV{
+			Key:             config.AuditOTLPSubSys,
+			Description:     "send audit logs to OpenTelemetry collectors",
+			MultipleTargets: true,
+		},
+		config.HelpKV{
+			Key:             config.AuditFileSubSys,
+			Description:     "write audit logs to local rotating files",
+			MultipleTargets: true,
+		},
+		config.HelpKV{
+			Key:         config.AuditChainSubSys,
+			Description: "hash chain and sign audit logs to make them tamper-evident",
+		},
+		config.HelpKV{
+			Key:         config.NotifySubSys,
+			Description: "retry and dead-letter bucket notifications which cannot be delivered",
+		},
 		config.HelpKV{
 			Key:             config.NotifyWebhookSubSys,
 			Description:     "publish bucket notifications to webhook endpoints",
@@ -187,6 +227,16 @@ func initHelp() {
 			Description:     "publish bucket notifications to Redis datastores",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.NotifyPulsarSubSys,
+			Description:     "publish bucket notifications to Pulsar topics",
+			MultipleTargets: true,
+		},
+		config.HelpKV{
+			Key:             config.NotifyGRPCSubSys,
+			Description:     "publish bucket notifications to gRPC sinks",
+			MultipleTargets: true,
+		},
 		config.HelpKV{
 			Key:         config.SubnetSubSys,
 			Type:        "string",
@@ -214,6 +264,7 @@ func initHelp() {
 		config.CompressionSubSys:    compress.Help,
 		config.HealSubSys:           heal.Help,
 		config.ScannerSubSys:        scanner.Help,
+		config.EventJournalSubSys:   journal.Help,
 		config.IdentityOpenIDSubSys: openid.Help,
 		config.IdentityLDAPSubSys:   xldap.Help,
 		config.IdentityTLSSubSys:    xtls.Help,
@@ -221,6 +272,10 @@ func initHelp() {
 		config.LoggerWebhookSubSys:  logger.Help,
 		config.AuditWebhookSubSys:   logger.HelpWebhook,
 		config.AuditKafkaSubSys:     logger.HelpKafka,
+		config.AuditSyslogSubSys:    logger.HelpSyslog,
+		config.AuditOTLPSubSys:      logger.HelpOTLP,
+		config.AuditFileSubSys:      logger.HelpFile,
+		config.AuditChainSubSys:     logger.HelpChain,
 		config.NotifyAMQPSubSys:     notify.HelpAMQP,
 		config.NotifyKafkaSubSys:    notify.HelpKafka,
 		config.NotifyMQTTSubSys:     notify.HelpMQTT,
@@ -231,6 +286,9 @@ func initHelp() {
 		config.NotifyRedisSubSys:    notify.HelpRedis,
 		config.NotifyWebhookSubSys:  notify.HelpWebhook,
 		config.NotifyESSubSys:       notify.HelpES,
+		config.NotifyPulsarSubSys:   notify.HelpPulsar,
+		config.NotifyGRPCSubSys:     notify.HelpGRPC,
+		config.NotifySubSys:         notify.HelpDelivery,
 		config.SubnetSubSys:         subnet.HelpLicense,
 	}
 
@@ -301,6 +359,20 @@ func validateConfig(s config.Config) error {
 		return err
 	}
 
+	if _, err = journal.LookupConfig(s[config.EventJournalSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	deliveryCfg, err := notify.LookupDeliveryConfig(s[config.NotifySubSys][config.Default])
+	if err != nil {
+		return err
+	}
+	if deliveryCfg.DeadLetterBucket != "" && objAPI != nil {
+		if _, err = objAPI.GetBucketInfo(GlobalContext, deliveryCfg.DeadLetterBucket); err != nil {
+			return fmt.Errorf("Unable to use dead-letter bucket %s: %w", deliveryCfg.DeadLetterBucket, err)
+		}
+	}
+
 	{
 		etcdCfg, err := etcd.LookupConfig(s[config.EtcdSubSys][config.Default], globalRootCAs)
 		if err != nil {
@@ -314,7 +386,7 @@ func validateConfig(s config.Config) error {
 			etcdClnt.Close()
 		}
 	}
-	if _, err := openid.LookupConfig(s[config.IdentityOpenIDSubSys][config.Default],
+	if _, err := openid.LookupProviders(s[config.IdentityOpenIDSubSys],
 		NewGatewayHTTPTransport(), xhttp.DrainBody); err != nil {
 		return err
 	}
@@ -345,13 +417,28 @@ func validateConfig(s config.Config) error {
 		return err
 	}
 
-	if _, err := logger.LookupConfig(s); err != nil {
+	loggerCfg, err := logger.LookupConfig(s)
+	if err != nil {
+		return err
+	}
+
+	if _, err := loadAuditChainKey(loggerCfg.AuditChain); err != nil {
 		return err
 	}
 
 	return notify.TestNotificationTargets(GlobalContext, s, NewGatewayHTTPTransport(), globalNotificationSys.ConfiguredTargetIDs())
 }
 
+// loggerQueueDir returns the queue directory of a logger target, each
+// target is queued in its own subdirectory of the configured directory
+// so that targets sharing a queue_dir never replay each other's entries.
+func loggerQueueDir(queueDir, subSys, name string) string {
+	if queueDir == "" {
+		return ""
+	}
+	return filepath.Join(queueDir, "minio-"+subSys+"-"+name)
+}
+
 func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 	ctx := GlobalContext
 
@@ -500,11 +587,12 @@ func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 		logger.Info("CRITICAL: enabling %s is not recommended in a production environment", xtls.EnvIdentityTLSSkipVerify)
 	}
 
-	globalOpenIDConfig, err = openid.LookupConfig(s[config.IdentityOpenIDSubSys][config.Default],
+	globalOpenIDProviders, err = openid.LookupProviders(s[config.IdentityOpenIDSubSys],
 		NewGatewayHTTPTransport(), xhttp.DrainBody)
 	if err != nil {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize OpenID: %w", err))
 	}
+	globalOpenIDConfig = globalOpenIDProviders[config.Default]
 
 	opaCfg, err := opa.LookupConfig(s[config.PolicyOPASubSys][config.Default],
 		NewGatewayHTTPTransport(), xhttp.DrainBody)
@@ -512,7 +600,6 @@ func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize OPA: %w", err))
 	}
 
-	globalOpenIDValidators = getOpenIDValidators(globalOpenIDConfig)
 	globalPolicyOPA = opa.New(opaCfg)
 
 	globalLDAPConfig, err = xldap.Lookup(s[config.IdentityLDAPSubSys][config.Default],
@@ -520,6 +607,7 @@ func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 	if err != nil {
 		logger.LogIf(ctx, fmt.Errorf("Unable to parse LDAP configuration: %w", err))
 	}
+	registerLDAPConditionKeys(globalLDAPConfig.UserConditionAttributes)
 
 	globalSubnetConfig, err = subnet.LookupConfig(s[config.SubnetSubSys][config.Default])
 	if err != nil {
@@ -534,8 +622,16 @@ func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize logger: %w", err))
 	}
 
-	for _, l := range loggerCfg.HTTP {
+	globalAuditChainKey, err = loadAuditChainKey(loggerCfg.AuditChain)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to load audit chain signing key: %w", err))
+	} else if loggerCfg.AuditChain.Enabled {
+		logger.EnableAuditChain(globalAuditChainKey, loggerCfg.AuditChain.SignInterval)
+	}
+
+	for name, l := range loggerCfg.HTTP {
 		if l.Enabled {
+			l.QueueDir = loggerQueueDir(l.QueueDir, config.LoggerWebhookSubSys, name)
 			l.LogOnce = logger.LogOnceIf
 			l.UserAgent = loggerUserAgent
 			l.Transport = NewGatewayHTTPTransportWithClientCerts(l.ClientCert, l.ClientKey)
@@ -546,28 +642,63 @@ func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 		}
 	}
 
-	for _, l := range loggerCfg.AuditWebhook {
+	for name, l := range loggerCfg.AuditWebhook {
 		if l.Enabled {
+			l.QueueDir = loggerQueueDir(l.QueueDir, config.AuditWebhookSubSys, name)
 			l.LogOnce = logger.LogOnceIf
 			l.UserAgent = loggerUserAgent
 			l.Transport = NewGatewayHTTPTransportWithClientCerts(l.ClientCert, l.ClientKey)
 			// Enable http audit logging
-			if err = logger.AddAuditTarget(http.New(l)); err != nil {
+			if err = logger.AddAuditTargetWithFilter(http.New(l), l.Filter); err != nil {
 				logger.LogIf(ctx, fmt.Errorf("Unable to initialize audit HTTP target: %w", err))
 			}
 		}
 	}
 
-	for _, l := range loggerCfg.AuditKafka {
+	for name, l := range loggerCfg.AuditKafka {
 		if l.Enabled {
+			l.Queue.Dir = loggerQueueDir(l.Queue.Dir, config.AuditKafkaSubSys, name)
 			l.LogOnce = logger.LogOnceIf
 			// Enable Kafka audit logging
-			if err = logger.AddAuditTarget(kafka.New(l)); err != nil {
+			if err = logger.AddAuditTargetWithFilter(kafka.New(l), l.Filter); err != nil {
 				logger.LogIf(ctx, fmt.Errorf("Unable to initialize audit Kafka target: %w", err))
 			}
 		}
 	}
 
+	for _, l := range loggerCfg.AuditSyslog {
+		if l.Enabled {
+			l.LogOnce = logger.LogOnceIf
+			l.RootCAs = globalRootCAs
+			// Enable syslog audit logging
+			if err = logger.AddAuditTargetWithFilter(syslog.New(l), l.Filter); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize audit syslog target: %w", err))
+			}
+		}
+	}
+
+	for _, l := range loggerCfg.AuditOTLP {
+		if l.Enabled {
+			l.LogOnce = logger.LogOnceIf
+			l.UserAgent = loggerUserAgent
+			l.Transport = NewGatewayHTTPTransportWithClientCerts(l.ClientCert, l.ClientKey)
+			// Enable OTLP audit logging
+			if err = logger.AddAuditTargetWithFilter(otlp.New(l), l.Filter); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize audit OTLP target: %w", err))
+			}
+		}
+	}
+
+	for _, l := range loggerCfg.AuditFile {
+		if l.Enabled {
+			l.LogOnce = logger.LogOnceIf
+			// Enable file audit logging
+			if err = logger.AddAuditTargetWithFilter(file.New(l), l.Filter); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize audit file target: %w", err))
+			}
+		}
+	}
+
 	globalConfigTargetList, err = notify.GetNotificationTargets(GlobalContext, s, NewGatewayHTTPTransport(), false)
 	if err != nil {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize notification target(s): %w", err))
@@ -623,6 +754,18 @@ func applyDynamicConfig(ctx context.Context, objAPI ObjectLayer, s config.Config
 		return fmt.Errorf("Unable to apply scanner config: %w", err)
 	}
 
+	// Event journal
+	journalCfg, err := journal.LookupConfig(s[config.EventJournalSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply event journal config: %w", err)
+	}
+
+	// Notification delivery
+	deliveryCfg, err := notify.LookupDeliveryConfig(s[config.NotifySubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply notification delivery config: %w", err)
+	}
+
 	// Apply configurations.
 	// We should not fail after this.
 	var setDriveCounts []int
@@ -641,6 +784,16 @@ func applyDynamicConfig(ctx context.Context, objAPI ObjectLayer, s config.Config
 	scannerCycle.Update(scannerCfg.Cycle)
 	logger.LogIf(ctx, scannerSleeper.Update(scannerCfg.Delay, scannerCfg.MaxWait))
 
+	if globalEventJournalSys != nil {
+		globalEventJournalSys.SetConfig(journalCfg)
+	}
+
+	deliveryPolicy := event.DeliveryPolicy{RetryBudget: deliveryCfg.RetryBudget}
+	if deliveryCfg.DeadLetterBucket != "" {
+		deliveryPolicy.DeadLetter = deadLetterEvents(deliveryCfg.DeadLetterBucket)
+	}
+	event.SetDeliveryPolicy(deliveryPolicy)
+
 	// Update all dynamic config values in memory.
 	globalServerConfigMu.Lock()
 	defer globalServerConfigMu.Unlock()
@@ -769,17 +922,3 @@ func loadConfig(objAPI ObjectLayer) error {
 
 	return nil
 }
-
-// getOpenIDValidators - returns ValidatorList which contains
-// enabled providers in server config.
-// A new authentication provider is added like below
-// * Add a new provider in pkg/iam/openid package.
-func getOpenIDValidators(cfg openid.Config) *openid.Validators {
-	validators := openid.NewValidators()
-
-	if cfg.Enabled {
-		validators.Add(&cfg)
-	}
-
-	return validators
-}
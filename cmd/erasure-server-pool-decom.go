@@ -0,0 +1,1181 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/hash"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/console"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+
+// PoolDecommissionInfo currently decommissioning information
+type PoolDecommissionInfo struct {
+	StartTime   time.Time `json:"startTime" msg:"st"`
+	StartSize   int64     `json:"startSize" msg:"ss"`
+	TotalSize   int64     `json:"totalSize" msg:"ts"`
+	CurrentSize int64     `json:"currentSize" msg:"cs"`
+
+	Complete bool `json:"complete" msg:"cmp"`
+	Failed   bool `json:"failed" msg:"fl"`
+	Canceled bool `json:"canceled" msg:"cnl"`
+
+	// Internal information.
+	QueuedBuckets         []string `json:"-" msg:"bkts"`
+	DecommissionedBuckets []string `json:"-" msg:"dbkts"`
+
+	// Last bucket/object decommissioned, Object is the last
+	// object of the erasure set at index Set.
+	Bucket string `json:"-" msg:"bkt"`
+	Set    int    `json:"-" msg:"set"`
+	Object string `json:"-" msg:"obj"`
+
+	// Verbose information
+	ItemsDecommissioned     int64 `json:"objectsDecommissioned" msg:"id"`
+	ItemsDecommissionFailed int64 `json:"objectsDecommissionFailed" msg:"idf"`
+	BytesDone               int64 `json:"bytesDecommissioned" msg:"bd"`
+	BytesFailed             int64 `json:"bytesDecommissionedFailed" msg:"bf"`
+}
+
+// bucketPop removes a bucket from the queue once it has been
+// decommissioned completely.
+func (pd *PoolDecommissionInfo) bucketPop(bucket string) {
+	pd.DecommissionedBuckets = append(pd.DecommissionedBuckets, bucket)
+	for i, b := range pd.QueuedBuckets {
+		if b == bucket {
+			// Bucket is done.
+			pd.QueuedBuckets = append(pd.QueuedBuckets[:i], pd.QueuedBuckets[i+1:]...)
+			// Clear tracker info.
+			if pd.Bucket == bucket {
+				pd.Bucket = "" // empty this out for next bucket
+				pd.Object = "" // empty this out for next object
+			}
+			return
+		}
+	}
+}
+
+func (pd *PoolDecommissionInfo) isBucketDecommissioned(bucket string) bool {
+	for _, b := range pd.DecommissionedBuckets {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+func (pd *PoolDecommissionInfo) bucketPush(bucket string) {
+	if pd.isBucketDecommissioned(bucket) {
+		return
+	}
+	for _, b := range pd.QueuedBuckets {
+		if b == bucket {
+			return
+		}
+	}
+	pd.QueuedBuckets = append(pd.QueuedBuckets, bucket)
+	pd.Bucket = bucket
+}
+
+// PoolStatus captures current pool status, the JSON form is
+// compatible with madmin.PoolStatus.
+type PoolStatus struct {
+	ID           int                   `json:"id" msg:"id"`
+	CmdLine      string                `json:"cmdline" msg:"cl"`
+	LastUpdate   time.Time             `json:"lastUpdate" msg:"lu"`
+	Decommission *PoolDecommissionInfo `json:"decomissionInfo,omitempty" msg:"dec"`
+}
+
+type poolMeta struct {
+	Version int          `msg:"v"`
+	Pools   []PoolStatus `msg:"pls"`
+}
+
+// A decommission resumable tells us if decommission is worth
+// resuming upon restart of a cluster.
+func (p *poolMeta) returnResumablePools(n int) []PoolStatus {
+	var newPools []PoolStatus
+	for _, pool := range p.Pools {
+		if pool.Decommission == nil {
+			continue
+		}
+		if pool.Decommission.Complete || pool.Decommission.Canceled {
+			// Do not resume decommission upon completion
+			// or cancellation.
+			continue
+		}
+		newPools = append(newPools, pool)
+		if n > 0 && len(newPools) == n {
+			return newPools
+		}
+	}
+	return newPools
+}
+
+func (p *poolMeta) DecommissionComplete(idx int) bool {
+	if p.Pools[idx].Decommission != nil && !p.Pools[idx].Decommission.Complete {
+		p.Pools[idx].LastUpdate = UTCNow()
+		p.Pools[idx].Decommission.Complete = true
+		p.Pools[idx].Decommission.Failed = false
+		p.Pools[idx].Decommission.Canceled = false
+		return true
+	}
+	return false
+}
+
+func (p *poolMeta) DecommissionFailed(idx int) bool {
+	if p.Pools[idx].Decommission != nil && !p.Pools[idx].Decommission.Failed {
+		p.Pools[idx].LastUpdate = UTCNow()
+		p.Pools[idx].Decommission.StartTime = time.Time{}
+		p.Pools[idx].Decommission.Complete = false
+		p.Pools[idx].Decommission.Failed = true
+		p.Pools[idx].Decommission.Canceled = false
+		return true
+	}
+	return false
+}
+
+func (p *poolMeta) DecommissionCancel(idx int) bool {
+	if p.Pools[idx].Decommission != nil && !p.Pools[idx].Decommission.Canceled {
+		p.Pools[idx].LastUpdate = UTCNow()
+		p.Pools[idx].Decommission.StartTime = time.Time{}
+		p.Pools[idx].Decommission.Complete = false
+		p.Pools[idx].Decommission.Failed = false
+		p.Pools[idx].Decommission.Canceled = true
+		return true
+	}
+	return false
+}
+
+func (p poolMeta) isBucketDecommissioned(idx int, bucket string) bool {
+	return p.Pools[idx].Decommission.isBucketDecommissioned(bucket)
+}
+
+func (p *poolMeta) BucketDone(idx int, bucket string) {
+	if p.Pools[idx].Decommission == nil {
+		// Decommission not in progress.
+		return
+	}
+	p.Pools[idx].Decommission.bucketPop(bucket)
+}
+
+func (p poolMeta) ResumeBucketObject(idx int) (bucket string, set int, object string) {
+	if p.Pools[idx].Decommission != nil {
+		bucket = p.Pools[idx].Decommission.Bucket
+		set = p.Pools[idx].Decommission.Set
+		object = p.Pools[idx].Decommission.Object
+	}
+	return
+}
+
+func (p *poolMeta) TrackCurrentBucketObject(idx int, bucket string, set int, object string) {
+	if p.Pools[idx].Decommission == nil {
+		// Decommission not in progress.
+		return
+	}
+	p.Pools[idx].Decommission.Bucket = bucket
+	p.Pools[idx].Decommission.Set = set
+	p.Pools[idx].Decommission.Object = object
+}
+
+// PendingBuckets returns a copy of the queued buckets, the queue
+// itself is modified by BucketDone while the buckets are processed.
+func (p *poolMeta) PendingBuckets(idx int) []string {
+	if p.Pools[idx].Decommission == nil {
+		// Decommission not in progress.
+		return nil
+	}
+
+	return append([]string(nil), p.Pools[idx].Decommission.QueuedBuckets...)
+}
+
+func (p *poolMeta) QueueBuckets(idx int, buckets []string) {
+	// add new queued buckets
+	for _, bucket := range buckets {
+		p.Pools[idx].Decommission.bucketPush(bucket)
+	}
+}
+
+var (
+	errDecommissionAlreadyRunning = errors.New("decommission is already in progress")
+	errDecommissionComplete       = errors.New("decommission is complete, please remove the servers from command-line")
+	errDecommissionNotStarted     = errors.New("decommission is not in progress")
+	errDecommissionLastPool       = errors.New("decommission is not allowed, at least one other pool must be available for writes")
+)
+
+func (pd *PoolDecommissionInfo) inProgress() bool {
+	return pd != nil && !pd.Complete && !pd.Failed && !pd.Canceled
+}
+
+func (p *poolMeta) Decommission(idx int, pi poolSpaceInfo) error {
+	for i, pool := range p.Pools {
+		if idx == i {
+			continue
+		}
+		if pool.Decommission.inProgress() {
+			// Do not allow multiple decommissions at the same time.
+			// We shall for now only allow one pool decommission at
+			// a time.
+			return fmt.Errorf("%w at index: %d", errDecommissionAlreadyRunning, i)
+		}
+	}
+
+	// Return an error when there is decommission on going - the user needs
+	// to explicitly cancel it first in order to restart decommissioning again.
+	if p.Pools[idx].Decommission.inProgress() {
+		return errDecommissionAlreadyRunning
+	}
+
+	now := UTCNow()
+	p.Pools[idx].LastUpdate = now
+	p.Pools[idx].Decommission = &PoolDecommissionInfo{
+		StartTime:   now,
+		StartSize:   pi.Free,
+		CurrentSize: pi.Free,
+		TotalSize:   pi.Total,
+	}
+	return nil
+}
+
+func (p poolMeta) IsSuspended(idx int) bool {
+	if idx >= len(p.Pools) {
+		// We only really care if pool is suspended or not, there is
+		// no assumption made about the pool being present.
+		return false
+	}
+	// A canceled decommission makes the pool writable again.
+	return p.Pools[idx].Decommission != nil && !p.Pools[idx].Decommission.Canceled
+}
+
+// validate checks the stored pool layout against the pools given on the
+// command line, it returns true if the layout changed and needs to be saved.
+// Pools that were removed from the command line must have been
+// decommissioned completely.
+func (p *poolMeta) validate(pools []*erasureSets) (bool, error) {
+	type poolInfo struct {
+		position  int
+		completed bool
+	}
+
+	rememberedPools := make(map[string]poolInfo)
+	for idx, pool := range p.Pools {
+		rememberedPools[pool.CmdLine] = poolInfo{
+			position:  idx,
+			completed: pool.Decommission != nil && pool.Decommission.Complete,
+		}
+	}
+
+	specifiedPools := make(map[string]int)
+	for idx, pool := range pools {
+		specifiedPools[pool.cmdLine] = idx
+	}
+
+	// Decommissioned pools stay suspended until they are
+	// removed from the command line.
+	for _, pool := range pools {
+		if pi, ok := rememberedPools[pool.cmdLine]; ok && pi.completed {
+			logger.Info("pool(%s) = %s is decommissioned, please remove from server command line",
+				poolOrdinal(pi.position+1), pool.cmdLine)
+		}
+	}
+
+	// Check if the remembered pools were removed from the command line
+	// without being decommissioned first.
+	for k, pi := range rememberedPools {
+		if _, ok := specifiedPools[k]; !ok && !pi.completed {
+			return false, fmt.Errorf("pool(%s) = %s is not decommissioned, please add it back to the server command line",
+				poolOrdinal(pi.position+1), k)
+		}
+	}
+
+	if len(rememberedPools) == 0 || len(specifiedPools) != len(rememberedPools) {
+		return true, nil
+	}
+
+	// Pool positions may change when a decommissioned pool
+	// is removed from the command line.
+	for k, pi := range rememberedPools {
+		if idx, ok := specifiedPools[k]; ok && idx != pi.position {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// poolOrdinal returns the human friendly ordinal for the pool position.
+func poolOrdinal(i int) string {
+	switch i {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	}
+	return fmt.Sprintf("%dth", i)
+}
+
+func (p *poolMeta) load(ctx context.Context, pool *erasureSets, pools []*erasureSets) error {
+	data, err := readConfig(ctx, pool, poolMetaName)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) || isErrObjectNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if len(data) == 0 {
+		// Seems to be empty create a new poolMeta object.
+		return nil
+	}
+	if len(data) <= 4 {
+		return fmt.Errorf("poolMeta: no data")
+	}
+	// Read header
+	switch binary.LittleEndian.Uint16(data[0:2]) {
+	case poolMetaFormat:
+	default:
+		return fmt.Errorf("poolMeta: unknown format: %d", binary.LittleEndian.Uint16(data[0:2]))
+	}
+	switch binary.LittleEndian.Uint16(data[2:4]) {
+	case poolMetaVersion:
+	default:
+		return fmt.Errorf("poolMeta: unknown version: %d", binary.LittleEndian.Uint16(data[2:4]))
+	}
+
+	// OK, parse data.
+	if _, err = p.UnmarshalMsg(data[4:]); err != nil {
+		return err
+	}
+
+	switch p.Version {
+	case poolMetaVersionV1:
+	default:
+		return fmt.Errorf("unexpected pool meta version: %d", p.Version)
+	}
+
+	return nil
+}
+
+func (p *poolMeta) CountItem(idx int, size int64, failed bool) {
+	pd := p.Pools[idx].Decommission
+	if pd != nil {
+		if failed {
+			pd.ItemsDecommissionFailed++
+			pd.BytesFailed += size
+		} else {
+			pd.ItemsDecommissioned++
+			pd.BytesDone += size
+		}
+		p.Pools[idx].Decommission = pd
+	}
+}
+
+func (p *poolMeta) updateAfter(ctx context.Context, idx int, pools []*erasureSets, duration time.Duration) error {
+	if p.Pools[idx].Decommission == nil {
+		return errInvalidArgument
+	}
+	now := UTCNow()
+	if now.Sub(p.Pools[idx].LastUpdate) >= duration {
+		if serverDebugLog {
+			console.Debugf("decommission: persisting poolMeta on drive: threshold:%s, poolMeta:%#v\n", now.Sub(p.Pools[idx].LastUpdate), p.Pools[idx])
+		}
+		p.Pools[idx].LastUpdate = now
+		return p.save(ctx, pools)
+	}
+	return nil
+}
+
+func (p poolMeta) save(ctx context.Context, pools []*erasureSets) error {
+	data := make([]byte, 4, p.Msgsize()+4)
+
+	// Initialize the header.
+	binary.LittleEndian.PutUint16(data[0:2], poolMetaFormat)
+	binary.LittleEndian.PutUint16(data[2:4], poolMetaVersion)
+
+	buf, err := p.MarshalMsg(data)
+	if err != nil {
+		return err
+	}
+
+	// Saves on all pools to make sure decommissioning of first pool is allowed.
+	for _, eset := range pools {
+		if err = saveConfig(ctx, eset, poolMetaName, buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	poolMetaName      = "pool.bin"
+	poolMetaFormat    = 1
+	poolMetaVersionV1 = 1
+	poolMetaVersion   = poolMetaVersionV1
+)
+
+// Init() initializes pools and saves additional information about them
+// in 'pool.bin', this is eventually used for decommissioning the pool.
+func (z *erasureServerPools) Init(ctx context.Context) error {
+	meta := poolMeta{}
+
+	if err := meta.load(ctx, z.serverPools[0], z.serverPools); err != nil {
+		return err
+	}
+
+	update, err := meta.validate(z.serverPools)
+	if err != nil {
+		return err
+	}
+
+	// if no update is needed return right away.
+	if !update {
+		z.poolMeta = meta
+
+		// We are only supporting single pool decommission at this time
+		// so it makes sense to only resume single pools at any given
+		// time, in future meta.returnResumablePools() might take
+		// '-1' as argument to decommission multiple pools at a time
+		// but this is not a priority at the moment.
+		for _, pool := range meta.returnResumablePools(1) {
+			idx := pool.ID
+			if !z.serverPools[0].endpoints[0].IsLocal {
+				// Only the first node of the first pool
+				// resumes the decommission.
+				continue
+			}
+			go func(pool PoolStatus) {
+				switch err := z.Decommission(ctx, idx); err {
+				case nil:
+					// we already started decommission
+				case errDecommissionAlreadyRunning:
+					// A previous decommission running found restart it.
+					z.doDecommissionInRoutine(ctx, idx)
+				default:
+					logger.LogIf(ctx, fmt.Errorf("Unable to resume decommission of pool %v: %w", pool, err))
+				}
+			}(pool)
+		}
+
+		return nil
+	}
+
+	remembered := make(map[string]PoolStatus, len(meta.Pools))
+	for _, pool := range meta.Pools {
+		remembered[pool.CmdLine] = pool
+	}
+
+	meta = poolMeta{Version: poolMetaVersionV1}
+	for idx, pool := range z.serverPools {
+		ps := PoolStatus{
+			ID:         idx,
+			CmdLine:    pool.cmdLine,
+			LastUpdate: UTCNow(),
+		}
+		if rp, ok := remembered[pool.cmdLine]; ok {
+			ps.Decommission = rp.Decommission
+		}
+		meta.Pools = append(meta.Pools, ps)
+	}
+	if err = meta.save(ctx, z.serverPools); err != nil {
+		return err
+	}
+	z.poolMeta = meta
+	return nil
+}
+
+func (z *erasureServerPools) decommissionObject(ctx context.Context, bucket string, version FileInfo, gr *GetObjectReader) (err error) {
+	defer gr.Close()
+
+	object := decodeDirObject(version.Name)
+	objInfo := gr.ObjInfo
+
+	// Preserve all the metadata including the internal
+	// encryption and compression keys, the data is copied
+	// as is without decryption or decompression.
+	userDefined := make(map[string]string, len(version.Metadata))
+	for k, v := range version.Metadata {
+		userDefined[k] = v
+	}
+
+	if len(version.Parts) > 1 {
+		uploadID, err := z.NewMultipartUpload(ctx, bucket, object, ObjectOptions{
+			Versioned:   version.VersionID != "",
+			VersionID:   version.VersionID,
+			MTime:       version.ModTime,
+			UserDefined: userDefined,
+		})
+		if err != nil {
+			return fmt.Errorf("decommissionObject: NewMultipartUpload() %w", err)
+		}
+		defer func() {
+			if err != nil {
+				z.AbortMultipartUpload(ctx, bucket, object, uploadID, ObjectOptions{})
+			}
+		}()
+		parts := make([]CompletePart, len(version.Parts))
+		for i, part := range version.Parts {
+			hr, err := hash.NewReader(gr, part.Size, "", "", part.ActualSize)
+			if err != nil {
+				return fmt.Errorf("decommissionObject: hash.NewReader() %w", err)
+			}
+			pi, err := z.PutObjectPart(ctx, bucket, object, uploadID,
+				part.Number,
+				NewPutObjReader(hr),
+				ObjectOptions{})
+			if err != nil {
+				return fmt.Errorf("decommissionObject: PutObjectPart() %w", err)
+			}
+			parts[i] = CompletePart{
+				ETag:       pi.ETag,
+				PartNumber: pi.PartNumber,
+			}
+		}
+		_, err = z.CompleteMultipartUpload(ctx, bucket, object, uploadID, parts, ObjectOptions{
+			MTime:       version.ModTime,
+			UserDefined: map[string]string{"etag": version.Metadata["etag"]},
+		})
+		if err != nil {
+			err = fmt.Errorf("decommissionObject: CompleteMultipartUpload() %w", err)
+		}
+		return err
+	}
+
+	actualSize, err := objInfo.GetActualSize()
+	if err != nil {
+		return err
+	}
+	hr, err := hash.NewReader(gr, objInfo.Size, "", "", actualSize)
+	if err != nil {
+		return fmt.Errorf("decommissionObject: hash.NewReader() %w", err)
+	}
+	_, err = z.PutObject(ctx,
+		bucket,
+		object,
+		NewPutObjReader(hr),
+		ObjectOptions{
+			Versioned:   version.VersionID != "",
+			VersionID:   version.VersionID,
+			MTime:       version.ModTime,
+			UserDefined: userDefined,
+		})
+	if err != nil {
+		err = fmt.Errorf("decommissionObject: PutObject() %w", err)
+	}
+	return err
+}
+
+// decommissionDeleteMarker re-creates a delete marker with its original
+// version ID and modification time on a pool that is not decommissioning.
+func (z *erasureServerPools) decommissionDeleteMarker(ctx context.Context, bucket string, version FileInfo) error {
+	object := decodeDirObject(version.Name)
+	idx, err := z.getPoolIdx(ctx, bucket, object, 0)
+	if err != nil {
+		return err
+	}
+	versionID := version.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	_, err = z.serverPools[idx].DeleteObject(ctx, bucket, version.Name, ObjectOptions{
+		Versioned:         true,
+		VersionID:         versionID,
+		MTime:             version.ModTime,
+		DeleteReplication: version.ReplicationState,
+		DeleteMarker:      true, // make sure we create a delete marker
+	})
+	return err
+}
+
+// newerExistsElsewhere returns true if a null version of the object with a
+// newer modification time was already written to a pool that is not being
+// decommissioned, in such a case the version must not be moved.
+func (z *erasureServerPools) newerExistsElsewhere(ctx context.Context, idx int, bucket string, version FileInfo) bool {
+	if version.VersionID != "" {
+		return false
+	}
+	for i, pool := range z.serverPools {
+		if i == idx {
+			continue
+		}
+		oi, err := pool.GetObjectInfo(ctx, bucket, version.Name, ObjectOptions{
+			VersionID: nullVersionID,
+			NoLock:    true,
+		})
+		if err == nil && !oi.ModTime.Before(version.ModTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteObjectVersions removes the given versions of an object from the
+// set once they are available on another pool, a prefix delete is not
+// used here since it would also remove the objects nested under it.
+func deleteObjectVersions(ctx context.Context, set *erasureObjects, bucket string, versions []FileInfo, opts ObjectOptions) error {
+	for _, version := range versions {
+		opts.VersionID = version.VersionID
+		if opts.VersionID == "" {
+			opts.VersionID = nullVersionID
+		}
+		_, err := set.DeleteObject(ctx, bucket, encodeDirObject(version.Name), opts)
+		if err != nil && !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (z *erasureServerPools) decommissionPool(ctx context.Context, idx int, pool *erasureSets, bucket string) error {
+	z.poolMetaMutex.RLock()
+	rbucket, rset, robject := z.poolMeta.ResumeBucketObject(idx)
+	z.poolMetaMutex.RUnlock()
+	resume := rbucket != "" && rbucket == bucket
+
+	prefix := ""
+	if strings.HasPrefix(bucket, minioMetaBucket+SlashSeparator) {
+		// Only the configuration and bucket metadata
+		// are decommissioned from the system bucket.
+		bucket, prefix = path2BucketObject(bucket)
+	}
+
+	for setIdx, set := range pool.sets {
+		// If we resume to the same bucket, the sets are listed in
+		// order: sets before the last known one are done, its
+		// listing is forwarded to the last known item.
+		var forwardTo string
+		if resume {
+			if setIdx < rset {
+				continue
+			}
+			if setIdx == rset {
+				forwardTo = robject
+			}
+		}
+
+		disks, _ := set.getOnlineDisksWithHealing()
+		if len(disks) == 0 {
+			logger.LogIf(ctx, fmt.Errorf("decommission: no online drives found for a set in pool %d", idx+1))
+			continue
+		}
+
+		decommissionEntry := func(entry metaCacheEntry) {
+			if entry.isDir() {
+				return
+			}
+
+			fivs, err := entry.fileInfoVersions(bucket)
+			if err != nil {
+				return
+			}
+
+			// We need a reversed order for Decommissioning,
+			// to create the appropriate stack.
+			versionsSorter(fivs.Versions).reverse()
+
+			var decommissionedCount int
+			for _, version := range fivs.Versions {
+				// TODO: Skip transitioned objects for now.
+				if version.IsRemote() {
+					logger.LogIf(ctx, fmt.Errorf("decommission skipping transitioned object %s/%s (%s)",
+						bucket, version.Name, version.VersionID))
+					z.poolMetaMutex.Lock()
+					z.poolMeta.CountItem(idx, version.Size, true)
+					z.poolMetaMutex.Unlock()
+					continue
+				}
+
+				if z.newerExistsElsewhere(ctx, idx, bucket, version) {
+					// A newer null version was written elsewhere
+					// while this pool was being drained.
+					decommissionedCount++
+					continue
+				}
+
+				// We will skip decommissioning delete markers
+				// with single version, its as good as there
+				// is no data associated with the object.
+				if version.Deleted && len(fivs.Versions) == 1 {
+					decommissionedCount++
+					continue
+				}
+
+				if version.Deleted {
+					if err := z.decommissionDeleteMarker(ctx, bucket, version); err != nil {
+						logger.LogIf(ctx, err)
+						z.poolMetaMutex.Lock()
+						z.poolMeta.CountItem(idx, 0, true)
+						z.poolMetaMutex.Unlock()
+						continue
+					}
+					decommissionedCount++
+					z.poolMetaMutex.Lock()
+					z.poolMeta.CountItem(idx, 0, false)
+					z.poolMetaMutex.Unlock()
+					continue
+				}
+
+				versionID := version.VersionID
+				if versionID == "" {
+					versionID = nullVersionID
+				}
+
+				gr, err := set.GetObjectNInfo(ctx,
+					bucket,
+					encodeDirObject(version.Name),
+					nil,
+					http.Header{},
+					noLock, // all mutations are blocked reads are safe without locks.
+					ObjectOptions{
+						VersionID:    versionID,
+						NoDecryption: true,
+					})
+				if err != nil {
+					logger.LogIf(ctx, err)
+					z.poolMetaMutex.Lock()
+					z.poolMeta.CountItem(idx, version.Size, true)
+					z.poolMetaMutex.Unlock()
+					continue
+				}
+				// gr.Close() is ensured by decommissionObject().
+				if err = z.decommissionObject(ctx, bucket, version, gr); err != nil {
+					logger.LogIf(ctx, err)
+					z.poolMetaMutex.Lock()
+					z.poolMeta.CountItem(idx, version.Size, true)
+					z.poolMetaMutex.Unlock()
+					continue
+				}
+				decommissionedCount++
+				z.poolMetaMutex.Lock()
+				z.poolMeta.CountItem(idx, version.Size, false)
+				z.poolMetaMutex.Unlock()
+			}
+
+			// if all versions were decommissioned, then we can delete the object versions.
+			if decommissionedCount == len(fivs.Versions) {
+				logger.LogIf(ctx, deleteObjectVersions(ctx, set, bucket, fivs.Versions, ObjectOptions{}))
+			}
+
+			z.poolMetaMutex.Lock()
+			z.poolMeta.TrackCurrentBucketObject(idx, pathJoin(bucket, prefix), setIdx, fivs.Name)
+			logger.LogIf(ctx, z.poolMeta.updateAfter(ctx, idx, z.serverPools, 30*time.Second))
+			z.poolMetaMutex.Unlock()
+		}
+
+		// How to resolve partial results.
+		resolver := metadataResolutionParams{
+			dirQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+			objQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+			bucket:    bucket,
+		}
+
+		err := listPathRaw(ctx, listPathRawOptions{
+			disks:          disks,
+			bucket:         bucket,
+			path:           prefix,
+			recursive:      true,
+			forwardTo:      forwardTo,
+			minDisks:       len(disks) / 2, // to capture all quorum ratios
+			reportNotFound: false,
+			agreed:         decommissionEntry,
+			partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
+				entry, ok := entries.resolve(&resolver)
+				if ok {
+					decommissionEntry(*entry)
+				}
+			},
+			finished: nil,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (z *erasureServerPools) decommissionInBackground(ctx context.Context, idx int) error {
+	pool := z.serverPools[idx]
+
+	z.poolMetaMutex.RLock()
+	pending := z.poolMeta.PendingBuckets(idx)
+	z.poolMetaMutex.RUnlock()
+
+	for _, bucket := range pending {
+		z.poolMetaMutex.RLock()
+		done := z.poolMeta.isBucketDecommissioned(idx, bucket)
+		z.poolMetaMutex.RUnlock()
+		if done {
+			if serverDebugLog {
+				console.Debugln("decommission: already done, moving on", bucket)
+			}
+
+			z.poolMetaMutex.Lock()
+			z.poolMeta.BucketDone(idx, bucket) // remove from pendingBuckets and persist.
+			logger.LogIf(ctx, z.poolMeta.save(ctx, z.serverPools))
+			z.poolMetaMutex.Unlock()
+			continue
+		}
+		if serverDebugLog {
+			console.Debugln("decommission: currently on bucket", bucket)
+		}
+		if err := z.decommissionPool(ctx, idx, pool, bucket); err != nil {
+			return err
+		}
+		z.poolMetaMutex.Lock()
+		z.poolMeta.BucketDone(idx, bucket)
+		logger.LogIf(ctx, z.poolMeta.save(ctx, z.serverPools))
+		z.poolMetaMutex.Unlock()
+	}
+	return nil
+}
+
+func (z *erasureServerPools) doDecommissionInRoutine(ctx context.Context, idx int) {
+	z.poolMetaMutex.Lock()
+	var dctx context.Context
+	dctx, z.decommissionCancelers[idx] = context.WithCancel(GlobalContext)
+	z.poolMetaMutex.Unlock()
+
+	if err := z.decommissionInBackground(dctx, idx); err != nil {
+		logger.LogIf(GlobalContext, err)
+		logger.LogIf(GlobalContext, z.DecommissionFailed(dctx, idx))
+		return
+	}
+
+	z.poolMetaMutex.RLock()
+	failed := z.poolMeta.Pools[idx].Decommission.ItemsDecommissionFailed > 0
+	z.poolMetaMutex.RUnlock()
+
+	if failed {
+		// Decommission failed indicate as such.
+		logger.LogIf(GlobalContext, z.DecommissionFailed(dctx, idx))
+	} else {
+		// Complete the decommission..
+		logger.LogIf(GlobalContext, z.CompleteDecommission(dctx, idx))
+	}
+}
+
+// IsSuspended returns true if the pool at idx is being
+// decommissioned and must not receive any new writes.
+func (z *erasureServerPools) IsSuspended(idx int) bool {
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+	return z.poolMeta.IsSuspended(idx)
+}
+
+// Decommission - start decommission session.
+func (z *erasureServerPools) Decommission(ctx context.Context, idx int) error {
+	if idx < 0 {
+		return errInvalidArgument
+	}
+
+	if z.SinglePool() {
+		return errInvalidArgument
+	}
+
+	// Make pool unwritable before decommissioning.
+	if err := z.StartDecommission(ctx, idx); err != nil {
+		return err
+	}
+
+	go z.doDecommissionInRoutine(ctx, idx)
+
+	// Successfully started decommissioning.
+	return nil
+}
+
+type decomError struct {
+	Err string
+}
+
+func (d decomError) Error() string {
+	return d.Err
+}
+
+type poolSpaceInfo struct {
+	Free  int64
+	Total int64
+	Used  int64
+}
+
+func (z *erasureServerPools) getDecommissionPoolSpaceInfo(idx int) (pi poolSpaceInfo, err error) {
+	if idx < 0 {
+		return pi, errInvalidArgument
+	}
+	if idx+1 > len(z.serverPools) {
+		return pi, errInvalidArgument
+	}
+
+	info, errs := z.serverPools[idx].StorageInfo(context.Background())
+	for _, err := range errs {
+		if err != nil {
+			return pi, errInvalidArgument
+		}
+	}
+	info.Backend = z.BackendInfo()
+	for _, disk := range info.Disks {
+		if disk.Healing {
+			return pi, decomError{
+				Err: fmt.Sprintf("%s drive is healing, decommission will not be started", disk.Endpoint),
+			}
+		}
+	}
+
+	usableTotal := int64(GetTotalUsableCapacity(info.Disks, info))
+	usableFree := int64(GetTotalUsableCapacityFree(info.Disks, info))
+	return poolSpaceInfo{
+		Total: usableTotal,
+		Free:  usableFree,
+		Used:  usableTotal - usableFree,
+	}, nil
+}
+
+// Status returns the current status of the pool at idx.
+func (z *erasureServerPools) Status(ctx context.Context, idx int) (PoolStatus, error) {
+	if idx < 0 {
+		return PoolStatus{}, errInvalidArgument
+	}
+
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+
+	pi, err := z.getDecommissionPoolSpaceInfo(idx)
+	if err != nil {
+		return PoolStatus{}, errInvalidArgument
+	}
+
+	if idx >= len(z.poolMeta.Pools) {
+		return PoolStatus{ID: idx, CmdLine: z.serverPools[idx].cmdLine}, nil
+	}
+
+	poolInfo := z.poolMeta.Pools[idx]
+	if poolInfo.Decommission != nil {
+		pd := *poolInfo.Decommission
+		pd.TotalSize = pi.Total
+		pd.CurrentSize = pi.Free
+		poolInfo.Decommission = &pd
+	} else {
+		poolInfo.Decommission = &PoolDecommissionInfo{
+			TotalSize:   pi.Total,
+			CurrentSize: pi.Free,
+		}
+	}
+	return poolInfo, nil
+}
+
+// ReloadPoolMeta re-reads the pool layout and the decommission
+// state from the drives, called when a peer updates it.
+func (z *erasureServerPools) ReloadPoolMeta(ctx context.Context) (err error) {
+	meta := poolMeta{}
+
+	if err = meta.load(ctx, z.serverPools[0], z.serverPools); err != nil {
+		return err
+	}
+
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+
+	z.poolMeta = meta
+	return nil
+}
+
+// DecommissionCancel cancels an ongoing decommission, the
+// pool is made available for writes again.
+func (z *erasureServerPools) DecommissionCancel(ctx context.Context, idx int) (err error) {
+	if idx < 0 {
+		return errInvalidArgument
+	}
+
+	if z.SinglePool() {
+		return errInvalidArgument
+	}
+
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+
+	if idx >= len(z.poolMeta.Pools) || z.poolMeta.Pools[idx].Decommission == nil {
+		return errDecommissionNotStarted
+	}
+
+	if z.poolMeta.DecommissionCancel(idx) {
+		if cancel := z.decommissionCancelers[idx]; cancel != nil {
+			cancel() // cancel any active thread.
+		}
+		if err = z.poolMeta.save(ctx, z.serverPools); err != nil {
+			return err
+		}
+		globalNotificationSys.ReloadPoolMeta(ctx)
+	}
+	return nil
+}
+
+// DecommissionFailed marks the decommission of the pool at idx as failed.
+func (z *erasureServerPools) DecommissionFailed(ctx context.Context, idx int) (err error) {
+	if idx < 0 {
+		return errInvalidArgument
+	}
+
+	if z.SinglePool() {
+		return errInvalidArgument
+	}
+
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+
+	if z.poolMeta.DecommissionFailed(idx) {
+		if cancel := z.decommissionCancelers[idx]; cancel != nil {
+			cancel() // cancel any active thread.
+		}
+		if err = z.poolMeta.save(context.Background(), z.serverPools); err != nil {
+			return err
+		}
+		globalNotificationSys.ReloadPoolMeta(ctx)
+	}
+	return nil
+}
+
+// CompleteDecommission marks the decommission of the pool at idx as complete.
+func (z *erasureServerPools) CompleteDecommission(ctx context.Context, idx int) (err error) {
+	if idx < 0 {
+		return errInvalidArgument
+	}
+
+	if z.SinglePool() {
+		return errInvalidArgument
+	}
+
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+
+	if z.poolMeta.DecommissionComplete(idx) {
+		if err = z.poolMeta.save(context.Background(), z.serverPools); err != nil {
+			return err
+		}
+		globalNotificationSys.ReloadPoolMeta(ctx)
+	}
+	return nil
+}
+
+// StartDecommission suspends writes on the pool at idx and queues
+// all buckets to be decommissioned.
+func (z *erasureServerPools) StartDecommission(ctx context.Context, idx int) (err error) {
+	if idx < 0 {
+		return errInvalidArgument
+	}
+
+	if z.SinglePool() {
+		return errInvalidArgument
+	}
+
+	if z.IsRebalanceStarted() {
+		return errDecommissionRebalanceAlreadyRunning
+	}
+
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Make sure to heal the buckets to ensure the new
+	// pool has the new buckets, this is to avoid
+	// failures later.
+	for _, bucket := range buckets {
+		z.HealBucket(ctx, bucket.Name, madmin.HealOpts{Recreate: true})
+	}
+
+	decomBuckets := make([]string, len(buckets))
+	for i := range buckets {
+		decomBuckets[i] = buckets[i].Name
+	}
+
+	// TODO: Support decommissioning transition tiers.
+	for _, bucket := range []string{
+		pathJoin(minioMetaBucket, minioConfigPrefix),
+		pathJoin(minioMetaBucket, bucketMetaPrefix),
+	} {
+		decomBuckets = append(decomBuckets, bucket)
+	}
+
+	var pool *erasureSets
+	for pidx := range z.serverPools {
+		if pidx == idx {
+			pool = z.serverPools[idx]
+			break
+		}
+	}
+
+	if pool == nil {
+		return errInvalidArgument
+	}
+
+	pi, err := z.getDecommissionPoolSpaceInfo(idx)
+	if err != nil {
+		return err
+	}
+
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+
+	if idx >= len(z.poolMeta.Pools) {
+		return errInvalidArgument
+	}
+
+	if z.poolMeta.Pools[idx].Decommission != nil && z.poolMeta.Pools[idx].Decommission.Complete {
+		return errDecommissionComplete
+	}
+
+	available := 0
+	for pidx := range z.serverPools {
+		if pidx != idx && !z.poolMeta.IsSuspended(pidx) {
+			available++
+		}
+	}
+	if available == 0 {
+		return errDecommissionLastPool
+	}
+
+	if err = z.poolMeta.Decommission(idx, pi); err != nil {
+		return err
+	}
+	z.poolMeta.QueueBuckets(idx, decomBuckets)
+	if err = z.poolMeta.save(ctx, z.serverPools); err != nil {
+		return err
+	}
+	globalNotificationSys.ReloadPoolMeta(ctx)
+	return nil
+}
+
+// GetPoolIdx returns the index of the pool specified by
+// its command line arguments, -1 if no such pool exists.
+func (z *erasureServerPools) GetPoolIdx(pool string) int {
+	for idx, eset := range z.serverPools {
+		if eset.cmdLine == pool {
+			return idx
+		}
+	}
+	return -1
+}
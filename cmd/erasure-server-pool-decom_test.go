@@ -0,0 +1,369 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func prepareErasurePools() (ObjectLayer, []string, error) {
+	nDisks := 32
+	fsDirs, err := getRandomDisks(nDisks)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pools := mustGetNewEndpoints(fsDirs[:16]...)
+	pools = append(pools, mustGetNewEndpoints(fsDirs[16:]...)...)
+
+	// Everything is fine, should return nil
+	objLayer, err := newErasureServerPools(context.Background(), EndpointServerPools{
+		{SetCount: 2, DrivesPerSet: 8, Endpoints: pools[:16], CmdLine: "pool1"},
+		{SetCount: 1, DrivesPerSet: 16, Endpoints: pools[16:], CmdLine: "pool2"},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	newAllSubsystems()
+	return objLayer, fsDirs, nil
+}
+
+func TestPoolMetaValidate(t *testing.T) {
+	testCases := []struct {
+		meta           poolMeta
+		pools          []*erasureSets
+		expectedUpdate bool
+		expectedErr    bool
+		name           string
+	}{
+		{
+			name:           "fresh",
+			meta:           poolMeta{},
+			pools:          []*erasureSets{{cmdLine: "pool1"}, {cmdLine: "pool2"}},
+			expectedUpdate: true,
+		},
+		{
+			name: "unchanged",
+			meta: poolMeta{Version: poolMetaVersion, Pools: []PoolStatus{
+				{ID: 0, CmdLine: "pool1"},
+				{ID: 1, CmdLine: "pool2"},
+			}},
+			pools: []*erasureSets{{cmdLine: "pool1"}, {cmdLine: "pool2"}},
+		},
+		{
+			name: "pool-expanded",
+			meta: poolMeta{Version: poolMetaVersion, Pools: []PoolStatus{
+				{ID: 0, CmdLine: "pool1"},
+			}},
+			pools:          []*erasureSets{{cmdLine: "pool1"}, {cmdLine: "pool2"}},
+			expectedUpdate: true,
+		},
+		{
+			name: "decommissioned-pool-removed",
+			meta: poolMeta{Version: poolMetaVersion, Pools: []PoolStatus{
+				{ID: 0, CmdLine: "pool1", Decommission: &PoolDecommissionInfo{Complete: true}},
+				{ID: 1, CmdLine: "pool2"},
+			}},
+			pools:          []*erasureSets{{cmdLine: "pool2"}},
+			expectedUpdate: true,
+		},
+		{
+			name: "pool-removed-without-decommission",
+			meta: poolMeta{Version: poolMetaVersion, Pools: []PoolStatus{
+				{ID: 0, CmdLine: "pool1"},
+				{ID: 1, CmdLine: "pool2"},
+			}},
+			pools:       []*erasureSets{{cmdLine: "pool2"}},
+			expectedErr: true,
+		},
+		{
+			name: "pool-removed-during-decommission",
+			meta: poolMeta{Version: poolMetaVersion, Pools: []PoolStatus{
+				{ID: 0, CmdLine: "pool1", Decommission: &PoolDecommissionInfo{}},
+				{ID: 1, CmdLine: "pool2"},
+			}},
+			pools:       []*erasureSets{{cmdLine: "pool2"}},
+			expectedErr: true,
+		},
+		{
+			name: "pools-reordered",
+			meta: poolMeta{Version: poolMetaVersion, Pools: []PoolStatus{
+				{ID: 0, CmdLine: "pool1"},
+				{ID: 1, CmdLine: "pool2"},
+			}},
+			pools:          []*erasureSets{{cmdLine: "pool2"}, {cmdLine: "pool1"}},
+			expectedUpdate: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			update, err := testCase.meta.validate(testCase.pools)
+			if testCase.expectedErr && err == nil {
+				t.Fatalf("Expected error, but found none")
+			}
+			if !testCase.expectedErr && err != nil {
+				t.Fatalf("Expected success, but found %v", err)
+			}
+			if update != testCase.expectedUpdate {
+				t.Fatalf("Expected update %t, got %t", testCase.expectedUpdate, update)
+			}
+		})
+	}
+}
+
+func TestPoolMetaDecommission(t *testing.T) {
+	meta := poolMeta{Version: poolMetaVersion, Pools: []PoolStatus{
+		{ID: 0, CmdLine: "pool1"},
+		{ID: 1, CmdLine: "pool2"},
+		{ID: 2, CmdLine: "pool3"},
+	}}
+
+	if err := meta.Decommission(0, poolSpaceInfo{Total: 100, Free: 50}); err != nil {
+		t.Fatal(err)
+	}
+	if !meta.IsSuspended(0) {
+		t.Fatal("Expected pool to be suspended")
+	}
+	if err := meta.Decommission(0, poolSpaceInfo{}); err != errDecommissionAlreadyRunning {
+		t.Fatalf("Expected %v, got %v", errDecommissionAlreadyRunning, err)
+	}
+	if err := meta.Decommission(1, poolSpaceInfo{}); err == nil {
+		t.Fatal("Expected only one decommission at a time")
+	}
+
+	meta.QueueBuckets(0, []string{"bucket1", "bucket2", "bucket1"})
+	if pending := meta.PendingBuckets(0); len(pending) != 2 {
+		t.Fatalf("Expected 2 queued buckets, got %v", pending)
+	}
+	meta.BucketDone(0, "bucket1")
+	if !meta.isBucketDecommissioned(0, "bucket1") {
+		t.Fatal("Expected bucket1 to be decommissioned")
+	}
+	meta.QueueBuckets(0, []string{"bucket1"})
+	if pending := meta.PendingBuckets(0); len(pending) != 1 || pending[0] != "bucket2" {
+		t.Fatalf("Expected only bucket2 to be pending, got %v", pending)
+	}
+
+	if !meta.DecommissionCancel(0) {
+		t.Fatal("Expected decommission to be canceled")
+	}
+	if meta.IsSuspended(0) {
+		t.Fatal("Expected canceled pool to be writable")
+	}
+	if len(meta.returnResumablePools(-1)) != 0 {
+		t.Fatal("Expected canceled decommission to not be resumable")
+	}
+
+	// A canceled decommission can be restarted on any pool.
+	if err := meta.Decommission(1, poolSpaceInfo{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(meta.returnResumablePools(-1)) != 1 {
+		t.Fatal("Expected decommission to be resumable")
+	}
+}
+
+func TestDecommissionPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasurePools()
+	if err != nil {
+		t.Fatalf("Initialization of object layer failed for Erasure setup: %s", err)
+	}
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	if err = z.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Several buckets make sure that every queued bucket is processed.
+	buckets := []string{"decom-bucket-1", "decom-bucket-2", "decom-bucket-3", "decom-bucket-4"}
+	for _, bucket := range buckets {
+		if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bucket := buckets[0]
+
+	var objects []string
+	for i := 0; i < 10; i++ {
+		object := fmt.Sprintf("prefix/object-%d", i)
+		objects = append(objects, object)
+	}
+	for _, bucket := range buckets {
+		for i, object := range objects {
+			data := bytes.Repeat([]byte{byte(i)}, 1024*(i+1))
+			_, err = z.serverPools[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if err = z.StartDecommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !z.IsSuspended(0) {
+		t.Fatal("Expected pool to be suspended")
+	}
+	if err = z.StartDecommission(ctx, 1); err == nil {
+		t.Fatal("Expected second decommission to fail")
+	}
+
+	// New writes must not land on the decommissioning pool.
+	data := []byte("new data")
+	_, err = obj.PutObject(ctx, bucket, "new-object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.serverPools[1].GetObjectInfo(ctx, bucket, "new-object", ObjectOptions{}); err != nil {
+		t.Fatalf("Expected new object on second pool, got %v", err)
+	}
+
+	if err = z.decommissionInBackground(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if pending := z.poolMeta.PendingBuckets(0); len(pending) != 0 {
+		t.Fatalf("Expected no pending buckets, got %v", pending)
+	}
+	if err = z.CompleteDecommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, bucket := range buckets {
+		for i, object := range objects {
+			if _, err = z.serverPools[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+				t.Fatalf("Expected %s/%s to be removed from first pool, got %v", bucket, object, err)
+			}
+			gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(gr)
+			gr.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, bytes.Repeat([]byte{byte(i)}, 1024*(i+1))) {
+				t.Fatalf("Unexpected content for %s/%s after decommission", bucket, object)
+			}
+		}
+	}
+
+	status, err := z.Status(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Decommission.Complete {
+		t.Fatal("Expected decommission to be complete")
+	}
+	// Bucket metadata may also be placed on the first pool.
+	if status.Decommission.ItemsDecommissioned < int64(len(buckets)*len(objects)) {
+		t.Fatalf("Expected at least %d objects decommissioned, got %d", len(buckets)*len(objects), status.Decommission.ItemsDecommissioned)
+	}
+	if status.Decommission.ItemsDecommissionFailed != 0 {
+		t.Fatalf("Expected no failures, got %d", status.Decommission.ItemsDecommissionFailed)
+	}
+
+	// Decommission state must survive a reload.
+	if err = z.ReloadPoolMeta(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !z.IsSuspended(0) {
+		t.Fatal("Expected pool to remain suspended after reload")
+	}
+}
+
+func TestDecommissionPoolResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasurePools()
+	if err != nil {
+		t.Fatalf("Initialization of object layer failed for Erasure setup: %s", err)
+	}
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	if err = z.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "decom-resume"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	pool := z.serverPools[0]
+	var objects []string
+	for i := 0; i < 20; i++ {
+		object := fmt.Sprintf("object-%02d", i)
+		data := []byte(object)
+		_, err = pool.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, object)
+	}
+
+	if err = z.StartDecommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Resume after the last object of the first set, the objects
+	// of the second set sorting before it must not be skipped.
+	var last string
+	for _, object := range objects {
+		if pool.getHashedSetIndex(object) == 0 {
+			last = object
+		}
+	}
+	z.poolMetaMutex.Lock()
+	z.poolMeta.TrackCurrentBucketObject(0, bucket, 0, last)
+	z.poolMetaMutex.Unlock()
+
+	if err = z.decommissionPool(ctx, 0, pool, bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, object := range objects {
+		_, err = pool.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		switch {
+		case pool.getHashedSetIndex(object) == 0 && object < last:
+			// Considered done by the resumed decommission.
+			if err != nil {
+				t.Fatalf("Expected %s to be skipped, got %v", object, err)
+			}
+		case !isErrObjectNotFound(err):
+			t.Fatalf("Expected %s to be removed from first pool, got %v", object, err)
+		default:
+			if _, err = z.serverPools[1].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+				t.Fatalf("Expected %s on second pool, got %v", object, err)
+			}
+		}
+	}
+}
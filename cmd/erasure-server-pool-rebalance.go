@@ -0,0 +1,870 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/hash"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/console"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+
+// rebalStatus represents the state of a rebalance operation on a pool.
+type rebalStatus uint8
+
+const (
+	rebalNone rebalStatus = iota
+	rebalStarted
+	rebalCompleted
+	rebalStopped
+	rebalFailed
+)
+
+func (r rebalStatus) String() string {
+	switch r {
+	case rebalStarted:
+		return "Started"
+	case rebalCompleted:
+		return "Completed"
+	case rebalStopped:
+		return "Stopped"
+	case rebalFailed:
+		return "Failed"
+	default:
+		return "None"
+	}
+}
+
+type rebalanceInfo struct {
+	StartTime time.Time   `msg:"startTs"` // Time at which rebalance-start was issued.
+	EndTime   time.Time   `msg:"stopTs"`  // Time at which rebalance completed or was stopped.
+	Status    rebalStatus `msg:"status"`  // Current state of rebalance operation.
+}
+
+// rebalanceStats contains the progress of rebalancing a single pool.
+type rebalanceStats struct {
+	InitFreeSpace uint64 `json:"initFreeSpace" msg:"ifs"` // Pool free space at the start of rebalance.
+	InitCapacity  uint64 `json:"initCapacity" msg:"ic"`   // Pool capacity at the start of rebalance.
+
+	Buckets           []string `json:"buckets" msg:"bus"`           // buckets being rebalanced or to be rebalanced.
+	RebalancedBuckets []string `json:"rebalancedBuckets" msg:"rbs"` // buckets rebalanced.
+
+	// Last bucket/object rebalanced.
+	Bucket string `json:"bucket" msg:"bu"`
+	Object string `json:"object" msg:"ob"`
+
+	NumObjects    uint64        `json:"numObjects" msg:"no"`     // Number of objects rebalanced.
+	NumVersions   uint64        `json:"numVersions" msg:"nv"`    // Number of versions rebalanced.
+	NumFailed     uint64        `json:"numFailed" msg:"nf"`      // Number of versions that failed to be rebalanced.
+	Bytes         uint64        `json:"bytes" msg:"bs"`          // Number of bytes rebalanced.
+	Participating bool          `json:"participating" msg:"par"` // Pool is moving data to the other pools.
+	Info          rebalanceInfo `json:"info" msg:"inf"`
+}
+
+func (rs *rebalanceStats) update(bucket string, fi FileInfo) {
+	if fi.IsLatest {
+		rs.NumObjects++
+	}
+
+	rs.NumVersions++
+	rs.Bytes += uint64(fi.Size)
+	rs.Bucket = bucket
+	rs.Object = fi.Name
+}
+
+// rebalanceMeta is the persisted state of a rebalance operation,
+// saved as 'rebalance.bin' under the system bucket.
+type rebalanceMeta struct {
+	cancel          context.CancelFunc `msg:"-"` // to be invoked on rebalance-stop
+	lastRefreshedAt time.Time          `msg:"-"`
+
+	StoppedAt       time.Time         `msg:"stopTs"` // Time when rebalance-stop was issued.
+	ID              string            `msg:"id"`     // ID of the ongoing rebalance operation.
+	PercentFreeGoal float64           `msg:"pf"`     // Computed from total free space and capacity at the start of rebalance.
+	Band            float64           `msg:"bd"`     // Acceptable deviation of a pool's free space from the goal.
+	PoolStats       []*rebalanceStats `msg:"rss"`    // Per-pool rebalance stats keyed by pool index.
+}
+
+const (
+	rebalMetaName = "rebalance.bin"
+	rebalMetaFmt  = 1
+	rebalMetaVer  = 1
+
+	// Pools within 5% of the free space goal are considered balanced.
+	defaultRebalanceBand = 0.05
+)
+
+var (
+	errRebalanceNotStarted                 = errors.New("rebalance is not started")
+	errRebalanceAlreadyRunning             = errors.New("rebalance is already in progress")
+	errRebalanceNotNeeded                  = errors.New("pools are already balanced")
+	errRebalanceDecommissionAlreadyRunning = errors.New("rebalance cannot be started, decommission is already in progress")
+	errDecommissionRebalanceAlreadyRunning = errors.New("decommission cannot be started, rebalance is already in progress")
+)
+
+// load reads the rebalance metadata from the drives.
+func (r *rebalanceMeta) load(ctx context.Context, store objectIO) error {
+	data, err := readConfig(ctx, store, rebalMetaName)
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+	if len(data) <= 4 {
+		return fmt.Errorf("rebalanceMeta: no data")
+	}
+
+	// Read header
+	switch binary.LittleEndian.Uint16(data[0:2]) {
+	case rebalMetaFmt:
+	default:
+		return fmt.Errorf("rebalanceMeta: unknown format: %d", binary.LittleEndian.Uint16(data[0:2]))
+	}
+	switch binary.LittleEndian.Uint16(data[2:4]) {
+	case rebalMetaVer:
+	default:
+		return fmt.Errorf("rebalanceMeta: unknown version: %d", binary.LittleEndian.Uint16(data[2:4]))
+	}
+
+	// OK, parse data.
+	if _, err = r.UnmarshalMsg(data[4:]); err != nil {
+		return err
+	}
+
+	r.lastRefreshedAt = UTCNow()
+	return nil
+}
+
+// save persists the rebalance metadata on the drives.
+func (r *rebalanceMeta) save(ctx context.Context, store objectIO) error {
+	data := make([]byte, 4, r.Msgsize()+4)
+
+	// Initialize the header.
+	binary.LittleEndian.PutUint16(data[0:2], rebalMetaFmt)
+	binary.LittleEndian.PutUint16(data[2:4], rebalMetaVer)
+
+	buf, err := r.MarshalMsg(data)
+	if err != nil {
+		return err
+	}
+
+	return saveConfig(ctx, store, rebalMetaName, buf)
+}
+
+// isDone returns true if the free space of the pool at idx is within
+// the configured band of the free space goal.
+func (r *rebalanceMeta) isDone(idx int) bool {
+	ps := r.PoolStats[idx]
+	if ps.InitCapacity == 0 {
+		return true
+	}
+	pfi := float64(ps.InitFreeSpace+ps.Bytes) / float64(ps.InitCapacity)
+	return r.PercentFreeGoal-pfi <= r.Band
+}
+
+// loadRebalanceMeta reads the rebalance metadata from the drives, a
+// missing 'rebalance.bin' means no rebalance was ever started.
+func (z *erasureServerPools) loadRebalanceMeta(ctx context.Context) error {
+	r := &rebalanceMeta{}
+	err := r.load(ctx, z.serverPools[0])
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		return err
+	}
+
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	if errors.Is(err, errConfigNotFound) || len(r.PoolStats) != len(z.serverPools) {
+		// Rebalance metadata from a different pool layout
+		// is stale, a new rebalance must be started.
+		z.rebalMeta = nil
+		return nil
+	}
+
+	if z.rebalMeta != nil {
+		r.cancel = z.rebalMeta.cancel
+	}
+	z.rebalMeta = r
+	return nil
+}
+
+// initRebalanceMeta computes the free space goal and the pools
+// participating in a new rebalance operation and persists them.
+func (z *erasureServerPools) initRebalanceMeta(ctx context.Context, band float64) (id string, err error) {
+	if z.SinglePool() {
+		return "", errInvalidArgument
+	}
+
+	z.poolMetaMutex.RLock()
+	for idx := range z.serverPools {
+		if idx < len(z.poolMeta.Pools) && z.poolMeta.Pools[idx].Decommission.inProgress() {
+			z.poolMetaMutex.RUnlock()
+			return "", errRebalanceDecommissionAlreadyRunning
+		}
+	}
+	z.poolMetaMutex.RUnlock()
+
+	if z.IsRebalanceStarted() {
+		return "", errRebalanceAlreadyRunning
+	}
+
+	// Fetch the free space and capacity of all the pools.
+	poolsInfo := make([]poolSpaceInfo, len(z.serverPools))
+	var totalCap, totalFree uint64
+	for idx := range z.serverPools {
+		if z.IsSuspended(idx) {
+			// Suspended pools neither take part in the
+			// rebalance nor receive any data.
+			continue
+		}
+		pi, err := z.getDecommissionPoolSpaceInfo(idx)
+		if err != nil {
+			return "", err
+		}
+		poolsInfo[idx] = pi
+		totalCap += uint64(pi.Total)
+		totalFree += uint64(pi.Free)
+	}
+	if totalCap == 0 {
+		return "", errRebalanceNotNeeded
+	}
+
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Make sure to heal the buckets to ensure all the
+	// pools have the buckets, this is to avoid failures
+	// later.
+	bucketNames := make([]string, 0, len(buckets))
+	for _, bucket := range buckets {
+		z.HealBucket(ctx, bucket.Name, madmin.HealOpts{Recreate: true})
+		bucketNames = append(bucketNames, bucket.Name)
+	}
+
+	now := UTCNow()
+	r := &rebalanceMeta{
+		ID:              mustGetUUID(),
+		PercentFreeGoal: float64(totalFree) / float64(totalCap),
+		Band:            band,
+		PoolStats:       make([]*rebalanceStats, len(z.serverPools)),
+	}
+
+	var participating int
+	for idx, pi := range poolsInfo {
+		ps := &rebalanceStats{
+			InitFreeSpace: uint64(pi.Free),
+			InitCapacity:  uint64(pi.Total),
+		}
+		if pi.Total > 0 {
+			pfi := float64(pi.Free) / float64(pi.Total)
+			ps.Participating = r.PercentFreeGoal-pfi > band
+		}
+		if ps.Participating {
+			participating++
+			ps.Buckets = append([]string(nil), bucketNames...)
+			ps.Info = rebalanceInfo{
+				StartTime: now,
+				Status:    rebalStarted,
+			}
+		}
+		r.PoolStats[idx] = ps
+	}
+
+	if participating == 0 {
+		return "", errRebalanceNotNeeded
+	}
+
+	if err = r.save(ctx, z.serverPools[0]); err != nil {
+		return "", err
+	}
+
+	z.rebalMu.Lock()
+	z.rebalMeta = r
+	z.rebalMu.Unlock()
+
+	return r.ID, nil
+}
+
+// IsRebalanceStarted returns true if a rebalance is in progress.
+func (z *erasureServerPools) IsRebalanceStarted() bool {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+
+	if r := z.rebalMeta; r != nil {
+		if !r.StoppedAt.IsZero() {
+			return false
+		}
+		for _, ps := range r.PoolStats {
+			if ps.Participating && ps.Info.Status == rebalStarted {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsPoolRebalancing returns true if the pool at idx is moving its
+// data to the other pools, such pools do not receive new writes.
+func (z *erasureServerPools) IsPoolRebalancing(idx int) bool {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+
+	if r := z.rebalMeta; r != nil {
+		if !r.StoppedAt.IsZero() || idx >= len(r.PoolStats) {
+			return false
+		}
+		ps := r.PoolStats[idx]
+		return ps.Participating && ps.Info.Status == rebalStarted
+	}
+	return false
+}
+
+// nextRebalBucket returns the next bucket to be rebalanced from the pool
+// at idx, an empty string is returned once all buckets are done.
+func (z *erasureServerPools) nextRebalBucket(idx int) string {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+
+	if z.rebalMeta == nil || !z.rebalMeta.StoppedAt.IsZero() {
+		return ""
+	}
+
+	ps := z.rebalMeta.PoolStats[idx]
+	if ps.Info.Status != rebalStarted {
+		return ""
+	}
+	if len(ps.Buckets) == 0 {
+		return ""
+	}
+	return ps.Buckets[0]
+}
+
+// bucketRebalanceDone moves the bucket from the queue of pending
+// buckets to the rebalanced buckets of the pool at idx.
+func (z *erasureServerPools) bucketRebalanceDone(bucket string, idx int) {
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	ps := z.rebalMeta.PoolStats[idx]
+	for i, b := range ps.Buckets {
+		if b == bucket {
+			ps.Buckets = append(ps.Buckets[:i], ps.Buckets[i+1:]...)
+			ps.RebalancedBuckets = append(ps.RebalancedBuckets, bucket)
+			break
+		}
+	}
+}
+
+// checkIfRebalanceDone marks the rebalance of the pool at idx as
+// complete once its free space is within the band of the goal.
+func (z *erasureServerPools) checkIfRebalanceDone(idx int) bool {
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	ps := z.rebalMeta.PoolStats[idx]
+	if ps.Info.Status == rebalCompleted {
+		return true
+	}
+
+	if z.rebalMeta.isDone(idx) {
+		ps.Info.Status = rebalCompleted
+		ps.Info.EndTime = UTCNow()
+		return true
+	}
+	return false
+}
+
+// updatePoolStats records a version rebalanced from the pool at idx.
+func (z *erasureServerPools) updatePoolStats(idx int, bucket string, fi FileInfo, failed bool) {
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	ps := z.rebalMeta.PoolStats[idx]
+	if failed {
+		ps.NumFailed++
+		return
+	}
+	ps.update(bucket, fi)
+}
+
+// saveRebalanceStats persists the rebalance metadata if it has not been
+// saved in the last 'duration', a zero duration saves right away.
+func (z *erasureServerPools) saveRebalanceStats(ctx context.Context, duration time.Duration) error {
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	if z.rebalMeta == nil {
+		return nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		// Rebalance was stopped, the state is persisted by
+		// the node stopping it.
+		return err
+	}
+
+	now := UTCNow()
+	if now.Sub(z.rebalMeta.lastRefreshedAt) < duration {
+		return nil
+	}
+	if serverDebugLog {
+		console.Debugf("rebalance: persisting rebalanceMeta on drive: threshold:%s\n", now.Sub(z.rebalMeta.lastRefreshedAt))
+	}
+	z.rebalMeta.lastRefreshedAt = now
+	return z.rebalMeta.save(ctx, z.serverPools[0])
+}
+
+// StartRebalance moves data from the participating pools to the other
+// pools in the background, one routine per participating pool.
+func (z *erasureServerPools) StartRebalance() {
+	z.rebalMu.Lock()
+	if z.rebalMeta == nil || !z.rebalMeta.StoppedAt.IsZero() {
+		z.rebalMu.Unlock()
+		return
+	}
+	if z.rebalMeta.cancel != nil {
+		// Rebalance is already running on this node.
+		z.rebalMu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(GlobalContext)
+	z.rebalMeta.cancel = cancel
+	var pools []int
+	for idx, ps := range z.rebalMeta.PoolStats {
+		if ps.Participating && ps.Info.Status == rebalStarted {
+			pools = append(pools, idx)
+		}
+	}
+	z.rebalMu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, idx := range pools {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			if err := z.rebalanceBuckets(ctx, idx); err != nil && !errors.Is(err, context.Canceled) {
+				logger.LogIf(GlobalContext, fmt.Errorf("rebalance of pool %d failed: %w", idx+1, err))
+			}
+		}(idx)
+	}
+	wg.Wait()
+
+	z.rebalMu.Lock()
+	if z.rebalMeta != nil {
+		z.rebalMeta.cancel = nil
+	}
+	z.rebalMu.Unlock()
+	cancel()
+}
+
+// StopRebalance cancels the rebalance routines running on this node
+// and persists their progress.
+func (z *erasureServerPools) StopRebalance() error {
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	r := z.rebalMeta
+	if r == nil || r.cancel == nil {
+		// rebalance not running on this node, nothing to do
+		return nil
+	}
+
+	r.cancel()
+	r.cancel = nil
+	return r.save(GlobalContext, z.serverPools[0])
+}
+
+// rebalanceBuckets moves data off the pool at idx, one bucket at a time,
+// until the pool reaches the free space goal or all buckets are done.
+func (z *erasureServerPools) rebalanceBuckets(ctx context.Context, idx int) (err error) {
+	defer func() {
+		if ctx.Err() != nil {
+			// Rebalance was stopped, the stopping node
+			// has already persisted the final state.
+			return
+		}
+
+		z.rebalMu.Lock()
+		if z.rebalMeta == nil {
+			z.rebalMu.Unlock()
+			return
+		}
+		ps := z.rebalMeta.PoolStats[idx]
+		switch {
+		case err != nil:
+			ps.Info.Status = rebalFailed
+		default:
+			ps.Info.Status = rebalCompleted
+		}
+		ps.Info.EndTime = UTCNow()
+		z.rebalMu.Unlock()
+
+		logger.LogIf(GlobalContext, z.saveRebalanceStats(GlobalContext, 0))
+		globalNotificationSys.LoadRebalanceMeta(GlobalContext, false)
+	}()
+
+	for {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		bucket := z.nextRebalBucket(idx)
+		if bucket == "" {
+			return nil
+		}
+
+		if err = z.rebalanceBucket(ctx, bucket, idx); err != nil {
+			return err
+		}
+
+		if z.checkIfRebalanceDone(idx) {
+			return nil
+		}
+
+		z.bucketRebalanceDone(bucket, idx)
+		logger.LogIf(ctx, z.saveRebalanceStats(ctx, 0))
+	}
+}
+
+// rebalanceObject re-creates a version on a pool that is not rebalancing,
+// the caller holds the namespace lock of the object.
+func (z *erasureServerPools) rebalanceObject(ctx context.Context, bucket string, version FileInfo, gr *GetObjectReader) (err error) {
+	defer gr.Close()
+
+	object := decodeDirObject(version.Name)
+	objInfo := gr.ObjInfo
+
+	// Preserve all the metadata including the internal
+	// encryption and compression keys, the data is copied
+	// as is without decryption or decompression.
+	userDefined := make(map[string]string, len(version.Metadata))
+	for k, v := range version.Metadata {
+		userDefined[k] = v
+	}
+
+	if len(version.Parts) > 1 {
+		// The namespace lock of the object is held by the caller,
+		// the destination pool is picked without locking it again.
+		idx, err := z.getPoolIdxNoLock(ctx, bucket, object, version.Size)
+		if err != nil {
+			return fmt.Errorf("rebalanceObject: getPoolIdxNoLock() %w", err)
+		}
+		pool := z.serverPools[idx]
+		uploadID, err := pool.NewMultipartUpload(ctx, bucket, object, ObjectOptions{
+			Versioned:   version.VersionID != "",
+			VersionID:   version.VersionID,
+			MTime:       version.ModTime,
+			UserDefined: userDefined,
+		})
+		if err != nil {
+			return fmt.Errorf("rebalanceObject: NewMultipartUpload() %w", err)
+		}
+		defer func() {
+			if err != nil {
+				pool.AbortMultipartUpload(ctx, bucket, object, uploadID, ObjectOptions{})
+			}
+		}()
+		parts := make([]CompletePart, len(version.Parts))
+		for i, part := range version.Parts {
+			hr, err := hash.NewReader(gr, part.Size, "", "", part.ActualSize)
+			if err != nil {
+				return fmt.Errorf("rebalanceObject: hash.NewReader() %w", err)
+			}
+			pi, err := pool.PutObjectPart(ctx, bucket, object, uploadID,
+				part.Number,
+				NewPutObjReader(hr),
+				ObjectOptions{})
+			if err != nil {
+				return fmt.Errorf("rebalanceObject: PutObjectPart() %w", err)
+			}
+			parts[i] = CompletePart{
+				ETag:       pi.ETag,
+				PartNumber: pi.PartNumber,
+			}
+		}
+		_, err = pool.CompleteMultipartUpload(ctx, bucket, object, uploadID, parts, ObjectOptions{
+			MTime:       version.ModTime,
+			UserDefined: map[string]string{"etag": version.Metadata["etag"]},
+			NoLock:      true,
+		})
+		if err != nil {
+			err = fmt.Errorf("rebalanceObject: CompleteMultipartUpload() %w", err)
+		}
+		return err
+	}
+
+	actualSize, err := objInfo.GetActualSize()
+	if err != nil {
+		return err
+	}
+	hr, err := hash.NewReader(gr, objInfo.Size, "", "", actualSize)
+	if err != nil {
+		return fmt.Errorf("rebalanceObject: hash.NewReader() %w", err)
+	}
+	_, err = z.PutObject(ctx,
+		bucket,
+		object,
+		NewPutObjReader(hr),
+		ObjectOptions{
+			Versioned:   version.VersionID != "",
+			VersionID:   version.VersionID,
+			MTime:       version.ModTime,
+			UserDefined: userDefined,
+			NoLock:      true,
+		})
+	if err != nil {
+		err = fmt.Errorf("rebalanceObject: PutObject() %w", err)
+	}
+	return err
+}
+
+// rebalanceDeleteMarker re-creates a delete marker with its original
+// version ID and modification time on a pool that is not rebalancing.
+func (z *erasureServerPools) rebalanceDeleteMarker(ctx context.Context, bucket string, version FileInfo) error {
+	object := decodeDirObject(version.Name)
+	idx, err := z.getPoolIdxNoLock(ctx, bucket, object, 0)
+	if err != nil {
+		return err
+	}
+	versionID := version.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	_, err = z.serverPools[idx].DeleteObject(ctx, bucket, version.Name, ObjectOptions{
+		Versioned:         true,
+		VersionID:         versionID,
+		MTime:             version.ModTime,
+		DeleteReplication: version.ReplicationState,
+		DeleteMarker:      true, // make sure we create a delete marker
+		NoLock:            true,
+	})
+	return err
+}
+
+// rebalanceEntry moves all the versions of an object from the pool at
+// idx while holding the namespace lock of the object, the versions are
+// removed from the pool only once all of them were moved.
+func (z *erasureServerPools) rebalanceEntry(ctx context.Context, idx int, set *erasureObjects, bucket string, entry metaCacheEntry) {
+	if entry.isDir() {
+		return
+	}
+
+	fivs, err := entry.fileInfoVersions(bucket)
+	if err != nil {
+		return
+	}
+
+	lk := z.NewNSLock(bucket, fivs.Name)
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("rebalance: unable to lock %s/%s: %w", bucket, fivs.Name, err))
+		for _, version := range fivs.Versions {
+			z.updatePoolStats(idx, bucket, version, true)
+		}
+		return
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	// Move the oldest version first to keep the
+	// version history intact on the new pool.
+	versionsSorter(fivs.Versions).reverse()
+
+	var rebalanced int
+	for _, version := range fivs.Versions {
+		// TODO: Skip transitioned objects for now.
+		if version.IsRemote() {
+			z.updatePoolStats(idx, bucket, version, true)
+			continue
+		}
+
+		if z.newerExistsElsewhere(ctx, idx, bucket, version) {
+			// A newer null version was written elsewhere,
+			// this version is no longer visible.
+			rebalanced++
+			continue
+		}
+
+		// A delete marker without any versions has no
+		// data associated with it, it is simply removed.
+		if version.Deleted && len(fivs.Versions) == 1 {
+			rebalanced++
+			continue
+		}
+
+		if version.Deleted {
+			if err = z.rebalanceDeleteMarker(ctx, bucket, version); err != nil {
+				logger.LogIf(ctx, err)
+				z.updatePoolStats(idx, bucket, version, true)
+				continue
+			}
+			rebalanced++
+			z.updatePoolStats(idx, bucket, version, false)
+			continue
+		}
+
+		versionID := version.VersionID
+		if versionID == "" {
+			versionID = nullVersionID
+		}
+
+		gr, err := set.GetObjectNInfo(ctx,
+			bucket,
+			encodeDirObject(version.Name),
+			nil,
+			http.Header{},
+			noLock, // the namespace lock is held by the caller.
+			ObjectOptions{
+				VersionID:    versionID,
+				NoDecryption: true,
+			})
+		if err != nil {
+			logger.LogIf(ctx, err)
+			z.updatePoolStats(idx, bucket, version, true)
+			continue
+		}
+		// gr.Close() is ensured by rebalanceObject().
+		if err = z.rebalanceObject(ctx, bucket, version, gr); err != nil {
+			logger.LogIf(ctx, err)
+			z.updatePoolStats(idx, bucket, version, true)
+			continue
+		}
+		rebalanced++
+		z.updatePoolStats(idx, bucket, version, false)
+	}
+
+	// Remove the versions from this pool only if all of
+	// them were moved, partially moved objects are retried
+	// by the next rebalance.
+	if rebalanced == len(fivs.Versions) {
+		logger.LogIf(ctx, deleteObjectVersions(ctx, set, bucket, fivs.Versions, ObjectOptions{NoLock: true}))
+	}
+}
+
+// rebalanceBucket moves the objects of bucket from the pool at idx, the
+// listing stops early once the pool has reached the free space goal.
+func (z *erasureServerPools) rebalanceBucket(ctx context.Context, bucket string, idx int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pool := z.serverPools[idx]
+	for _, set := range pool.sets {
+		disks, _ := set.getOnlineDisksWithHealing()
+		if len(disks) == 0 {
+			logger.LogIf(ctx, fmt.Errorf("rebalance: no online drives found for a set in pool %d", idx+1))
+			continue
+		}
+
+		var done bool
+		rebalanceEntry := func(entry metaCacheEntry) {
+			if done {
+				return
+			}
+			z.rebalanceEntry(ctx, idx, set, bucket, entry)
+			if z.checkIfRebalanceDone(idx) {
+				done = true
+				cancel() // stop listing.
+				return
+			}
+			logger.LogIf(ctx, z.saveRebalanceStats(ctx, 30*time.Second))
+		}
+
+		// How to resolve partial results.
+		resolver := metadataResolutionParams{
+			dirQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+			objQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+			bucket:    bucket,
+		}
+
+		err := listPathRaw(ctx, listPathRawOptions{
+			disks:          disks,
+			bucket:         bucket,
+			recursive:      true,
+			minDisks:       len(disks) / 2, // to capture all quorum ratios
+			reportNotFound: false,
+			agreed:         rebalanceEntry,
+			partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
+				entry, ok := entries.resolve(&resolver)
+				if ok {
+					rebalanceEntry(*entry)
+				}
+			},
+			finished: nil,
+		})
+		if done {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markRebalanceStopped marks the ongoing rebalance as stopped on the
+// drives, the rebalance routines must have been stopped already.
+func (z *erasureServerPools) markRebalanceStopped(ctx context.Context) error {
+	// Fetch the latest progress saved by the node
+	// that was running the rebalance.
+	if err := z.loadRebalanceMeta(ctx); err != nil {
+		return err
+	}
+
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	r := z.rebalMeta
+	if r == nil || !r.StoppedAt.IsZero() {
+		return errRebalanceNotStarted
+	}
+
+	now := UTCNow()
+	r.StoppedAt = now
+	for _, ps := range r.PoolStats {
+		if ps.Participating && ps.Info.Status == rebalStarted {
+			ps.Info.Status = rebalStopped
+			ps.Info.EndTime = now
+		}
+	}
+	return r.save(ctx, z.serverPools[0])
+}
+
+// resumeRebalance resumes a rebalance interrupted by a restart, only
+// the first node of the first pool runs the rebalance.
+func (z *erasureServerPools) resumeRebalance() {
+	if !z.serverPools[0].endpoints[0].IsLocal {
+		return
+	}
+	if z.IsRebalanceStarted() {
+		go z.StartRebalance()
+	}
+}
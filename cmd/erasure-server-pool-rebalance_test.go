@@ -0,0 +1,261 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestRebalanceMetaIsDone(t *testing.T) {
+	r := rebalanceMeta{
+		PercentFreeGoal: 0.5,
+		Band:            0.05,
+		PoolStats: []*rebalanceStats{
+			{InitCapacity: 1000, InitFreeSpace: 100},
+			{InitCapacity: 1000, InitFreeSpace: 900},
+		},
+	}
+
+	if r.isDone(0) {
+		t.Fatal("Expected first pool to need rebalancing")
+	}
+	if !r.isDone(1) {
+		t.Fatal("Expected second pool to not need rebalancing")
+	}
+
+	// 350 bytes moved brings the free space within 5% of the goal.
+	r.PoolStats[0].Bytes = 340
+	if r.isDone(0) {
+		t.Fatal("Expected first pool to be outside the band")
+	}
+	r.PoolStats[0].Bytes = 350
+	if !r.isDone(0) {
+		t.Fatal("Expected first pool to be within the band")
+	}
+}
+
+func TestRebalancePools(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasurePools()
+	if err != nil {
+		t.Fatalf("Initialization of object layer failed for Erasure setup: %s", err)
+	}
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	if err = z.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pools on the same drives always have the same
+	// free space, there is nothing to rebalance.
+	if _, err = z.initRebalanceMeta(ctx, defaultRebalanceBand); err != errRebalanceNotNeeded {
+		t.Fatalf("Expected %v, got %v", errRebalanceNotNeeded, err)
+	}
+
+	bucket := "rebal-bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	var objects []string
+	for i := 0; i < 10; i++ {
+		object := fmt.Sprintf("prefix/object-%d", i)
+		data := bytes.Repeat([]byte{byte(i)}, 1024)
+		_, err = z.serverPools[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, object)
+	}
+
+	// Multiple versions of the same object.
+	versioned := "versioned-object"
+	var versionIDs []string
+	for i := 0; i < 3; i++ {
+		data := bytes.Repeat([]byte{byte(i)}, 512)
+		oi, err := z.serverPools[0].PutObject(ctx, bucket, versioned, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		versionIDs = append(versionIDs, oi.VersionID)
+	}
+
+	// A multipart object is moved part by part.
+	multipart := "multipart-object"
+	uploadID, err := z.serverPools[0].NewMultipartUpload(ctx, bucket, multipart, ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var multipartData []byte
+	var parts []CompletePart
+	for i := 1; i <= 2; i++ {
+		data := bytes.Repeat([]byte{byte(i)}, 5*humanize.MiByte)
+		multipartData = append(multipartData, data...)
+		pi, err := z.serverPools[0].PutObjectPart(ctx, bucket, multipart, uploadID, i, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, CompletePart{PartNumber: pi.PartNumber, ETag: pi.ETag})
+	}
+	if _, err = z.serverPools[0].CompleteMultipartUpload(ctx, bucket, multipart, uploadID, parts, ObjectOptions{Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pretend the first pool is full, all of its
+	// data must be moved to reach the goal.
+	z.rebalMeta = &rebalanceMeta{
+		ID:              mustGetUUID(),
+		PercentFreeGoal: 0.5,
+		Band:            defaultRebalanceBand,
+		PoolStats: []*rebalanceStats{
+			{
+				InitCapacity:  1 << 30,
+				Participating: true,
+				Buckets:       []string{bucket},
+				Info:          rebalanceInfo{StartTime: UTCNow(), Status: rebalStarted},
+			},
+			{
+				InitCapacity:  1 << 30,
+				InitFreeSpace: 1 << 30,
+			},
+		},
+	}
+
+	if !z.IsPoolRebalancing(0) || z.IsPoolRebalancing(1) {
+		t.Fatal("Expected only the first pool to be rebalancing")
+	}
+
+	z.StartRebalance()
+
+	if z.IsRebalanceStarted() {
+		t.Fatal("Expected rebalance to be complete")
+	}
+
+	for _, object := range append(objects, versioned, multipart) {
+		if _, err = z.serverPools[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("Expected %s to be moved from first pool, got %v", object, err)
+		}
+		if _, err = z.serverPools[1].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+			t.Fatalf("Expected %s on second pool, got %v", object, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err = GetObject(ctx, obj, bucket, multipart, 0, int64(len(multipartData)), &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), multipartData) {
+		t.Fatalf("Unexpected content for %s after rebalance", multipart)
+	}
+
+	// Version history must be preserved.
+	loi, err := obj.ListObjectVersions(ctx, bucket, versioned, "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loi.Objects) != len(versionIDs) {
+		t.Fatalf("Expected %d versions, got %d", len(versionIDs), len(loi.Objects))
+	}
+	for i, oi := range loi.Objects {
+		// Versions are listed latest first.
+		if expected := versionIDs[len(versionIDs)-1-i]; oi.VersionID != expected {
+			t.Fatalf("Expected version %s, got %s", expected, oi.VersionID)
+		}
+	}
+
+	rs, err := rebalanceStatus(ctx, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.Pools[0].Status != rebalCompleted.String() {
+		t.Fatalf("Expected rebalance to be completed, got %s", rs.Pools[0].Status)
+	}
+	if progress := rs.Pools[0].Progress; progress.NumVersions != uint64(len(objects)+len(versionIDs)+1) || progress.NumFailed != 0 {
+		t.Fatalf("Unexpected rebalance progress %#v", progress)
+	}
+}
+
+func TestRebalanceStopsAtGoal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasurePools()
+	if err != nil {
+		t.Fatalf("Initialization of object layer failed for Erasure setup: %s", err)
+	}
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	if err = z.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "rebal-bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		data := bytes.Repeat([]byte{byte(i)}, 1000)
+		_, err = z.serverPools[0].PutObject(ctx, bucket, fmt.Sprintf("object-%d", i), mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Moving 3000 bytes is enough to reach the goal.
+	z.rebalMeta = &rebalanceMeta{
+		ID:              mustGetUUID(),
+		PercentFreeGoal: 0.5,
+		Band:            0.1,
+		PoolStats: []*rebalanceStats{
+			{
+				InitCapacity:  10000,
+				InitFreeSpace: 1000,
+				Participating: true,
+				Buckets:       []string{bucket},
+				Info:          rebalanceInfo{StartTime: UTCNow(), Status: rebalStarted},
+			},
+			{
+				InitCapacity:  10000,
+				InitFreeSpace: 10000,
+			},
+		},
+	}
+
+	z.StartRebalance()
+
+	rs, err := rebalanceStatus(ctx, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.Pools[0].Status != rebalCompleted.String() {
+		t.Fatalf("Expected rebalance to be completed, got %s", rs.Pools[0].Status)
+	}
+	if moved := rs.Pools[0].Progress.NumObjects; moved != 3 {
+		t.Fatalf("Expected 3 objects to be moved, got %d", moved)
+	}
+}
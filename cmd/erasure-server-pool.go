@@ -27,6 +27,7 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -42,10 +43,18 @@ import (
 type erasureServerPools struct {
 	GatewayUnsupported
 
-	serverPools []*erasureSets
+	poolMetaMutex sync.RWMutex
+	poolMeta      poolMeta
+	serverPools   []*erasureSets
 
 	// Shut down async operations
 	shutdown context.CancelFunc
+
+	// Active decommission canceler
+	decommissionCancelers []context.CancelFunc
+
+	rebalMu   sync.RWMutex
+	rebalMeta *rebalanceMeta
 }
 
 func (z *erasureServerPools) SinglePool() bool {
@@ -112,7 +121,12 @@ func newErasureServerPools(ctx context.Context, endpointServerPools EndpointServ
 		if err != nil {
 			return nil, err
 		}
+		z.serverPools[i].cmdLine = ep.CmdLine
+		if z.serverPools[i].cmdLine == "" {
+			z.serverPools[i].cmdLine = strings.Join(ep.Endpoints.GetAllStrings(), " ")
+		}
 	}
+	z.decommissionCancelers = make([]context.CancelFunc, len(z.serverPools))
 	ctx, z.shutdown = context.WithCancel(ctx)
 	go intDataUpdateTracker.start(ctx, localDrives...)
 	return z, nil
@@ -261,6 +275,12 @@ func (z *erasureServerPools) getServerPoolsAvailableSpace(ctx context.Context, b
 
 	for i, zinfo := range storageInfos {
 		var available uint64
+		if z.IsSuspended(i) || z.IsPoolRebalancing(i) {
+			// Pools being decommissioned or rebalanced do
+			// not accept new writes.
+			serverPools[i] = poolAvailableSpace{Index: i}
+			continue
+		}
 		if !isMinioMetaBucketName(bucket) && !hasSpaceFor(zinfo, size) {
 			serverPools[i] = poolAvailableSpace{Index: i}
 			continue
@@ -319,6 +339,18 @@ func (z *erasureServerPools) getPoolIdxExistingWithOpts(ctx context.Context, buc
 	})
 
 	for _, pinfo := range poolObjInfos {
+		// skip all objects from suspended pools if asked by the
+		// caller.
+		if z.IsSuspended(pinfo.PoolIndex) && opts.SkipDecommissioned {
+			continue
+		}
+
+		// skip all objects from pools being rebalanced if
+		// asked by the caller.
+		if z.IsPoolRebalancing(pinfo.PoolIndex) && opts.SkipRebalancing {
+			continue
+		}
+
 		if pinfo.Err != nil && !isErrObjectNotFound(pinfo.Err) {
 			return -1, pinfo.Err
 		}
@@ -353,7 +385,7 @@ func (z *erasureServerPools) getPoolIdxExisting(ctx context.Context, bucket, obj
 }
 
 func (z *erasureServerPools) getPoolIdxNoLock(ctx context.Context, bucket, object string, size int64) (idx int, err error) {
-	idx, err = z.getPoolIdxExistingNoLock(ctx, bucket, object)
+	idx, err = z.getPoolIdxExistingWithOpts(ctx, bucket, object, ObjectOptions{NoLock: true, SkipDecommissioned: true, SkipRebalancing: true})
 	if err != nil && !isErrObjectNotFound(err) {
 		return idx, err
 	}
@@ -369,9 +401,10 @@ func (z *erasureServerPools) getPoolIdxNoLock(ctx context.Context, bucket, objec
 }
 
 // getPoolIdx returns the found previous object and its corresponding pool idx,
-// if none are found falls back to most available space pool.
+// if none are found falls back to most available space pool, pools being
+// decommissioned or rebalanced are never returned.
 func (z *erasureServerPools) getPoolIdx(ctx context.Context, bucket, object string, size int64) (idx int, err error) {
-	idx, err = z.getPoolIdxExisting(ctx, bucket, object)
+	idx, err = z.getPoolIdxExistingWithOpts(ctx, bucket, object, ObjectOptions{SkipDecommissioned: true, SkipRebalancing: true})
 	if err != nil && !isErrObjectNotFound(err) {
 		return idx, err
 	}
@@ -993,14 +1026,24 @@ func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObjec
 	}
 
 	if cpSrcDstSame && srcInfo.metadataOnly {
+		// Metadata only updates must be applied on the pool
+		// holding the object, even if it is being decommissioned.
+		metaIdx := poolIdx
+		existingIdx, err := z.getPoolIdxExistingNoLock(ctx, dstBucket, dstObject)
+		if err != nil && !isErrObjectNotFound(err) {
+			return objInfo, err
+		}
+		if err == nil {
+			metaIdx = existingIdx
+		}
 		// Version ID is set for the destination and source == destination version ID.
 		if dstOpts.VersionID != "" && srcOpts.VersionID == dstOpts.VersionID {
-			return z.serverPools[poolIdx].CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
+			return z.serverPools[metaIdx].CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
 		}
 		// Destination is not versioned and source version ID is empty
 		// perform an in-place update.
 		if !dstOpts.Versioned && srcOpts.VersionID == "" {
-			return z.serverPools[poolIdx].CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
+			return z.serverPools[metaIdx].CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
 		}
 		// Destination is versioned, source is not destination version,
 		// as a special case look for if the source object is not legacy
@@ -1010,7 +1053,7 @@ func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObjec
 			// CopyObject optimization where we don't create an entire copy
 			// of the content, instead we add a reference.
 			srcInfo.versionOnly = true
-			return z.serverPools[poolIdx].CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
+			return z.serverPools[metaIdx].CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
 		}
 	}
 
@@ -1202,6 +1245,11 @@ func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, obj
 	}
 
 	for idx, pool := range z.serverPools {
+		if z.IsSuspended(idx) || z.IsPoolRebalancing(idx) {
+			// New uploads are never placed on pools
+			// being decommissioned or rebalanced.
+			continue
+		}
 		result, err := pool.ListMultipartUploads(ctx, bucket, object, "", "", "", maxUploadsList)
 		if err != nil {
 			return "", err
@@ -83,6 +83,9 @@ type erasureSets struct {
 
 	poolIndex int
 
+	// Pool arguments as provided on the command line.
+	cmdLine string
+
 	// A channel to send the set index to the MRF when
 	// any disk belonging to that set is connected
 	setReconnectEvent chan int
@@ -0,0 +1,391 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/event/journal"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	// Prefix of the event journal of a bucket
+	// under the bucket metadata prefix.
+	eventJournalPrefix = "events"
+
+	// Interval at which recorded events are written to the journal.
+	eventJournalFlushInterval = time.Second
+
+	// Interval at which expired journal segments are removed.
+	eventJournalCleanupInterval = 10 * time.Minute
+
+	// Query parameters to read the event journal from a position.
+	eventJournalSeq   = "seq"
+	eventJournalNode  = "node"
+	eventJournalSince = "since"
+
+	// Maximum number of recorded events kept in memory per
+	// bucket, events are written right away once reached.
+	eventJournalMaxPending = 10000
+)
+
+// eventJournalSys records the bucket events of the buckets with the
+// event journal enabled. Every server buffers the events it generates
+// and periodically writes them as a segment to the system bucket,
+// segments are removed once they exceed the retention.
+type eventJournalSys struct {
+	mu      sync.Mutex
+	config  journal.Config
+	pending map[string][]journal.Record
+	flushCh chan struct{}
+
+	// initialized is set once the journal is written,
+	// which is never the case in gateway mode.
+	initialized bool
+
+	// id distinguishes the segments written by this server.
+	id string
+}
+
+// newEventJournalSys - creates new event journal system.
+func newEventJournalSys() *eventJournalSys {
+	return &eventJournalSys{
+		pending: make(map[string][]journal.Record),
+		flushCh: make(chan struct{}, 1),
+		id:      mustGetUUID(),
+	}
+}
+
+// SetConfig - applies the event journal configuration.
+func (sys *eventJournalSys) SetConfig(config journal.Config) {
+	sys.mu.Lock()
+	defer sys.mu.Unlock()
+	sys.config = config
+}
+
+// Enabled - returns true if the events of the bucket are journaled.
+func (sys *eventJournalSys) Enabled(bucket string) bool {
+	sys.mu.Lock()
+	defer sys.mu.Unlock()
+	return sys.initialized && sys.config.BucketEnabled(bucket)
+}
+
+// Record - adds an event to the journal of its bucket.
+func (sys *eventJournalSys) Record(ev event.Event) {
+	bucket := ev.S3.Bucket.Name
+
+	sys.mu.Lock()
+	defer sys.mu.Unlock()
+	if !sys.initialized || !sys.config.BucketEnabled(bucket) {
+		return
+	}
+	sys.pending[bucket] = append(sys.pending[bucket], journal.Record{
+		Seq:   journal.Seq(ev),
+		Node:  sys.id,
+		Event: ev,
+	})
+	if len(sys.pending[bucket]) >= eventJournalMaxPending {
+		select {
+		case sys.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Init - starts writing recorded events to the journal.
+func (sys *eventJournalSys) Init(ctx context.Context, objAPI ObjectLayer) {
+	sys.mu.Lock()
+	sys.initialized = true
+	sys.mu.Unlock()
+
+	go sys.run(ctx, objAPI)
+}
+
+func (sys *eventJournalSys) run(ctx context.Context, objAPI ObjectLayer) {
+	flushTicker := time.NewTicker(eventJournalFlushInterval)
+	defer flushTicker.Stop()
+	cleanupTicker := time.NewTicker(eventJournalCleanupInterval)
+	defer cleanupTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-flushTicker.C:
+			sys.flush(ctx, objAPI)
+		case <-sys.flushCh:
+			sys.flush(ctx, objAPI)
+		case <-cleanupTicker.C:
+			sys.cleanup(ctx, objAPI)
+		}
+	}
+}
+
+// flush - writes the recorded events of every bucket as a segment.
+func (sys *eventJournalSys) flush(ctx context.Context, objAPI ObjectLayer) {
+	sys.mu.Lock()
+	pending := sys.pending
+	sys.pending = make(map[string][]journal.Record)
+	sys.mu.Unlock()
+
+	for bucket, records := range pending {
+		if len(records) == 0 {
+			continue
+		}
+		first, last := records[0].Seq, records[0].Seq
+		for _, record := range records {
+			if record.Seq < first {
+				first = record.Seq
+			}
+			if record.Seq > last {
+				last = record.Seq
+			}
+		}
+
+		data, err := journal.EncodeRecords(records)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		segment := path.Join(eventJournalBucketPrefix(bucket), journal.SegmentName(first, last, sys.id))
+		logger.LogIf(ctx, saveConfig(ctx, objAPI, segment, data))
+	}
+}
+
+// cleanup - removes the journal segments which exceed the retention.
+func (sys *eventJournalSys) cleanup(ctx context.Context, objAPI ObjectLayer) {
+	sys.mu.Lock()
+	retention := sys.config.Retention
+	sys.mu.Unlock()
+	if retention <= 0 {
+		return
+	}
+	expired := journal.TimeSeq(UTCNow().Add(-retention))
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	for _, bucket := range buckets {
+		segments, err := listEventJournalSegments(ctx, objAPI, bucket.Name)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		for _, segment := range segments {
+			if segment.Last >= expired {
+				continue
+			}
+			err = deleteConfig(ctx, objAPI, path.Join(eventJournalBucketPrefix(bucket.Name), segment.Name))
+			if err != nil && err != errConfigNotFound {
+				logger.LogIf(ctx, err)
+			}
+		}
+	}
+}
+
+// segments - returns the stored segments of the journal of the bucket
+// and the events recorded by this server which are not written to the
+// journal yet, as an unnamed segment.
+func (sys *eventJournalSys) segments(ctx context.Context, objAPI ObjectLayer, bucket string) ([]journal.Segment, []journal.Record, error) {
+	segments, err := listEventJournalSegments(ctx, objAPI, bucket)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sys.mu.Lock()
+	pending := append([]journal.Record{}, sys.pending[bucket]...)
+	sys.mu.Unlock()
+	if len(pending) > 0 {
+		first, last := pending[0].Seq, pending[0].Seq
+		for _, record := range pending {
+			if record.Seq < first {
+				first = record.Seq
+			}
+			if record.Seq > last {
+				last = record.Seq
+			}
+		}
+		segments = append(segments, journal.Segment{Node: sys.id, First: first, Last: last})
+	}
+	return segments, pending, nil
+}
+
+// Head - returns the position of the last journaled event of the bucket.
+func (sys *eventJournalSys) Head(ctx context.Context, objAPI ObjectLayer, bucket string) (journal.Position, error) {
+	segments, _, err := sys.segments(ctx, objAPI, bucket)
+	if err != nil {
+		return journal.Position{}, err
+	}
+	return journal.Head(segments), nil
+}
+
+// Read - returns up to limit journaled events of the bucket after the
+// position from, including the events recorded by this server which
+// are not written to the journal yet.
+func (sys *eventJournalSys) Read(ctx context.Context, objAPI ObjectLayer, bucket string, from journal.Position, limit int) ([]journal.Record, error) {
+	segments, pending, err := sys.segments(ctx, objAPI, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return journal.Page(segments, from, limit, func(segment journal.Segment) ([]journal.Record, error) {
+		if segment.Name == "" {
+			return pending, nil
+		}
+		data, err := readConfig(ctx, objAPI, path.Join(eventJournalBucketPrefix(bucket), segment.Name))
+		if err != nil {
+			if err == errConfigNotFound {
+				// Removed by a concurrent cleanup.
+				return nil, nil
+			}
+			return nil, err
+		}
+		return journal.DecodeRecords(data)
+	})
+}
+
+// ReadAll - calls fn for every journaled event of the bucket after
+// the position from up to and including the position until.
+func (sys *eventJournalSys) ReadAll(ctx context.Context, objAPI ObjectLayer, bucket string, from, until journal.Position, fn func(journal.Record) error) error {
+	for {
+		records, err := sys.Read(ctx, objAPI, bucket, from, maxObjectList)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		for _, record := range records {
+			if until.Less(record.Position()) {
+				return nil
+			}
+			if err = fn(record); err != nil {
+				return err
+			}
+			from = record.Position()
+		}
+	}
+}
+
+var errEventJournalPosition = errors.New("only one of 'seq' and 'since' may be specified")
+
+// parseEventJournalPosition - returns the position after which the
+// event journal is read, ok is false if no position is specified.
+// A seq without a node reads the events after that sequence number.
+func parseEventJournalPosition(values url.Values) (from journal.Position, ok bool, err error) {
+	seq, node, since := values.Get(eventJournalSeq), values.Get(eventJournalNode), values.Get(eventJournalSince)
+	switch {
+	case seq != "" && since != "":
+		return from, false, errEventJournalPosition
+	case seq != "":
+		from.Seq, err = strconv.ParseUint(seq, 10, 64)
+		if err != nil {
+			return from, false, err
+		}
+		if node == "" {
+			from.Seq++
+		}
+		from.Node = node
+		return from, true, nil
+	case since != "":
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return from, false, err
+		}
+		// Events at exactly the given time are included.
+		from.Seq = journal.TimeSeq(t)
+		return from, true, nil
+	}
+	return from, false, nil
+}
+
+// lastEventSeq is the sequence number of the last event generated by this server.
+var lastEventSeq uint64
+
+// nextEventSeq - returns the sequence number of an event generated at
+// t, the sequence numbers of the events of a server strictly increase
+// even if several events are generated at the same time.
+func nextEventSeq(t time.Time) uint64 {
+	for {
+		last := atomic.LoadUint64(&lastEventSeq)
+		seq := journal.TimeSeq(t)
+		if seq <= last {
+			seq = last + 1
+		}
+		if atomic.CompareAndSwapUint64(&lastEventSeq, last, seq) {
+			return seq
+		}
+	}
+}
+
+// eventJournalKey - identifies an event delivered both by
+// the event journal and by a listen subscription.
+type eventJournalKey struct {
+	sequencer string
+	name      event.Name
+	key       string
+	versionID string
+}
+
+func newEventJournalKey(ev event.Event) eventJournalKey {
+	return eventJournalKey{
+		sequencer: ev.S3.Object.Sequencer,
+		name:      ev.EventName,
+		key:       ev.S3.Object.Key,
+		versionID: ev.S3.Object.VersionID,
+	}
+}
+
+func eventJournalBucketPrefix(bucket string) string {
+	return path.Join(bucketMetaPrefix, bucket, eventJournalPrefix)
+}
+
+// listEventJournalSegments - lists the stored segments of the journal of a bucket.
+func listEventJournalSegments(ctx context.Context, objAPI ObjectLayer, bucket string) ([]journal.Segment, error) {
+	var segments []journal.Segment
+	prefix := eventJournalBucketPrefix(bucket) + SlashSeparator
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, minioMetaBucket, prefix, marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range res.Objects {
+			segment, err := journal.ParseSegmentName(path.Base(obj.Name))
+			if err != nil {
+				continue
+			}
+			segments = append(segments, segment)
+		}
+		if !res.IsTruncated {
+			return segments, nil
+		}
+		marker = res.NextMarker
+	}
+}
@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"crypto/ed25519"
 	"crypto/x509"
 	"errors"
 	"net/http"
@@ -175,6 +176,8 @@ var (
 	globalEnvTargetList *event.TargetList
 
 	globalBucketMetadataSys *BucketMetadataSys
+	globalBucketLoggingSys  *bucketLoggingSys
+	globalEventJournalSys   *eventJournalSys
 	globalBucketMonitor     *bandwidth.Monitor
 	globalPolicySys         *PolicySys
 	globalIAMSys            *IAMSys
@@ -235,11 +238,20 @@ var (
 
 	globalActiveCred auth.Credentials
 
+	// Key the audit chain is signed and verified with.
+	globalAuditChainKey ed25519.PrivateKey
+
 	globalPublicCerts []*x509.Certificate
 
 	globalDomainNames []string      // Root domains for virtual host style requests
 	globalDomainIPs   set.StringSet // Root domain IP address(s) for a distributed MinIO deployment
 
+	// Root domains of the bucket website endpoints, requests to
+	// <bucket>.<domain> are served as static websites.
+	globalWebsiteDomainNames []string
+	// Address of the dedicated bucket website listener.
+	globalWebsiteAddr string
+
 	globalOperationTimeout       = newDynamicTimeout(10*time.Minute, 5*time.Minute) // default timeout for general ops
 	globalDeleteOperationTimeout = newDynamicTimeout(5*time.Minute, 1*time.Minute)  // default time for delete ops
 
@@ -284,8 +296,8 @@ var (
 	// Some standard content-types which we strictly dis-allow for compression.
 	standardExcludeCompressContentTypes = []string{"video/*", "audio/*", "application/zip", "application/x-gzip", "application/x-zip-compressed", " application/x-compress", "application/x-spoon"}
 
-	// Authorization validators list.
-	globalOpenIDValidators *openid.Validators
+	// Configured OpenID providers.
+	globalOpenIDProviders openid.Providers
 
 	// OPA policy system.
 	globalPolicyOPA *opa.Opa
@@ -46,6 +46,8 @@ type apiConfig struct {
 	staleUploadsExpiry          time.Duration
 	staleUploadsCleanupInterval time.Duration
 	deleteCleanupInterval       time.Duration
+
+	selectMaxMemory int64
 }
 
 func (t *apiConfig) init(cfg api.Config, setDriveCounts []int) {
@@ -115,6 +117,14 @@ func (t *apiConfig) init(cfg api.Config, setDriveCounts []int) {
 	t.staleUploadsExpiry = cfg.StaleUploadsExpiry
 	t.staleUploadsCleanupInterval = cfg.StaleUploadsCleanupInterval
 	t.deleteCleanupInterval = cfg.DeleteCleanupInterval
+	t.selectMaxMemory = cfg.SelectMaxMemory
+}
+
+func (t *apiConfig) getSelectMaxMemory() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.selectMaxMemory
 }
 
 func (t *apiConfig) getListQuorum() int {
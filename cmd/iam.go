@@ -71,6 +71,11 @@ type IAMSys struct {
 
 	// configLoaded will be closed and remain so after first load.
 	configLoaded chan struct{}
+
+	// Attribute claims of the LDAP users with service accounts by
+	// user DN, refreshed along with their group memberships.
+	ldapAttributesMu sync.RWMutex
+	ldapAttributes   map[string]map[string]interface{}
 }
 
 // IAMUserType represents a user type inside MinIO server
@@ -273,7 +278,7 @@ func (sys *IAMSys) Init(ctx context.Context, objAPI ObjectLayer, etcdClient *etc
 
 	// Set up polling for expired accounts and credentials purging.
 	switch {
-	case globalOpenIDConfig.ProviderEnabled():
+	case globalOpenIDProviders.ProviderEnabled():
 		go func() {
 			ticker := time.NewTicker(sys.iamRefreshInterval)
 			defer ticker.Stop()
@@ -295,6 +300,7 @@ func (sys *IAMSys) Init(ctx context.Context, objAPI ObjectLayer, etcdClient *etc
 				case <-ticker.C:
 					sys.purgeExpiredCredentialsForLDAP(ctx)
 					sys.updateGroupMembershipsForLDAP(ctx)
+					sys.updateAttributesForLDAP()
 				case <-ctx.Done():
 					return
 				}
@@ -803,11 +809,11 @@ func (sys *IAMSys) purgeExpiredCredentialsForExternalSSO(ctx context.Context) {
 	parentUsers := sys.store.GetAllParentUsers()
 	var expiredUsers []string
 	for _, parentUser := range parentUsers {
-		userid, err := parseOpenIDParentUser(parentUser)
+		userid, issuer, err := parseOpenIDParentUser(parentUser)
 		if err == errSkipFile {
 			continue
 		}
-		u, err := globalOpenIDConfig.LookupUser(userid)
+		u, err := globalOpenIDProviders.LookupUser(userid, issuer)
 		if err != nil {
 			logger.LogIf(GlobalContext, err)
 			continue
@@ -918,6 +924,56 @@ func (sys *IAMSys) updateGroupMembershipsForLDAP(ctx context.Context) {
 	}
 }
 
+// ldapAttributeClaims - returns the attribute claims of an LDAP user,
+// looked up on the LDAP server if they are not cached yet.
+func (sys *IAMSys) ldapAttributeClaims(userDN string) (map[string]interface{}, error) {
+	sys.ldapAttributesMu.RLock()
+	claims, ok := sys.ldapAttributes[userDN]
+	sys.ldapAttributesMu.RUnlock()
+	if ok {
+		return claims, nil
+	}
+
+	claims, err := getLDAPAttributeClaims(userDN)
+	if err != nil {
+		return nil, err
+	}
+	sys.ldapAttributesMu.Lock()
+	if sys.ldapAttributes == nil {
+		sys.ldapAttributes = make(map[string]map[string]interface{})
+	}
+	sys.ldapAttributes[userDN] = claims
+	sys.ldapAttributesMu.Unlock()
+	return claims, nil
+}
+
+// updateAttributesForLDAP - refreshes the cached attribute claims of
+// the LDAP users, the claims of users which cannot be looked up are
+// dropped and looked up again when authorizing their next request.
+func (sys *IAMSys) updateAttributesForLDAP() {
+	sys.ldapAttributesMu.RLock()
+	userDNs := make([]string, 0, len(sys.ldapAttributes))
+	for userDN := range sys.ldapAttributes {
+		userDNs = append(userDNs, userDN)
+	}
+	sys.ldapAttributesMu.RUnlock()
+
+	for _, userDN := range userDNs {
+		claims, err := getLDAPAttributeClaims(userDN)
+		sys.ldapAttributesMu.Lock()
+		if err != nil {
+			delete(sys.ldapAttributes, userDN)
+		} else {
+			sys.ldapAttributes[userDN] = claims
+		}
+		sys.ldapAttributesMu.Unlock()
+		if err != nil {
+			// Log and continue error - perhaps it'll work the next time.
+			logger.LogIf(GlobalContext, err)
+		}
+	}
+}
+
 // GetUser - get user credentials
 func (sys *IAMSys) GetUser(accessKey string) (cred auth.Credentials, ok bool) {
 	if !sys.Initialized() {
@@ -1083,6 +1139,20 @@ func (sys *IAMSys) IsAllowedServiceAccount(args iampolicy.Args, parentUser strin
 		return false
 	}
 
+	// Service accounts never expire, the attributes of their LDAP
+	// parent user are resolved when authorizing instead of being
+	// taken from the claims of the service account.
+	var ldapAttrClaims map[string]interface{}
+	if sys.usersSysType == LDAPUsersSysType && globalLDAPConfig.UserAttributesEnabled() &&
+		globalLDAPConfig.IsLDAPUserDN(parentUser) {
+		ldapAttrClaims, err = sys.ldapAttributeClaims(parentUser)
+		if err != nil {
+			logger.LogIf(GlobalContext, err)
+			return false
+		}
+		svcPolicies = append(svcPolicies, ldapAttributePolicies(ldapAttrClaims)...)
+	}
+
 	if len(svcPolicies) == 0 {
 		return false
 	}
@@ -1098,6 +1168,14 @@ func (sys *IAMSys) IsAllowedServiceAccount(args iampolicy.Args, parentUser strin
 	// These are dynamic values set them appropriately.
 	parentArgs.ConditionValues["username"] = []string{parentUser}
 	parentArgs.ConditionValues["userid"] = []string{parentUser}
+	if ldapAttrClaims != nil {
+		for _, attr := range globalLDAPConfig.UserConditionAttributes {
+			delete(parentArgs.ConditionValues, attr)
+			if values := claimStrings(ldapAttrClaims[ldapAttributePrefix+attr]); len(values) > 0 {
+				parentArgs.ConditionValues[attr] = values
+			}
+		}
+	}
 
 	saPolicyClaim, ok := args.Claims[iamPolicyClaimNameSA()]
 	if !ok {
@@ -1148,6 +1226,16 @@ func (sys *IAMSys) IsAllowedServiceAccount(args iampolicy.Args, parentUser strin
 	return combinedPolicy.IsAllowed(parentArgs) && subPolicy.IsAllowed(parentArgs)
 }
 
+// ldapAttributePolicies returns the policies mapped from the policy
+// attributes of an LDAP user held by the claims.
+func ldapAttributePolicies(claims map[string]interface{}) []string {
+	policies, ok := iampolicy.GetPoliciesFromClaims(claims, ldapPolicy)
+	if !ok {
+		return nil
+	}
+	return policies.ToSlice()
+}
+
 // IsAllowedLDAPSTS - checks for LDAP specific claims and values
 func (sys *IAMSys) IsAllowedLDAPSTS(args iampolicy.Args, parentUser string) bool {
 	// parentUser value must match the ldap user in the claim.
@@ -1167,6 +1255,7 @@ func (sys *IAMSys) IsAllowedLDAPSTS(args iampolicy.Args, parentUser string) bool
 	if err != nil {
 		return false
 	}
+	ldapPolicies = append(ldapPolicies, ldapAttributePolicies(args.Claims)...)
 
 	if len(ldapPolicies) == 0 {
 		return false
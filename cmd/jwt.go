@@ -41,6 +41,10 @@ const (
 
 	// URL JWT token expiry is one minute (might be exposed).
 	defaultURLJWTExpiry = time.Minute
+
+	// SCIM JWT token expiry is 7 days, at most 30 days.
+	defaultSCIMJWTExpiry = 7 * 24 * time.Hour
+	maxSCIMJWTExpiry     = 30 * 24 * time.Hour
 )
 
 var (
@@ -90,6 +94,19 @@ func authenticateNode(accessKey, secretKey, audience string) (string, error) {
 	return jwt.SignedString([]byte(secretKey))
 }
 
+// authenticateSCIM returns a bearer token for the SCIM provisioning API,
+// the token ID is passed to revoke the token.
+func authenticateSCIM(accessKey, secretKey, tokenID string, expiresAt time.Time) (string, error) {
+	claims := xjwt.NewStandardClaims()
+	claims.SetExpiry(expiresAt)
+	claims.SetAccessKey(accessKey)
+	claims.SetIssuer(scimTokenIssuer)
+	claims.Id = tokenID
+
+	jwt := jwtgo.NewWithClaims(jwtgo.SigningMethodHS512, claims)
+	return jwt.SignedString([]byte(secretKey))
+}
+
 func authenticateWeb(accessKey, secretKey string) (string, error) {
 	return authenticateJWTUsers(accessKey, secretKey, defaultJWTExpiry)
 }
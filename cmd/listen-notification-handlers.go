@@ -19,11 +19,13 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/event/journal"
 	"github.com/minio/minio/internal/logger"
 	policy "github.com/minio/pkg/bucket/policy"
 )
@@ -117,6 +119,22 @@ func (api objectAPIHandlers) ListenNotificationHandler(w http.ResponseWriter, r
 		}
 	}
 
+	// Resuming from the event journal is only possible for
+	// a single bucket with the event journal enabled.
+	from, resume, err := parseEventJournalPosition(values)
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+	if resume && (bucketName == "" || globalEventJournalSys == nil || !globalEventJournalSys.Enabled(bucketName)) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest,
+			errors.New("event journal is not enabled for this bucket")), r.URL)
+		return
+	}
+	values.Del(eventJournalSeq)
+	values.Del(eventJournalNode)
+	values.Del(eventJournalSince)
+
 	rulesMap := event.NewRulesMap(eventNames, pattern, event.TargetID{ID: mustGetUUID()})
 
 	setEventStreamHeaders(w)
@@ -127,6 +145,9 @@ func (api objectAPIHandlers) ListenNotificationHandler(w http.ResponseWriter, r
 
 	peers, _ := newPeerRestClients(globalEndpoints)
 
+	// Events generated from now on are delivered by the subscription.
+	subscribed := journal.TimeSeq(UTCNow())
+
 	globalHTTPListen.Subscribe(listenCh, ctx.Done(), func(evI interface{}) bool {
 		ev, ok := evI.(event.Event)
 		if !ok {
@@ -150,15 +171,55 @@ func (api objectAPIHandlers) ListenNotificationHandler(w http.ResponseWriter, r
 		peer.Listen(listenCh, ctx.Done(), values)
 	}
 
+	enc := json.NewEncoder(w)
+
+	// replayed holds the replayed events which may
+	// also be delivered by the subscription.
+	var replayed map[eventJournalKey]struct{}
+	if resume {
+		// Replay the journal up to its position once subscribed,
+		// later events are only delivered by the subscription.
+		until, err := globalEventJournalSys.Head(ctx, objAPI, bucketName)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+		replayed = make(map[eventJournalKey]struct{})
+		err = globalEventJournalSys.ReadAll(ctx, objAPI, bucketName, from, until, func(record journal.Record) error {
+			if !rulesMap.MatchSimple(record.Event.EventName, record.Event.S3.Object.Key) {
+				return nil
+			}
+			if record.Seq >= subscribed {
+				replayed[newEventJournalKey(record.Event)] = struct{}{}
+			}
+			if err := enc.Encode(struct{ Records []event.Event }{[]event.Event{record.Event}}); err != nil {
+				return err
+			}
+			w.(http.Flusher).Flush()
+			return nil
+		})
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+	}
+
 	keepAliveTicker := time.NewTicker(500 * time.Millisecond)
 	defer keepAliveTicker.Stop()
 
-	enc := json.NewEncoder(w)
 	for {
 		select {
 		case evI := <-listenCh:
 			ev, ok := evI.(event.Event)
 			if ok {
+				if len(replayed) > 0 {
+					// Drop the events which were already replayed.
+					key := newEventJournalKey(ev)
+					if _, ok := replayed[key]; ok {
+						delete(replayed, key)
+						continue
+					}
+				}
 				if err := enc.Encode(struct{ Records []event.Event }{[]event.Event{ev}}); err != nil {
 					return
 				}
@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/logger"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -72,6 +73,9 @@ const (
 	usageSubsystem            MetricSubsystem = "usage"
 	ilmSubsystem              MetricSubsystem = "ilm"
 	scannerSubsystem          MetricSubsystem = "scanner"
+	notifySubsystem           MetricSubsystem = "notify"
+	logTargetSubsystem        MetricSubsystem = "log_target"
+	ldapSubsystem             MetricSubsystem = "ldap"
 )
 
 // MetricName are the individual names for the metric.
@@ -129,6 +133,26 @@ const (
 	expiryPendingTasks     MetricName = "expiry_pending_tasks"
 	transitionPendingTasks MetricName = "transition_pending_tasks"
 	transitionActiveTasks  MetricName = "transition_active_tasks"
+
+	eventsQueuedTotal       MetricName = "events_queued_total"
+	eventsSentTotal         MetricName = "events_sent_total"
+	eventsFailedTotal       MetricName = "events_failed_total"
+	eventsDroppedTotal      MetricName = "events_dropped_total"
+	eventsDeadLetteredTotal MetricName = "events_dead_lettered_total"
+	queueLength             MetricName = "queue_length"
+	queueOldestEventAge     MetricName = "queue_oldest_event_age_seconds"
+
+	entriesSentTotal    MetricName = "entries_sent_total"
+	entriesFailedTotal  MetricName = "entries_failed_total"
+	entriesDroppedTotal MetricName = "entries_dropped_total"
+	entriesPending      MetricName = "entries_pending"
+
+	requestsTotal         MetricName = "requests_total"
+	requestSecondsTotal   MetricName = "request_seconds_total"
+	serverOnline          MetricName = "server_online"
+	poolConnections       MetricName = "pool_connections"
+	groupCacheHitsTotal   MetricName = "group_cache_hits_total"
+	groupCacheMissesTotal MetricName = "group_cache_misses_total"
 )
 
 const (
@@ -259,6 +283,9 @@ func GetGeneratorsForPeer() []MetricsGenerator {
 		getS3TTFBMetric,
 		getILMNodeMetrics,
 		getScannerNodeMetrics,
+		getNotificationMetrics,
+		getLogTargetMetrics,
+		getLDAPMetrics,
 	}
 	return g
 }
@@ -1040,6 +1067,337 @@ func getExpiryPendingTasksMD() MetricDescription {
 	}
 }
 
+func getNotifyEventsQueuedTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifySubsystem,
+		Name:      eventsQueuedTotal,
+		Help:      "Total number of events persisted in the queue store of the target.",
+		Type:      counterMetric,
+	}
+}
+
+func getNotifyEventsSentTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifySubsystem,
+		Name:      eventsSentTotal,
+		Help:      "Total number of events delivered to the target.",
+		Type:      counterMetric,
+	}
+}
+
+func getNotifyEventsFailedTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifySubsystem,
+		Name:      eventsFailedTotal,
+		Help:      "Total number of failed event deliveries to the target.",
+		Type:      counterMetric,
+	}
+}
+
+func getNotifyEventsDroppedTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifySubsystem,
+		Name:      eventsDroppedTotal,
+		Help:      "Total number of events discarded without being delivered to the target.",
+		Type:      counterMetric,
+	}
+}
+
+func getNotifyEventsDeadLetteredTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifySubsystem,
+		Name:      eventsDeadLetteredTotal,
+		Help:      "Total number of undelivered events written to the dead-letter bucket.",
+		Type:      counterMetric,
+	}
+}
+
+func getNotifyQueueLengthMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifySubsystem,
+		Name:      queueLength,
+		Help:      "Number of events waiting in the queue store of the target.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getNotifyQueueOldestEventAgeMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifySubsystem,
+		Name:      queueOldestEventAge,
+		Help:      "Age of the oldest event waiting in the queue store of the target.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getNotificationMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "NotificationMetrics",
+		cachedRead: cachedRead,
+		read: func(_ context.Context) (metrics []Metric) {
+			for id, stats := range event.GetTargetStats() {
+				labels := map[string]string{"target_id": id.ID, "target_name": id.Name}
+				metrics = append(metrics,
+					Metric{
+						Description:    getNotifyEventsQueuedTotalMD(),
+						Value:          float64(stats.Queued),
+						VariableLabels: labels,
+					},
+					Metric{
+						Description:    getNotifyEventsSentTotalMD(),
+						Value:          float64(stats.Sent),
+						VariableLabels: labels,
+					},
+					Metric{
+						Description:    getNotifyEventsFailedTotalMD(),
+						Value:          float64(stats.Failed),
+						VariableLabels: labels,
+					},
+					Metric{
+						Description:    getNotifyEventsDroppedTotalMD(),
+						Value:          float64(stats.Dropped),
+						VariableLabels: labels,
+					},
+					Metric{
+						Description:    getNotifyEventsDeadLetteredTotalMD(),
+						Value:          float64(stats.DeadLettered),
+						VariableLabels: labels,
+					},
+					Metric{
+						Description:    getNotifyQueueLengthMD(),
+						Value:          float64(stats.QueueLength),
+						VariableLabels: labels,
+					},
+					Metric{
+						Description:    getNotifyQueueOldestEventAgeMD(),
+						Value:          stats.OldestEventAge.Seconds(),
+						VariableLabels: labels,
+					},
+				)
+			}
+			return metrics
+		},
+	}
+}
+
+func getLogTargetEntriesSentTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: logTargetSubsystem,
+		Name:      entriesSentTotal,
+		Help:      "Total number of log entries delivered to the target.",
+		Type:      counterMetric,
+	}
+}
+
+func getLogTargetEntriesFailedTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: logTargetSubsystem,
+		Name:      entriesFailedTotal,
+		Help:      "Total number of failed log entry deliveries to the target.",
+		Type:      counterMetric,
+	}
+}
+
+func getLogTargetEntriesDroppedTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: logTargetSubsystem,
+		Name:      entriesDroppedTotal,
+		Help:      "Total number of log entries dropped because the queue of the target was full.",
+		Type:      counterMetric,
+	}
+}
+
+func getLogTargetEntriesPendingMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: logTargetSubsystem,
+		Name:      entriesPending,
+		Help:      "Number of log entries waiting to be delivered to the target.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getLogTargetMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "LogTargetMetrics",
+		cachedRead: cachedRead,
+		read: func(_ context.Context) (metrics []Metric) {
+			addTargets := func(targetType string, targets []logger.Target) {
+				for _, t := range targets {
+					st, ok := t.(logger.StatsTarget)
+					if !ok {
+						continue
+					}
+					stats := st.Stats()
+					labels := map[string]string{"target_type": targetType, "target_name": t.String()}
+					metrics = append(metrics,
+						Metric{
+							Description:    getLogTargetEntriesSentTotalMD(),
+							Value:          float64(stats.Sent),
+							VariableLabels: labels,
+						},
+						Metric{
+							Description:    getLogTargetEntriesFailedTotalMD(),
+							Value:          float64(stats.Failed),
+							VariableLabels: labels,
+						},
+						Metric{
+							Description:    getLogTargetEntriesDroppedTotalMD(),
+							Value:          float64(stats.Dropped),
+							VariableLabels: labels,
+						},
+						Metric{
+							Description:    getLogTargetEntriesPendingMD(),
+							Value:          float64(stats.Pending),
+							VariableLabels: labels,
+						},
+					)
+				}
+			}
+			addTargets("audit", logger.AuditTargets())
+			addTargets("logger", logger.Targets())
+			return metrics
+		},
+	}
+}
+
+func getLDAPRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ldapSubsystem,
+		Name:      requestsTotal,
+		Help:      "Total number of requests sent to the LDAP server.",
+		Type:      counterMetric,
+	}
+}
+
+func getLDAPErrorsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ldapSubsystem,
+		Name:      errorsTotal,
+		Help:      "Total number of failed requests sent to the LDAP server.",
+		Type:      counterMetric,
+	}
+}
+
+func getLDAPRequestSecondsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ldapSubsystem,
+		Name:      requestSecondsTotal,
+		Help:      "Total time spent on requests sent to the LDAP server in seconds.",
+		Type:      counterMetric,
+	}
+}
+
+func getLDAPServerOnlineMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ldapSubsystem,
+		Name:      serverOnline,
+		Help:      "Whether the LDAP server is used, 0 while it is skipped after a failure.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getLDAPPoolConnectionsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ldapSubsystem,
+		Name:      poolConnections,
+		Help:      "Number of open LDAP lookup connections.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getLDAPGroupCacheHitsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ldapSubsystem,
+		Name:      groupCacheHitsTotal,
+		Help:      "Total number of LDAP group lookups served from the group cache.",
+		Type:      counterMetric,
+	}
+}
+
+func getLDAPGroupCacheMissesTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ldapSubsystem,
+		Name:      groupCacheMissesTotal,
+		Help:      "Total number of LDAP group lookups not found in the group cache.",
+		Type:      counterMetric,
+	}
+}
+
+func getLDAPMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "LDAPMetrics",
+		cachedRead: cachedRead,
+		read: func(_ context.Context) (metrics []Metric) {
+			if !globalLDAPConfig.Enabled {
+				return nil
+			}
+			stats := globalLDAPConfig.Stats()
+			for _, server := range stats.Servers {
+				labels := map[string]string{"server": server.Addr}
+				online := 0.0
+				if server.Online {
+					online = 1
+				}
+				metrics = append(metrics,
+					Metric{
+						Description:    getLDAPRequestsTotalMD(),
+						Value:          float64(server.Requests),
+						VariableLabels: labels,
+					},
+					Metric{
+						Description:    getLDAPErrorsTotalMD(),
+						Value:          float64(server.Errors),
+						VariableLabels: labels,
+					},
+					Metric{
+						Description:    getLDAPRequestSecondsTotalMD(),
+						Value:          server.Latency.Seconds(),
+						VariableLabels: labels,
+					},
+					Metric{
+						Description:    getLDAPServerOnlineMD(),
+						Value:          online,
+						VariableLabels: labels,
+					},
+				)
+			}
+			metrics = append(metrics,
+				Metric{
+					Description: getLDAPPoolConnectionsMD(),
+					Value:       float64(stats.PoolConnections),
+				},
+				Metric{
+					Description: getLDAPGroupCacheHitsTotalMD(),
+					Value:       float64(stats.GroupCacheHits),
+				},
+				Metric{
+					Description: getLDAPGroupCacheMissesTotalMD(),
+					Value:       float64(stats.GroupCacheMisses),
+				},
+			)
+			return metrics
+		},
+	}
+}
+
 func getILMNodeMetrics() MetricsGroup {
 	return MetricsGroup{
 		id:         "ILMNodeMetrics",
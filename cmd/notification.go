@@ -36,9 +36,11 @@ import (
 	"github.com/klauspost/compress/zip"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio-go/v7/pkg/tags"
 	bucketBandwidth "github.com/minio/minio/internal/bucket/bandwidth"
 	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/hash"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/minio/internal/sync/errgroup"
@@ -720,8 +722,8 @@ func (sys *NotificationSys) ConfiguredTargetIDs() []event.TargetID {
 	var targetIDs []event.TargetID
 	for _, rmap := range sys.bucketRulesMap {
 		for _, rules := range rmap {
-			for _, targetSet := range rules {
-				for id := range targetSet {
+			for _, rule := range rules {
+				for id := range rule.TargetIDs {
 					targetIDs = append(targetIDs, id)
 				}
 			}
@@ -771,7 +773,7 @@ func (sys *NotificationSys) RemoveAllRemoteTargets() {
 // Send - sends event data to all matching targets.
 func (sys *NotificationSys) Send(args eventArgs) {
 	sys.RLock()
-	targetIDSet := sys.bucketRulesMap[args.BucketName].Match(args.EventName, args.Object.Name)
+	targetIDSet := sys.bucketRulesMap[args.BucketName].MatchObject(args.EventName, args.objectProperties())
 	sys.RUnlock()
 
 	if len(targetIDSet) == 0 {
@@ -1309,10 +1311,27 @@ type eventArgs struct {
 	UserAgent    string
 }
 
+// objectProperties - returns the object properties notification rules
+// are matched on.
+func (args eventArgs) objectProperties() event.ObjectProperties {
+	props := event.ObjectProperties{
+		Name:         args.Object.Name,
+		Size:         args.Object.Size,
+		ContentType:  args.Object.ContentType,
+		UserMetadata: args.Object.UserDefined,
+	}
+	if args.Object.UserTags != "" {
+		if t, err := tags.ParseObjectTags(args.Object.UserTags); err == nil {
+			props.Tags = t.ToMap()
+		}
+	}
+	return props
+}
+
 // ToEvent - converts to notification event.
 func (args eventArgs) ToEvent(escape bool) event.Event {
 	eventTime := UTCNow()
-	uniqueID := fmt.Sprintf("%X", eventTime.UnixNano())
+	uniqueID := fmt.Sprintf("%X", nextEventSeq(eventTime))
 
 	respElements := map[string]string{
 		"x-amz-request-id": args.RespElements["requestId"],
@@ -1395,13 +1414,66 @@ func sendEvent(args eventArgs) {
 		return
 	}
 
-	if globalHTTPListen.NumSubscribers() > 0 {
-		globalHTTPListen.Publish(args.ToEvent(false))
+	listen := globalHTTPListen.NumSubscribers() > 0
+	journaled := globalEventJournalSys != nil && globalEventJournalSys.Enabled(args.BucketName)
+	if listen || journaled {
+		ev := args.ToEvent(false)
+		if listen {
+			globalHTTPListen.Publish(ev)
+		}
+		if journaled {
+			globalEventJournalSys.Record(ev)
+		}
 	}
 
 	globalNotificationSys.Send(args)
 }
 
+// deadLetterRecord - bucket notification which could not be delivered.
+type deadLetterRecord struct {
+	Target string      `json:"target"`
+	Reason string      `json:"reason"`
+	Time   time.Time   `json:"time"`
+	Event  event.Event `json:"event"`
+}
+
+// deadLetterEvents - returns a function writing undeliverable bucket
+// notifications as objects named <target>/<date>/<uuid>.json into bucket.
+func deadLetterEvents(bucket string) func(id event.TargetID, ev event.Event, reason error) error {
+	return func(id event.TargetID, ev event.Event, reason error) error {
+		objAPI := newObjectLayerFn()
+		if objAPI == nil {
+			return errServerNotInitialized
+		}
+
+		now := UTCNow()
+		data, err := json.Marshal(deadLetterRecord{
+			Target: id.String(),
+			Reason: reason.Error(),
+			Time:   now,
+			Event:  ev,
+		})
+		if err != nil {
+			return err
+		}
+
+		hashReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", getSHA256Hash(data), int64(len(data)))
+		if err != nil {
+			return err
+		}
+
+		object := pathJoin(id.String(), now.Format("2006-01-02"), mustGetUUID()+".json")
+		_, err = objAPI.PutObject(GlobalContext, bucket, object, NewPutObjReader(hashReader), ObjectOptions{
+			Versioned:        globalBucketVersioningSys.Enabled(bucket),
+			VersionSuspended: globalBucketVersioningSys.Suspended(bucket),
+			UserDefined: map[string]string{
+				xhttp.ContentType: "application/json",
+			},
+		})
+		return err
+	}
+}
+
 // GetBandwidthReports - gets the bandwidth report from all nodes including self.
 func (sys *NotificationSys) GetBandwidthReports(ctx context.Context, buckets ...string) madmin.BucketBandwidthReport {
 	reports := make([]*madmin.BucketBandwidthReport, len(sys.peerClients))
@@ -1558,6 +1630,67 @@ func (sys *NotificationSys) Speedtest(ctx context.Context, size int, concurrent
 	return results
 }
 
+// ReloadPoolMeta reloads on disk updates on pool metadata
+func (sys *NotificationSys) ReloadPoolMeta(ctx context.Context) {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.ReloadPoolMeta(ctx)
+		}, idx, *client.host)
+	}
+	for _, nErr := range ng.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+}
+
+// LoadRebalanceMeta notifies all peers to reload the rebalance metadata,
+// the rebalance is started on the responsible peer if startRebalance is set.
+func (sys *NotificationSys) LoadRebalanceMeta(ctx context.Context, startRebalance bool) {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.LoadRebalanceMeta(ctx, startRebalance)
+		}, idx, *client.host)
+	}
+	for _, nErr := range ng.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+}
+
+// StopRebalance notifies all peers to stop their rebalance routines.
+func (sys *NotificationSys) StopRebalance(ctx context.Context) {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.StopRebalance(ctx)
+		}, idx, *client.host)
+	}
+	for _, nErr := range ng.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+}
+
 // ReloadSiteReplicationConfig - tells all peer minio nodes to reload the
 // site-replication configuration.
 func (sys *NotificationSys) ReloadSiteReplicationConfig(ctx context.Context) []error {
@@ -365,6 +365,27 @@ func (e BucketSSEConfigNotFound) Error() string {
 	return "No bucket encryption configuration found for bucket: " + e.Bucket
 }
 
+// BucketCorsNotFound - no bucket CORS configuration found
+type BucketCorsNotFound GenericError
+
+func (e BucketCorsNotFound) Error() string {
+	return "No bucket CORS configuration found for bucket: " + e.Bucket
+}
+
+// BucketLoggingNotFound - no bucket logging configuration found
+type BucketLoggingNotFound GenericError
+
+func (e BucketLoggingNotFound) Error() string {
+	return "No bucket logging configuration found for bucket: " + e.Bucket
+}
+
+// BucketWebsiteNotFound - no bucket website configuration found
+type BucketWebsiteNotFound GenericError
+
+func (e BucketWebsiteNotFound) Error() string {
+	return "No bucket website configuration found for bucket: " + e.Bucket
+}
+
 // BucketTaggingNotFound - no bucket tags found
 type BucketTaggingNotFound GenericError
 
@@ -386,6 +407,20 @@ func (e BucketQuotaConfigNotFound) Error() string {
 	return "No quota config found for bucket : " + e.Bucket
 }
 
+// BucketTransformNotFound - no bucket transform config found.
+type BucketTransformNotFound GenericError
+
+func (e BucketTransformNotFound) Error() string {
+	return "No transform config found for bucket : " + e.Bucket
+}
+
+// BucketTransformFailed - bucket transformer failed to process the object.
+type BucketTransformFailed GenericError
+
+func (e BucketTransformFailed) Error() string {
+	return "Transform failed for " + e.Bucket + "/" + e.Object + ": " + e.Err.Error()
+}
+
 // BucketQuotaExceeded - bucket quota exceeded.
 type BucketQuotaExceeded GenericError
 
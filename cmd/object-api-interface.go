@@ -71,6 +71,10 @@ type ObjectOptions struct {
 
 	// Use the maximum parity (N/2), used when saving server configuration files
 	MaxParity bool
+
+	SkipDecommissioned bool // set to skip the decommissioned pools when placing new writes.
+	SkipRebalancing    bool // set to skip the pools being rebalanced when placing new writes.
+	NoDecryption       bool // indicates if the stream must be read without decryption and decompression.
 }
 
 // ExpirationOptions represents object options for object expiration at objectLayer.
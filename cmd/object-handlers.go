@@ -297,6 +297,7 @@ func (api objectAPIHandlers) SelectObjectContentHandler(w http.ResponseWriter, r
 		}
 	}
 
+	s3Select.SetMemoryLimit(globalAPIConfig.getSelectMaxMemory())
 	s3Select.Evaluate(w)
 
 	// Notify object accessed via a GET request.
@@ -395,6 +396,16 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		}
 	}
 
+	// Stream the object through the transformer configured on the bucket, if any.
+	transformConfig, _ := globalBucketMetadataSys.GetTransformConfig(bucket)
+	if transformConfig != nil {
+		if opts.PartNumber > 0 || (rs != nil && !transformConfig.SupportsRange) {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrTransformRangeNotSupported), r.URL)
+			return
+		}
+		getObjectNInfo = transformGetObjectNInfo(getObjectNInfo, transformConfig, logger.GetReqInfo(ctx).AccessKey)
+	}
+
 	// Validate pre-conditions if any.
 	opts.CheckPrecondFn = func(oi ObjectInfo) bool {
 		if objectAPI.IsEncryptionSupported() {
@@ -414,7 +425,8 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 			proxy  bool
 		)
 		proxytgts := getproxyTargets(ctx, bucket, object, opts)
-		if !proxytgts.Empty() {
+		// Proxied objects would bypass the bucket transformer.
+		if !proxytgts.Empty() && transformConfig == nil {
 			// proxy to replication target if active-active replication is in place.
 			reader, proxy = proxyGetToReplicationTarget(ctx, bucket, object, rs, r.Header, opts, proxytgts)
 			if reader != nil && proxy {
@@ -491,11 +503,21 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		}
 	}
 
+	// Length and range of transformed content are only known to the transformer.
+	tr, transformed := gr.Reader.(*transformReader)
+	if transformed {
+		rs = nil
+	}
+
 	if err = setObjectHeaders(w, objInfo, rs, opts); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
 
+	if transformed {
+		tr.setHeaders(w)
+	}
+
 	// Set Parts Count Header
 	if opts.PartNumber > 0 && len(objInfo.Parts) > 0 {
 		setPartsCountHeaders(w, objInfo)
@@ -505,7 +527,7 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 
 	statusCodeWritten := false
 	httpWriter := xioutil.WriteOnClose(w)
-	if rs != nil || opts.PartNumber > 0 {
+	if rs != nil || opts.PartNumber > 0 || (transformed && tr.statusCode == http.StatusPartialContent) {
 		statusCodeWritten = true
 		w.WriteHeader(http.StatusPartialContent)
 	}
@@ -4189,6 +4211,7 @@ func (api objectAPIHandlers) PostRestoreObjectHandler(w http.ResponseWriter, r *
 			rw := logger.NewResponseWriter(nr)
 			rw.LogErrBody = true
 			rw.LogAllBody = true
+			rreq.SelectParameters.SetMemoryLimit(globalAPIConfig.getSelectMaxMemory())
 			rreq.SelectParameters.Evaluate(rw)
 			rreq.SelectParameters.Close()
 			return
@@ -81,6 +81,9 @@ func configureServerHandler(endpointServerPools EndpointServerPools) (http.Handl
 	// Add server metrics router
 	registerMetricsRouter(router)
 
+	// Add SCIM provisioning router
+	registerSCIMRouter(router)
+
 	// Add STS router always.
 	registerSTSRouter(router)
 
@@ -0,0 +1,1027 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/auth"
+	xhttp "github.com/minio/minio/internal/http"
+	xjwt "github.com/minio/minio/internal/jwt"
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+const (
+	scimPathPrefix = minioReservedBucketPath + "/scim/v2"
+
+	// Issuer of the bearer tokens accepted by the SCIM API.
+	scimTokenIssuer = "scim"
+
+	// Prefix of the IDs of the revoked SCIM bearer tokens.
+	scimRevokedTokensPrefix = iamConfigPrefix + "/scim/revoked"
+
+	scimRequestBodyLimit = 1 << 20 // 1 MiB
+)
+
+// scimAPIHandlers implements the SCIM 2.0 provisioning API for users
+// and groups of the internal IDP.
+type scimAPIHandlers struct{}
+
+// registerSCIMRouter - registers the SCIM 2.0 API.
+func registerSCIMRouter(router *mux.Router) {
+	scimAPI := scimAPIHandlers{}
+
+	scimRouter := router.NewRoute().PathPrefix(scimPathPrefix).Subrouter()
+
+	scimRouter.Methods(http.MethodGet).Path("/ServiceProviderConfig").HandlerFunc(httpTraceHdrs(scimAPI.ServiceProviderConfigHandler))
+
+	scimRouter.Methods(http.MethodGet).Path("/Users").HandlerFunc(httpTraceHdrs(scimAPI.ListUsersHandler))
+	scimRouter.Methods(http.MethodPost).Path("/Users").HandlerFunc(httpTraceHdrs(scimAPI.CreateUserHandler))
+	scimRouter.Methods(http.MethodGet).Path("/Users/{id}").HandlerFunc(httpTraceHdrs(scimAPI.GetUserHandler))
+	scimRouter.Methods(http.MethodPut).Path("/Users/{id}").HandlerFunc(httpTraceHdrs(scimAPI.ReplaceUserHandler))
+	scimRouter.Methods(http.MethodPatch).Path("/Users/{id}").HandlerFunc(httpTraceHdrs(scimAPI.PatchUserHandler))
+	scimRouter.Methods(http.MethodDelete).Path("/Users/{id}").HandlerFunc(httpTraceHdrs(scimAPI.DeleteUserHandler))
+
+	scimRouter.Methods(http.MethodGet).Path("/Groups").HandlerFunc(httpTraceHdrs(scimAPI.ListGroupsHandler))
+	scimRouter.Methods(http.MethodPost).Path("/Groups").HandlerFunc(httpTraceHdrs(scimAPI.CreateGroupHandler))
+	scimRouter.Methods(http.MethodGet).Path("/Groups/{id}").HandlerFunc(httpTraceHdrs(scimAPI.GetGroupHandler))
+	scimRouter.Methods(http.MethodPut).Path("/Groups/{id}").HandlerFunc(httpTraceHdrs(scimAPI.ReplaceGroupHandler))
+	scimRouter.Methods(http.MethodPatch).Path("/Groups/{id}").HandlerFunc(httpTraceHdrs(scimAPI.PatchGroupHandler))
+	scimRouter.Methods(http.MethodDelete).Path("/Groups/{id}").HandlerFunc(httpTraceHdrs(scimAPI.DeleteGroupHandler))
+}
+
+// Check to allow access to the reserved "bucket" `/minio` for SCIM
+// API requests.
+func isSCIMReq(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, scimPathPrefix+SlashSeparator)
+}
+
+func writeSCIMResponse(w http.ResponseWriter, statusCode int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeSCIMError(context.Background(), w, err)
+		return
+	}
+	writeResponse(w, statusCode, data, mimeSCIM)
+}
+
+func writeSCIMError(ctx context.Context, w http.ResponseWriter, err error) {
+	serr := toSCIMError(err)
+	statusCode, _ := strconv.Atoi(serr.Status)
+	if statusCode == http.StatusInternalServerError {
+		logger.LogIf(ctx, err)
+	}
+	data, _ := json.Marshal(serr)
+	writeResponse(w, statusCode, data, mimeSCIM)
+}
+
+// authenticateSCIMRequest verifies the SCIM bearer token of a request,
+// writes an error response if it is invalid.
+func authenticateSCIMRequest(ctx context.Context, w http.ResponseWriter, r *http.Request) (*xjwt.MapClaims, bool, bool) {
+	if newObjectLayerFn() == nil || globalNotificationSys == nil || !globalIAMSys.Initialized() {
+		writeSCIMError(ctx, w, errServerNotInitialized)
+		return nil, false, false
+	}
+	claims, owner, err := webRequestAuthenticate(r)
+	if err != nil || !claims.VerifyIssuer(scimTokenIssuer, true) {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusUnauthorized, "", "invalid or missing SCIM bearer token"))
+		return nil, false, false
+	}
+	if globalIAMSys.usersSysType != MinIOUsersSysType {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusForbidden, "", "SCIM provisioning requires the internal identity provider"))
+		return nil, false, false
+	}
+	tokenID, _ := claims.Lookup("jti")
+	if tokenID == "" {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusUnauthorized, "", "invalid or missing SCIM bearer token"))
+		return nil, false, false
+	}
+	revoked, err := isSCIMTokenRevoked(ctx, newObjectLayerFn(), tokenID)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return nil, false, false
+	}
+	if revoked {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusUnauthorized, "", "the SCIM bearer token is revoked"))
+		return nil, false, false
+	}
+	return claims, owner, true
+}
+
+// scimRevokedToken - a revoked SCIM bearer token, kept until
+// the longest lived token issued before would have expired.
+type scimRevokedToken struct {
+	Expiration time.Time `json:"expiration"`
+}
+
+func scimRevokedTokenPath(tokenID string) string {
+	return path.Join(scimRevokedTokensPrefix, tokenID+".json")
+}
+
+// isSCIMTokenRevoked - returns true if the SCIM bearer token is revoked.
+func isSCIMTokenRevoked(ctx context.Context, objAPI ObjectLayer, tokenID string) (bool, error) {
+	_, err := readConfig(ctx, objAPI, scimRevokedTokenPath(tokenID))
+	switch err {
+	case nil:
+		return true, nil
+	case errConfigNotFound:
+		return false, nil
+	}
+	return false, err
+}
+
+// revokeSCIMToken - revokes a SCIM bearer token and removes the
+// revoked tokens which expired by now.
+func revokeSCIMToken(ctx context.Context, objAPI ObjectLayer, tokenID string) error {
+	data, err := json.Marshal(scimRevokedToken{Expiration: UTCNow().Add(maxSCIMJWTExpiry)})
+	if err != nil {
+		return err
+	}
+	if err = saveConfig(ctx, objAPI, scimRevokedTokenPath(tokenID), data); err != nil {
+		return err
+	}
+
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, minioMetaBucket, scimRevokedTokensPrefix+SlashSeparator, marker, "", maxObjectList)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return nil
+		}
+		for _, obj := range res.Objects {
+			data, err := readConfig(ctx, objAPI, obj.Name)
+			if err != nil {
+				continue
+			}
+			var revoked scimRevokedToken
+			if err = json.Unmarshal(data, &revoked); err != nil || revoked.Expiration.After(UTCNow()) {
+				continue
+			}
+			if err = deleteConfig(ctx, objAPI, obj.Name); err != nil && err != errConfigNotFound {
+				logger.LogIf(ctx, err)
+			}
+		}
+		if !res.IsTruncated {
+			return nil
+		}
+		marker = res.NextMarker
+	}
+}
+
+// checkSCIMActions checks that the account of the SCIM bearer token is
+// allowed to perform all actions, writes an error response otherwise.
+func checkSCIMActions(ctx context.Context, w http.ResponseWriter, r *http.Request, claims *xjwt.MapClaims, owner bool, actions ...iampolicy.AdminAction) bool {
+	for _, action := range actions {
+		if !globalIAMSys.IsAllowed(iampolicy.Args{
+			AccountName:     claims.AccessKey,
+			Action:          iampolicy.Action(action),
+			ConditionValues: getConditionValues(r, "", claims.AccessKey, claims.Map()),
+			IsOwner:         owner,
+			Claims:          claims.Map(),
+		}) {
+			writeSCIMError(ctx, w, newSCIMError(http.StatusForbidden, "",
+				fmt.Sprintf("%s is not allowed to perform %s", claims.AccessKey, action)))
+			return false
+		}
+	}
+	return true
+}
+
+// parseSCIMRequest decodes the JSON request body into v.
+func parseSCIMRequest(r *http.Request, v interface{}) error {
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, scimRequestBodyLimit))
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return newSCIMError(http.StatusBadRequest, scimInvalidSyntax, err.Error())
+	}
+	return nil
+}
+
+// scimResourceID returns the unescaped resource id of the request path.
+func scimResourceID(r *http.Request) (string, error) {
+	id, err := url.PathUnescape(mux.Vars(r)["id"])
+	if err != nil || id == "" {
+		return "", newSCIMError(http.StatusBadRequest, scimInvalidValue, "invalid resource id")
+	}
+	return id, nil
+}
+
+func scimLocation(r *http.Request, resource, id string) string {
+	return getURLScheme(globalIsTLS) + "://" + r.Host + scimPathPrefix + SlashSeparator + resource + SlashSeparator + url.PathEscape(id)
+}
+
+func logPeerErrs(ctx context.Context, nerrs []NotificationPeerErr) {
+	for _, nerr := range nerrs {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+}
+
+// checkSCIMPolicies verifies that all policies exist.
+func checkSCIMPolicies(policies []string) error {
+	for _, policy := range policies {
+		if _, err := globalIAMSys.InfoPolicy(policy); err != nil {
+			if err == errNoSuchPolicy {
+				return newSCIMError(http.StatusBadRequest, scimInvalidValue, fmt.Sprintf("policy %q does not exist", policy))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// setSCIMPolicies maps the policies to a user or group, an empty list
+// removes the mapping.
+func setSCIMPolicies(ctx context.Context, name string, policies []string, isGroup bool) error {
+	policy := strings.Join(policies, ",")
+	if err := globalIAMSys.PolicyDBSet(name, policy, isGroup); err != nil {
+		return err
+	}
+
+	// Notify all other MinIO peers to reload policy
+	if !globalIAMSys.HasWatcher() {
+		logPeerErrs(ctx, globalNotificationSys.LoadPolicyMapping(name, isGroup))
+	}
+
+	return globalSiteReplicationSys.IAMChangeHook(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemPolicyMapping,
+		PolicyMapping: &madmin.SRPolicyMapping{
+			UserOrGroup: name,
+			IsGroup:     isGroup,
+			Policy:      policy,
+		},
+	})
+}
+
+// actions returns the admin actions required to apply the update.
+func (u scimUserUpdate) actions() []iampolicy.AdminAction {
+	var actions []iampolicy.AdminAction
+	if u.password != nil {
+		actions = append(actions, iampolicy.CreateUserAdminAction)
+	}
+	if u.active != nil {
+		actions = append(actions, iampolicy.EnableUserAdminAction)
+	}
+	if u.setRoles {
+		actions = append(actions, iampolicy.AttachPolicyAdminAction)
+	}
+	return actions
+}
+
+// updateSCIMUser applies the changes of a PUT or PATCH request to a user.
+func updateSCIMUser(ctx context.Context, name string, u scimUserUpdate) error {
+	if u.setRoles {
+		if err := checkSCIMPolicies(u.roles); err != nil {
+			return err
+		}
+	}
+	if u.password != nil {
+		if err := globalIAMSys.SetUserSecretKey(name, *u.password); err != nil {
+			return err
+		}
+	}
+	if u.active != nil {
+		status := madmin.AccountEnabled
+		if !*u.active {
+			status = madmin.AccountDisabled
+		}
+		if err := globalIAMSys.SetUserStatus(name, status); err != nil {
+			return err
+		}
+	}
+	if u.password != nil || u.active != nil {
+		// Notify all other MinIO peers to reload user.
+		if !globalIAMSys.HasWatcher() {
+			logPeerErrs(ctx, globalNotificationSys.LoadUser(name, false))
+		}
+	}
+	if u.setRoles {
+		return setSCIMPolicies(ctx, name, u.roles, false)
+	}
+	return nil
+}
+
+// scimIdentityExists returns whether the access key is in use by the
+// root user, a user, a service account or temporary credentials.
+func scimIdentityExists(accessKey string) bool {
+	if accessKey == globalActiveCred.AccessKey {
+		return true
+	}
+	if _, ok := globalIAMSys.GetUser(accessKey); ok {
+		return true
+	}
+	// GetUser skips disabled credentials, which must not be
+	// replaced either.
+	_, ok := globalIAMSys.store.GetUser(accessKey)
+	return ok
+}
+
+// getSCIMUser returns the SCIM resource of an internal IDP user.
+func getSCIMUser(r *http.Request, name string) (scimUser, error) {
+	info, err := globalIAMSys.GetUserInfo(name)
+	if err == errIAMActionNotAllowed {
+		// Temporary credentials and service accounts are not users.
+		err = errNoSuchUser
+	}
+	if err != nil {
+		return scimUser{}, err
+	}
+	return newSCIMUser(name, info, scimLocation(r, "Users", name)), nil
+}
+
+// actions returns the admin actions required to apply the update.
+func (g scimGroupUpdate) actions(current []string) []iampolicy.AdminAction {
+	var actions []iampolicy.AdminAction
+	if g.setMembers {
+		if len(removeStrings(g.members, current...)) > 0 {
+			actions = append(actions, iampolicy.AddUserToGroupAdminAction)
+		}
+		if len(removeStrings(current, g.members...)) > 0 {
+			actions = append(actions, iampolicy.RemoveUserFromGroupAdminAction)
+		}
+	}
+	if g.setPolicies {
+		actions = append(actions, iampolicy.AttachPolicyAdminAction)
+	}
+	return actions
+}
+
+// updateSCIMGroup applies the changes of a PUT or PATCH request to a
+// group with the current members.
+func updateSCIMGroup(ctx context.Context, group string, current []string, g scimGroupUpdate) error {
+	if g.setPolicies {
+		if err := checkSCIMPolicies(g.policies); err != nil {
+			return err
+		}
+	}
+	if g.setMembers {
+		added := removeStrings(g.members, current...)
+		removed := removeStrings(current, g.members...)
+		if len(added) > 0 {
+			if err := globalIAMSys.AddUsersToGroup(group, added); err != nil {
+				return err
+			}
+		}
+		if len(removed) > 0 {
+			if err := globalIAMSys.RemoveUsersFromGroup(group, removed); err != nil {
+				return err
+			}
+		}
+		// Notify all other MinIO peers to load group.
+		if (len(added) > 0 || len(removed) > 0) && !globalIAMSys.HasWatcher() {
+			logPeerErrs(ctx, globalNotificationSys.LoadGroup(group))
+		}
+	}
+	if g.setPolicies {
+		return setSCIMPolicies(ctx, group, g.policies, true)
+	}
+	return nil
+}
+
+// getSCIMGroup returns the SCIM resource of a group.
+func getSCIMGroup(r *http.Request, group string) (scimGroup, error) {
+	desc, err := globalIAMSys.GetGroupDescription(group)
+	if err != nil {
+		return scimGroup{}, err
+	}
+	return newSCIMGroup(desc, scimLocation(r, "Groups", group)), nil
+}
+
+// ServiceProviderConfigHandler - GET /minio/scim/v2/ServiceProviderConfig
+func (api scimAPIHandlers) ServiceProviderConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMServiceProviderConfig")
+
+	if _, _, ok := authenticateSCIMRequest(ctx, w, r); !ok {
+		return
+	}
+
+	supported := func(b bool) map[string]bool {
+		return map[string]bool{"supported": b}
+	}
+	writeSCIMResponse(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{scimServiceProviderConfigSchema},
+		"patch":          supported(true),
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": scimMaxResults},
+		"changePassword": supported(true),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]interface{}{
+			{
+				"type":        "oauthbearertoken",
+				"name":        "OAuth Bearer Token",
+				"description": "Bearer token issued by the MinIO admin API",
+				"primary":     true,
+			},
+		},
+	})
+}
+
+// ListUsersHandler - GET /minio/scim/v2/Users?filter={filter}&startIndex={index}&count={count}
+func (api scimAPIHandlers) ListUsersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMListUsers")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	claims, owner, ok := authenticateSCIMRequest(ctx, w, r)
+	if !ok || !checkSCIMActions(ctx, w, r, claims, owner, iampolicy.ListUsersAdminAction) {
+		return
+	}
+
+	query := r.URL.Query()
+	userName, filtered, err := parseSCIMFilter(query.Get("filter"), "userName")
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	startIndex, count, err := parseSCIMPagination(query)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	users, err := globalIAMSys.ListUsers()
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	names := make([]string, 0, len(users))
+	for name := range users {
+		if !filtered || name == userName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	resources := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		resources = append(resources, newSCIMUser(name, users[name], scimLocation(r, "Users", name)))
+	}
+	writeSCIMResponse(w, http.StatusOK, newSCIMListResponse(resources, startIndex, count))
+}
+
+// CreateUserHandler - POST /minio/scim/v2/Users
+func (api scimAPIHandlers) CreateUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMCreateUser")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	claims, owner, ok := authenticateSCIMRequest(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	var user scimUser
+	if err := parseSCIMRequest(r, &user); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if user.UserName == "" {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, scimInvalidValue, "userName is required"))
+		return
+	}
+
+	actions := []iampolicy.AdminAction{iampolicy.CreateUserAdminAction}
+	if user.Roles != nil {
+		actions = append(actions, iampolicy.AttachPolicyAdminAction)
+	}
+	if !checkSCIMActions(ctx, w, r, claims, owner, actions...) {
+		return
+	}
+
+	if scimIdentityExists(user.UserName) {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusConflict, scimUniqueness, fmt.Sprintf("access key %q already exists", user.UserName)))
+		return
+	}
+	roles := scimValueStrings(user.Roles)
+	if err := checkSCIMPolicies(roles); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	// Users provisioned without a password get a random secret key,
+	// which can be changed later on.
+	secretKey := user.Password
+	if secretKey == "" {
+		cred, err := auth.GetNewCredentials()
+		if err != nil {
+			writeSCIMError(ctx, w, err)
+			return
+		}
+		secretKey = cred.SecretKey
+	}
+	status := madmin.AccountEnabled
+	if user.Active != nil && !*user.Active {
+		status = madmin.AccountDisabled
+	}
+	if err := globalIAMSys.CreateUser(user.UserName, madmin.UserInfo{
+		SecretKey: secretKey,
+		Status:    status,
+	}); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	// Notify all other Minio peers to reload user
+	if !globalIAMSys.HasWatcher() {
+		logPeerErrs(ctx, globalNotificationSys.LoadUser(user.UserName, false))
+	}
+
+	if len(roles) > 0 {
+		if err := setSCIMPolicies(ctx, user.UserName, roles, false); err != nil {
+			writeSCIMError(ctx, w, err)
+			return
+		}
+	}
+
+	resource, err := getSCIMUser(r, user.UserName)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	w.Header().Set(xhttp.Location, resource.Meta.Location)
+	writeSCIMResponse(w, http.StatusCreated, resource)
+}
+
+// GetUserHandler - GET /minio/scim/v2/Users/{id}
+func (api scimAPIHandlers) GetUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMGetUser")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	claims, owner, ok := authenticateSCIMRequest(ctx, w, r)
+	if !ok || !checkSCIMActions(ctx, w, r, claims, owner, iampolicy.GetUserAdminAction) {
+		return
+	}
+
+	name, err := scimResourceID(r)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	resource, err := getSCIMUser(r, name)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	writeSCIMResponse(w, http.StatusOK, resource)
+}
+
+// ReplaceUserHandler - PUT /minio/scim/v2/Users/{id}
+//
+// Attributes missing in the request are left unchanged.
+func (api scimAPIHandlers) ReplaceUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMReplaceUser")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	claims, owner, ok := authenticateSCIMRequest(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	name, err := scimResourceID(r)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	var user scimUser
+	if err = parseSCIMRequest(r, &user); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if user.UserName != "" && user.UserName != name {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, scimMutability, "users can not be renamed"))
+		return
+	}
+
+	var update scimUserUpdate
+	if user.Password != "" {
+		update.password = &user.Password
+	}
+	update.active = user.Active
+	if user.Roles != nil {
+		update.roles, update.setRoles = scimValueStrings(user.Roles), true
+	}
+	if !checkSCIMActions(ctx, w, r, claims, owner, update.actions()...) {
+		return
+	}
+
+	if _, err = getSCIMUser(r, name); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if err = updateSCIMUser(ctx, name, update); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	resource, err := getSCIMUser(r, name)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	writeSCIMResponse(w, http.StatusOK, resource)
+}
+
+// PatchUserHandler - PATCH /minio/scim/v2/Users/{id}
+func (api scimAPIHandlers) PatchUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMPatchUser")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	claims, owner, ok := authenticateSCIMRequest(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	name, err := scimResourceID(r)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	var patch scimPatchRequest
+	if err = parseSCIMRequest(r, &patch); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	current, err := getSCIMUser(r, name)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	update, err := scimUserPatch(patch.Operations, scimValueStrings(current.Roles))
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if !checkSCIMActions(ctx, w, r, claims, owner, update.actions()...) {
+		return
+	}
+	if err = updateSCIMUser(ctx, name, update); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	resource, err := getSCIMUser(r, name)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	writeSCIMResponse(w, http.StatusOK, resource)
+}
+
+// DeleteUserHandler - DELETE /minio/scim/v2/Users/{id}
+func (api scimAPIHandlers) DeleteUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMDeleteUser")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	claims, owner, ok := authenticateSCIMRequest(ctx, w, r)
+	if !ok || !checkSCIMActions(ctx, w, r, claims, owner, iampolicy.DeleteUserAdminAction) {
+		return
+	}
+
+	name, err := scimResourceID(r)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if _, err = getSCIMUser(r, name); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if err = globalIAMSys.DeleteUser(name); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	// Notify all other MinIO peers to delete user.
+	logPeerErrs(ctx, globalNotificationSys.DeleteUser(name))
+
+	writeResponse(w, http.StatusNoContent, nil, mimeNone)
+}
+
+// ListGroupsHandler - GET /minio/scim/v2/Groups?filter={filter}&startIndex={index}&count={count}
+func (api scimAPIHandlers) ListGroupsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMListGroups")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	claims, owner, ok := authenticateSCIMRequest(ctx, w, r)
+	if !ok || !checkSCIMActions(ctx, w, r, claims, owner, iampolicy.ListGroupsAdminAction, iampolicy.GetGroupAdminAction) {
+		return
+	}
+
+	query := r.URL.Query()
+	displayName, filtered, err := parseSCIMFilter(query.Get("filter"), "displayName")
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	startIndex, count, err := parseSCIMPagination(query)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	groups, err := globalIAMSys.ListGroups()
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	sort.Strings(groups)
+
+	resources := make([]interface{}, 0, len(groups))
+	for _, group := range groups {
+		if filtered && group != displayName {
+			continue
+		}
+		resource, err := getSCIMGroup(r, group)
+		if err == errNoSuchGroup {
+			// Removed after listing.
+			continue
+		}
+		if err != nil {
+			writeSCIMError(ctx, w, err)
+			return
+		}
+		resources = append(resources, resource)
+	}
+	writeSCIMResponse(w, http.StatusOK, newSCIMListResponse(resources, startIndex, count))
+}
+
+// CreateGroupHandler - POST /minio/scim/v2/Groups
+func (api scimAPIHandlers) CreateGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMCreateGroup")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	claims, owner, ok := authenticateSCIMRequest(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	var group scimGroup
+	if err := parseSCIMRequest(r, &group); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if group.DisplayName == "" {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, scimInvalidValue, "displayName is required"))
+		return
+	}
+
+	actions := []iampolicy.AdminAction{iampolicy.AddUserToGroupAdminAction}
+	if group.Policy != nil {
+		actions = append(actions, iampolicy.AttachPolicyAdminAction)
+	}
+	if !checkSCIMActions(ctx, w, r, claims, owner, actions...) {
+		return
+	}
+
+	if _, err := globalIAMSys.GetGroupDescription(group.DisplayName); err == nil {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusConflict, scimUniqueness, fmt.Sprintf("group %q already exists", group.DisplayName)))
+		return
+	}
+	var policies []string
+	if group.Policy != nil {
+		policies = group.Policy.Policies
+	}
+	if err := checkSCIMPolicies(policies); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	// Groups without members are created as well.
+	if err := globalIAMSys.AddUsersToGroup(group.DisplayName, scimValueStrings(group.Members)); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	// Notify all other MinIO peers to load group.
+	if !globalIAMSys.HasWatcher() {
+		logPeerErrs(ctx, globalNotificationSys.LoadGroup(group.DisplayName))
+	}
+
+	if len(policies) > 0 {
+		if err := setSCIMPolicies(ctx, group.DisplayName, policies, true); err != nil {
+			writeSCIMError(ctx, w, err)
+			return
+		}
+	}
+
+	resource, err := getSCIMGroup(r, group.DisplayName)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	w.Header().Set(xhttp.Location, resource.Meta.Location)
+	writeSCIMResponse(w, http.StatusCreated, resource)
+}
+
+// GetGroupHandler - GET /minio/scim/v2/Groups/{id}
+func (api scimAPIHandlers) GetGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMGetGroup")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	claims, owner, ok := authenticateSCIMRequest(ctx, w, r)
+	if !ok || !checkSCIMActions(ctx, w, r, claims, owner, iampolicy.GetGroupAdminAction) {
+		return
+	}
+
+	group, err := scimResourceID(r)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	resource, err := getSCIMGroup(r, group)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	writeSCIMResponse(w, http.StatusOK, resource)
+}
+
+// ReplaceGroupHandler - PUT /minio/scim/v2/Groups/{id}
+//
+// Attributes missing in the request are left unchanged.
+func (api scimAPIHandlers) ReplaceGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMReplaceGroup")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	claims, owner, ok := authenticateSCIMRequest(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	name, err := scimResourceID(r)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	var group scimGroup
+	if err = parseSCIMRequest(r, &group); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if group.DisplayName != "" && group.DisplayName != name {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, scimMutability, "groups can not be renamed"))
+		return
+	}
+
+	current, err := getSCIMGroup(r, name)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	var update scimGroupUpdate
+	if group.Members != nil {
+		update.members, update.setMembers = scimValueStrings(group.Members), true
+	}
+	if group.Policy != nil {
+		update.policies, update.setPolicies = group.Policy.Policies, true
+	}
+	members := scimValueStrings(current.Members)
+	if !checkSCIMActions(ctx, w, r, claims, owner, update.actions(members)...) {
+		return
+	}
+	if err = updateSCIMGroup(ctx, name, members, update); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	resource, err := getSCIMGroup(r, name)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	writeSCIMResponse(w, http.StatusOK, resource)
+}
+
+// PatchGroupHandler - PATCH /minio/scim/v2/Groups/{id}
+func (api scimAPIHandlers) PatchGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMPatchGroup")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	claims, owner, ok := authenticateSCIMRequest(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	name, err := scimResourceID(r)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	var patch scimPatchRequest
+	if err = parseSCIMRequest(r, &patch); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	current, err := getSCIMGroup(r, name)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	members := scimValueStrings(current.Members)
+	update, err := scimGroupPatch(patch.Operations, name, members, current.Policy.Policies)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if !checkSCIMActions(ctx, w, r, claims, owner, update.actions(members)...) {
+		return
+	}
+	if err = updateSCIMGroup(ctx, name, members, update); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	resource, err := getSCIMGroup(r, name)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	writeSCIMResponse(w, http.StatusOK, resource)
+}
+
+// DeleteGroupHandler - DELETE /minio/scim/v2/Groups/{id}
+//
+// The members are removed from the group before it is deleted.
+func (api scimAPIHandlers) DeleteGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMDeleteGroup")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	claims, owner, ok := authenticateSCIMRequest(ctx, w, r)
+	if !ok || !checkSCIMActions(ctx, w, r, claims, owner, iampolicy.RemoveUserFromGroupAdminAction) {
+		return
+	}
+
+	group, err := scimResourceID(r)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	current, err := getSCIMGroup(r, group)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if members := scimValueStrings(current.Members); len(members) > 0 {
+		if err = globalIAMSys.RemoveUsersFromGroup(group, members); err != nil {
+			writeSCIMError(ctx, w, err)
+			return
+		}
+	}
+	if err = globalIAMSys.RemoveUsersFromGroup(group, nil); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	// Notify all other MinIO peers to load group.
+	if !globalIAMSys.HasWatcher() {
+		logPeerErrs(ctx, globalNotificationSys.LoadGroup(group))
+	}
+
+	writeResponse(w, http.StatusNoContent, nil, mimeNone)
+}
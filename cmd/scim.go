@@ -0,0 +1,583 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/auth"
+)
+
+// SCIM 2.0 schema URIs, RFC 7643 and RFC 7644.
+const (
+	scimUserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimGroupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	scimListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimPatchOpSchema               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	scimErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	// Extension schema holding the policies mapped to a group.
+	scimGroupPolicySchema = "urn:minio:params:scim:schemas:extension:2.0:Group"
+)
+
+// SCIM error types, RFC 7644 section 3.12.
+const (
+	scimInvalidFilter = "invalidFilter"
+	scimInvalidSyntax = "invalidSyntax"
+	scimInvalidPath   = "invalidPath"
+	scimInvalidValue  = "invalidValue"
+	scimMutability    = "mutability"
+	scimUniqueness    = "uniqueness"
+)
+
+// Maximum number of resources returned in a list response.
+const scimMaxResults = 1000
+
+type scimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location,omitempty"`
+}
+
+// scimValue - a multi-valued attribute entry such as a group member or
+// a user role.
+type scimValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// scimUser - SCIM User resource, "roles" holds the policies mapped to
+// the user.
+type scimUser struct {
+	Schemas  []string    `json:"schemas"`
+	ID       string      `json:"id,omitempty"`
+	UserName string      `json:"userName"`
+	Password string      `json:"password,omitempty"`
+	Active   *bool       `json:"active,omitempty"`
+	Groups   []scimValue `json:"groups,omitempty"`
+	Roles    []scimValue `json:"roles,omitempty"`
+	Meta     *scimMeta   `json:"meta,omitempty"`
+}
+
+type scimGroupPolicy struct {
+	Policies []string `json:"policies"`
+}
+
+// scimGroup - SCIM Group resource, the policies mapped to the group are
+// held by the MinIO group extension.
+type scimGroup struct {
+	Schemas     []string         `json:"schemas"`
+	ID          string           `json:"id,omitempty"`
+	DisplayName string           `json:"displayName"`
+	Members     []scimValue      `json:"members,omitempty"`
+	Policy      *scimGroupPolicy `json:"urn:minio:params:scim:schemas:extension:2.0:Group,omitempty"`
+	Meta        *scimMeta        `json:"meta,omitempty"`
+}
+
+type scimListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+type scimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type scimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []scimPatchOperation `json:"Operations"`
+}
+
+// scimError - SCIM error response, also returned as error by the
+// request parsing and IAM update helpers.
+type scimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+func (e scimError) Error() string {
+	return e.Detail
+}
+
+func newSCIMError(status int, scimType, detail string) scimError {
+	return scimError{
+		Schemas:  []string{scimErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+// toSCIMError converts IAM errors to SCIM errors.
+func toSCIMError(err error) scimError {
+	var serr scimError
+	switch {
+	case errors.As(err, &serr):
+		return serr
+	case errors.Is(err, errNoSuchUser), errors.Is(err, errNoSuchGroup):
+		return newSCIMError(http.StatusNotFound, "", err.Error())
+	case errors.Is(err, errNoSuchPolicy), errors.Is(err, errInvalidArgument),
+		errors.Is(err, auth.ErrInvalidAccessKeyLength), errors.Is(err, auth.ErrInvalidSecretKeyLength):
+		return newSCIMError(http.StatusBadRequest, scimInvalidValue, err.Error())
+	case errors.Is(err, errIAMActionNotAllowed):
+		return newSCIMError(http.StatusForbidden, "", err.Error())
+	case errors.Is(err, errServerNotInitialized):
+		return newSCIMError(http.StatusServiceUnavailable, "", err.Error())
+	}
+	return newSCIMError(http.StatusInternalServerError, "", err.Error())
+}
+
+// scimFilterRegexp matches the only supported filter expression,
+// an equality test of an attribute with a string e.g. `userName eq "bob"`.
+var scimFilterRegexp = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9.]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// parseSCIMFilter parses a filter comparing attr with a value, the
+// attribute name is case insensitive. An empty filter matches all
+// resources.
+func parseSCIMFilter(filter, attr string) (value string, ok bool, err error) {
+	if filter == "" {
+		return "", false, nil
+	}
+	m := scimFilterRegexp.FindStringSubmatch(filter)
+	if m == nil || !strings.EqualFold(m[1], attr) {
+		return "", false, newSCIMError(http.StatusBadRequest, scimInvalidFilter,
+			fmt.Sprintf("only filters of the form '%s eq \"value\"' are supported", attr))
+	}
+	value, err = strconv.Unquote(m[2])
+	if err != nil {
+		return "", false, newSCIMError(http.StatusBadRequest, scimInvalidFilter, err.Error())
+	}
+	return value, true, nil
+}
+
+// parseSCIMPagination returns the 1-based start index and the maximum
+// number of resources requested by the startIndex and count parameters.
+func parseSCIMPagination(values url.Values) (startIndex, count int, err error) {
+	startIndex, count = 1, scimMaxResults
+	if v := values.Get("startIndex"); v != "" {
+		startIndex, err = strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, newSCIMError(http.StatusBadRequest, scimInvalidValue, "invalid startIndex")
+		}
+		if startIndex < 1 {
+			startIndex = 1
+		}
+	}
+	if v := values.Get("count"); v != "" {
+		count, err = strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, newSCIMError(http.StatusBadRequest, scimInvalidValue, "invalid count")
+		}
+		if count < 0 {
+			count = 0
+		}
+		if count > scimMaxResults {
+			count = scimMaxResults
+		}
+	}
+	return startIndex, count, nil
+}
+
+// newSCIMListResponse returns the requested page of the resources.
+func newSCIMListResponse(resources []interface{}, startIndex, count int) scimListResponse {
+	page := []interface{}{}
+	if start := startIndex - 1; start < len(resources) {
+		end := start + count
+		if end > len(resources) {
+			end = len(resources)
+		}
+		page = resources[start:end]
+	}
+	return scimListResponse{
+		Schemas:      []string{scimListResponseSchema},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	}
+}
+
+// parseSCIMBool parses a boolean value, some clients send booleans as
+// strings e.g. "False".
+func parseSCIMBool(data json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return false, newSCIMError(http.StatusBadRequest, scimInvalidValue, "expecting a boolean value")
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, newSCIMError(http.StatusBadRequest, scimInvalidValue, "expecting a boolean value")
+	}
+	return b, nil
+}
+
+// parseSCIMValues parses a list of multi-valued attribute entries, a
+// single entry is accepted as well.
+func parseSCIMValues(data json.RawMessage) ([]string, error) {
+	var entries []scimValue
+	if err := json.Unmarshal(data, &entries); err != nil {
+		var entry scimValue
+		if err = json.Unmarshal(data, &entry); err != nil {
+			return nil, newSCIMError(http.StatusBadRequest, scimInvalidValue, "expecting a list of values")
+		}
+		entries = []scimValue{entry}
+	}
+	return scimValueStrings(entries), nil
+}
+
+func scimValueStrings(entries []scimValue) []string {
+	values := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Value != "" {
+			values = append(values, entry.Value)
+		}
+	}
+	return values
+}
+
+func newSCIMValues(values []string) []scimValue {
+	entries := make([]scimValue, 0, len(values))
+	for _, value := range values {
+		entries = append(entries, scimValue{Value: value, Display: value})
+	}
+	return entries
+}
+
+// scimValuePathRegexp matches a path selecting a single entry of a
+// multi-valued attribute e.g. `members[value eq "bob"]`.
+var scimValuePathRegexp = regexp.MustCompile(`^\s*([A-Za-z]+)\[\s*(?i:value)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*")\s*\]\s*$`)
+
+// parseSCIMPath returns the attribute of a patch path and the value
+// selected by a value filter, if any.
+func parseSCIMPath(path string) (attr, value string, err error) {
+	if m := scimValuePathRegexp.FindStringSubmatch(path); m != nil {
+		value, err = strconv.Unquote(m[2])
+		if err != nil {
+			return "", "", newSCIMError(http.StatusBadRequest, scimInvalidPath, err.Error())
+		}
+		return m[1], value, nil
+	}
+	return strings.TrimSpace(path), "", nil
+}
+
+// scimUserUpdate - changes of a user applied by a PUT or PATCH request,
+// nil fields are left unchanged.
+type scimUserUpdate struct {
+	active   *bool
+	password *string
+	roles    []string
+	setRoles bool
+}
+
+// scimUserPatch returns the changes of a user made by patch operations.
+// current holds the current policies of the user.
+func scimUserPatch(ops []scimPatchOperation, current []string) (u scimUserUpdate, err error) {
+	roles := current
+	for _, op := range ops {
+		attr, selected, err := parseSCIMPath(op.Path)
+		if err != nil {
+			return u, err
+		}
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+			if attr == "" {
+				// Value holds the attributes to be replaced.
+				var attrs map[string]json.RawMessage
+				if err = json.Unmarshal(op.Value, &attrs); err != nil {
+					return u, newSCIMError(http.StatusBadRequest, scimInvalidSyntax, "expecting an object value without path")
+				}
+				for k, v := range attrs {
+					if roles, err = u.apply(strings.ToLower(op.Op), k, v, roles); err != nil {
+						return u, err
+					}
+				}
+				continue
+			}
+			if selected != "" {
+				return u, newSCIMError(http.StatusBadRequest, scimInvalidPath, "value filters are only supported by remove")
+			}
+			if roles, err = u.apply(strings.ToLower(op.Op), attr, op.Value, roles); err != nil {
+				return u, err
+			}
+		case "remove":
+			if !strings.EqualFold(attr, "roles") {
+				return u, newSCIMError(http.StatusBadRequest, scimMutability, fmt.Sprintf("attribute %q can not be removed", attr))
+			}
+			u.setRoles = true
+			switch {
+			case selected != "":
+				roles = removeStrings(roles, selected)
+			case len(op.Value) > 0:
+				values, err := parseSCIMValues(op.Value)
+				if err != nil {
+					return u, err
+				}
+				roles = removeStrings(roles, values...)
+			default:
+				roles = nil
+			}
+		default:
+			return u, newSCIMError(http.StatusBadRequest, scimInvalidSyntax, fmt.Sprintf("unsupported operation %q", op.Op))
+		}
+	}
+	if u.setRoles {
+		u.roles = roles
+	}
+	return u, nil
+}
+
+// apply adds an "add" or "replace" operation of attr to the update.
+func (u *scimUserUpdate) apply(op, attr string, value json.RawMessage, roles []string) ([]string, error) {
+	switch strings.ToLower(attr) {
+	case "active":
+		active, err := parseSCIMBool(value)
+		if err != nil {
+			return nil, err
+		}
+		u.active = &active
+	case "password":
+		var password string
+		if err := json.Unmarshal(value, &password); err != nil {
+			return nil, newSCIMError(http.StatusBadRequest, scimInvalidValue, "expecting a string password")
+		}
+		u.password = &password
+	case "roles":
+		values, err := parseSCIMValues(value)
+		if err != nil {
+			return nil, err
+		}
+		u.setRoles = true
+		if op == "replace" {
+			return values, nil
+		}
+		return mergeStrings(roles, values...), nil
+	default:
+		return nil, newSCIMError(http.StatusBadRequest, scimMutability, fmt.Sprintf("attribute %q can not be modified", attr))
+	}
+	return roles, nil
+}
+
+// scimGroupUpdate - changes of a group applied by a PUT or PATCH
+// request.
+type scimGroupUpdate struct {
+	members     []string
+	setMembers  bool
+	policies    []string
+	setPolicies bool
+}
+
+// scimGroupPatch returns the members and policies of a group after
+// applying patch operations.
+func scimGroupPatch(ops []scimPatchOperation, name string, members, policies []string) (g scimGroupUpdate, err error) {
+	g.members, g.policies = members, policies
+	policyAttr := strings.ToLower(scimGroupPolicySchema + ":policies")
+	for _, op := range ops {
+		attr, selected, err := parseSCIMPath(op.Path)
+		if err != nil {
+			return g, err
+		}
+		opName := strings.ToLower(op.Op)
+		if attr == "" && (opName == "add" || opName == "replace") {
+			// Value holds the attributes to be replaced.
+			var group struct {
+				DisplayName *string          `json:"displayName"`
+				Members     []scimValue      `json:"members"`
+				Policy      *scimGroupPolicy `json:"urn:minio:params:scim:schemas:extension:2.0:Group"`
+			}
+			if err = json.Unmarshal(op.Value, &group); err != nil {
+				return g, newSCIMError(http.StatusBadRequest, scimInvalidSyntax, "expecting an object value without path")
+			}
+			if group.DisplayName != nil && *group.DisplayName != name {
+				return g, newSCIMError(http.StatusBadRequest, scimMutability, "groups can not be renamed")
+			}
+			if group.Members != nil {
+				g.setMembers = true
+				if opName == "replace" {
+					g.members = scimValueStrings(group.Members)
+				} else {
+					g.members = mergeStrings(g.members, scimValueStrings(group.Members)...)
+				}
+			}
+			if group.Policy != nil {
+				g.setPolicies = true
+				if opName == "replace" {
+					g.policies = group.Policy.Policies
+				} else {
+					g.policies = mergeStrings(g.policies, group.Policy.Policies...)
+				}
+			}
+			continue
+		}
+
+		switch strings.ToLower(attr) {
+		case "members":
+			g.setMembers = true
+			switch {
+			case opName == "remove" && selected != "":
+				g.members = removeStrings(g.members, selected)
+			case opName == "remove" && len(op.Value) == 0:
+				g.members = nil
+			case selected != "":
+				return g, newSCIMError(http.StatusBadRequest, scimInvalidPath, "value filters are only supported by remove")
+			default:
+				values, err := parseSCIMValues(op.Value)
+				if err != nil {
+					return g, err
+				}
+				switch opName {
+				case "add":
+					g.members = mergeStrings(g.members, values...)
+				case "replace":
+					g.members = values
+				case "remove":
+					g.members = removeStrings(g.members, values...)
+				default:
+					return g, newSCIMError(http.StatusBadRequest, scimInvalidSyntax, fmt.Sprintf("unsupported operation %q", op.Op))
+				}
+			}
+		case policyAttr:
+			g.setPolicies = true
+			var values []string
+			if len(op.Value) > 0 {
+				if err = json.Unmarshal(op.Value, &values); err != nil {
+					return g, newSCIMError(http.StatusBadRequest, scimInvalidValue, "expecting a list of policy names")
+				}
+			}
+			switch opName {
+			case "add":
+				g.policies = mergeStrings(g.policies, values...)
+			case "replace":
+				g.policies = values
+			case "remove":
+				if len(values) == 0 {
+					g.policies = nil
+				} else {
+					g.policies = removeStrings(g.policies, values...)
+				}
+			default:
+				return g, newSCIMError(http.StatusBadRequest, scimInvalidSyntax, fmt.Sprintf("unsupported operation %q", op.Op))
+			}
+		case "displayname":
+			var displayName string
+			if err = json.Unmarshal(op.Value, &displayName); err != nil || displayName != name {
+				return g, newSCIMError(http.StatusBadRequest, scimMutability, "groups can not be renamed")
+			}
+		default:
+			return g, newSCIMError(http.StatusBadRequest, scimInvalidPath, fmt.Sprintf("unsupported attribute %q", attr))
+		}
+	}
+	return g, nil
+}
+
+// mergeStrings appends the values missing in list.
+func mergeStrings(list []string, values ...string) []string {
+	merged := append([]string{}, list...)
+	for _, value := range values {
+		if !containsString(merged, value) {
+			merged = append(merged, value)
+		}
+	}
+	return merged
+}
+
+// removeStrings returns list without values.
+func removeStrings(list []string, values ...string) []string {
+	var result []string
+	for _, value := range list {
+		if !containsString(values, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// splitPolicies splits a comma separated list of policy names.
+func splitPolicies(policy string) []string {
+	var policies []string
+	for _, p := range strings.Split(policy, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			policies = append(policies, p)
+		}
+	}
+	return policies
+}
+
+func newSCIMUser(name string, info madmin.UserInfo, location string) scimUser {
+	active := info.Status == madmin.AccountEnabled
+	groups := append([]string{}, info.MemberOf...)
+	sort.Strings(groups)
+	return scimUser{
+		Schemas:  []string{scimUserSchema},
+		ID:       name,
+		UserName: name,
+		Active:   &active,
+		Groups:   newSCIMValues(groups),
+		Roles:    newSCIMValues(splitPolicies(info.PolicyName)),
+		Meta: &scimMeta{
+			ResourceType: "User",
+			Location:     location,
+		},
+	}
+}
+
+func newSCIMGroup(desc madmin.GroupDesc, location string) scimGroup {
+	members := append([]string{}, desc.Members...)
+	sort.Strings(members)
+	return scimGroup{
+		Schemas:     []string{scimGroupSchema, scimGroupPolicySchema},
+		ID:          desc.Name,
+		DisplayName: desc.Name,
+		Members:     newSCIMValues(members),
+		Policy: &scimGroupPolicy{
+			Policies: append([]string{}, splitPolicies(desc.Policy)...),
+		},
+		Meta: &scimMeta{
+			ResourceType: "Group",
+			Location:     location,
+		},
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseSCIMFilter(t *testing.T) {
+	testCases := []struct {
+		filter   string
+		value    string
+		ok       bool
+		expectOk bool
+	}{
+		{filter: "", expectOk: true},
+		{filter: `userName eq "bob"`, value: "bob", ok: true, expectOk: true},
+		{filter: `username EQ "bob"`, value: "bob", ok: true, expectOk: true},
+		{filter: `userName eq "a \"quoted\" name"`, value: `a "quoted" name`, ok: true, expectOk: true},
+		{filter: `displayName eq "bob"`},
+		{filter: `userName co "bob"`},
+		{filter: `userName eq "bob" and active eq true`},
+	}
+
+	for i, testCase := range testCases {
+		value, ok, err := parseSCIMFilter(testCase.filter, "userName")
+		if (err == nil) != testCase.expectOk {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if value != testCase.value || ok != testCase.ok {
+			t.Fatalf("Test %d: expected (%q, %t), got (%q, %t)", i+1, testCase.value, testCase.ok, value, ok)
+		}
+	}
+}
+
+func TestSCIMPagination(t *testing.T) {
+	resources := []interface{}{"a", "b", "c", "d", "e"}
+	testCases := []struct {
+		query    string
+		page     []interface{}
+		expectOk bool
+	}{
+		{query: "", page: resources, expectOk: true},
+		{query: "startIndex=2&count=2", page: []interface{}{"b", "c"}, expectOk: true},
+		{query: "startIndex=0&count=1", page: []interface{}{"a"}, expectOk: true},
+		{query: "startIndex=4", page: []interface{}{"d", "e"}, expectOk: true},
+		{query: "startIndex=6", page: []interface{}{}, expectOk: true},
+		{query: "count=0", page: []interface{}{}, expectOk: true},
+		{query: "startIndex=x"},
+		{query: "count=x"},
+	}
+
+	for i, testCase := range testCases {
+		values, err := url.ParseQuery(testCase.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		startIndex, count, err := parseSCIMPagination(values)
+		if (err == nil) != testCase.expectOk {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err != nil {
+			continue
+		}
+		resp := newSCIMListResponse(resources, startIndex, count)
+		if resp.TotalResults != len(resources) {
+			t.Fatalf("Test %d: expected %d total results, got %d", i+1, len(resources), resp.TotalResults)
+		}
+		if !reflect.DeepEqual(resp.Resources, testCase.page) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.page, resp.Resources)
+		}
+	}
+}
+
+func TestSCIMUserPatch(t *testing.T) {
+	testCases := []struct {
+		ops      string
+		current  []string
+		active   *bool
+		password *string
+		roles    []string
+		setRoles bool
+		expectOk bool
+	}{
+		{
+			ops:      `[{"op":"replace","path":"active","value":false}]`,
+			active:   boolPtr(false),
+			expectOk: true,
+		},
+		{
+			// Some clients send booleans as strings.
+			ops:      `[{"op":"Replace","value":{"active":"True","password":"secret123"}}]`,
+			active:   boolPtr(true),
+			password: stringPtr("secret123"),
+			expectOk: true,
+		},
+		{
+			ops:      `[{"op":"add","path":"roles","value":[{"value":"readwrite"}]}]`,
+			current:  []string{"readonly"},
+			roles:    []string{"readonly", "readwrite"},
+			setRoles: true,
+			expectOk: true,
+		},
+		{
+			ops:      `[{"op":"remove","path":"roles[value eq \"readonly\"]"}]`,
+			current:  []string{"readonly", "diagnostics"},
+			roles:    []string{"diagnostics"},
+			setRoles: true,
+			expectOk: true,
+		},
+		{
+			ops:      `[{"op":"remove","path":"roles"}]`,
+			current:  []string{"readonly"},
+			setRoles: true,
+			expectOk: true,
+		},
+		{ops: `[{"op":"replace","path":"userName","value":"alice"}]`},
+		{ops: `[{"op":"remove","path":"active"}]`},
+		{ops: `[{"op":"move","path":"active","value":true}]`},
+	}
+
+	for i, testCase := range testCases {
+		var ops []scimPatchOperation
+		if err := json.Unmarshal([]byte(testCase.ops), &ops); err != nil {
+			t.Fatal(err)
+		}
+		u, err := scimUserPatch(ops, testCase.current)
+		if (err == nil) != testCase.expectOk {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(u.active, testCase.active) || !reflect.DeepEqual(u.password, testCase.password) {
+			t.Fatalf("Test %d: unexpected update %+v", i+1, u)
+		}
+		if u.setRoles != testCase.setRoles || len(u.roles) != len(testCase.roles) ||
+			(len(u.roles) > 0 && !reflect.DeepEqual(u.roles, testCase.roles)) {
+			t.Fatalf("Test %d: expected roles %v, got %v", i+1, testCase.roles, u.roles)
+		}
+	}
+}
+
+func TestSCIMGroupPatch(t *testing.T) {
+	testCases := []struct {
+		ops         string
+		members     []string
+		policies    []string
+		setMembers  bool
+		setPolicies bool
+		expectOk    bool
+	}{
+		{
+			ops:        `[{"op":"add","path":"members","value":[{"value":"carol"}]}]`,
+			members:    []string{"alice", "bob", "carol"},
+			policies:   []string{"readonly"},
+			setMembers: true,
+			expectOk:   true,
+		},
+		{
+			ops:        `[{"op":"remove","path":"members[value eq \"alice\"]"}]`,
+			members:    []string{"bob"},
+			policies:   []string{"readonly"},
+			setMembers: true,
+			expectOk:   true,
+		},
+		{
+			ops:         `[{"op":"replace","value":{"displayName":"devs","members":[{"value":"dave"}],"urn:minio:params:scim:schemas:extension:2.0:Group":{"policies":["readwrite"]}}}]`,
+			members:     []string{"dave"},
+			policies:    []string{"readwrite"},
+			setMembers:  true,
+			setPolicies: true,
+			expectOk:    true,
+		},
+		{ops: `[{"op":"replace","value":{"displayName":"ops"}}]`},
+	}
+
+	for i, testCase := range testCases {
+		var ops []scimPatchOperation
+		if err := json.Unmarshal([]byte(testCase.ops), &ops); err != nil {
+			t.Fatal(err)
+		}
+		g, err := scimGroupPatch(ops, "devs", []string{"alice", "bob"}, []string{"readonly"})
+		if (err == nil) != testCase.expectOk {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err != nil {
+			continue
+		}
+		if g.setMembers != testCase.setMembers || g.setPolicies != testCase.setPolicies {
+			t.Fatalf("Test %d: unexpected update %+v", i+1, g)
+		}
+		if !reflect.DeepEqual(g.members, testCase.members) || !reflect.DeepEqual(g.policies, testCase.policies) {
+			t.Fatalf("Test %d: expected %v %v, got %v %v", i+1, testCase.members, testCase.policies, g.members, g.policies)
+		}
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
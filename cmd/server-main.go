@@ -26,6 +26,7 @@ import (
 	"log"
 	"math/rand"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
@@ -226,6 +227,12 @@ func newAllSubsystems() {
 		globalBucketMetadataSys.Reset()
 	}
 
+	// Create new bucket logging system.
+	globalBucketLoggingSys = newBucketLoggingSys()
+
+	// Create new bucket event journal system.
+	globalEventJournalSys = newEventJournalSys()
+
 	// Create the bucket bandwidth monitor
 	globalBucketMonitor = bandwidth.NewMonitor(GlobalContext, totalNodeCount())
 
@@ -427,6 +434,26 @@ func initAllSubsystems(ctx context.Context, newObject ObjectLayer) (err error) {
 		if err = globalTierConfigMgr.Init(ctx, newObject); err != nil {
 			return err
 		}
+
+		// Initialize pool metadata, resumes any pending decommission.
+		if z, ok := newObject.(*erasureServerPools); ok {
+			if err = z.Init(ctx); err != nil {
+				if configRetriableErrors(err) {
+					return fmt.Errorf("Unable to initialize pool metadata: %w", err)
+				}
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize pool metadata, decommissioning will be unavailable %w", err))
+			}
+
+			// Resume any rebalance interrupted by a restart.
+			if err = z.loadRebalanceMeta(ctx); err != nil {
+				if configRetriableErrors(err) {
+					return fmt.Errorf("Unable to initialize rebalance metadata: %w", err)
+				}
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize rebalance metadata %w", err))
+			} else {
+				z.resumeRebalance()
+			}
+		}
 	}
 	return nil
 }
@@ -511,7 +538,7 @@ func serverMain(ctx *cli.Context) {
 		addrs = append(addrs, globalMinioAddr)
 	}
 
-	httpServer := xhttp.NewServer(addrs, setCriticalErrorHandler(corsHandler(handler)), getCert)
+	httpServer := xhttp.NewServer(addrs, setCriticalErrorHandler(setWebsiteHandler(corsHandler(handler))), getCert)
 	httpServer.BaseContext = func(listener net.Listener) context.Context {
 		return GlobalContext
 	}
@@ -523,6 +550,19 @@ func serverMain(ctx *cli.Context) {
 
 	setHTTPServer(httpServer)
 
+	if globalWebsiteAddr != "" {
+		// Serve bucket websites on the dedicated listener.
+		websiteServer := xhttp.NewServer([]string{globalWebsiteAddr},
+			setCriticalErrorHandler(addCustomHeaders(http.HandlerFunc(websiteHandler))), getCert)
+		websiteServer.BaseContext = func(listener net.Listener) context.Context {
+			return GlobalContext
+		}
+		websiteServer.ErrorLog = log.New(&nullWriter{}, "", 0)
+		go func() {
+			globalHTTPServerErrorCh <- websiteServer.Start(GlobalContext)
+		}()
+	}
+
 	if globalIsDistErasure && globalEndpoints.FirstLocal() {
 		for {
 			// Additionally in distributed setup, validate the setup and configuration.
@@ -574,6 +614,12 @@ func serverMain(ctx *cli.Context) {
 
 	initDataScanner(GlobalContext, newObject)
 
+	// Initialize bucket access logging.
+	globalBucketLoggingSys.Init(GlobalContext, newObject)
+
+	// Initialize bucket event journal.
+	globalEventJournalSys.Init(GlobalContext, newObject)
+
 	if globalIsErasure { // to be done after config init
 		initBackgroundReplication(GlobalContext, newObject)
 		initBackgroundTransition(GlobalContext, newObject)
@@ -140,6 +140,7 @@ func printServerCommonMsg(apiEndpoints []string) {
 		}
 	}
 	printEventNotifiers()
+	printOpenIDRoles()
 
 	if globalBrowserEnabled {
 		consoleEndpointStr := strings.Join(stripStandardPorts(getConsoleEndpoints(), globalMinioConsoleHost), " ")
@@ -175,6 +176,21 @@ func printEventNotifiers() {
 	logStartupMessage(arnMsg)
 }
 
+// Prints the ARNs of the roles of the OpenID providers.
+func printOpenIDRoles() {
+	roles := globalOpenIDProviders.Roles()
+	if len(roles) == 0 {
+		return
+	}
+
+	arnMsg := color.Blue("Role ARNs: ")
+	for _, role := range roles {
+		arnMsg += color.Bold(fmt.Sprintf("%s ", role.ARN))
+	}
+
+	logStartupMessage(arnMsg)
+}
+
 // Prints startup message for command line access. Prints link to our documentation
 // and custom platform specific message.
 func printCLIAccessMsg(endPoint string, alias string) {
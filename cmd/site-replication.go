@@ -20,8 +20,10 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
@@ -38,9 +40,11 @@ import (
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio-go/v7/pkg/replication"
 	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio-go/v7/pkg/signer"
 	"github.com/minio/minio/internal/auth"
 	sreplication "github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/bucket/versioning"
+	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/pkg/bucket/policy"
 	iampolicy "github.com/minio/pkg/iam/policy"
@@ -56,6 +60,19 @@ const (
 	srStateFormatVersion1 = 1
 )
 
+// srBucketMetaTypeCorsConfig is the site replication bucket metadata
+// type for bucket CORS configuration.
+const srBucketMetaTypeCorsConfig = "cors-config"
+
+// srBucketMeta - bucket metadata replicated to peer sites. It extends
+// madmin.SRBucketMeta with the bucket configs that have no field there.
+type srBucketMeta struct {
+	madmin.SRBucketMeta
+
+	// base64 encoded CORS configuration XML
+	CORSConfig *string `json:"corsConfig,omitempty"`
+}
+
 var (
 	errSRCannotJoin     = errors.New("this site is already configured for site-replication")
 	errSRDuplicateSites = errors.New("duplicate sites provided for site-replication")
@@ -1158,6 +1175,80 @@ func (c *SiteReplicationSys) BucketMetaHook(ctx context.Context, item madmin.SRB
 	return cErr.summaryErr
 }
 
+// BucketCorsConfigHook - replicates a bucket CORS configuration change,
+// or its deletion when corsConfig is nil, to all remote peer clusters.
+func (c *SiteReplicationSys) BucketCorsConfigHook(ctx context.Context, bucket string, corsConfig *string) error {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.enabled {
+		return nil
+	}
+
+	item := srBucketMeta{
+		SRBucketMeta: madmin.SRBucketMeta{
+			Type:   srBucketMetaTypeCorsConfig,
+			Bucket: bucket,
+		},
+		CORSConfig: corsConfig,
+	}
+	cErr := c.concDo(nil, func(d string, p madmin.PeerInfo) error {
+		err := c.replicateBucketMeta(ctx, d, item)
+		logger.LogIf(ctx, c.annotatePeerErr(p.Name, "SRInternalReplicateBucketMeta", err))
+		return err
+	})
+	return cErr.summaryErr
+}
+
+// replicateBucketMeta - sends item to the bucket metadata peer API of the
+// given peer. This is the request madmin's SRInternalReplicateBucketMeta
+// sends, but it carries the fields that only srBucketMeta has.
+//
+// NOTE: ensure to take at least a read lock on SiteReplicationSys before
+// calling this.
+func (c *SiteReplicationSys) replicateBucketMeta(ctx context.Context, deploymentID string, item srBucketMeta) error {
+	creds, err := c.getPeerCreds()
+	if err != nil {
+		return wrapSRErr(err)
+	}
+	peer, ok := c.state.Peers[deploymentID]
+	if !ok {
+		return wrapSRErr(errSRPeerNotFound)
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return wrapSRErr(err)
+	}
+	epURL, err := url.Parse(peer.Endpoint)
+	if err != nil {
+		return wrapSRErr(err)
+	}
+	epURL.Path = adminPathPrefix + adminAPIVersionPrefix + "/site-replication/peer/bucket-meta"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, epURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return wrapSRErr(err)
+	}
+	req.ContentLength = int64(len(data))
+	sum := sha256.Sum256(data)
+	req.Header.Set(xhttp.AmzContentSha256, hex.EncodeToString(sum[:]))
+	req = signer.SignV4(*req, creds.AccessKey, creds.SecretKey, "", "")
+
+	client := &http.Client{Transport: newRemoteClusterHTTPTransport()}
+	resp, err := client.Do(req)
+	if err != nil {
+		return wrapSRErr(err)
+	}
+	defer xhttp.DrainBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		errResp := madmin.ErrorResponse{Code: resp.Status}
+		json.NewDecoder(resp.Body).Decode(&errResp)
+		return wrapSRErr(errResp)
+	}
+	return nil
+}
+
 // PeerBucketPolicyHandler - copies/deletes policy to local cluster.
 func (c *SiteReplicationSys) PeerBucketPolicyHandler(ctx context.Context, bucket string, policy *policy.Policy) error {
 	if policy != nil {
@@ -1244,6 +1335,28 @@ func (c *SiteReplicationSys) PeerBucketSSEConfigHandler(ctx context.Context, buc
 	return nil
 }
 
+// PeerBucketCorsConfigHandler - copies/deletes CORS config to local cluster.
+func (c *SiteReplicationSys) PeerBucketCorsConfigHandler(ctx context.Context, bucket string, corsConfig *string) error {
+	if corsConfig != nil {
+		configData, err := base64.StdEncoding.DecodeString(*corsConfig)
+		if err != nil {
+			return wrapSRErr(err)
+		}
+		err = globalBucketMetadataSys.Update(bucket, bucketCorsConfig, configData)
+		if err != nil {
+			return wrapSRErr(err)
+		}
+		return nil
+	}
+
+	// Delete cors config
+	err := globalBucketMetadataSys.Update(bucket, bucketCorsConfig, nil)
+	if err != nil {
+		return wrapSRErr(err)
+	}
+	return nil
+}
+
 // getAdminClient - NOTE: ensure to take at least a read lock on SiteReplicationSys
 // before calling this.
 func (c *SiteReplicationSys) getAdminClient(ctx context.Context, deploymentID string) (*madmin.AdminClient, error) {
@@ -1400,6 +1513,26 @@ func (c *SiteReplicationSys) syncLocalToPeers(ctx context.Context) SRError {
 				return errSRBucketMetaError(err)
 			}
 		}
+
+		// Replicate existing bucket CORS settings
+		corsConfig, err := globalBucketMetadataSys.GetCorsConfig(bucket)
+		found = true
+		if _, ok := err.(BucketCorsNotFound); ok {
+			found = false
+		} else if err != nil {
+			return errSRBackendIssue(err)
+		}
+		if found {
+			corsConfigData, err := xml.Marshal(corsConfig)
+			if err != nil {
+				return wrapSRErr(err)
+			}
+			corsConfigStr := base64.StdEncoding.EncodeToString(corsConfigData)
+			err = c.BucketCorsConfigHook(ctx, bucket, &corsConfigStr)
+			if err != nil {
+				return errSRBucketMetaError(err)
+			}
+		}
 	}
 
 	{
@@ -26,14 +26,17 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/internal/auth"
 	"github.com/minio/minio/internal/config/identity/openid"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy/condition"
 	iampolicy "github.com/minio/pkg/iam/policy"
 	"github.com/minio/pkg/wildcard"
 )
@@ -48,6 +51,7 @@ const (
 	stsWebIdentityToken       = "WebIdentityToken"
 	stsWebIdentityAccessToken = "WebIdentityAccessToken" // only valid if UserInfo is enabled.
 	stsDurationSeconds        = "DurationSeconds"
+	stsRoleArn                = "RoleArn"
 	stsLDAPUsername           = "LDAPUsername"
 	stsLDAPPassword           = "LDAPPassword"
 
@@ -70,19 +74,27 @@ const (
 	// JWT claim to check the parent user
 	parentClaim = "parent"
 
+	// JWT claim of the assumed OpenID role
+	roleArnClaim = "roleArn"
+
 	// LDAP claim keys
 	ldapUser  = "ldapUser"
 	ldapUserN = "ldapUsername"
+
+	// LDAP claim of the policies held by the policy attributes of the
+	// user, and prefix of the claims of its condition attributes.
+	ldapPolicy          = "ldapPolicy"
+	ldapAttributePrefix = "ldap:"
 )
 
-func parseOpenIDParentUser(parentUser string) (userID string, err error) {
+func parseOpenIDParentUser(parentUser string) (userID, issuer string, err error) {
 	if strings.HasPrefix(parentUser, "openid:") {
 		tokens := strings.SplitN(strings.TrimPrefix(parentUser, "openid:"), ":", 2)
 		if len(tokens) == 2 {
-			return tokens[0], nil
+			return tokens[0], tokens[1], nil
 		}
 	}
-	return "", errSkipFile
+	return "", "", errSkipFile
 }
 
 // stsAPIHandlers implements and provides http handlers for AWS STS API.
@@ -326,25 +338,38 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 	ctx = newContext(r, w, action)
 	defer logger.AuditLog(ctx, w, r, nil)
 
-	if globalOpenIDValidators == nil {
+	if globalOpenIDProviders == nil {
 		writeSTSErrorResponse(ctx, w, true, ErrSTSNotInitialized, errServerNotInitialized)
 		return
 	}
 
-	v, err := globalOpenIDValidators.Get("jwt")
-	if err != nil {
-		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
-		return
-	}
-
 	token := r.Form.Get(stsToken)
 	if token == "" {
 		token = r.Form.Get(stsWebIdentityToken)
 	}
 
+	// Select the provider of the requested role, otherwise
+	// the provider which issued the token.
+	var (
+		p    openid.Config
+		role openid.Role
+		err  error
+	)
+	roleArn := r.Form.Get(stsRoleArn)
+	if roleArn != "" {
+		p, role, err = globalOpenIDProviders.SelectRole(roleArn)
+	} else {
+		p, err = globalOpenIDProviders.Select(token)
+		role = p.RolePolicyRole()
+	}
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
 	accessToken := r.Form.Get(stsWebIdentityAccessToken)
 
-	m, err := v.Validate(token, accessToken, r.Form.Get(stsDurationSeconds))
+	m, err := p.Validate(token, accessToken, r.Form.Get(stsDurationSeconds))
 	if err != nil {
 		switch err {
 		case openid.ErrTokenExpired:
@@ -376,7 +401,7 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 			errors.New("STS JWT Token has `aud` claim invalid, `aud` must match configured OpenID Client ID"))
 		return
 	}
-	if !audValues.Contains(globalOpenIDConfig.ClientID) {
+	if !audValues.Contains(p.ClientID) {
 		// if audience claims is missing, look for "azp" claims.
 		// OPTIONAL. Authorized party - the party to which the ID
 		// Token was issued. If present, it MUST contain the OAuth
@@ -392,13 +417,20 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 				errors.New("STS JWT Token has `aud` claim invalid, `aud` must match configured OpenID Client ID"))
 			return
 		}
-		if !azpValues.Contains(globalOpenIDConfig.ClientID) {
+		if !azpValues.Contains(p.ClientID) {
 			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
 				errors.New("STS JWT Token has `azp` claim invalid, `azp` must match configured OpenID Client ID"))
 			return
 		}
 	}
 
+	// The requested role, or the provider role policy, is only
+	// granted to tokens satisfying the trust condition of the role.
+	if err = role.Trusts(m); err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSAccessDenied, err)
+		return
+	}
+
 	var subFromToken string
 	if v, ok := m[subClaim]; ok {
 		subFromToken, _ = v.(string)
@@ -418,9 +450,14 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 	// JWT has requested a custom claim with policy value set.
 	// This is a MinIO STS API specific value, this value should
 	// be set and configured on your identity provider as part of
-	// JWT custom claims.
+	// JWT custom claims. The policy of the requested role, or of
+	// the provider role policy, is applied instead if present.
 	var policyName string
-	policySet, ok := iampolicy.GetPoliciesFromClaims(m, iamPolicyClaimNameOpenID())
+	claimName := p.ClaimPrefix + p.ClaimName
+	policySet, ok := iampolicy.GetPoliciesFromClaims(m, claimName)
+	if role.Policy != "" {
+		policySet, ok = set.CreateStringSet(role.Policy), true
+	}
 	policies := strings.Join(policySet.ToSlice(), ",")
 	if ok {
 		policyName = globalIAMSys.CurrentPolicies(policies)
@@ -429,7 +466,7 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 	if globalPolicyOPA == nil {
 		if !ok {
 			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
-				fmt.Errorf("%s claim missing from the JWT token, credentials will not be generated", iamPolicyClaimNameOpenID()))
+				fmt.Errorf("%s claim missing from the JWT token, credentials will not be generated", claimName))
 			return
 		} else if policyName == "" {
 			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
@@ -438,6 +475,9 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 		}
 	}
 	m[iamPolicyClaimNameOpenID()] = policyName
+	if roleArn != "" {
+		m[roleArnClaim] = roleArn
+	}
 
 	sessionPolicyStr := r.Form.Get(stsPolicy)
 	// https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRoleWithWebIdentity.html
@@ -602,8 +642,19 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 		return
 	}
 
-	// Check if this user or their groups have a policy applied.
+	attrClaims, err := getLDAPAttributeClaims(ldapUserDN)
+	if err != nil {
+		err = fmt.Errorf("LDAP server error: %w", err)
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
+	// Check if this user, their groups or their policy attributes have a
+	// policy applied.
 	ldapPolicies, _ := globalIAMSys.PolicyDBGet(ldapUserDN, false, groupDistNames...)
+	if policies, ok := attrClaims[ldapPolicy].(string); ok {
+		ldapPolicies = append(ldapPolicies, strings.Split(policies, ",")...)
+	}
 	if len(ldapPolicies) == 0 && globalPolicyOPA == nil {
 		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
 			fmt.Errorf("expecting a policy to be set for user `%s` or one of their groups: `%s` - rejecting this request",
@@ -622,6 +673,9 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 		ldapUser:  ldapUserDN,
 		ldapUserN: ldapUsername,
 	}
+	for k, v := range attrClaims {
+		m[k] = v
+	}
 
 	if len(sessionPolicyStr) > 0 {
 		m[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString([]byte(sessionPolicyStr))
@@ -684,6 +738,59 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 }
 
+// getLDAPAttributeClaims returns the claims holding the policies mapped
+// from the policy attributes and the values of the condition attributes
+// of an LDAP user.
+func getLDAPAttributeClaims(userDN string) (map[string]interface{}, error) {
+	claims := make(map[string]interface{})
+	if !globalLDAPConfig.UserAttributesEnabled() {
+		return claims, nil
+	}
+
+	values, err := globalLDAPConfig.LookupUserAttributes(userDN)
+	if err != nil {
+		return nil, err
+	}
+	if policies := globalLDAPConfig.AttributePolicies(values); len(policies) > 0 {
+		claims[ldapPolicy] = strings.Join(policies, ",")
+	}
+	for attr, v := range globalLDAPConfig.ConditionAttributes(values) {
+		claims[ldapAttributePrefix+attr] = v
+	}
+	return claims, nil
+}
+
+// ldapConditionKeysOnce registers the LDAP condition keys once, the
+// supported condition keys are read without locking when policies are
+// parsed and must not change once requests are served.
+var ldapConditionKeysOnce sync.Once
+
+// registerLDAPConditionKeys makes the "ldap:<attribute>" condition keys
+// of the LDAP condition attributes valid in policies, the keys are only
+// registered at startup, changing the LDAP configuration requires a
+// restart.
+func registerLDAPConditionKeys(attrs []string) {
+	ldapConditionKeysOnce.Do(func() {
+		addLDAPConditionKeys(attrs)
+	})
+}
+
+// ldapConditionKeysMu serializes the updates of the supported condition keys.
+var ldapConditionKeysMu sync.Mutex
+
+func addLDAPConditionKeys(attrs []string) {
+	ldapConditionKeysMu.Lock()
+	defer ldapConditionKeysMu.Unlock()
+	for _, attr := range attrs {
+		key := condition.KeyName(ldapAttributePrefix + attr)
+		if key.ToKey().IsValid() {
+			continue
+		}
+		condition.AllSupportedKeys = append(condition.AllSupportedKeys, key)
+		condition.CommonKeys = append(condition.CommonKeys, key)
+	}
+}
+
 // AssumeRoleWithCertificate implements user authentication with client certificates.
 // It verifies the client-provided X.509 certificate, maps the certificate to an S3 policy
 // and returns temp. S3 credentials to the client.
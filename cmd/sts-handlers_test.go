@@ -31,6 +31,7 @@ import (
 	"github.com/minio/madmin-go"
 	minio "github.com/minio/minio-go/v7"
 	cr "github.com/minio/minio-go/v7/pkg/credentials"
+	iampolicy "github.com/minio/pkg/iam/policy"
 	"golang.org/x/oauth2"
 )
 
@@ -616,3 +617,50 @@ func TestIAMWithOpenIDServerSuite(t *testing.T) {
 		)
 	}
 }
+
+func TestLDAPConditionAttributes(t *testing.T) {
+	addLDAPConditionKeys([]string{"department"})
+
+	p, err := iampolicy.ParseConfig(strings.NewReader(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": ["s3:GetObject"],
+   "Resource": ["arn:aws:s3:::${ldap:department}/*"],
+   "Condition": {"StringEquals": {"ldap:department": ["finance"]}}
+  }
+ ]
+}`))
+	if err != nil {
+		t.Fatalf("unable to parse policy with LDAP condition attribute: %v", err)
+	}
+
+	testCases := []struct {
+		claims   map[string]interface{}
+		bucket   string
+		expected bool
+	}{
+		{map[string]interface{}{ldapAttributePrefix + "department": "finance"}, "finance", true},
+		{map[string]interface{}{ldapAttributePrefix + "department": []interface{}{"finance", "sales"}}, "finance", true},
+		{map[string]interface{}{ldapAttributePrefix + "department": "sales"}, "sales", false},
+		{map[string]interface{}{ldapAttributePrefix + "department": "finance"}, "sales", false},
+		{map[string]interface{}{}, "finance", false},
+	}
+	for i, testCase := range testCases {
+		r, err := http.NewRequest(http.MethodGet, "http://localhost:9000/"+testCase.bucket+"/object", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		allowed := p.IsAllowed(iampolicy.Args{
+			Action:          iampolicy.GetObjectAction,
+			BucketName:      testCase.bucket,
+			ObjectName:      "object",
+			ConditionValues: getConditionValues(r, "", "", testCase.claims),
+			Claims:          testCase.claims,
+		})
+		if allowed != testCase.expected {
+			t.Errorf("Test %d: expected allowed to be %v, got %v", i+1, testCase.expected, allowed)
+		}
+	}
+}
@@ -38,6 +38,13 @@ func (v versionsSorter) sort() {
 	})
 }
 
+// reverse sorts the versions oldest first.
+func (v versionsSorter) reverse() {
+	sort.SliceStable(v, func(i, j int) bool {
+		return v[i].ModTime.Before(v[j].ModTime)
+	})
+}
+
 func getFileInfoVersions(xlMetaBuf []byte, volume, path string) (FileInfoVersions, error) {
 	fivs, err := getAllFileInfoVersions(xlMetaBuf, volume, path)
 	if err != nil {
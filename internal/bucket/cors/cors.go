@@ -0,0 +1,191 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cors
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/minio/pkg/wildcard"
+)
+
+const (
+	xmlNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	// Maximum number of rules allowed in a CORS configuration on AWS S3.
+	maxRules = 100
+
+	// Maximum length of a rule ID.
+	maxRuleIDLength = 255
+)
+
+// Rule - a single CORS rule, specifies the origins allowed to access
+// the bucket along with the methods and headers they may use.
+type Rule struct {
+	ID             string   `xml:"ID,omitempty"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// Validate - validates the CORS rule.
+func (r Rule) Validate() error {
+	if len(r.ID) > maxRuleIDLength {
+		return Errorf("ID must be less than %d characters", maxRuleIDLength)
+	}
+	if len(r.AllowedOrigins) == 0 {
+		return Errorf("at least one AllowedOrigin must be specified")
+	}
+	for _, origin := range r.AllowedOrigins {
+		if strings.Count(origin, "*") > 1 {
+			return Errorf("AllowedOrigin %q can not have more than one wildcard", origin)
+		}
+	}
+	if len(r.AllowedMethods) == 0 {
+		return Errorf("at least one AllowedMethod must be specified")
+	}
+	for _, method := range r.AllowedMethods {
+		switch method {
+		case http.MethodGet, http.MethodPut, http.MethodHead, http.MethodPost, http.MethodDelete:
+		default:
+			return Errorf("found unsupported HTTP method in CORS config. Unsupported method is %s", method)
+		}
+	}
+	for _, header := range r.AllowedHeaders {
+		if strings.Count(header, "*") > 1 {
+			return Errorf("AllowedHeader %q can not have more than one wildcard", header)
+		}
+	}
+	for _, header := range r.ExposeHeaders {
+		if strings.Contains(header, "*") {
+			return Errorf("ExposeHeader %q contains wildcard. We currently do not support wildcard for ExposeHeader", header)
+		}
+	}
+	if r.MaxAgeSeconds < 0 {
+		return Errorf("MaxAgeSeconds must be a positive integer")
+	}
+	return nil
+}
+
+// AllowsOrigin returns true if the origin matches one of the allowed
+// origins of the rule.
+func (r Rule) AllowsOrigin(origin string) bool {
+	for _, allowed := range r.AllowedOrigins {
+		if wildcard.MatchSimple(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsAnyOrigin returns true if the rule allows all the origins, in
+// which case responses must not be specific to the requesting origin.
+func (r Rule) AllowsAnyOrigin() bool {
+	for _, allowed := range r.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsMethod returns true if method is one of the allowed methods.
+func (r Rule) AllowsMethod(method string) bool {
+	for _, allowed := range r.AllowedMethods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsHeaders returns true if all the headers match one of the
+// allowed headers, headers are matched case-insensitively.
+func (r Rule) AllowsHeaders(headers []string) bool {
+	for _, header := range headers {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if header == "" {
+			continue
+		}
+		var found bool
+		for _, allowed := range r.AllowedHeaders {
+			if wildcard.MatchSimple(strings.ToLower(allowed), header) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Config - bucket CORS configuration.
+type Config struct {
+	XMLNS     string   `xml:"xmlns,attr,omitempty"`
+	XMLName   xml.Name `xml:"CORSConfiguration"`
+	CORSRules []Rule   `xml:"CORSRule"`
+}
+
+// Validate - validates the CORS configuration.
+func (c Config) Validate() error {
+	if len(c.CORSRules) == 0 {
+		return Errorf("at least one CORSRule must be specified")
+	}
+	if len(c.CORSRules) > maxRules {
+		return Errorf("CORS configuration can not have more than %d rules", maxRules)
+	}
+	for _, rule := range c.CORSRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Match returns the first rule matching the origin, method and the
+// headers of a request, nil is returned if no rule matches.
+func (c *Config) Match(origin, method string, headers []string) *Rule {
+	for i := range c.CORSRules {
+		rule := &c.CORSRules[i]
+		if rule.AllowsOrigin(origin) && rule.AllowsMethod(method) && rule.AllowsHeaders(headers) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// ParseConfig - parses data in given reader to CORS configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.XMLNS == "" {
+		c.XMLNS = xmlNS
+	}
+	return &c, nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expectedErr bool
+	}{
+		{ // 1. Valid configuration
+			input: `<CORSConfiguration>
+				<CORSRule>
+					<AllowedOrigin>https://*.example.com</AllowedOrigin>
+					<AllowedMethod>PUT</AllowedMethod>
+					<AllowedMethod>POST</AllowedMethod>
+					<AllowedHeader>*</AllowedHeader>
+					<ExposeHeader>ETag</ExposeHeader>
+					<MaxAgeSeconds>3000</MaxAgeSeconds>
+				</CORSRule>
+				<CORSRule>
+					<AllowedOrigin>*</AllowedOrigin>
+					<AllowedMethod>GET</AllowedMethod>
+				</CORSRule>
+			</CORSConfiguration>`,
+		},
+		{ // 2. No rules
+			input:       `<CORSConfiguration></CORSConfiguration>`,
+			expectedErr: true,
+		},
+		{ // 3. Missing origin
+			input: `<CORSConfiguration><CORSRule>
+					<AllowedMethod>GET</AllowedMethod>
+				</CORSRule></CORSConfiguration>`,
+			expectedErr: true,
+		},
+		{ // 4. Missing method
+			input: `<CORSConfiguration><CORSRule>
+					<AllowedOrigin>*</AllowedOrigin>
+				</CORSRule></CORSConfiguration>`,
+			expectedErr: true,
+		},
+		{ // 5. Unsupported method
+			input: `<CORSConfiguration><CORSRule>
+					<AllowedOrigin>*</AllowedOrigin>
+					<AllowedMethod>PATCH</AllowedMethod>
+				</CORSRule></CORSConfiguration>`,
+			expectedErr: true,
+		},
+		{ // 6. Multiple wildcards in origin
+			input: `<CORSConfiguration><CORSRule>
+					<AllowedOrigin>https://*.*.com</AllowedOrigin>
+					<AllowedMethod>GET</AllowedMethod>
+				</CORSRule></CORSConfiguration>`,
+			expectedErr: true,
+		},
+		{ // 7. Wildcard in expose header
+			input: `<CORSConfiguration><CORSRule>
+					<AllowedOrigin>*</AllowedOrigin>
+					<AllowedMethod>GET</AllowedMethod>
+					<ExposeHeader>x-amz-*</ExposeHeader>
+				</CORSRule></CORSConfiguration>`,
+			expectedErr: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := ParseConfig(strings.NewReader(tc.input))
+		if tc.expectedErr && err == nil {
+			t.Fatalf("Test %d: expected error, got nil", i+1)
+		}
+		if !tc.expectedErr && err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+	}
+}
+
+func TestConfigMatch(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<CORSConfiguration>
+		<CORSRule>
+			<ID>uploads</ID>
+			<AllowedOrigin>https://*.example.com</AllowedOrigin>
+			<AllowedMethod>PUT</AllowedMethod>
+			<AllowedMethod>POST</AllowedMethod>
+			<AllowedHeader>Content-*</AllowedHeader>
+			<AllowedHeader>x-amz-meta-*</AllowedHeader>
+		</CORSRule>
+		<CORSRule>
+			<ID>downloads</ID>
+			<AllowedOrigin>*</AllowedOrigin>
+			<AllowedMethod>GET</AllowedMethod>
+		</CORSRule>
+	</CORSConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		origin     string
+		method     string
+		headers    []string
+		expectedID string
+	}{
+		{"https://app.example.com", "PUT", nil, "uploads"},
+		{"https://app.example.com", "PUT", []string{"content-type", " X-Amz-Meta-Name"}, "uploads"},
+		{"https://app.example.com", "PUT", []string{"authorization"}, ""},
+		{"https://app.example.org", "PUT", nil, ""},
+		{"https://app.example.org", "GET", nil, "downloads"},
+		{"https://app.example.com", "DELETE", nil, ""},
+	}
+
+	for i, tc := range testCases {
+		rule := config.Match(tc.origin, tc.method, tc.headers)
+		var id string
+		if rule != nil {
+			id = rule.ID
+		}
+		if id != tc.expectedID {
+			t.Fatalf("Test %d: expected rule %q, got %q", i+1, tc.expectedID, id)
+		}
+	}
+}
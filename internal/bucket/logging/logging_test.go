@@ -0,0 +1,64 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input       string
+		enabled     bool
+		expectedErr bool
+	}{
+		{ // 1. Logging enabled
+			input: `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+				<LoggingEnabled>
+					<TargetBucket>logs</TargetBucket>
+					<TargetPrefix>access/</TargetPrefix>
+				</LoggingEnabled>
+			</BucketLoggingStatus>`,
+			enabled: true,
+		},
+		{ // 2. Logging disabled
+			input: `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"/>`,
+		},
+		{ // 3. Missing target bucket
+			input: `<BucketLoggingStatus>
+				<LoggingEnabled><TargetPrefix>access/</TargetPrefix></LoggingEnabled>
+			</BucketLoggingStatus>`,
+			expectedErr: true,
+		},
+		{ // 4. Malformed XML
+			input:       `<BucketLoggingStatus>`,
+			expectedErr: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		config, err := ParseConfig(strings.NewReader(tc.input))
+		if tc.expectedErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, tc.expectedErr, err)
+		}
+		if err == nil && config.Enabled() != tc.enabled {
+			t.Fatalf("Test %d: expected enabled %v, got %v", i+1, tc.enabled, config.Enabled())
+		}
+	}
+}
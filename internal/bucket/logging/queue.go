@@ -0,0 +1,235 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Default queue size limit in bytes.
+	defaultQueueLimit = 1 << 30
+
+	segmentExt = ".log"
+)
+
+// ErrQueueLimitExceeded - returned when the queue has reached its
+// size limit, entries are dropped until the queue is drained.
+var ErrQueueLimitExceeded = errors.New("bucket logging queue limit exceeded")
+
+// Entry - a queued access log record along with its destination.
+type Entry struct {
+	TargetBucket string `json:"targetBucket"`
+	TargetPrefix string `json:"targetPrefix"`
+	Record       string `json:"record"`
+}
+
+// Queue - durable on-disk queue of access log entries. Entries are
+// appended to the active segment, segments are sealed by Rotate and
+// are removed once their entries have been delivered.
+type Queue struct {
+	sync.Mutex
+	directory string
+	limit     int64
+	size      int64
+	active    *os.File
+}
+
+// NewQueue - creates an instance of Queue.
+func NewQueue(directory string, limit int64) *Queue {
+	if limit <= 0 {
+		limit = defaultQueueLimit
+	}
+	return &Queue{
+		directory: directory,
+		limit:     limit,
+	}
+}
+
+// Open - creates the queue directory if not present and opens a new
+// active segment, segments left behind by a previous run are kept
+// and returned by the next Rotate.
+func (q *Queue) Open() error {
+	q.Lock()
+	defer q.Unlock()
+
+	if err := os.MkdirAll(q.directory, os.FileMode(0770)); err != nil {
+		return err
+	}
+
+	segments, err := q.list()
+	if err != nil {
+		return err
+	}
+	for _, segment := range segments {
+		fi, err := os.Stat(filepath.Join(q.directory, segment))
+		if err != nil {
+			return err
+		}
+		q.size += fi.Size()
+	}
+
+	return q.openSegment()
+}
+
+// lockless call
+func (q *Queue) openSegment() error {
+	name := fmt.Sprintf("%020d%s", time.Now().UnixNano(), segmentExt)
+	f, err := os.OpenFile(filepath.Join(q.directory, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, os.FileMode(0660))
+	if err != nil {
+		return err
+	}
+	q.active = f
+	return nil
+}
+
+// Put - appends an entry to the active segment.
+func (q *Queue) Put(e Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	q.Lock()
+	defer q.Unlock()
+
+	if q.active == nil {
+		return os.ErrClosed
+	}
+	if q.size+int64(len(data)) > q.limit {
+		return ErrQueueLimitExceeded
+	}
+	n, err := q.active.Write(data)
+	q.size += int64(n)
+	return err
+}
+
+// Rotate - seals the active segment and opens a new one, returns the
+// names of all the sealed segments from the oldest to the newest.
+func (q *Queue) Rotate() ([]string, error) {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.active == nil {
+		return nil, os.ErrClosed
+	}
+	if err := q.active.Close(); err != nil {
+		return nil, err
+	}
+	if err := q.openSegment(); err != nil {
+		q.active = nil
+		return nil, err
+	}
+
+	segments, err := q.list()
+	if err != nil {
+		return nil, err
+	}
+	active := filepath.Base(q.active.Name())
+	sealed := segments[:0]
+	for _, segment := range segments {
+		if segment != active {
+			sealed = append(sealed, segment)
+		}
+	}
+	return sealed, nil
+}
+
+// Get - returns all the entries of a sealed segment, entries which
+// can not be decoded such as the ones partially written during a
+// crash are skipped.
+func (q *Queue) Get(segment string) ([]Entry, error) {
+	data, err := ioutil.ReadFile(filepath.Join(q.directory, segment))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		var e Entry
+		if err = json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Del - removes a sealed segment from the queue.
+func (q *Queue) Del(segment string) error {
+	q.Lock()
+	defer q.Unlock()
+
+	path := filepath.Join(q.directory, segment)
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil {
+		return err
+	}
+	q.size -= fi.Size()
+	if q.size < 0 {
+		q.size = 0
+	}
+	return nil
+}
+
+// Close - closes the active segment.
+func (q *Queue) Close() error {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.active == nil {
+		return nil
+	}
+	err := q.active.Close()
+	q.active = nil
+	return err
+}
+
+// list lock less, segment names are sortable by creation time.
+func (q *Queue) list() ([]string, error) {
+	files, err := ioutil.ReadDir(q.directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []string
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), segmentExt) {
+			segments = append(segments, file.Name())
+		}
+	}
+	sort.Strings(segments)
+	return segments, nil
+}
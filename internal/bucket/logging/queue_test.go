@@ -0,0 +1,110 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueue(t *testing.T) {
+	dir := t.TempDir()
+
+	queue := NewQueue(dir, 0)
+	if err := queue.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := []Entry{
+		{TargetBucket: "logs", TargetPrefix: "a/", Record: "record-1"},
+		{TargetBucket: "logs", TargetPrefix: "b/", Record: "record-2"},
+	}
+	for _, e := range entries {
+		if err := queue.Put(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	segments, err := queue.Rotate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 1 {
+		t.Fatalf("expected 1 sealed segment, got %d", len(segments))
+	}
+
+	// Entries put after a rotation go to the new active segment.
+	if err = queue.Put(Entry{TargetBucket: "logs", Record: "record-3"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := queue.Get(segments[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, entries) {
+		t.Fatalf("expected %v, got %v", entries, got)
+	}
+	if err = queue.Del(segments[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err = queue.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Segments left behind are returned after re-opening the queue.
+	queue = NewQueue(dir, 0)
+	if err = queue.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer queue.Close()
+
+	segments, err = queue.Rotate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []string
+	for _, segment := range segments {
+		got, err = queue.Get(segment)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range got {
+			records = append(records, e.Record)
+		}
+	}
+	if !reflect.DeepEqual(records, []string{"record-3"}) {
+		t.Fatalf("expected [record-3], got %v", records)
+	}
+}
+
+func TestQueueLimit(t *testing.T) {
+	queue := NewQueue(t.TempDir(), 100)
+	if err := queue.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer queue.Close()
+
+	e := Entry{TargetBucket: "logs", Record: "record"}
+	if err := queue.Put(e); err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.Put(e); err != ErrQueueLimitExceeded {
+		t.Fatalf("expected %v, got %v", ErrQueueLimitExceeded, err)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+// ErrorCodeTag - name of the request tag carrying the S3 error code of
+// a failed request.
+const ErrorCodeTag = "errorCode"
+
+// timeFormat - time format used in S3 server access logs.
+const timeFormat = "02/Jan/2006:15:04:05 -0700"
+
+// Record - a single S3 server access log record.
+type Record struct {
+	BucketOwner      string
+	Bucket           string
+	Time             time.Time
+	RemoteIP         string
+	Requester        string
+	RequestID        string
+	Operation        string
+	Key              string
+	RequestURI       string
+	HTTPStatus       int
+	ErrorCode        string
+	BytesSent        int64
+	ObjectSize       int64
+	TotalTime        time.Duration
+	TurnAroundTime   time.Duration
+	Referer          string
+	UserAgent        string
+	VersionID        string
+	HostID           string
+	SignatureVersion string
+	CipherSuite      string
+	AuthType         string
+	HostHeader       string
+	TLSVersion       string
+}
+
+// NewRecord - constructs an access log record from the audit entry
+// generated for an incoming request.
+func NewRecord(entry audit.Entry, r *http.Request, bucketOwner string) Record {
+	rec := Record{
+		BucketOwner: bucketOwner,
+		Bucket:      entry.API.Bucket,
+		RemoteIP:    entry.RemoteHost,
+		Requester:   entry.AccessKey,
+		RequestID:   entry.RequestID,
+		Key:         entry.API.Object,
+		RequestURI:  r.Method + " " + r.URL.RequestURI() + " " + r.Proto,
+		HTTPStatus:  entry.API.StatusCode,
+		Referer:     r.Referer(),
+		UserAgent:   entry.UserAgent,
+		VersionID:   entry.RespHeader[http.CanonicalHeaderKey(xhttp.AmzVersionID)],
+		HostID:      entry.DeploymentID,
+		HostHeader:  r.Host,
+	}
+
+	rec.Time, _ = time.Parse(time.RFC3339Nano, entry.Time)
+	rec.TotalTime, _ = time.ParseDuration(entry.API.TimeToResponse)
+	rec.TurnAroundTime, _ = time.ParseDuration(entry.API.TimeToFirstByte)
+	if errCode, ok := entry.Tags[ErrorCodeTag].(string); ok {
+		rec.ErrorCode = errCode
+	}
+	rec.Operation = operation(r, rec.Key != "")
+
+	// Content-Length excludes the response headers
+	// which are accounted in the output bytes.
+	rec.BytesSent = entry.API.OutputBytes
+	if size, err := strconv.ParseInt(entry.RespHeader[xhttp.ContentLength], 10, 64); err == nil {
+		rec.BytesSent = size
+	}
+
+	rec.ObjectSize = -1
+	if rec.Key != "" && rec.HTTPStatus < http.StatusMultipleChoices {
+		switch r.Method {
+		case http.MethodPut, http.MethodPost:
+			rec.ObjectSize = entry.API.InputBytes
+		case http.MethodGet, http.MethodHead:
+			rec.ObjectSize = rec.BytesSent
+			// Ranged requests carry the object size in Content-Range.
+			if contentRange := entry.RespHeader[xhttp.ContentRange]; contentRange != "" {
+				if i := strings.LastIndex(contentRange, "/"); i != -1 {
+					if size, err := strconv.ParseInt(contentRange[i+1:], 10, 64); err == nil {
+						rec.ObjectSize = size
+					}
+				}
+			}
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(r.Header.Get(xhttp.Authorization), "AWS4-HMAC-SHA256"):
+		rec.SignatureVersion, rec.AuthType = "SigV4", "AuthHeader"
+	case strings.HasPrefix(r.Header.Get(xhttp.Authorization), "AWS "):
+		rec.SignatureVersion, rec.AuthType = "SigV2", "AuthHeader"
+	case r.URL.Query().Get(xhttp.AmzAlgorithm) != "":
+		rec.SignatureVersion, rec.AuthType = "SigV4", "QueryString"
+	case r.URL.Query().Get(xhttp.AmzSignatureV2) != "":
+		rec.SignatureVersion, rec.AuthType = "SigV2", "QueryString"
+	}
+
+	if r.TLS != nil {
+		rec.CipherSuite = tlsCipherSuiteName(r.TLS.CipherSuite)
+		rec.TLSVersion = tlsVersionName(r.TLS.Version)
+	}
+	return rec
+}
+
+// subResources - sub-resources reported in the operation of a record,
+// in the order of precedence.
+var subResources = []string{
+	"uploads", "uploadId", "acl", "policy", "tagging", "versioning", "lifecycle",
+	"encryption", "replication", "object-lock", "retention", "legal-hold",
+	"notification", "cors", "logging", "website", "location", "versions",
+	"select", "restore", "delete",
+}
+
+// operation - returns the operation of a request in the form of
+// REST.HTTP_method.resource_type, e.g. REST.PUT.OBJECT.
+func operation(r *http.Request, isObject bool) string {
+	resource := "BUCKET"
+	if isObject {
+		resource = "OBJECT"
+	}
+	query := r.URL.Query()
+	for _, subResource := range subResources {
+		if _, ok := query[subResource]; !ok {
+			continue
+		}
+		if subResource == "uploadId" {
+			resource = "UPLOAD"
+		} else {
+			resource = resource + "_" + strings.ToUpper(strings.ReplaceAll(subResource, "-", "_"))
+		}
+		break
+	}
+	return "REST." + r.Method + "." + resource
+}
+
+// String - returns the record in the S3 server access log format.
+func (rec Record) String() string {
+	fields := []string{
+		orDash(rec.BucketOwner),
+		orDash(rec.Bucket),
+		"[" + rec.Time.Format(timeFormat) + "]",
+		orDash(rec.RemoteIP),
+		orDash(rec.Requester),
+		orDash(rec.RequestID),
+		orDash(rec.Operation),
+		orDash(url.QueryEscape(rec.Key)),
+		quote(rec.RequestURI),
+		strconv.Itoa(rec.HTTPStatus),
+		orDash(rec.ErrorCode),
+		sizeOrDash(rec.BytesSent),
+		sizeOrDash(rec.ObjectSize),
+		strconv.FormatInt(rec.TotalTime.Milliseconds(), 10),
+		durationOrDash(rec.TurnAroundTime),
+		quote(rec.Referer),
+		quote(rec.UserAgent),
+		orDash(rec.VersionID),
+		orDash(rec.HostID),
+		orDash(rec.SignatureVersion),
+		orDash(rec.CipherSuite),
+		orDash(rec.AuthType),
+		orDash(rec.HostHeader),
+		orDash(rec.TLSVersion),
+	}
+	return strings.Join(fields, " ")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func quote(s string) string {
+	return `"` + orDash(s) + `"`
+}
+
+func sizeOrDash(size int64) string {
+	if size <= 0 {
+		return "-"
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+func durationOrDash(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return strconv.FormatInt(d.Milliseconds(), 10)
+}
+
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLSv1"
+	case tls.VersionTLS11:
+		return "TLSv1.1"
+	case tls.VersionTLS12:
+		return "TLSv1.2"
+	case tls.VersionTLS13:
+		return "TLSv1.3"
+	}
+	return ""
+}
+
+func tlsCipherSuiteName(id uint16) string {
+	for _, suite := range tls.CipherSuites() {
+		if suite.ID == id {
+			return suite.Name
+		}
+	}
+	return ""
+}
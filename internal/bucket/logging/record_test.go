@@ -0,0 +1,81 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+func TestRecordString(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "http://localhost:9000/bucket/photos/2021/a.jpg?versionId=v1", nil)
+	r.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=minio/20211017/us-east-1/s3/aws4_request")
+	r.Header.Set("Referer", "https://example.com/")
+
+	entry := audit.Entry{
+		Time:       "2021-10-17T10:20:30.123456789Z",
+		RemoteHost: "192.168.1.10",
+		RequestID:  "16AEB9F6C4B4B2A0",
+		AccessKey:  "minio",
+		UserAgent:  "aws-cli/2.0",
+		RespHeader: map[string]string{
+			"Content-Length":   "100",
+			"Content-Range":    "bytes 0-99/1024",
+			"X-Amz-Version-Id": "v1",
+		},
+	}
+	entry.API.Bucket = "bucket"
+	entry.API.Object = "photos/2021/a.jpg"
+	entry.API.StatusCode = http.StatusPartialContent
+	entry.API.OutputBytes = 512
+	entry.API.TimeToResponse = "25000000ns"
+	entry.API.TimeToFirstByte = "10000000ns"
+
+	rec := NewRecord(entry, r, "owner")
+	expected := `owner bucket [17/Oct/2021:10:20:30 +0000] 192.168.1.10 minio 16AEB9F6C4B4B2A0 REST.GET.OBJECT photos%2F2021%2Fa.jpg "GET /bucket/photos/2021/a.jpg?versionId=v1 HTTP/1.1" 206 - 100 1024 25 10 "https://example.com/" "aws-cli/2.0" v1 - SigV4 - AuthHeader localhost:9000 -`
+	if got := rec.String(); got != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, got)
+	}
+}
+
+func TestRecordOperation(t *testing.T) {
+	testCases := []struct {
+		method   string
+		url      string
+		isObject bool
+		expected string
+	}{
+		{http.MethodPut, "/bucket/object", true, "REST.PUT.OBJECT"},
+		{http.MethodGet, "/bucket", false, "REST.GET.BUCKET"},
+		{http.MethodGet, "/bucket?versioning", false, "REST.GET.BUCKET_VERSIONING"},
+		{http.MethodPut, "/bucket/object?tagging", true, "REST.PUT.OBJECT_TAGGING"},
+		{http.MethodPut, "/bucket/object?legal-hold", true, "REST.PUT.OBJECT_LEGAL_HOLD"},
+		{http.MethodPut, "/bucket/object?partNumber=1&uploadId=abc", true, "REST.PUT.UPLOAD"},
+		{http.MethodPost, "/bucket/object?uploads", true, "REST.POST.OBJECT_UPLOADS"},
+	}
+
+	for i, tc := range testCases {
+		r := httptest.NewRequest(tc.method, "http://localhost:9000"+tc.url, nil)
+		if got := operation(r, tc.isObject); got != tc.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.expected, got)
+		}
+	}
+}
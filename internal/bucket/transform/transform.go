@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transform
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+)
+
+// Minimum length of the secret used to sign the request context.
+const minSecretLength = 8
+
+// Config - transformer configured on a bucket, object content
+// returned by GetObject is streamed through the HTTP function
+// at Endpoint before it is sent back to the client.
+type Config struct {
+	// Endpoint of the HTTP function, object content is sent
+	// to it as the body of a POST request.
+	Endpoint string `json:"endpoint"`
+
+	// Secret used to sign the request context sent to
+	// the endpoint, it is never returned by the server.
+	Secret string `json:"secret,omitempty"`
+
+	// SupportsRange is set when the endpoint honours the
+	// Range header and returns only the requested bytes
+	// of the transformed content.
+	SupportsRange bool `json:"supportsRange,omitempty"`
+}
+
+// Validate - validates the transform configuration.
+func (c Config) Validate() error {
+	if c.Endpoint == "" {
+		return Errorf("endpoint cannot be empty")
+	}
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return Errorf("invalid endpoint %s: %w", c.Endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return Errorf("unsupported endpoint scheme %s", u.Scheme)
+	}
+	if u.Host == "" {
+		return Errorf("endpoint %s has no host", c.Endpoint)
+	}
+	if u.RawQuery != "" {
+		return Errorf("endpoint %s cannot have a query", c.Endpoint)
+	}
+	if len(c.Secret) < minSecretLength {
+		return Errorf("secret must be at least %d characters long", minSecretLength)
+	}
+	return nil
+}
+
+// URL - returns the parsed endpoint.
+func (c Config) URL() *url.URL {
+	u, _ := url.Parse(c.Endpoint)
+	return u
+}
+
+// ParseConfig - parses the transform configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := json.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transform
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expectedErr bool
+	}{
+		{ // 1. Valid endpoint and secret
+			input: `{"endpoint":"https://transform.example.com/redact","secret":"transform-secret"}`,
+		},
+		{ // 2. Endpoint supporting ranges
+			input: `{"endpoint":"http://localhost:8080","secret":"transform-secret","supportsRange":true}`,
+		},
+		{ // 3. Missing endpoint
+			input:       `{"secret":"transform-secret"}`,
+			expectedErr: true,
+		},
+		{ // 4. Unsupported endpoint scheme
+			input:       `{"endpoint":"ftp://transform.example.com","secret":"transform-secret"}`,
+			expectedErr: true,
+		},
+		{ // 5. Endpoint without host
+			input:       `{"endpoint":"http:///redact","secret":"transform-secret"}`,
+			expectedErr: true,
+		},
+		{ // 6. Endpoint with a query
+			input:       `{"endpoint":"https://transform.example.com/?fn=redact","secret":"transform-secret"}`,
+			expectedErr: true,
+		},
+		{ // 7. Short secret
+			input:       `{"endpoint":"https://transform.example.com","secret":"short"}`,
+			expectedErr: true,
+		},
+		{ // 8. Malformed JSON
+			input:       `{"endpoint":`,
+			expectedErr: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := ParseConfig(strings.NewReader(tc.input))
+		if tc.expectedErr && err == nil {
+			t.Errorf("Test %d: expected error, got nil", i+1)
+		}
+		if !tc.expectedErr && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+	}
+}
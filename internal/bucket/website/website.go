@@ -0,0 +1,231 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package website
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	xmlNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	// Maximum number of routing rules allowed on AWS S3.
+	maxRoutingRules = 50
+)
+
+// IndexDocument - the suffix appended to requests for a directory.
+type IndexDocument struct {
+	Suffix string `xml:"Suffix"`
+}
+
+// ErrorDocument - the object returned when an error occurs.
+type ErrorDocument struct {
+	Key string `xml:"Key"`
+}
+
+// RedirectAllRequestsTo - redirects all requests to another host.
+type RedirectAllRequestsTo struct {
+	HostName string `xml:"HostName"`
+	Protocol string `xml:"Protocol,omitempty"`
+}
+
+// Condition - the condition that must be met for a redirect to apply.
+type Condition struct {
+	HTTPErrorCodeReturnedEquals string `xml:"HttpErrorCodeReturnedEquals,omitempty"`
+	KeyPrefixEquals             string `xml:"KeyPrefixEquals,omitempty"`
+}
+
+// Redirect - where a matching request is redirected to.
+type Redirect struct {
+	HostName             string `xml:"HostName,omitempty"`
+	HTTPRedirectCode     string `xml:"HttpRedirectCode,omitempty"`
+	Protocol             string `xml:"Protocol,omitempty"`
+	ReplaceKeyPrefixWith string `xml:"ReplaceKeyPrefixWith,omitempty"`
+	ReplaceKeyWith       string `xml:"ReplaceKeyWith,omitempty"`
+}
+
+// RoutingRule - redirects requests matching a condition.
+type RoutingRule struct {
+	Condition *Condition `xml:"Condition,omitempty"`
+	Redirect  Redirect   `xml:"Redirect"`
+}
+
+// Config - bucket website configuration.
+type Config struct {
+	XMLNS                 string                 `xml:"xmlns,attr,omitempty"`
+	XMLName               xml.Name               `xml:"WebsiteConfiguration"`
+	IndexDocument         *IndexDocument         `xml:"IndexDocument,omitempty"`
+	ErrorDocument         *ErrorDocument         `xml:"ErrorDocument,omitempty"`
+	RedirectAllRequestsTo *RedirectAllRequestsTo `xml:"RedirectAllRequestsTo,omitempty"`
+	RoutingRules          []RoutingRule          `xml:"RoutingRules>RoutingRule,omitempty"`
+}
+
+func validProtocol(protocol string) bool {
+	return protocol == "" || protocol == "http" || protocol == "https"
+}
+
+// Validate - validates the routing rule.
+func (r RoutingRule) Validate() error {
+	if r.Condition != nil {
+		if code := r.Condition.HTTPErrorCodeReturnedEquals; code != "" {
+			if n, err := strconv.Atoi(code); err != nil || n < 400 || n > 599 {
+				return Errorf("HttpErrorCodeReturnedEquals must be a 4XX or 5XX status code, got %s", code)
+			}
+		}
+	}
+	redirect := r.Redirect
+	if redirect == (Redirect{}) {
+		return Errorf("Redirect must specify at least one of HostName, HttpRedirectCode, Protocol, ReplaceKeyPrefixWith or ReplaceKeyWith")
+	}
+	if redirect.ReplaceKeyPrefixWith != "" && redirect.ReplaceKeyWith != "" {
+		return Errorf("ReplaceKeyPrefixWith and ReplaceKeyWith can not be specified together")
+	}
+	if code := redirect.HTTPRedirectCode; code != "" {
+		if n, err := strconv.Atoi(code); err != nil || n < 300 || n > 399 {
+			return Errorf("HttpRedirectCode must be a 3XX status code, got %s", code)
+		}
+	}
+	if !validProtocol(redirect.Protocol) {
+		return Errorf("Protocol must be either http or https, got %s", redirect.Protocol)
+	}
+	return nil
+}
+
+// Validate - validates the website configuration.
+func (c Config) Validate() error {
+	if c.RedirectAllRequestsTo != nil {
+		if c.IndexDocument != nil || c.ErrorDocument != nil || len(c.RoutingRules) > 0 {
+			return Errorf("RedirectAllRequestsTo can not be specified with other elements")
+		}
+		if c.RedirectAllRequestsTo.HostName == "" {
+			return Errorf("RedirectAllRequestsTo must specify a HostName")
+		}
+		if !validProtocol(c.RedirectAllRequestsTo.Protocol) {
+			return Errorf("Protocol must be either http or https, got %s", c.RedirectAllRequestsTo.Protocol)
+		}
+		return nil
+	}
+
+	if c.IndexDocument == nil {
+		return Errorf("IndexDocument must be specified")
+	}
+	if c.IndexDocument.Suffix == "" || strings.Contains(c.IndexDocument.Suffix, "/") {
+		return Errorf("IndexDocument Suffix must be non-empty and can not contain a slash")
+	}
+	if c.ErrorDocument != nil && c.ErrorDocument.Key == "" {
+		return Errorf("ErrorDocument must specify a Key")
+	}
+	if len(c.RoutingRules) > maxRoutingRules {
+		return Errorf("website configuration can not have more than %d routing rules", maxRoutingRules)
+	}
+	for _, rule := range c.RoutingRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IndexKey - returns the index document key of a key which refers to
+// a directory, false is returned for keys which are not directories.
+func (c *Config) IndexKey(key string) (string, bool) {
+	if c.IndexDocument == nil || (key != "" && !strings.HasSuffix(key, "/")) {
+		return key, false
+	}
+	return key + c.IndexDocument.Suffix, true
+}
+
+// Route - returns the first routing rule matching the key and the
+// status code returned for it, statusCode is 0 when no error occurred
+// yet. nil is returned if no rule matches.
+func (c *Config) Route(key string, statusCode int) *RoutingRule {
+	var errorCode string
+	if statusCode != 0 {
+		errorCode = strconv.Itoa(statusCode)
+	}
+	for i := range c.RoutingRules {
+		rule := &c.RoutingRules[i]
+		condition := rule.Condition
+		if condition == nil {
+			condition = &Condition{}
+		}
+		if !strings.HasPrefix(key, condition.KeyPrefixEquals) {
+			continue
+		}
+		if condition.HTTPErrorCodeReturnedEquals != errorCode {
+			continue
+		}
+		return rule
+	}
+	return nil
+}
+
+// Location - returns the location and the status code of the redirect
+// of a key, host and protocol are the ones of the original request.
+func (r RoutingRule) Location(protocol, host, key string) (string, int) {
+	if r.Redirect.Protocol != "" {
+		protocol = r.Redirect.Protocol
+	}
+	if r.Redirect.HostName != "" {
+		host = r.Redirect.HostName
+	}
+
+	switch {
+	case r.Redirect.ReplaceKeyWith != "":
+		key = r.Redirect.ReplaceKeyWith
+	case r.Redirect.ReplaceKeyPrefixWith != "":
+		var prefix string
+		if r.Condition != nil {
+			prefix = r.Condition.KeyPrefixEquals
+		}
+		key = r.Redirect.ReplaceKeyPrefixWith + strings.TrimPrefix(key, prefix)
+	}
+
+	statusCode := http.StatusMovedPermanently
+	if code, err := strconv.Atoi(r.Redirect.HTTPRedirectCode); err == nil {
+		statusCode = code
+	}
+	return protocol + "://" + host + "/" + key, statusCode
+}
+
+// Location - returns the location all requests are redirected to.
+func (r RedirectAllRequestsTo) Location(protocol, key string) string {
+	if r.Protocol != "" {
+		protocol = r.Protocol
+	}
+	return protocol + "://" + r.HostName + "/" + key
+}
+
+// ParseConfig - parses data in given reader to website configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.XMLNS == "" {
+		c.XMLNS = xmlNS
+	}
+	return &c, nil
+}
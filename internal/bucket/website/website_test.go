@@ -0,0 +1,142 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package website
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expectedErr bool
+	}{
+		{ // 1. Index and error documents with routing rules
+			input: `<WebsiteConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+				<IndexDocument><Suffix>index.html</Suffix></IndexDocument>
+				<ErrorDocument><Key>error.html</Key></ErrorDocument>
+				<RoutingRules>
+					<RoutingRule>
+						<Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition>
+						<Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect>
+					</RoutingRule>
+				</RoutingRules>
+			</WebsiteConfiguration>`,
+		},
+		{ // 2. Redirect all requests
+			input: `<WebsiteConfiguration>
+				<RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>https</Protocol></RedirectAllRequestsTo>
+			</WebsiteConfiguration>`,
+		},
+		{ // 3. Redirect all requests along with an index document
+			input: `<WebsiteConfiguration>
+				<IndexDocument><Suffix>index.html</Suffix></IndexDocument>
+				<RedirectAllRequestsTo><HostName>example.com</HostName></RedirectAllRequestsTo>
+			</WebsiteConfiguration>`,
+			expectedErr: true,
+		},
+		{ // 4. Missing index document
+			input:       `<WebsiteConfiguration><ErrorDocument><Key>error.html</Key></ErrorDocument></WebsiteConfiguration>`,
+			expectedErr: true,
+		},
+		{ // 5. Index document suffix with a slash
+			input:       `<WebsiteConfiguration><IndexDocument><Suffix>a/index.html</Suffix></IndexDocument></WebsiteConfiguration>`,
+			expectedErr: true,
+		},
+		{ // 6. Invalid redirect code
+			input: `<WebsiteConfiguration>
+				<IndexDocument><Suffix>index.html</Suffix></IndexDocument>
+				<RoutingRules><RoutingRule><Redirect><HttpRedirectCode>200</HttpRedirectCode></Redirect></RoutingRule></RoutingRules>
+			</WebsiteConfiguration>`,
+			expectedErr: true,
+		},
+		{ // 7. Both key replacements
+			input: `<WebsiteConfiguration>
+				<IndexDocument><Suffix>index.html</Suffix></IndexDocument>
+				<RoutingRules><RoutingRule><Redirect>
+					<ReplaceKeyPrefixWith>a/</ReplaceKeyPrefixWith><ReplaceKeyWith>b</ReplaceKeyWith>
+				</Redirect></RoutingRule></RoutingRules>
+			</WebsiteConfiguration>`,
+			expectedErr: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := ParseConfig(strings.NewReader(tc.input))
+		if tc.expectedErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, tc.expectedErr, err)
+		}
+	}
+}
+
+func TestConfigRouting(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<WebsiteConfiguration>
+		<IndexDocument><Suffix>index.html</Suffix></IndexDocument>
+		<RoutingRules>
+			<RoutingRule>
+				<Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition>
+				<Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect>
+			</RoutingRule>
+			<RoutingRule>
+				<Condition><HttpErrorCodeReturnedEquals>404</HttpErrorCodeReturnedEquals></Condition>
+				<Redirect><HostName>example.com</HostName><HttpRedirectCode>302</HttpRedirectCode><ReplaceKeyWith>404.html</ReplaceKeyWith></Redirect>
+			</RoutingRule>
+		</RoutingRules>
+	</WebsiteConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key, ok := config.IndexKey("photos/"); !ok || key != "photos/index.html" {
+		t.Errorf("unexpected index key %s", key)
+	}
+	if key, ok := config.IndexKey(""); !ok || key != "index.html" {
+		t.Errorf("unexpected index key %s", key)
+	}
+	if _, ok := config.IndexKey("photos/a.jpg"); ok {
+		t.Errorf("expected photos/a.jpg not to be a directory")
+	}
+
+	testCases := []struct {
+		key              string
+		statusCode       int
+		expectedLocation string
+		expectedCode     int
+	}{
+		{"docs/a.html", 0, "http://localhost/documents/a.html", http.StatusMovedPermanently},
+		{"missing.html", 0, "", 0},
+		{"missing.html", http.StatusNotFound, "http://example.com/404.html", http.StatusFound},
+		{"missing.html", http.StatusForbidden, "", 0},
+	}
+
+	for i, tc := range testCases {
+		rule := config.Route(tc.key, tc.statusCode)
+		if rule == nil {
+			if tc.expectedLocation != "" {
+				t.Errorf("Test %d: expected a matching rule", i+1)
+			}
+			continue
+		}
+		location, code := rule.Location("http", "localhost", tc.key)
+		if location != tc.expectedLocation || code != tc.expectedCode {
+			t.Errorf("Test %d: expected %s (%d), got %s (%d)", i+1, tc.expectedLocation, tc.expectedCode, location, code)
+		}
+	}
+}
@@ -94,5 +94,11 @@ var (
 			Optional:    true,
 			Type:        "duration",
 		},
+		config.HelpKV{
+			Key:         apiSelectMaxMemory,
+			Description: `set the maximum memory used by S3 Select to group and sort a query result, defaults to "64MiB"`,
+			Optional:    true,
+			Type:        "string",
+		},
 	}
 )
@@ -77,16 +77,22 @@ const (
 	LoggerWebhookSubSys  = "logger_webhook"
 	AuditWebhookSubSys   = "audit_webhook"
 	AuditKafkaSubSys     = "audit_kafka"
+	AuditSyslogSubSys    = "audit_syslog"
+	AuditOTLPSubSys      = "audit_otlp"
+	AuditFileSubSys      = "audit_file"
+	AuditChainSubSys     = "audit_chain"
 	HealSubSys           = "heal"
 	ScannerSubSys        = "scanner"
 	CrawlerSubSys        = "crawler"
 	SubnetSubSys         = "subnet"
+	EventJournalSubSys   = "event_journal"
 
 	// Add new constants here if you add new fields to config.
 )
 
 // Notification config constants.
 const (
+	NotifySubSys         = "notify"
 	NotifyKafkaSubSys    = "notify_kafka"
 	NotifyMQTTSubSys     = "notify_mqtt"
 	NotifyMySQLSubSys    = "notify_mysql"
@@ -97,6 +103,8 @@ const (
 	NotifyPostgresSubSys = "notify_postgres"
 	NotifyRedisSubSys    = "notify_redis"
 	NotifyWebhookSubSys  = "notify_webhook"
+	NotifyPulsarSubSys   = "notify_pulsar"
+	NotifyGRPCSubSys     = "notify_grpc"
 
 	// Add new constants here if you add new fields to config.
 )
@@ -113,6 +121,10 @@ var SubSystems = set.CreateStringSet(
 	LoggerWebhookSubSys,
 	AuditWebhookSubSys,
 	AuditKafkaSubSys,
+	AuditSyslogSubSys,
+	AuditOTLPSubSys,
+	AuditFileSubSys,
+	AuditChainSubSys,
 	PolicyOPASubSys,
 	IdentityLDAPSubSys,
 	IdentityOpenIDSubSys,
@@ -129,7 +141,11 @@ var SubSystems = set.CreateStringSet(
 	NotifyPostgresSubSys,
 	NotifyRedisSubSys,
 	NotifyWebhookSubSys,
+	NotifyPulsarSubSys,
+	NotifyGRPCSubSys,
+	NotifySubSys,
 	SubnetSubSys,
+	EventJournalSubSys,
 )
 
 // SubSystemsDynamic - all sub-systems that have dynamic config.
@@ -139,6 +155,8 @@ var SubSystemsDynamic = set.CreateStringSet(
 	ScannerSubSys,
 	HealSubSys,
 	SubnetSubSys,
+	EventJournalSubSys,
+	NotifySubSys,
 )
 
 // SubSystemsSingleTargets - subsystems which only support single target.
@@ -150,12 +168,14 @@ var SubSystemsSingleTargets = set.CreateStringSet([]string{
 	APISubSys,
 	StorageClassSubSys,
 	CompressionSubSys,
+	AuditChainSubSys,
 	PolicyOPASubSys,
 	IdentityLDAPSubSys,
-	IdentityOpenIDSubSys,
 	IdentityTLSSubSys,
 	HealSubSys,
 	ScannerSubSys,
+	EventJournalSubSys,
+	NotifySubSys,
 }...)
 
 // Constant separators
@@ -37,6 +37,9 @@ const (
 	EnvArgs       = "MINIO_ARGS"
 	EnvDNSWebhook = "MINIO_DNS_WEBHOOK_ENDPOINT"
 
+	EnvWebsiteDomain  = "MINIO_WEBSITE_DOMAIN"
+	EnvWebsiteAddress = "MINIO_WEBSITE_ADDRESS"
+
 	EnvMinIOSubnetLicense      = "MINIO_SUBNET_LICENSE"
 	EnvMinIOServerURL          = "MINIO_SERVER_URL"
 	EnvMinIOBrowserRedirectURL = "MINIO_BROWSER_REDIRECT_URL"
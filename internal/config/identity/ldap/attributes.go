@@ -0,0 +1,144 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	ldap "github.com/go-ldap/ldap/v3"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/pkg/bucket/policy/condition"
+)
+
+// LDAP attribute descriptions, RFC 4512 section 2.5.
+var validAttributeName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*$`)
+
+// Condition attributes which would shadow a built-in condition key, the
+// values of "ldap:<attribute>" are looked up by the bare attribute name
+// like those of every other condition key. Taken before any "ldap:"
+// key is registered.
+var reservedConditionAttributes = func() set.StringSet {
+	reserved := set.NewStringSet()
+	for _, keys := range [][]condition.KeyName{condition.AllSupportedKeys, condition.AllSupportedAdminKeys} {
+		for _, key := range keys {
+			reserved.Add(strings.ToLower(key.Name()))
+		}
+	}
+	return reserved
+}()
+
+// IsReservedConditionAttribute returns true if a condition attribute
+// has the name of a built-in condition key, names are case insensitive.
+func IsReservedConditionAttribute(attr string) bool {
+	return reservedConditionAttributes.Contains(strings.ToLower(attr))
+}
+
+// parseUserAttributes parses a comma separated list of attribute names.
+func parseUserAttributes(value string) ([]string, error) {
+	var attrs []string
+	for _, attr := range strings.Split(value, ",") {
+		attr = strings.TrimSpace(attr)
+		if attr == "" {
+			continue
+		}
+		if !validAttributeName.MatchString(attr) {
+			return nil, fmt.Errorf("invalid attribute name %q", attr)
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs, nil
+}
+
+// UserAttributesEnabled returns true if policy or condition attributes
+// are configured.
+func (l Config) UserAttributesEnabled() bool {
+	return len(l.UserPolicyAttributes) > 0 || len(l.UserConditionAttributes) > 0
+}
+
+// LookupUserAttributes returns the values of the configured policy and
+// condition attributes of a user DN, attributes without values are
+// omitted.
+func (l *Config) LookupUserAttributes(userDN string) (map[string][]string, error) {
+	attrs := set.CreateStringSet(l.UserPolicyAttributes...).
+		Union(set.CreateStringSet(l.UserConditionAttributes...)).ToSlice()
+
+	var entry *ldap.Entry
+	err := l.withLookupConn(func(conn *ldap.Conn) error {
+		searchRequest := ldap.NewSearchRequest(
+			userDN,
+			ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+			"(objectClass=*)",
+			attrs,
+			nil,
+		)
+		sres, err := conn.Search(searchRequest)
+		if err != nil {
+			return err
+		}
+		if len(sres.Entries) != 1 {
+			return errors.New("user entry not found")
+		}
+		entry = sres.Entries[0]
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to lookup attributes of %s: %w", userDN, err)
+	}
+
+	values := make(map[string][]string, len(attrs))
+	for _, attr := range attrs {
+		// Attribute names are case insensitive, the server may return
+		// them in a different case than requested.
+		if v := entry.GetEqualFoldAttributeValues(attr); len(v) > 0 {
+			values[attr] = v
+		}
+	}
+	return values, nil
+}
+
+// AttributePolicies returns the policy names held by the policy
+// attributes of a user.
+func (l Config) AttributePolicies(values map[string][]string) []string {
+	var policies []string
+	seen := set.NewStringSet()
+	for _, attr := range l.UserPolicyAttributes {
+		for _, policy := range values[attr] {
+			policy = strings.TrimSpace(policy)
+			if policy != "" && !seen.Contains(policy) {
+				seen.Add(policy)
+				policies = append(policies, policy)
+			}
+		}
+	}
+	return policies
+}
+
+// ConditionAttributes returns the values of the condition attributes
+// of a user.
+func (l Config) ConditionAttributes(values map[string][]string) map[string][]string {
+	conditions := make(map[string][]string, len(l.UserConditionAttributes))
+	for _, attr := range l.UserConditionAttributes {
+		if v, ok := values[attr]; ok {
+			conditions[attr] = v
+		}
+	}
+	return conditions
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseUserAttributes(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected []string
+		success  bool
+	}{
+		{"", nil, true},
+		{"department", []string{"department"}, true},
+		{" department , employeeType,", []string{"department", "employeeType"}, true},
+		{"1department", nil, false},
+		{"department;binary", nil, false},
+	}
+	for i, testCase := range testCases {
+		attrs, err := parseUserAttributes(testCase.value)
+		if err != nil && testCase.success {
+			t.Errorf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+		if !reflect.DeepEqual(attrs, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, attrs)
+		}
+	}
+}
+
+func TestUserAttributes(t *testing.T) {
+	l := Config{
+		UserPolicyAttributes:    []string{"department", "memberOfPolicy"},
+		UserConditionAttributes: []string{"department", "employeeType"},
+	}
+	values := map[string][]string{
+		"department":     {"finance"},
+		"memberOfPolicy": {"readonly", "finance", " "},
+		"employeeType":   {"contractor"},
+	}
+
+	policies := l.AttributePolicies(values)
+	if expected := []string{"finance", "readonly"}; !reflect.DeepEqual(policies, expected) {
+		t.Errorf("expected policies %v, got %v", expected, policies)
+	}
+
+	conditions := l.ConditionAttributes(values)
+	expected := map[string][]string{
+		"department":   {"finance"},
+		"employeeType": {"contractor"},
+	}
+	if !reflect.DeepEqual(conditions, expected) {
+		t.Errorf("expected conditions %v, got %v", expected, conditions)
+	}
+}
+
+func TestIsReservedConditionAttribute(t *testing.T) {
+	for attr, reserved := range map[string]bool{
+		"user":          true,
+		"username":      true,
+		"SourceIp":      true,
+		"sourceip":      true,
+		"userid":        true,
+		"prefix":        true,
+		"versionid":     true,
+		"sub":           true,
+		"groups":        true,
+		"department":    false,
+		"employeeType":  false,
+		"memberOfGroup": false,
+	} {
+		if IsReservedConditionAttribute(attr) != reserved {
+			t.Errorf("%s: expected reserved to be %v", attr, reserved)
+		}
+	}
+}
+
+func TestGroupRDNValue(t *testing.T) {
+	testCases := []struct {
+		dn       string
+		expected string
+	}{
+		{"cn=devs,ou=groups,dc=example,dc=com", "devs"},
+		{"CN=Domain Admins,CN=Users,DC=example,DC=com", "Domain Admins"},
+		{"not a dn", "not a dn"},
+	}
+	for i, testCase := range testCases {
+		if value := groupRDNValue(testCase.dn); value != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, value)
+		}
+	}
+}
+
+func TestMergeGroups(t *testing.T) {
+	groups := mergeGroups([]string{"cn=a", "cn=b"}, []string{"cn=b", "cn=c", "cn=c"})
+	if expected := []string{"cn=a", "cn=b", "cn=c"}; !reflect.DeepEqual(groups, expected) {
+		t.Errorf("expected %v, got %v", expected, groups)
+	}
+}
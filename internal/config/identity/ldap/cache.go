@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Maximum number of users with cached groups.
+const maxGroupCacheEntries = 100000
+
+type groupCacheEntry struct {
+	groups  []string
+	expires time.Time
+}
+
+// groupCache - caches the groups of users by user DN for a fixed time.
+type groupCache struct {
+	// Placed first for 64-bit alignment of atomic operations.
+	hits   int64
+	misses int64
+
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]groupCacheEntry
+}
+
+func newGroupCache(ttl time.Duration) *groupCache {
+	return &groupCache{
+		ttl:     ttl,
+		entries: make(map[string]groupCacheEntry),
+	}
+}
+
+// get returns the cached groups of a user DN.
+func (c *groupCache) get(userDN string) ([]string, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[userDN]
+	if ok && time.Now().After(entry.expires) {
+		delete(c.entries, userDN)
+		ok = false
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false
+	}
+	atomic.AddInt64(&c.hits, 1)
+	return entry.groups, true
+}
+
+// set caches the groups of a user DN, if the cache is full expired
+// entries are removed and the groups are not cached if it still is.
+func (c *groupCache) set(userDN string, groups []string) {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxGroupCacheEntries {
+		for dn, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, dn)
+			}
+		}
+		if len(c.entries) >= maxGroupCacheEntries {
+			return
+		}
+	}
+	c.entries[userDN] = groupCacheEntry{
+		groups:  groups,
+		expires: now.Add(c.ttl),
+	}
+}
@@ -18,11 +18,9 @@
 package ldap
 
 import (
-	"crypto/tls"
 	"crypto/x509"
 	"errors"
 	"fmt"
-	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -47,7 +45,8 @@ const (
 type Config struct {
 	Enabled bool `json:"enabled"`
 
-	// E.g. "ldap.minio.io:636"
+	// E.g. "ldap.minio.io:636", or a comma separated list of
+	// servers tried in order e.g. "ldap1.minio.io:636,ldap2.minio.io:636"
 	ServerAddr string `json:"serverAddr"`
 
 	// User DN search parameters
@@ -59,6 +58,11 @@ type Config struct {
 	GroupSearchBaseDistNames []string `json:"-"`
 	GroupSearchFilter        string   `json:"groupSearchFilter"`
 
+	// User attributes whose values are policy names, and user
+	// attributes available as policy condition variables.
+	UserPolicyAttributes    []string `json:"userPolicyAttributes,omitempty"`
+	UserConditionAttributes []string `json:"userConditionAttributes,omitempty"`
+
 	// Lookup bind LDAP service account
 	LookupBindDN       string `json:"lookupBindDN"`
 	LookupBindPassword string `json:"lookupBindPassword"`
@@ -68,6 +72,13 @@ type Config struct {
 	serverInsecure    bool          // allows plain text connection to LDAP server
 	serverStartTLS    bool          // allows using StartTLS connection to LDAP server
 	rootCAs           *x509.CertPool
+
+	servers    []*server
+	pool       *connPool   // lookup bind connections
+	groupCache *groupCache // nil if disabled
+
+	nestedGroupSearch   string // one of the nestedGroups* modes
+	nestedGroupMaxDepth int
 }
 
 // LDAP keys and envs.
@@ -82,6 +93,13 @@ const (
 	TLSSkipVerify      = "tls_skip_verify"
 	ServerInsecure     = "server_insecure"
 	ServerStartTLS     = "server_starttls"
+	LookupPoolSize     = "lookup_pool_size"
+	GroupCacheTTL      = "group_cache_ttl"
+	GroupSearchNested  = "group_search_nested"
+	GroupSearchDepth   = "group_search_max_depth"
+
+	UserPolicyAttributes    = "user_policy_attributes"
+	UserConditionAttributes = "user_condition_attributes"
 
 	EnvServerAddr         = "MINIO_IDENTITY_LDAP_SERVER_ADDR"
 	EnvTLSSkipVerify      = "MINIO_IDENTITY_LDAP_TLS_SKIP_VERIFY"
@@ -94,6 +112,13 @@ const (
 	EnvGroupSearchBaseDN  = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_BASE_DN"
 	EnvLookupBindDN       = "MINIO_IDENTITY_LDAP_LOOKUP_BIND_DN"
 	EnvLookupBindPassword = "MINIO_IDENTITY_LDAP_LOOKUP_BIND_PASSWORD"
+	EnvLookupPoolSize     = "MINIO_IDENTITY_LDAP_LOOKUP_POOL_SIZE"
+	EnvGroupCacheTTL      = "MINIO_IDENTITY_LDAP_GROUP_CACHE_TTL"
+	EnvGroupSearchNested  = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_NESTED"
+	EnvGroupSearchDepth   = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_MAX_DEPTH"
+
+	EnvUserPolicyAttributes    = "MINIO_IDENTITY_LDAP_USER_POLICY_ATTRIBUTES"
+	EnvUserConditionAttributes = "MINIO_IDENTITY_LDAP_USER_CONDITION_ATTRIBUTES"
 )
 
 var removedKeys = []string{
@@ -147,6 +172,30 @@ var (
 			Key:   LookupBindPassword,
 			Value: "",
 		},
+		config.KV{
+			Key:   LookupPoolSize,
+			Value: strconv.Itoa(defaultLookupPoolSize),
+		},
+		config.KV{
+			Key:   GroupCacheTTL,
+			Value: "0s",
+		},
+		config.KV{
+			Key:   GroupSearchNested,
+			Value: nestedGroupsOff,
+		},
+		config.KV{
+			Key:   GroupSearchDepth,
+			Value: strconv.Itoa(defaultNestedGroupMaxDepth),
+		},
+		config.KV{
+			Key:   UserPolicyAttributes,
+			Value: "",
+		},
+		config.KV{
+			Key:   UserConditionAttributes,
+			Value: "",
+		},
 	}
 )
 
@@ -208,8 +257,9 @@ func (l *Config) lookupUserDN(conn *ldap.Conn, username string) (string, error)
 	return searchResult.Entries[0].DN, nil
 }
 
-func (l *Config) searchForUserGroups(conn *ldap.Conn, username, bindDN string) ([]string, error) {
-	// User groups lookup.
+// searchGroups returns the groups which have username or bindDN as a
+// direct member.
+func (l *Config) searchGroups(conn *ldap.Conn, username, bindDN string) ([]string, error) {
 	var groups []string
 	if l.GroupSearchFilter != "" {
 		for _, groupSearchBase := range l.GroupSearchBaseDistNames {
@@ -237,27 +287,40 @@ func (l *Config) searchForUserGroups(conn *ldap.Conn, username, bindDN string) (
 	return groups, nil
 }
 
-// LookupUserDN searches for the full DN and groups of a given username
-func (l *Config) LookupUserDN(username string) (string, []string, error) {
-	conn, err := l.Connect()
+// lookupUserGroups returns the groups of a user, from the group cache
+// if enabled. conn is assumed to be using the lookup bind service account.
+func (l *Config) lookupUserGroups(conn *ldap.Conn, username, bindDN string) ([]string, error) {
+	if l.groupCache != nil {
+		if groups, ok := l.groupCache.get(bindDN); ok {
+			return groups, nil
+		}
+	}
+	groups, err := l.searchForUserGroups(conn, username, bindDN)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
-	defer conn.Close()
-
-	// Bind to the lookup user account
-	if err = l.lookupBind(conn); err != nil {
-		return "", nil, err
+	if l.groupCache != nil {
+		l.groupCache.set(bindDN, groups)
 	}
+	return groups, nil
+}
 
-	// Lookup user DN
-	bindDN, err := l.lookupUserDN(conn, username)
-	if err != nil {
-		errRet := fmt.Errorf("Unable to find user DN: %w", err)
-		return "", nil, errRet
-	}
+// LookupUserDN searches for the full DN and groups of a given username
+func (l *Config) LookupUserDN(username string) (string, []string, error) {
+	var (
+		bindDN string
+		groups []string
+	)
+	err := l.withLookupConn(func(conn *ldap.Conn) (err error) {
+		// Lookup user DN
+		bindDN, err = l.lookupUserDN(conn, username)
+		if err != nil {
+			return fmt.Errorf("Unable to find user DN: %w", err)
+		}
 
-	groups, err := l.searchForUserGroups(conn, username, bindDN)
+		groups, err = l.lookupUserGroups(conn, username, bindDN)
+		return err
+	})
 	if err != nil {
 		return "", nil, err
 	}
@@ -268,39 +331,38 @@ func (l *Config) LookupUserDN(username string) (string, []string, error) {
 // Bind - binds to ldap, searches LDAP and returns the distinguished name of the
 // user and the list of groups.
 func (l *Config) Bind(username, password string) (string, []string, error) {
-	conn, err := l.Connect()
-	if err != nil {
-		return "", nil, err
-	}
-	defer conn.Close()
-
-	var bindDN string
-	// Bind to the lookup user account
-	if err = l.lookupBind(conn); err != nil {
-		return "", nil, err
-	}
-
 	// Lookup user DN
-	bindDN, err = l.lookupUserDN(conn, username)
+	var bindDN string
+	err := l.withLookupConn(func(conn *ldap.Conn) (err error) {
+		bindDN, err = l.lookupUserDN(conn, username)
+		return err
+	})
 	if err != nil {
 		errRet := fmt.Errorf("Unable to find user DN: %w", err)
 		return "", nil, errRet
 	}
 
-	// Authenticate the user credentials.
+	// Authenticate the user credentials on a separate connection,
+	// pooled connections remain bound to the lookup user account.
+	conn, err := l.dial()
+	if err != nil {
+		return "", nil, err
+	}
+	start := time.Now()
 	err = conn.Bind(bindDN, password)
+	conn.server.record(start, err)
+	conn.Close()
 	if err != nil {
 		errRet := fmt.Errorf("LDAP auth failed for DN %s: %w", bindDN, err)
 		return "", nil, errRet
 	}
 
-	// Bind to the lookup user account again to perform group search.
-	if err = l.lookupBind(conn); err != nil {
-		return "", nil, err
-	}
-
 	// User groups lookup.
-	groups, err := l.searchForUserGroups(conn, username, bindDN)
+	var groups []string
+	err = l.withLookupConn(func(conn *ldap.Conn) (err error) {
+		groups, err = l.lookupUserGroups(conn, username, bindDN)
+		return err
+	})
 	if err != nil {
 		return "", nil, err
 	}
@@ -308,37 +370,18 @@ func (l *Config) Bind(username, password string) (string, []string, error) {
 	return bindDN, groups, nil
 }
 
-// Connect connect to ldap server.
+// Connect connect to ldap server, the configured servers are tried
+// in order.
 func (l *Config) Connect() (ldapConn *ldap.Conn, err error) {
 	if l == nil {
 		return nil, errors.New("LDAP is not configured")
 	}
 
-	_, _, err = net.SplitHostPort(l.ServerAddr)
+	conn, err := l.dial()
 	if err != nil {
-		// User default LDAP port if none specified "636"
-		l.ServerAddr = net.JoinHostPort(l.ServerAddr, "636")
-	}
-
-	if l.serverInsecure {
-		return ldap.Dial("tcp", l.ServerAddr)
-	}
-
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: l.tlsSkipVerify,
-		RootCAs:            l.rootCAs,
-	}
-
-	if l.serverStartTLS {
-		conn, err := ldap.Dial("tcp", l.ServerAddr)
-		if err != nil {
-			return nil, err
-		}
-		err = conn.StartTLS(tlsConfig)
-		return conn, err
+		return nil, err
 	}
-
-	return ldap.DialTLS("tcp", l.ServerAddr, tlsConfig)
+	return conn.Conn, nil
 }
 
 // GetExpiryDuration - return parsed expiry duration.
@@ -381,17 +424,15 @@ func (l Config) IsLDAPUserDN(user string) bool {
 // GetNonEligibleUserDistNames - find user accounts (DNs) that are no longer
 // present in the LDAP server or do not meet filter criteria anymore
 func (l *Config) GetNonEligibleUserDistNames(userDistNames []string) ([]string, error) {
-	conn, err := l.Connect()
-	if err != nil {
-		return nil, err
-	}
-	defer conn.Close()
-
-	// Bind to the lookup user account
-	if err = l.lookupBind(conn); err != nil {
-		return nil, err
-	}
+	var nonExistentUsers []string
+	err := l.withLookupConn(func(conn *ldap.Conn) (err error) {
+		nonExistentUsers, err = l.getNonEligibleUserDistNames(conn, userDistNames)
+		return err
+	})
+	return nonExistentUsers, err
+}
 
+func (l *Config) getNonEligibleUserDistNames(conn *ldap.Conn, userDistNames []string) ([]string, error) {
 	// Evaluate the filter again with generic wildcard instead of  specific values
 	filter := strings.Replace(l.UserDNSearchFilter, "%s", "*", -1)
 
@@ -426,26 +467,22 @@ func (l *Config) GetNonEligibleUserDistNames(userDistNames []string) ([]string,
 // LookupGroupMemberships - for each DN finds the set of LDAP groups they are a
 // member of.
 func (l *Config) LookupGroupMemberships(userDistNames []string, userDNToUsernameMap map[string]string) (map[string]set.StringSet, error) {
-	conn, err := l.Connect()
+	var res map[string]set.StringSet
+	err := l.withLookupConn(func(conn *ldap.Conn) error {
+		res = make(map[string]set.StringSet, len(userDistNames))
+		for _, userDistName := range userDistNames {
+			username := userDNToUsernameMap[userDistName]
+			groups, err := l.lookupUserGroups(conn, username, userDistName)
+			if err != nil {
+				return err
+			}
+			res[userDistName] = set.CreateStringSet(groups...)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
-
-	// Bind to the lookup user account
-	if err = l.lookupBind(conn); err != nil {
-		return nil, err
-	}
-
-	res := make(map[string]set.StringSet, len(userDistNames))
-	for _, userDistName := range userDistNames {
-		username := userDNToUsernameMap[userDistName]
-		groups, err := l.searchForUserGroups(conn, username, userDistName)
-		if err != nil {
-			return nil, err
-		}
-		res[userDistName] = set.CreateStringSet(groups...)
-	}
 
 	return res, nil
 }
@@ -475,6 +512,24 @@ func Lookup(kvs config.KVS, rootCAs *x509.CertPool) (l Config, err error) {
 	l.rootCAs = rootCAs
 	l.ServerAddr = ldapServer
 	l.stsExpiryDuration = defaultLDAPExpiry
+	l.servers, err = parseServerAddrs(ldapServer)
+	if err != nil {
+		return l, err
+	}
+
+	// Lookup connection pool and group cache configuration
+	poolSize, err := strconv.Atoi(env.Get(EnvLookupPoolSize, kvs.GetWithDefault(LookupPoolSize, DefaultKVS)))
+	if err != nil || poolSize <= 0 {
+		return l, config.Errorf("invalid value for %s, expecting a positive number", LookupPoolSize)
+	}
+	l.pool = newConnPool(poolSize)
+	groupCacheTTL, err := time.ParseDuration(env.Get(EnvGroupCacheTTL, kvs.GetWithDefault(GroupCacheTTL, DefaultKVS)))
+	if err != nil || groupCacheTTL < 0 {
+		return l, config.Errorf("invalid value for %s, expecting a duration", GroupCacheTTL)
+	}
+	if groupCacheTTL > 0 {
+		l.groupCache = newGroupCache(groupCacheTTL)
+	}
 
 	// LDAP connection configuration
 	if v := env.Get(EnvServerInsecure, kvs.Get(ServerInsecure)); v != "" {
@@ -536,5 +591,37 @@ func Lookup(kvs config.KVS, rootCAs *x509.CertPool) (l Config, err error) {
 		l.GroupSearchBaseDistNames = strings.Split(l.GroupSearchBaseDistName, dnDelimiter)
 	}
 
+	// Nested group search configuration
+	l.nestedGroupSearch = env.Get(EnvGroupSearchNested, kvs.GetWithDefault(GroupSearchNested, DefaultKVS))
+	switch l.nestedGroupSearch {
+	case nestedGroupsOff:
+	case nestedGroupsInChain, nestedGroupsRecursive:
+		if l.GroupSearchFilter == "" {
+			return l, config.Errorf("%s requires %s and %s to be set", GroupSearchNested, GroupSearchFilter, GroupSearchBaseDN)
+		}
+	default:
+		return l, config.Errorf("invalid value for %s, expecting one of %q, %q or %q", GroupSearchNested,
+			nestedGroupsOff, nestedGroupsInChain, nestedGroupsRecursive)
+	}
+	l.nestedGroupMaxDepth, err = strconv.Atoi(env.Get(EnvGroupSearchDepth, kvs.GetWithDefault(GroupSearchDepth, DefaultKVS)))
+	if err != nil || l.nestedGroupMaxDepth <= 0 {
+		return l, config.Errorf("invalid value for %s, expecting a positive number", GroupSearchDepth)
+	}
+
+	// User attributes configuration
+	l.UserPolicyAttributes, err = parseUserAttributes(env.Get(EnvUserPolicyAttributes, kvs.Get(UserPolicyAttributes)))
+	if err != nil {
+		return l, config.Errorf("invalid value for %s: %v", UserPolicyAttributes, err)
+	}
+	l.UserConditionAttributes, err = parseUserAttributes(env.Get(EnvUserConditionAttributes, kvs.Get(UserConditionAttributes)))
+	if err != nil {
+		return l, config.Errorf("invalid value for %s: %v", UserConditionAttributes, err)
+	}
+	for _, attr := range l.UserConditionAttributes {
+		if IsReservedConditionAttribute(attr) {
+			return l, config.Errorf("invalid value for %s: %s is reserved", UserConditionAttributes, attr)
+		}
+	}
+
 	return l, nil
 }
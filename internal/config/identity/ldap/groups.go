@@ -0,0 +1,131 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"fmt"
+
+	ldap "github.com/go-ldap/ldap/v3"
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+// Nested group search modes.
+const (
+	nestedGroupsOff       = "off"
+	nestedGroupsInChain   = "in_chain"
+	nestedGroupsRecursive = "recursive"
+
+	defaultNestedGroupMaxDepth = 5
+)
+
+// Active Directory matching rule which walks the chain of ancestry of
+// a group membership to its root.
+const matchingRuleInChain = "1.2.840.113556.1.4.1941"
+
+// searchForUserGroups returns the groups of a user, including the
+// groups the direct groups are nested in if enabled.
+func (l *Config) searchForUserGroups(conn *ldap.Conn, username, bindDN string) ([]string, error) {
+	groups, err := l.searchGroups(conn, username, bindDN)
+	if err != nil {
+		return nil, err
+	}
+
+	switch l.nestedGroupSearch {
+	case nestedGroupsInChain:
+		nested, err := l.searchGroupsInChain(conn, bindDN)
+		if err != nil {
+			return nil, err
+		}
+		return mergeGroups(groups, nested), nil
+	case nestedGroupsRecursive:
+		return l.searchParentGroups(conn, groups)
+	}
+	return groups, nil
+}
+
+// searchGroupsInChain returns all groups bindDN is a direct or nested
+// member of with a single search per base DN, only supported by Active
+// Directory.
+func (l *Config) searchGroupsInChain(conn *ldap.Conn, bindDN string) ([]string, error) {
+	var groups []string
+	filter := fmt.Sprintf("(member:%s:=%s)", matchingRuleInChain, ldap.EscapeFilter(bindDN))
+	for _, groupSearchBase := range l.GroupSearchBaseDistNames {
+		searchRequest := ldap.NewSearchRequest(
+			groupSearchBase,
+			ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+			filter,
+			[]string{}, // only need DN
+			nil,
+		)
+		newGroups, err := getGroups(conn, searchRequest)
+		if err != nil {
+			return nil, fmt.Errorf("Error finding nested groups of %s: %w", bindDN, err)
+		}
+		groups = append(groups, newGroups...)
+	}
+	return groups, nil
+}
+
+// searchParentGroups expands groups with the groups they are nested
+// in by running the group search filter for every group, up to the
+// configured depth. %d is substituted with the group DN and %s with
+// the value of its first RDN, e.g. the "cn" of the group.
+func (l *Config) searchParentGroups(conn *ldap.Conn, groups []string) ([]string, error) {
+	seen := set.CreateStringSet(groups...)
+	children := groups
+	for depth := 0; depth < l.nestedGroupMaxDepth && len(children) > 0; depth++ {
+		var parents []string
+		for _, groupDN := range children {
+			found, err := l.searchGroups(conn, groupRDNValue(groupDN), groupDN)
+			if err != nil {
+				return nil, err
+			}
+			for _, parent := range found {
+				if !seen.Contains(parent) {
+					seen.Add(parent)
+					parents = append(parents, parent)
+				}
+			}
+		}
+		groups = append(groups, parents...)
+		children = parents
+	}
+	return groups, nil
+}
+
+// groupRDNValue returns the value of the first RDN of a DN, the DN
+// itself is returned if it can not be parsed.
+func groupRDNValue(groupDN string) string {
+	dn, err := ldap.ParseDN(groupDN)
+	if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+		return groupDN
+	}
+	return dn.RDNs[0].Attributes[0].Value
+}
+
+// mergeGroups appends the groups of b missing in a.
+func mergeGroups(a, b []string) []string {
+	seen := set.CreateStringSet(a...)
+	for _, group := range b {
+		if !seen.Contains(group) {
+			seen.Add(group)
+			a = append(a, group)
+		}
+	}
+	return a
+}
@@ -24,7 +24,7 @@ var (
 	Help = config.HelpKVS{
 		config.HelpKV{
 			Key:         ServerAddr,
-			Description: `AD/LDAP server address e.g. "myldapserver.com:636"`,
+			Description: `AD/LDAP server address e.g. "myldapserver.com:636", comma separated servers are tried in order e.g. "ldap1.example.com:636,ldap2.example.com:636"`,
 			Type:        "address",
 			Sensitive:   true,
 		},
@@ -42,6 +42,18 @@ var (
 			Type:        "string",
 			Sensitive:   true,
 		},
+		config.HelpKV{
+			Key:         LookupPoolSize,
+			Description: `maximum number of connections of the read-only service account kept open, defaults to "8"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         GroupCacheTTL,
+			Description: `duration the groups of a user are cached for, "0s" disables the cache e.g. "5m"`,
+			Optional:    true,
+			Type:        "duration",
+		},
 		config.HelpKV{
 			Key:         UserDNSearchBaseDN,
 			Description: `Base LDAP DN to search for user DN`,
@@ -66,6 +78,30 @@ var (
 			Optional:    true,
 			Type:        "list",
 		},
+		config.HelpKV{
+			Key:         GroupSearchNested,
+			Description: `resolve nested groups, "in_chain" for Active Directory or "recursive" to repeat the group search for each group, defaults to "off"`,
+			Optional:    true,
+			Type:        "off|in_chain|recursive",
+		},
+		config.HelpKV{
+			Key:         GroupSearchDepth,
+			Description: `maximum nesting depth followed by the "recursive" nested group search, defaults to "5"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         UserPolicyAttributes,
+			Description: `comma separated list of user attributes whose values are policy names e.g. "department"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         UserConditionAttributes,
+			Description: `comma separated list of user attributes available as "${ldap:<attribute>}" policy variables e.g. "department,employeeType"`,
+			Optional:    true,
+			Type:        "csv",
+		},
 		config.HelpKV{
 			Key:         TLSSkipVerify,
 			Description: `trust server TLS without verification, defaults to "off" (verify)`,
@@ -0,0 +1,348 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ldap "github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// Time a server failing to connect is skipped for.
+	serverDownInterval = 30 * time.Second
+
+	// Timeout to establish a connection to a server.
+	serverDialTimeout = 5 * time.Second
+
+	// Time to wait for a lookup connection if the pool is exhausted.
+	poolWaitTimeout = 10 * time.Second
+
+	// Default number of lookup connections kept open.
+	defaultLookupPoolSize = 8
+)
+
+var errPoolTimeout = errors.New("timed out waiting for an LDAP lookup connection")
+
+// server - an LDAP server with its health and request statistics.
+type server struct {
+	// Placed first for 64-bit alignment of atomic operations.
+	requests int64
+	errors   int64
+	latency  int64 // total request latency in nanoseconds
+
+	addr string
+
+	mu        sync.Mutex
+	downUntil time.Time
+}
+
+// online returns false while a server which failed is skipped.
+func (s *server) online() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Now().After(s.downUntil)
+}
+
+func (s *server) markDown() {
+	s.mu.Lock()
+	s.downUntil = time.Now().Add(serverDownInterval)
+	s.mu.Unlock()
+}
+
+// record adds a request to the statistics of the server.
+func (s *server) record(start time.Time, err error) {
+	atomic.AddInt64(&s.requests, 1)
+	atomic.AddInt64(&s.latency, int64(time.Since(start)))
+	if err != nil && !isResultError(err) {
+		atomic.AddInt64(&s.errors, 1)
+	}
+}
+
+// isResultError returns true for errors reported by the server for a
+// valid request, such as a search without results or invalid user
+// credentials, which do not indicate a problem with the server.
+func isResultError(err error) bool {
+	return ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) ||
+		ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials)
+}
+
+func isNetworkError(err error) bool {
+	return ldap.IsErrorWithCode(err, ldap.ErrorNetwork)
+}
+
+// conn - a connection to an LDAP server.
+type conn struct {
+	*ldap.Conn
+	server *server
+}
+
+// connPool - bounded pool of connections bound as the lookup user.
+type connPool struct {
+	sem chan struct{}
+
+	mu   sync.Mutex
+	idle []*conn
+}
+
+func newConnPool(size int) *connPool {
+	return &connPool{sem: make(chan struct{}, size)}
+}
+
+// get returns an idle connection or opens a new one with dial, waits
+// if the maximum number of connections is open.
+func (p *connPool) get(dial func() (*conn, error)) (*conn, error) {
+	select {
+	case p.sem <- struct{}{}:
+	case <-time.After(poolWaitTimeout):
+		return nil, errPoolTimeout
+	}
+
+	p.mu.Lock()
+	for len(p.idle) > 0 {
+		c := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		if !c.IsClosing() && c.server.online() {
+			p.mu.Unlock()
+			return c, nil
+		}
+		c.Close()
+	}
+	p.mu.Unlock()
+
+	c, err := dial()
+	if err != nil {
+		<-p.sem
+		return nil, err
+	}
+	return c, nil
+}
+
+// put returns a connection to the pool, a broken connection is closed.
+func (p *connPool) put(c *conn, broken bool) {
+	if broken || c.IsClosing() {
+		c.Close()
+	} else {
+		p.mu.Lock()
+		p.idle = append(p.idle, c)
+		p.mu.Unlock()
+	}
+	<-p.sem
+}
+
+// open returns the number of open connections, idle or in use.
+func (p *connPool) open() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.sem) + len(p.idle)
+}
+
+// parseServerAddrs parses the comma separated server addresses, the
+// default LDAP port "636" is used if none is specified.
+func parseServerAddrs(serverAddr string) ([]*server, error) {
+	var servers []*server
+	for _, addr := range splitServerAddrs(serverAddr) {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			addr = net.JoinHostPort(addr, "636")
+		}
+		servers = append(servers, &server{addr: addr})
+	}
+	if len(servers) == 0 {
+		return nil, errors.New("LDAP server address is required")
+	}
+	return servers, nil
+}
+
+// splitServerAddrs splits a comma separated list of server addresses.
+func splitServerAddrs(serverAddr string) []string {
+	var addrs []string
+	for _, addr := range strings.Split(serverAddr, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// dial connects to the first online server in the configured order,
+// servers failing to connect are skipped for a while. If no server is
+// online all servers are tried.
+func (l *Config) dial() (*conn, error) {
+	servers := l.servers
+	if len(servers) == 0 {
+		var err error
+		if servers, err = parseServerAddrs(l.ServerAddr); err != nil {
+			return nil, err
+		}
+	}
+
+	var candidates []*server
+	for _, s := range servers {
+		if s.online() {
+			candidates = append(candidates, s)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = servers
+	}
+
+	var errs []error
+	for _, s := range candidates {
+		start := time.Now()
+		ldapConn, err := l.dialServer(s.addr)
+		s.record(start, err)
+		if err != nil {
+			s.markDown()
+			errs = append(errs, fmt.Errorf("%s: %w", s.addr, err))
+			continue
+		}
+		return &conn{Conn: ldapConn, server: s}, nil
+	}
+	if len(errs) == 1 {
+		return nil, errs[0]
+	}
+	return nil, fmt.Errorf("Unable to connect to any LDAP server: %v", errs)
+}
+
+func (l *Config) dialServer(addr string) (*ldap.Conn, error) {
+	dialer := ldap.DialWithDialer(&net.Dialer{Timeout: serverDialTimeout})
+	if l.serverInsecure {
+		return ldap.DialURL("ldap://"+addr, dialer)
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: l.tlsSkipVerify,
+		RootCAs:            l.rootCAs,
+	}
+
+	if l.serverStartTLS {
+		conn, err := ldap.DialURL("ldap://"+addr, dialer)
+		if err != nil {
+			return nil, err
+		}
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return conn, nil
+	}
+
+	return ldap.DialURL("ldaps://"+addr, dialer, ldap.DialWithTLSConfig(tlsConfig))
+}
+
+// dialLookup connects to a server and binds as the lookup user.
+func (l *Config) dialLookup() (*conn, error) {
+	c, err := l.dial()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	err = l.lookupBind(c.Conn)
+	c.server.record(start, err)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// withLookupConn calls fn with a pooled connection bound as the lookup
+// user. If the connection fails with a network error, the server is
+// skipped and fn is retried once with a new connection.
+func (l *Config) withLookupConn(fn func(conn *ldap.Conn) error) error {
+	for attempt := 0; ; attempt++ {
+		var (
+			c   *conn
+			err error
+		)
+		if l.pool != nil {
+			c, err = l.pool.get(l.dialLookup)
+		} else {
+			c, err = l.dialLookup()
+		}
+		if err != nil {
+			return err
+		}
+
+		start := time.Now()
+		err = fn(c.Conn)
+		c.server.record(start, err)
+
+		broken := isNetworkError(err)
+		if broken {
+			c.server.markDown()
+		}
+		if l.pool != nil {
+			l.pool.put(c, broken)
+		} else {
+			c.Close()
+		}
+		if broken && attempt == 0 {
+			continue
+		}
+		return err
+	}
+}
+
+// ServerStats - request statistics of an LDAP server.
+type ServerStats struct {
+	Addr     string
+	Online   bool
+	Requests int64
+	Errors   int64
+	Latency  time.Duration
+}
+
+// Stats - statistics of the LDAP servers, the lookup connection pool
+// and the group cache.
+type Stats struct {
+	Servers          []ServerStats
+	PoolConnections  int
+	GroupCacheHits   int64
+	GroupCacheMisses int64
+}
+
+// Stats returns the statistics of the LDAP servers, the lookup
+// connection pool and the group cache.
+func (l *Config) Stats() Stats {
+	var stats Stats
+	for _, s := range l.servers {
+		stats.Servers = append(stats.Servers, ServerStats{
+			Addr:     s.addr,
+			Online:   s.online(),
+			Requests: atomic.LoadInt64(&s.requests),
+			Errors:   atomic.LoadInt64(&s.errors),
+			Latency:  time.Duration(atomic.LoadInt64(&s.latency)),
+		})
+	}
+	if l.pool != nil {
+		stats.PoolConnections = l.pool.open()
+	}
+	if l.groupCache != nil {
+		stats.GroupCacheHits = atomic.LoadInt64(&l.groupCache.hits)
+		stats.GroupCacheMisses = atomic.LoadInt64(&l.groupCache.misses)
+	}
+	return stats
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseServerAddrs(t *testing.T) {
+	testCases := []struct {
+		serverAddr string
+		expected   []string
+		success    bool
+	}{
+		{"", nil, false},
+		{" , ", nil, false},
+		{"ldap.example.com", []string{"ldap.example.com:636"}, true},
+		{"ldap.example.com:389", []string{"ldap.example.com:389"}, true},
+		{
+			"ldap1.example.com:389, ldap2.example.com",
+			[]string{"ldap1.example.com:389", "ldap2.example.com:636"},
+			true,
+		},
+	}
+	for i, testCase := range testCases {
+		servers, err := parseServerAddrs(testCase.serverAddr)
+		if err != nil && testCase.success {
+			t.Errorf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+		var addrs []string
+		for _, server := range servers {
+			addrs = append(addrs, server.addr)
+		}
+		if !reflect.DeepEqual(addrs, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, addrs)
+		}
+	}
+}
+
+func TestServerMarkDown(t *testing.T) {
+	s := &server{addr: "ldap.example.com:636"}
+	if !s.online() {
+		t.Fatal("expected server to be online")
+	}
+	s.markDown()
+	if s.online() {
+		t.Fatal("expected server to be skipped after markDown")
+	}
+}
+
+func TestGroupCache(t *testing.T) {
+	c := newGroupCache(time.Hour)
+	if _, ok := c.get("uid=alice,dc=example,dc=com"); ok {
+		t.Fatal("expected a miss on an empty cache")
+	}
+	groups := []string{"cn=devs,dc=example,dc=com"}
+	c.set("uid=alice,dc=example,dc=com", groups)
+	cached, ok := c.get("uid=alice,dc=example,dc=com")
+	if !ok || !reflect.DeepEqual(cached, groups) {
+		t.Fatalf("expected %v, got %v", groups, cached)
+	}
+	if c.hits != 1 || c.misses != 1 {
+		t.Fatalf("expected 1 hit and 1 miss, got %d and %d", c.hits, c.misses)
+	}
+
+	c = newGroupCache(time.Nanosecond)
+	c.set("uid=alice,dc=example,dc=com", groups)
+	time.Sleep(time.Millisecond)
+	if _, ok := c.get("uid=alice,dc=example,dc=com"); ok {
+		t.Fatal("expected expired entry to be a miss")
+	}
+	if len(c.entries) != 0 {
+		t.Fatal("expected expired entry to be removed")
+	}
+}
@@ -68,6 +68,30 @@ var (
 			Optional:    true,
 			Type:        "csv",
 		},
+		config.HelpKV{
+			Key:         RolePolicy,
+			Description: `policy applied to all users of this provider, 'claim_name' is ignored if set e.g. "readonly"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Roles,
+			Description: `comma separated roles assumed by passing their ARN as RoleArn e.g. "readers=readonly,writers=readwrite"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         RolePolicyCondition,
+			Description: `claim value required in the token to be granted 'role_policy' e.g. "groups:minio-users"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         RoleConditions,
+			Description: `comma separated claim values required in the token to assume the roles e.g. "readers=groups:readers,writers=aud:minio-writers"`,
+			Optional:    true,
+			Type:        "csv",
+		},
 		config.HelpKV{
 			Key:         Vendor,
 			Description: `Specify vendor type for vendor specific behavior to checking validity of temporary credentials and service accounts on MinIO`,
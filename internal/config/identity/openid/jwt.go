@@ -43,7 +43,8 @@ import (
 type Config struct {
 	*sync.RWMutex
 
-	Enabled bool `json:"enabled"`
+	Enabled bool   `json:"enabled"`
+	Name    string `json:"name,omitempty"`
 	JWKS    struct {
 		URL *xnet.URL `json:"url"`
 	} `json:"jwks"`
@@ -52,10 +53,19 @@ type Config struct {
 	ClaimName     string    `json:"claimName,omitempty"`
 	ClaimUserinfo bool      `json:"claimUserInfo,omitempty"`
 	RedirectURI   string    `json:"redirectURI,omitempty"`
+	RolePolicy    string    `json:"rolePolicy,omitempty"`
 	DiscoveryDoc  DiscoveryDoc
 	ClientID      string
 	ClientSecret  string
 
+	// RolePolicies holds the policy of each role by role name.
+	RolePolicies map[string]string `json:"rolePolicies,omitempty"`
+
+	// RolePolicyCondition and RoleConditions hold the trust
+	// conditions of the role policy and of the roles by role name.
+	RolePolicyCondition *RoleCondition           `json:"rolePolicyCondition,omitempty"`
+	RoleConditions      map[string]RoleCondition `json:"roleConditions,omitempty"`
+
 	provider    provider.Provider
 	publicKeys  map[string]crypto.PublicKey
 	transport   *http.Transport
@@ -146,15 +156,15 @@ const (
 // InitializeProvider initializes if any additional vendor specific
 // information was provided, initialization will return an error
 // initial login fails.
-func (r Config) InitializeProvider(kvs config.KVS) error {
-	vendor := env.Get(EnvIdentityOpenIDVendor, kvs.Get(Vendor))
+func (r *Config) InitializeProvider(kvs config.KVS) error {
+	vendor := env.Get(targetEnv(EnvIdentityOpenIDVendor, r.Name), kvs.Get(Vendor))
 	if vendor == "" {
 		return nil
 	}
 	switch vendor {
 	case keyCloakVendor:
-		adminURL := env.Get(EnvIdentityOpenIDKeyCloakAdminURL, kvs.Get(KeyCloakAdminURL))
-		realm := env.Get(EnvIdentityOpenIDKeyCloakRealm, kvs.Get(KeyCloakRealm))
+		adminURL := env.Get(targetEnv(EnvIdentityOpenIDKeyCloakAdminURL, r.Name), kvs.Get(KeyCloakAdminURL))
+		realm := env.Get(targetEnv(EnvIdentityOpenIDKeyCloakRealm, r.Name), kvs.Get(KeyCloakRealm))
 		return r.InitializeKeycloakProvider(adminURL, realm)
 	default:
 		return fmt.Errorf("Unsupport vendor %s", keyCloakVendor)
@@ -369,6 +379,11 @@ const (
 	Vendor      = "vendor"
 	Scopes      = "scopes"
 	RedirectURI = "redirect_uri"
+	RolePolicy  = "role_policy"
+	Roles       = "roles"
+
+	RolePolicyCondition = "role_policy_condition"
+	RoleConditions      = "role_conditions"
 
 	// Vendor specific ENV only enabled if the Vendor matches == "vendor"
 	KeyCloakRealm    = "keycloak_realm"
@@ -383,6 +398,11 @@ const (
 	EnvIdentityOpenIDClaimPrefix   = "MINIO_IDENTITY_OPENID_CLAIM_PREFIX"
 	EnvIdentityOpenIDRedirectURI   = "MINIO_IDENTITY_OPENID_REDIRECT_URI"
 	EnvIdentityOpenIDScopes        = "MINIO_IDENTITY_OPENID_SCOPES"
+	EnvIdentityOpenIDRolePolicy    = "MINIO_IDENTITY_OPENID_ROLE_POLICY"
+	EnvIdentityOpenIDRoles         = "MINIO_IDENTITY_OPENID_ROLES"
+
+	EnvIdentityOpenIDRolePolicyCondition = "MINIO_IDENTITY_OPENID_ROLE_POLICY_CONDITION"
+	EnvIdentityOpenIDRoleConditions      = "MINIO_IDENTITY_OPENID_ROLE_CONDITIONS"
 
 	// Vendor specific ENVs only enabled if the Vendor matches == "vendor"
 	EnvIdentityOpenIDKeyCloakRealm    = "MINIO_IDENTITY_OPENID_KEYCLOAK_REALM"
@@ -467,6 +487,22 @@ var (
 			Key:   Scopes,
 			Value: "",
 		},
+		config.KV{
+			Key:   RolePolicy,
+			Value: "",
+		},
+		config.KV{
+			Key:   Roles,
+			Value: "",
+		},
+		config.KV{
+			Key:   RolePolicyCondition,
+			Value: "",
+		},
+		config.KV{
+			Key:   RoleConditions,
+			Value: "",
+		},
 	}
 )
 
@@ -475,8 +511,23 @@ func Enabled(kvs config.KVS) bool {
 	return kvs.Get(ConfigURL) != ""
 }
 
+// targetEnv - returns the name of an environment variable for the
+// named provider, e.g. MINIO_IDENTITY_OPENID_CONFIG_URL_KEYCLOAK.
+func targetEnv(name, target string) string {
+	if target == "" || target == config.Default {
+		return name
+	}
+	return name + config.Default + target
+}
+
 // LookupConfig lookup jwks from config, override with any ENVs.
 func LookupConfig(kvs config.KVS, transport *http.Transport, closeRespFn func(io.ReadCloser)) (c Config, err error) {
+	return lookupConfig(kvs, config.Default, transport, closeRespFn)
+}
+
+// lookupConfig lookup the config of the named provider, override
+// with any ENVs of the provider.
+func lookupConfig(kvs config.KVS, target string, transport *http.Transport, closeRespFn func(io.ReadCloser)) (c Config, err error) {
 	// remove this since we have removed this already.
 	kvs.Delete(JwksURL)
 
@@ -486,18 +537,41 @@ func LookupConfig(kvs config.KVS, transport *http.Transport, closeRespFn func(io
 
 	c = Config{
 		RWMutex:       &sync.RWMutex{},
-		ClaimName:     env.Get(EnvIdentityOpenIDClaimName, kvs.Get(ClaimName)),
-		ClaimUserinfo: env.Get(EnvIdentityOpenIDClaimUserInfo, kvs.Get(ClaimUserinfo)) == config.EnableOn,
-		ClaimPrefix:   env.Get(EnvIdentityOpenIDClaimPrefix, kvs.Get(ClaimPrefix)),
-		RedirectURI:   env.Get(EnvIdentityOpenIDRedirectURI, kvs.Get(RedirectURI)),
+		Name:          target,
+		ClaimName:     env.Get(targetEnv(EnvIdentityOpenIDClaimName, target), kvs.Get(ClaimName)),
+		ClaimUserinfo: env.Get(targetEnv(EnvIdentityOpenIDClaimUserInfo, target), kvs.Get(ClaimUserinfo)) == config.EnableOn,
+		ClaimPrefix:   env.Get(targetEnv(EnvIdentityOpenIDClaimPrefix, target), kvs.Get(ClaimPrefix)),
+		RedirectURI:   env.Get(targetEnv(EnvIdentityOpenIDRedirectURI, target), kvs.Get(RedirectURI)),
+		RolePolicy:    env.Get(targetEnv(EnvIdentityOpenIDRolePolicy, target), kvs.Get(RolePolicy)),
 		publicKeys:    make(map[string]crypto.PublicKey),
-		ClientID:      env.Get(EnvIdentityOpenIDClientID, kvs.Get(ClientID)),
-		ClientSecret:  env.Get(EnvIdentityOpenIDClientSecret, kvs.Get(ClientSecret)),
+		ClientID:      env.Get(targetEnv(EnvIdentityOpenIDClientID, target), kvs.Get(ClientID)),
+		ClientSecret:  env.Get(targetEnv(EnvIdentityOpenIDClientSecret, target), kvs.Get(ClientSecret)),
 		transport:     transport,
 		closeRespFn:   closeRespFn,
 	}
 
-	configURL := env.Get(EnvIdentityOpenIDURL, kvs.Get(ConfigURL))
+	c.RolePolicies, err = parseRoles(env.Get(targetEnv(EnvIdentityOpenIDRoles, target), kvs.Get(Roles)))
+	if err != nil {
+		return c, err
+	}
+
+	if value := env.Get(targetEnv(EnvIdentityOpenIDRolePolicyCondition, target), kvs.Get(RolePolicyCondition)); value != "" {
+		if c.RolePolicy == "" {
+			return c, config.Errorf("'%s' requires '%s' to be set", RolePolicyCondition, RolePolicy)
+		}
+		condition, err := parseRoleCondition(value)
+		if err != nil {
+			return c, err
+		}
+		c.RolePolicyCondition = &condition
+	}
+
+	c.RoleConditions, err = parseRoleConditions(env.Get(targetEnv(EnvIdentityOpenIDRoleConditions, target), kvs.Get(RoleConditions)), c.RolePolicies)
+	if err != nil {
+		return c, err
+	}
+
+	configURL := env.Get(targetEnv(EnvIdentityOpenIDURL, target), kvs.Get(ConfigURL))
 	if configURL != "" {
 		c.URL, err = xnet.ParseHTTPURL(configURL)
 		if err != nil {
@@ -513,7 +587,7 @@ func LookupConfig(kvs config.KVS, transport *http.Transport, closeRespFn func(io
 		return c, errors.New("please specify config_url to enable fetching claims from UserInfo endpoint")
 	}
 
-	if scopeList := env.Get(EnvIdentityOpenIDScopes, kvs.Get(Scopes)); scopeList != "" {
+	if scopeList := env.Get(targetEnv(EnvIdentityOpenIDScopes, target), kvs.Get(Scopes)); scopeList != "" {
 		var scopes []string
 		for _, scope := range strings.Split(scopeList, ",") {
 			scope = strings.TrimSpace(scope)
@@ -0,0 +1,175 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	jwtgo "github.com/golang-jwt/jwt/v4"
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/config/identity/openid/provider"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// Providers - holds the configured OpenID providers indexed by
+// their 'identity_openid:<name>' target name, the provider
+// configured as 'identity_openid' is named config.Default.
+type Providers map[string]Config
+
+var errNoProvider = errors.New("openid not configured")
+
+// LookupProviders lookup the configs of all OpenID providers, override
+// with any ENVs. The default provider is always present, a provider
+// without config_url is returned but not enabled.
+func LookupProviders(kvsMap map[string]config.KVS, transport *http.Transport, closeRespFn func(io.ReadCloser)) (Providers, error) {
+	providers := make(Providers)
+	targets := config.Merge(kvsMap, EnvIdentityOpenIDURL, DefaultKVS)
+	if _, ok := targets[config.Default]; !ok {
+		targets[config.Default] = DefaultKVS
+	}
+	names := make([]string, 0, len(targets))
+	for target := range targets {
+		if target != config.Default {
+			names = append(names, target)
+		}
+	}
+	sort.Strings(names)
+
+	// The default provider is looked up first, so that it is
+	// present even if a named provider fails.
+	for _, target := range append([]string{config.Default}, names...) {
+		c, err := lookupConfig(targets[target], target, transport, closeRespFn)
+		providers[target] = c
+		if err != nil {
+			if target != config.Default {
+				err = fmt.Errorf("identity_openid:%s: %w", target, err)
+			}
+			return providers, err
+		}
+	}
+
+	// Tokens must map to exactly one provider.
+	for _, name := range providers.enabled() {
+		for _, other := range providers.enabled() {
+			if name >= other {
+				continue
+			}
+			p, o := providers[name], providers[other]
+			if p.DiscoveryDoc.Issuer == o.DiscoveryDoc.Issuer && p.ClientID == o.ClientID {
+				return providers, config.Errorf("openid providers '%s' and '%s' have the same issuer and client_id", name, other)
+			}
+		}
+	}
+	return providers, nil
+}
+
+// enabled returns the sorted names of the enabled providers.
+func (p Providers) enabled() []string {
+	var names []string
+	for name, c := range p {
+		if c.Enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Enabled returns true if any provider is enabled.
+func (p Providers) Enabled() bool {
+	return len(p.enabled()) > 0
+}
+
+// ProviderEnabled returns true if any provider has vendor
+// specific behavior enabled.
+func (p Providers) ProviderEnabled() bool {
+	for _, c := range p {
+		if c.ProviderEnabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// Select returns the provider which issued the token. With a single
+// enabled provider it is always selected, otherwise the provider is
+// selected by the token issuer and, if the issuer is shared by several
+// providers or unknown, by the token audience or authorized party.
+// The token is not validated, the selected provider must validate it.
+func (p Providers) Select(token string) (Config, error) {
+	names := p.enabled()
+	switch len(names) {
+	case 0:
+		return Config{}, errNoProvider
+	case 1:
+		return p[names[0]], nil
+	}
+
+	var claims jwtgo.MapClaims
+	if _, _, err := new(jwtgo.Parser).ParseUnverified(token, &claims); err != nil {
+		return Config{}, err
+	}
+	iss, _ := claims["iss"].(string)
+
+	var candidates []string
+	for _, name := range names {
+		if p[name].DiscoveryDoc.Issuer == iss {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) == 1 {
+		return p[candidates[0]], nil
+	}
+	if len(candidates) == 0 {
+		candidates = names
+	}
+
+	audValues, _ := iampolicy.GetValuesFromClaims(claims, "aud")
+	azpValues, _ := iampolicy.GetValuesFromClaims(claims, "azp")
+	var matched []string
+	for _, name := range candidates {
+		clientID := p[name].ClientID
+		if audValues.Contains(clientID) || azpValues.Contains(clientID) {
+			matched = append(matched, name)
+		}
+	}
+	if len(matched) != 1 {
+		return Config{}, fmt.Errorf("no unique openid provider found for token issuer '%s'", iss)
+	}
+	return p[matched[0]], nil
+}
+
+// LookupUser lookup userid at the provider with the given issuer, with
+// a single enabled provider it is used regardless of the issuer.
+func (p Providers) LookupUser(userid, issuer string) (provider.User, error) {
+	names := p.enabled()
+	if len(names) == 1 {
+		return p[names[0]].LookupUser(userid)
+	}
+	for _, name := range names {
+		if p[name].DiscoveryDoc.Issuer == issuer {
+			return p[name].LookupUser(userid)
+		}
+	}
+	// Users of unknown providers are not validated.
+	return provider.User{ID: userid, Enabled: true}, nil
+}
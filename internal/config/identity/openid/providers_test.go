@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"os"
+	"testing"
+
+	jwtg "github.com/golang-jwt/jwt/v4"
+	"github.com/minio/minio/internal/config"
+)
+
+func newTestToken(t *testing.T, claims jwtg.MapClaims) string {
+	t.Helper()
+	token, err := jwtg.NewWithClaims(jwtg.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func newTestProvider(name, issuer, clientID string) Config {
+	c := Config{Enabled: true, Name: name, ClientID: clientID}
+	c.DiscoveryDoc.Issuer = issuer
+	return c
+}
+
+func TestProvidersSelect(t *testing.T) {
+	providers := Providers{
+		config.Default: Config{},
+		"keycloak":     newTestProvider("keycloak", "https://keycloak.example.com/realms/staff", "minio"),
+		"azure":        newTestProvider("azure", "https://login.microsoftonline.com/tenant/v2.0", "minio-partners"),
+		"azure2":       newTestProvider("azure2", "https://login.microsoftonline.com/tenant/v2.0", "minio-apps"),
+	}
+
+	testCases := []struct {
+		claims   jwtg.MapClaims
+		expected string
+	}{
+		// Selected by issuer.
+		{jwtg.MapClaims{"iss": "https://keycloak.example.com/realms/staff", "aud": "other"}, "keycloak"},
+		// Shared issuer, selected by audience.
+		{jwtg.MapClaims{"iss": "https://login.microsoftonline.com/tenant/v2.0", "aud": "minio-partners"}, "azure"},
+		{jwtg.MapClaims{"iss": "https://login.microsoftonline.com/tenant/v2.0", "aud": []string{"x", "minio-apps"}}, "azure2"},
+		// Shared issuer, selected by authorized party.
+		{jwtg.MapClaims{"iss": "https://login.microsoftonline.com/tenant/v2.0", "aud": "x", "azp": "minio-apps"}, "azure2"},
+		// Unknown issuer, selected by audience.
+		{jwtg.MapClaims{"iss": "https://unknown.example.com", "aud": "minio"}, "keycloak"},
+		// No match.
+		{jwtg.MapClaims{"iss": "https://login.microsoftonline.com/tenant/v2.0", "aud": "minio"}, ""},
+		{jwtg.MapClaims{"iss": "https://unknown.example.com", "aud": "unknown"}, ""},
+	}
+
+	for i, testCase := range testCases {
+		p, err := providers.Select(newTestToken(t, testCase.claims))
+		if testCase.expected == "" {
+			if err == nil {
+				t.Errorf("test %d: expected error, selected %s", i+1, p.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		if p.Name != testCase.expected {
+			t.Errorf("test %d: expected %s, got %s", i+1, testCase.expected, p.Name)
+		}
+	}
+
+	if _, err := providers.Select("invalid"); err == nil {
+		t.Error("expected error for malformed token")
+	}
+}
+
+func TestProvidersSelectSingle(t *testing.T) {
+	providers := Providers{
+		config.Default: newTestProvider(config.Default, "https://keycloak.example.com/realms/staff", "minio"),
+	}
+	// A single provider is selected without inspecting the token.
+	p, err := providers.Select("invalid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != config.Default {
+		t.Errorf("expected default provider, got %s", p.Name)
+	}
+
+	if _, err = (Providers{config.Default: Config{}}).Select("invalid"); err != errNoProvider {
+		t.Errorf("expected %v, got %v", errNoProvider, err)
+	}
+}
+
+func TestLookupProviders(t *testing.T) {
+	os.Setenv("MINIO_IDENTITY_OPENID_ROLE_POLICY_partners", "readonly")
+	defer os.Unsetenv("MINIO_IDENTITY_OPENID_ROLE_POLICY_partners")
+
+	kvsMap := map[string]config.KVS{
+		"partners": {
+			config.KV{Key: ClaimName, Value: "groups"},
+			config.KV{Key: RolePolicy, Value: "writeonly"},
+		},
+	}
+	providers, err := LookupProviders(kvsMap, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := providers[config.Default]; !ok {
+		t.Fatal("expected the default provider to be present")
+	}
+	if providers[config.Default].ClaimName != "policy" {
+		t.Errorf("expected default claim name, got %s", providers[config.Default].ClaimName)
+	}
+	p := providers["partners"]
+	if p.Name != "partners" || p.ClaimName != "groups" || p.RolePolicy != "readonly" {
+		t.Errorf("unexpected provider %#v", p)
+	}
+	if providers.Enabled() {
+		t.Error("expected no provider to be enabled without config_url")
+	}
+
+	kvsMap["partners"] = config.KVS{config.KV{Key: "unknown", Value: "x"}}
+	if _, err = LookupProviders(kvsMap, nil, nil); err == nil {
+		t.Error("expected error for invalid key")
+	}
+}
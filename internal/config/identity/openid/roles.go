@@ -0,0 +1,194 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/minio/minio/internal/config"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// roleARNPrefix - prefix of the ARNs of the roles of OpenID providers,
+// followed by the provider name and, for roles configured with 'roles',
+// the role name e.g. "arn:minio:iam:::role/partners/readers".
+const roleARNPrefix = "arn:minio:iam:::role/"
+
+// validRoleName - role names follow the AWS IAM role name rules.
+var validRoleName = regexp.MustCompile(`^[\w+.@-]{1,64}$`)
+
+var (
+	errRoleNotFound = errors.New("RoleArn does not match any role of the configured openid providers")
+
+	// ErrRoleNotTrusted - the token does not satisfy the trust condition of the role.
+	ErrRoleNotTrusted = errors.New("the token does not satisfy the trust condition of the role")
+)
+
+// Role - a policy attached to an OpenID provider, assumed by passing
+// its ARN as RoleArn to AssumeRoleWithWebIdentity or
+// AssumeRoleWithClientGrants.
+type Role struct {
+	ARN    string `json:"arn"`
+	Policy string `json:"policy"`
+	// Condition must be satisfied by the token to assume the role,
+	// a role without a condition is trusted by every token.
+	Condition *RoleCondition `json:"condition,omitempty"`
+}
+
+// RoleCondition - a value required in a claim of the token, e.g. a
+// group in the "groups" claim or an audience in the "aud" claim.
+type RoleCondition struct {
+	Claim string `json:"claim"`
+	Value string `json:"value"`
+}
+
+// Trusts returns an error unless the verified claims of a token
+// satisfy the condition of the role.
+func (r Role) Trusts(claims map[string]interface{}) error {
+	if r.Condition == nil {
+		return nil
+	}
+	values, ok := iampolicy.GetValuesFromClaims(claims, r.Condition.Claim)
+	if !ok || !values.Contains(r.Condition.Value) {
+		return ErrRoleNotTrusted
+	}
+	return nil
+}
+
+// RoleARN returns the ARN of a role of a provider, the ARN of the
+// 'role_policy' of the provider for an empty role name.
+func RoleARN(provider, role string) string {
+	if role == "" {
+		return roleARNPrefix + provider
+	}
+	return roleARNPrefix + provider + "/" + role
+}
+
+// parseRoles parses the 'roles' value, a comma separated list of
+// role names with the policy of the role e.g. "readers=readonly".
+func parseRoles(value string) (map[string]string, error) {
+	roles := make(map[string]string)
+	for _, role := range strings.Split(value, config.ValueSeparator) {
+		role = strings.TrimSpace(role)
+		if role == "" {
+			continue
+		}
+		kv := strings.SplitN(role, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, config.Errorf("invalid role '%s', expected '<role>=<policy>'", role)
+		}
+		if !validRoleName.MatchString(kv[0]) {
+			return nil, config.Errorf("invalid role name '%s'", kv[0])
+		}
+		if _, ok := roles[kv[0]]; ok {
+			return nil, config.Errorf("duplicate role name '%s'", kv[0])
+		}
+		roles[kv[0]] = kv[1]
+	}
+	return roles, nil
+}
+
+// parseRoleCondition parses a role condition, the claim and
+// the required value of the claim e.g. "groups:readers".
+func parseRoleCondition(value string) (RoleCondition, error) {
+	kv := strings.SplitN(strings.TrimSpace(value), ":", 2)
+	if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+		return RoleCondition{}, config.Errorf("invalid role condition '%s', expected '<claim>:<value>'", value)
+	}
+	return RoleCondition{Claim: kv[0], Value: kv[1]}, nil
+}
+
+// parseRoleConditions parses the 'role_conditions' value, a comma
+// separated list of role names with the condition of the role e.g.
+// "readers=groups:readers".
+func parseRoleConditions(value string, roles map[string]string) (map[string]RoleCondition, error) {
+	conditions := make(map[string]RoleCondition)
+	for _, role := range strings.Split(value, config.ValueSeparator) {
+		role = strings.TrimSpace(role)
+		if role == "" {
+			continue
+		}
+		kv := strings.SplitN(role, "=", 2)
+		if len(kv) != 2 {
+			return nil, config.Errorf("invalid role condition '%s', expected '<role>=<claim>:<value>'", role)
+		}
+		if _, ok := roles[kv[0]]; !ok {
+			return nil, config.Errorf("role condition of unknown role '%s'", kv[0])
+		}
+		if _, ok := conditions[kv[0]]; ok {
+			return nil, config.Errorf("duplicate role condition of role '%s'", kv[0])
+		}
+		condition, err := parseRoleCondition(kv[1])
+		if err != nil {
+			return nil, err
+		}
+		conditions[kv[0]] = condition
+	}
+	return conditions, nil
+}
+
+// RolePolicyRole returns the role of the 'role_policy' of the
+// provider, applied to the tokens issued by the provider when
+// no RoleArn is passed.
+func (r Config) RolePolicyRole() Role {
+	return Role{ARN: RoleARN(r.Name, ""), Policy: r.RolePolicy, Condition: r.RolePolicyCondition}
+}
+
+// Roles returns the roles of the provider sorted by ARN.
+func (r Config) Roles() []Role {
+	var roles []Role
+	if r.RolePolicy != "" {
+		roles = append(roles, r.RolePolicyRole())
+	}
+	for name, policy := range r.RolePolicies {
+		role := Role{ARN: RoleARN(r.Name, name), Policy: policy}
+		if condition, ok := r.RoleConditions[name]; ok {
+			role.Condition = &condition
+		}
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].ARN < roles[j].ARN
+	})
+	return roles
+}
+
+// Roles returns the roles of all enabled providers sorted by ARN.
+func (p Providers) Roles() []Role {
+	var roles []Role
+	for _, name := range p.enabled() {
+		roles = append(roles, p[name].Roles()...)
+	}
+	return roles
+}
+
+// SelectRole returns the provider and the role with the given ARN, the
+// verified claims of the token must satisfy the condition of the role.
+func (p Providers) SelectRole(arn string) (Config, Role, error) {
+	for _, name := range p.enabled() {
+		for _, role := range p[name].Roles() {
+			if role.ARN == arn {
+				return p[name], role, nil
+			}
+		}
+	}
+	return Config{}, Role{}, errRoleNotFound
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestParseRoles(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected map[string]string
+		success  bool
+	}{
+		{"", map[string]string{}, true},
+		{"readers=readonly", map[string]string{"readers": "readonly"}, true},
+		{"readers=readonly, writers=readwrite", map[string]string{"readers": "readonly", "writers": "readwrite"}, true},
+		{"readers", nil, false},
+		{"readers=", nil, false},
+		{"=readonly", nil, false},
+		{"read/ers=readonly", nil, false},
+		{"readers=readonly,readers=readwrite", nil, false},
+	}
+
+	for i, testCase := range testCases {
+		roles, err := parseRoles(testCase.value)
+		if testCase.success != (err == nil) {
+			t.Errorf("test %d: expected success %v, got %v", i+1, testCase.success, err)
+			continue
+		}
+		if testCase.success && !reflect.DeepEqual(roles, testCase.expected) {
+			t.Errorf("test %d: expected %v, got %v", i+1, testCase.expected, roles)
+		}
+	}
+}
+
+func TestProvidersSelectRole(t *testing.T) {
+	partners := newTestProvider("partners", "https://login.microsoftonline.com/tenant/v2.0", "minio-partners")
+	partners.RolePolicies = map[string]string{"readers": "readonly", "writers": "readwrite"}
+	partners.RoleConditions = map[string]RoleCondition{"writers": {Claim: "groups", Value: "writers"}}
+	staff := newTestProvider(config.Default, "https://keycloak.example.com/realms/staff", "minio")
+	staff.RolePolicy = "consoleAdmin"
+	disabled := Config{Name: "disabled", RolePolicy: "readwrite"}
+
+	providers := Providers{
+		config.Default: staff,
+		"partners":     partners,
+		"disabled":     disabled,
+	}
+
+	expectedRoles := []Role{
+		{ARN: "arn:minio:iam:::role/_", Policy: "consoleAdmin"},
+		{ARN: "arn:minio:iam:::role/partners/readers", Policy: "readonly"},
+		{ARN: "arn:minio:iam:::role/partners/writers", Policy: "readwrite", Condition: &RoleCondition{Claim: "groups", Value: "writers"}},
+	}
+	if roles := providers.Roles(); !reflect.DeepEqual(roles, expectedRoles) {
+		t.Errorf("expected %v, got %v", expectedRoles, roles)
+	}
+
+	for _, role := range expectedRoles {
+		p, selected, err := providers.SelectRole(role.ARN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(selected, role) {
+			t.Errorf("%s: expected role %v, got %v", role.ARN, role, selected)
+		}
+		if RoleARN(p.Name, "") != role.ARN && p.Name != "partners" {
+			t.Errorf("%s: unexpected provider %s", role.ARN, p.Name)
+		}
+	}
+
+	for _, arn := range []string{
+		"arn:minio:iam:::role/disabled",
+		"arn:minio:iam:::role/partners",
+		"arn:minio:iam:::role/partners/unknown",
+	} {
+		if _, _, err := providers.SelectRole(arn); err != errRoleNotFound {
+			t.Errorf("%s: expected %v, got %v", arn, errRoleNotFound, err)
+		}
+	}
+}
+
+func TestParseRoleConditions(t *testing.T) {
+	roles := map[string]string{"readers": "readonly", "writers": "readwrite"}
+	testCases := []struct {
+		value    string
+		expected map[string]RoleCondition
+		success  bool
+	}{
+		{"", map[string]RoleCondition{}, true},
+		{"writers=groups:writers", map[string]RoleCondition{"writers": {Claim: "groups", Value: "writers"}}, true},
+		{"readers=aud:minio, writers=groups:a:b", map[string]RoleCondition{
+			"readers": {Claim: "aud", Value: "minio"},
+			"writers": {Claim: "groups", Value: "a:b"},
+		}, true},
+		{"writers", nil, false},
+		{"writers=groups", nil, false},
+		{"writers=:writers", nil, false},
+		{"writers=groups:", nil, false},
+		{"admins=groups:admins", nil, false},
+		{"writers=groups:a,writers=groups:b", nil, false},
+	}
+
+	for i, testCase := range testCases {
+		conditions, err := parseRoleConditions(testCase.value, roles)
+		if testCase.success != (err == nil) {
+			t.Errorf("test %d: expected success %v, got %v", i+1, testCase.success, err)
+			continue
+		}
+		if testCase.success && !reflect.DeepEqual(conditions, testCase.expected) {
+			t.Errorf("test %d: expected %v, got %v", i+1, testCase.expected, conditions)
+		}
+	}
+}
+
+func TestRoleTrusts(t *testing.T) {
+	role := Role{ARN: RoleARN("partners", "writers"), Policy: "readwrite"}
+	if err := role.Trusts(map[string]interface{}{}); err != nil {
+		t.Errorf("expected a role without a condition to be trusted, got %v", err)
+	}
+
+	role.Condition = &RoleCondition{Claim: "groups", Value: "writers"}
+	testCases := []struct {
+		claims  map[string]interface{}
+		trusted bool
+	}{
+		{map[string]interface{}{"groups": []interface{}{"readers", "writers"}}, true},
+		{map[string]interface{}{"groups": "writers"}, true},
+		{map[string]interface{}{"groups": []interface{}{"readers"}}, false},
+		{map[string]interface{}{"aud": "writers"}, false},
+		{map[string]interface{}{}, false},
+	}
+	for i, testCase := range testCases {
+		err := role.Trusts(testCase.claims)
+		if testCase.trusted && err != nil {
+			t.Errorf("test %d: expected the role to be trusted, got %v", i+1, err)
+		}
+		if !testCase.trusted && err != ErrRoleNotTrusted {
+			t.Errorf("test %d: expected %v, got %v", i+1, ErrRoleNotTrusted, err)
+		}
+	}
+}
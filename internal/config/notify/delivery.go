@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package notify
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Delivery config constants.
+const (
+	DeadLetterBucket = "dead_letter_bucket"
+	RetryBudget      = "retry_budget"
+
+	EnvDeadLetterBucket = "MINIO_NOTIFY_DEAD_LETTER_BUCKET"
+	EnvRetryBudget      = "MINIO_NOTIFY_RETRY_BUDGET"
+)
+
+// DeliveryConfig - what happens to bucket notifications which cannot
+// be delivered to their targets.
+type DeliveryConfig struct {
+	// DeadLetterBucket, if set, stores the discarded notifications.
+	DeadLetterBucket string `json:"dead_letter_bucket"`
+	// RetryBudget is the number of failed deliveries after which a
+	// queued notification is discarded, zero retries forever.
+	RetryBudget int `json:"retry_budget"`
+}
+
+var (
+	// DefaultDeliveryKVS - default KV config for notification delivery
+	DefaultDeliveryKVS = config.KVS{
+		config.KV{
+			Key:   DeadLetterBucket,
+			Value: "",
+		},
+		config.KV{
+			Key:   RetryBudget,
+			Value: "0",
+		},
+	}
+
+	// HelpDelivery provides help for notification delivery config values
+	HelpDelivery = config.HelpKVS{
+		config.HelpKV{
+			Key:         DeadLetterBucket,
+			Description: `set a bucket to store bucket notifications which could not be delivered, disabled by default`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         RetryBudget,
+			Description: `set the number of failed deliveries after which a queued bucket notification is discarded, defaults to "0" (retry forever)`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+)
+
+// LookupDeliveryConfig - lookup notification delivery config and
+// override with valid environment settings if any.
+func LookupDeliveryConfig(kvs config.KVS) (cfg DeliveryConfig, err error) {
+	if err = config.CheckValidKeys(config.NotifySubSys, kvs, DefaultDeliveryKVS); err != nil {
+		return cfg, err
+	}
+	cfg.DeadLetterBucket = env.Get(EnvDeadLetterBucket, kvs.GetWithDefault(DeadLetterBucket, DefaultDeliveryKVS))
+	cfg.RetryBudget, err = strconv.Atoi(env.Get(EnvRetryBudget, kvs.GetWithDefault(RetryBudget, DefaultDeliveryKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'notify:retry_budget' value invalid: %w", err)
+	}
+	if cfg.RetryBudget < 0 {
+		return cfg, config.Errorf("'notify:retry_budget' must not be negative")
+	}
+	return cfg, nil
+}
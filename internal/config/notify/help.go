@@ -76,6 +76,24 @@ var (
 			Type:        "string",
 			Sensitive:   true,
 		},
+		config.HelpKV{
+			Key:         target.WebhookBatchSize,
+			Description: "number of events sent per request as a JSON array, requires 'queue_dir', defaults to '1'",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         target.WebhookFlushInterval,
+			Description: "maximum time to wait before sending an incomplete batch, defaults to '1s'",
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.WebhookCompression,
+			Description: "compress requests with 'gzip' or 'zstd', defaults to 'none'",
+			Optional:    true,
+			Type:        "string",
+		},
 	}
 
 	HelpAMQP = config.HelpKVS{
@@ -254,6 +272,18 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         target.KafkaMessageKey,
+			Description: `message key template using ${bucket}, ${object} and ${event}, defaults to "${bucket}/${object}"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.KafkaIdempotent,
+			Description: "set to 'on' to enable the idempotent producer, requires Kafka 0.11.0.0 or later",
+			Optional:    true,
+			Type:        "on|off",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -668,4 +698,137 @@ var (
 			Type:        "sentence",
 		},
 	}
+
+	HelpPulsar = config.HelpKVS{
+		config.HelpKV{
+			Key:         target.PulsarBroker,
+			Description: "Pulsar broker service URL e.g. 'pulsar://localhost:6650', use 'pulsar+ssl://' for TLS",
+			Type:        "url",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PulsarTopic,
+			Description: "Pulsar topic e.g. 'persistent://public/default/bucketevents'",
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.PulsarAuthToken,
+			Description: "JWT token for Pulsar token authentication",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PulsarTLSSkipVerify,
+			Description: `trust server TLS without verification, defaults to "on" (verify)`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.PulsarCertAuthority,
+			Description: "path to certificate chain of the target Pulsar broker",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PulsarClientCert,
+			Description: "client cert for Pulsar mTLS auth",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PulsarClientKey,
+			Description: "client cert key for Pulsar mTLS auth",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PulsarQueueDir,
+			Description: queueDirComment,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.PulsarQueueLimit,
+			Description: queueLimitComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpGRPC = config.HelpKVS{
+		config.HelpKV{
+			Key:         target.GRPCEndpoint,
+			Description: "gRPC sink address implementing 'minio.notify.v1.EventSink' e.g. 'localhost:9090'",
+			Type:        "address",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.GRPCAuthToken,
+			Description: "opaque string or JWT authorization token sent as 'authorization' metadata",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.GRPCTLS,
+			Description: "set to 'on' to enable TLS",
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.GRPCTLSSkipVerify,
+			Description: `trust server TLS without verification, defaults to "on" (verify)`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.GRPCCertAuthority,
+			Description: "path to certificate chain of the target gRPC sink",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.GRPCClientCert,
+			Description: "client cert for gRPC mTLS auth",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.GRPCClientKey,
+			Description: "client cert key for gRPC mTLS auth",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.GRPCQueueDir,
+			Description: queueDirComment,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.GRPCQueueLimit,
+			Description: queueLimitComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
 )
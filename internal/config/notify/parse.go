@@ -163,6 +163,16 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 		return nil, err
 	}
 
+	pulsarTargets, err := GetNotifyPulsar(cfg[config.NotifyPulsarSubSys])
+	if err != nil {
+		return nil, err
+	}
+
+	grpcTargets, err := GetNotifyGRPC(cfg[config.NotifyGRPCSubSys], transport.TLSClientConfig.RootCAs)
+	if err != nil {
+		return nil, err
+	}
+
 	for id, args := range amqpTargets {
 		if !args.Enable {
 			continue
@@ -366,6 +376,46 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 		}
 	}
 
+	for id, args := range pulsarTargets {
+		if !args.Enable {
+			continue
+		}
+		newTarget, err := target.NewPulsarTarget(id, args, ctx.Done(), logger.LogOnceIf, test)
+		if err != nil {
+			targetsOffline = true
+			if returnOnTargetError {
+				return nil, err
+			}
+			_ = newTarget.Close()
+		}
+		if err = targetList.Add(newTarget); err != nil {
+			logger.LogIf(context.Background(), err)
+			if returnOnTargetError {
+				return nil, err
+			}
+		}
+	}
+
+	for id, args := range grpcTargets {
+		if !args.Enable {
+			continue
+		}
+		newTarget, err := target.NewGRPCTarget(id, args, ctx.Done(), logger.LogOnceIf, test)
+		if err != nil {
+			targetsOffline = true
+			if returnOnTargetError {
+				return nil, err
+			}
+			_ = newTarget.Close()
+		}
+		if err = targetList.Add(newTarget); err != nil {
+			logger.LogIf(context.Background(), err)
+			if returnOnTargetError {
+				return nil, err
+			}
+		}
+	}
+
 	if targetsOffline {
 		return targetList, ErrTargetsOffline
 	}
@@ -386,6 +436,8 @@ var (
 		config.NotifyRedisSubSys:    DefaultRedisKVS,
 		config.NotifyWebhookSubSys:  DefaultWebhookKVS,
 		config.NotifyESSubSys:       DefaultESKVS,
+		config.NotifyPulsarSubSys:   DefaultPulsarKVS,
+		config.NotifyGRPCSubSys:     DefaultGRPCKVS,
 	}
 )
 
@@ -473,6 +525,14 @@ var (
 			Key:   target.KafkaVersion,
 			Value: "",
 		},
+		config.KV{
+			Key:   target.KafkaMessageKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaIdempotent,
+			Value: config.EnableOff,
+		},
 	}
 )
 
@@ -545,6 +605,16 @@ func GetNotifyKafka(kafkaKVS map[string]config.KVS) (map[string]target.KafkaArgs
 			versionEnv = versionEnv + config.Default + k
 		}
 
+		messageKeyEnv := target.EnvKafkaMessageKey
+		if k != config.Default {
+			messageKeyEnv = messageKeyEnv + config.Default + k
+		}
+
+		idempotentEnv := target.EnvKafkaIdempotent
+		if k != config.Default {
+			idempotentEnv = idempotentEnv + config.Default + k
+		}
+
 		kafkaArgs := target.KafkaArgs{
 			Enable:     enabled,
 			Brokers:    brokers,
@@ -552,6 +622,8 @@ func GetNotifyKafka(kafkaKVS map[string]config.KVS) (map[string]target.KafkaArgs
 			QueueDir:   env.Get(queueDirEnv, kv.Get(target.KafkaQueueDir)),
 			QueueLimit: queueLimit,
 			Version:    env.Get(versionEnv, kv.Get(target.KafkaVersion)),
+			MessageKey: env.Get(messageKeyEnv, kv.Get(target.KafkaMessageKey)),
+			Idempotent: env.Get(idempotentEnv, kv.Get(target.KafkaIdempotent)) == config.EnableOn,
 		}
 
 		tlsEnableEnv := target.EnvKafkaTLS
@@ -1450,6 +1522,18 @@ var (
 			Key:   target.WebhookClientKey,
 			Value: "",
 		},
+		config.KV{
+			Key:   target.WebhookBatchSize,
+			Value: "1",
+		},
+		config.KV{
+			Key:   target.WebhookFlushInterval,
+			Value: "1s",
+		},
+		config.KV{
+			Key:   target.WebhookCompression,
+			Value: target.WebhookCompressionNone,
+		},
 	}
 )
 
@@ -1503,6 +1587,29 @@ func GetNotifyWebhook(webhookKVS map[string]config.KVS, transport *http.Transpor
 			clientKeyEnv = clientKeyEnv + config.Default + k
 		}
 
+		batchSizeEnv := target.EnvWebhookBatchSize
+		if k != config.Default {
+			batchSizeEnv = batchSizeEnv + config.Default + k
+		}
+		batchSize, err := strconv.Atoi(env.Get(batchSizeEnv, kv.Get(target.WebhookBatchSize)))
+		if err != nil {
+			return nil, err
+		}
+
+		flushIntervalEnv := target.EnvWebhookFlushInterval
+		if k != config.Default {
+			flushIntervalEnv = flushIntervalEnv + config.Default + k
+		}
+		flushInterval, err := time.ParseDuration(env.Get(flushIntervalEnv, kv.Get(target.WebhookFlushInterval)))
+		if err != nil {
+			return nil, err
+		}
+
+		compressionEnv := target.EnvWebhookCompression
+		if k != config.Default {
+			compressionEnv = compressionEnv + config.Default + k
+		}
+
 		webhookArgs := target.WebhookArgs{
 			Enable:     enabled,
 			Endpoint:   *url,
@@ -1512,6 +1619,10 @@ func GetNotifyWebhook(webhookKVS map[string]config.KVS, transport *http.Transpor
 			QueueLimit: uint64(queueLimit),
 			ClientCert: env.Get(clientCertEnv, kv.Get(target.WebhookClientCert)),
 			ClientKey:  env.Get(clientKeyEnv, kv.Get(target.WebhookClientKey)),
+
+			BatchSize:     batchSize,
+			FlushInterval: flushInterval,
+			Compression:   env.Get(compressionEnv, kv.Get(target.WebhookCompression)),
 		}
 		if err = webhookArgs.Validate(); err != nil {
 			return nil, err
@@ -1808,3 +1919,280 @@ func GetNotifyAMQP(amqpKVS map[string]config.KVS) (map[string]target.AMQPArgs, e
 	}
 	return amqpTargets, nil
 }
+
+// DefaultPulsarKVS - default KV for Pulsar config
+var (
+	DefaultPulsarKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.PulsarBroker,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarTopic,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarAuthToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarTLSSkipVerify,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.PulsarCertAuthority,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarClientCert,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarClientKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarQueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarQueueLimit,
+			Value: "0",
+		},
+	}
+)
+
+// GetNotifyPulsar - returns a map of registered notification 'pulsar' targets
+func GetNotifyPulsar(pulsarKVS map[string]config.KVS) (map[string]target.PulsarArgs, error) {
+	pulsarTargets := make(map[string]target.PulsarArgs)
+	for k, kv := range config.Merge(pulsarKVS, target.EnvPulsarEnable, DefaultPulsarKVS) {
+		enableEnv := target.EnvPulsarEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		brokerEnv := target.EnvPulsarBroker
+		if k != config.Default {
+			brokerEnv = brokerEnv + config.Default + k
+		}
+		broker, err := xnet.ParseURL(env.Get(brokerEnv, kv.Get(target.PulsarBroker)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueLimitEnv := target.EnvPulsarQueueLimit
+		if k != config.Default {
+			queueLimitEnv = queueLimitEnv + config.Default + k
+		}
+		queueLimit, err := strconv.ParseUint(env.Get(queueLimitEnv, kv.Get(target.PulsarQueueLimit)), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		topicEnv := target.EnvPulsarTopic
+		if k != config.Default {
+			topicEnv = topicEnv + config.Default + k
+		}
+
+		authTokenEnv := target.EnvPulsarAuthToken
+		if k != config.Default {
+			authTokenEnv = authTokenEnv + config.Default + k
+		}
+
+		tlsSkipVerifyEnv := target.EnvPulsarTLSSkipVerify
+		if k != config.Default {
+			tlsSkipVerifyEnv = tlsSkipVerifyEnv + config.Default + k
+		}
+
+		certAuthorityEnv := target.EnvPulsarCertAuthority
+		if k != config.Default {
+			certAuthorityEnv = certAuthorityEnv + config.Default + k
+		}
+
+		clientCertEnv := target.EnvPulsarClientCert
+		if k != config.Default {
+			clientCertEnv = clientCertEnv + config.Default + k
+		}
+
+		clientKeyEnv := target.EnvPulsarClientKey
+		if k != config.Default {
+			clientKeyEnv = clientKeyEnv + config.Default + k
+		}
+
+		queueDirEnv := target.EnvPulsarQueueDir
+		if k != config.Default {
+			queueDirEnv = queueDirEnv + config.Default + k
+		}
+
+		pulsarArgs := target.PulsarArgs{
+			Enable:        enabled,
+			Broker:        *broker,
+			Topic:         env.Get(topicEnv, kv.Get(target.PulsarTopic)),
+			AuthToken:     env.Get(authTokenEnv, kv.Get(target.PulsarAuthToken)),
+			TLSSkipVerify: env.Get(tlsSkipVerifyEnv, kv.Get(target.PulsarTLSSkipVerify)) == config.EnableOn,
+			CertAuthority: env.Get(certAuthorityEnv, kv.Get(target.PulsarCertAuthority)),
+			ClientCert:    env.Get(clientCertEnv, kv.Get(target.PulsarClientCert)),
+			ClientKey:     env.Get(clientKeyEnv, kv.Get(target.PulsarClientKey)),
+			QueueDir:      env.Get(queueDirEnv, kv.Get(target.PulsarQueueDir)),
+			QueueLimit:    queueLimit,
+		}
+
+		if err = pulsarArgs.Validate(); err != nil {
+			return nil, err
+		}
+
+		pulsarTargets[k] = pulsarArgs
+	}
+	return pulsarTargets, nil
+}
+
+// DefaultGRPCKVS - default KV for gRPC config
+var (
+	DefaultGRPCKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.GRPCEndpoint,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.GRPCAuthToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.GRPCTLS,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.GRPCTLSSkipVerify,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.GRPCCertAuthority,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.GRPCClientCert,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.GRPCClientKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.GRPCQueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.GRPCQueueLimit,
+			Value: "0",
+		},
+	}
+)
+
+// GetNotifyGRPC - returns a map of registered notification 'grpc' targets
+func GetNotifyGRPC(grpcKVS map[string]config.KVS, rootCAs *x509.CertPool) (map[string]target.GRPCArgs, error) {
+	grpcTargets := make(map[string]target.GRPCArgs)
+	for k, kv := range config.Merge(grpcKVS, target.EnvGRPCEnable, DefaultGRPCKVS) {
+		enableEnv := target.EnvGRPCEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		endpointEnv := target.EnvGRPCEndpoint
+		if k != config.Default {
+			endpointEnv = endpointEnv + config.Default + k
+		}
+		endpoint, err := xnet.ParseHost(env.Get(endpointEnv, kv.Get(target.GRPCEndpoint)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueLimitEnv := target.EnvGRPCQueueLimit
+		if k != config.Default {
+			queueLimitEnv = queueLimitEnv + config.Default + k
+		}
+		queueLimit, err := strconv.ParseUint(env.Get(queueLimitEnv, kv.Get(target.GRPCQueueLimit)), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		authTokenEnv := target.EnvGRPCAuthToken
+		if k != config.Default {
+			authTokenEnv = authTokenEnv + config.Default + k
+		}
+
+		tlsEnv := target.EnvGRPCTLS
+		if k != config.Default {
+			tlsEnv = tlsEnv + config.Default + k
+		}
+
+		tlsSkipVerifyEnv := target.EnvGRPCTLSSkipVerify
+		if k != config.Default {
+			tlsSkipVerifyEnv = tlsSkipVerifyEnv + config.Default + k
+		}
+
+		certAuthorityEnv := target.EnvGRPCCertAuthority
+		if k != config.Default {
+			certAuthorityEnv = certAuthorityEnv + config.Default + k
+		}
+
+		clientCertEnv := target.EnvGRPCClientCert
+		if k != config.Default {
+			clientCertEnv = clientCertEnv + config.Default + k
+		}
+
+		clientKeyEnv := target.EnvGRPCClientKey
+		if k != config.Default {
+			clientKeyEnv = clientKeyEnv + config.Default + k
+		}
+
+		queueDirEnv := target.EnvGRPCQueueDir
+		if k != config.Default {
+			queueDirEnv = queueDirEnv + config.Default + k
+		}
+
+		grpcArgs := target.GRPCArgs{
+			Enable:        enabled,
+			Endpoint:      *endpoint,
+			AuthToken:     env.Get(authTokenEnv, kv.Get(target.GRPCAuthToken)),
+			TLS:           env.Get(tlsEnv, kv.Get(target.GRPCTLS)) == config.EnableOn,
+			TLSSkipVerify: env.Get(tlsSkipVerifyEnv, kv.Get(target.GRPCTLSSkipVerify)) == config.EnableOn,
+			CertAuthority: env.Get(certAuthorityEnv, kv.Get(target.GRPCCertAuthority)),
+			ClientCert:    env.Get(clientCertEnv, kv.Get(target.GRPCClientCert)),
+			ClientKey:     env.Get(clientKeyEnv, kv.Get(target.GRPCClientKey)),
+			QueueDir:      env.Get(queueDirEnv, kv.Get(target.GRPCQueueDir)),
+			QueueLimit:    queueLimit,
+			RootCAs:       rootCAs,
+		}
+
+		if err = grpcArgs.Validate(); err != nil {
+			return nil, err
+		}
+
+		grpcTargets[k] = grpcArgs
+	}
+	return grpcTargets, nil
+}
@@ -20,6 +20,7 @@ package event
 import (
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
 	"reflect"
 	"strings"
@@ -137,18 +138,180 @@ func (ruleList FilterRuleList) Pattern() string {
 	return NewPattern(prefix, suffix)
 }
 
-// S3Key - represents elements inside <S3Key>...</S3Key>
+// ObjectSizeFilter - represents elements inside <ObjectSize>...</ObjectSize>,
+// the object size range in bytes events are sent for.
+type ObjectSizeFilter struct {
+	Min *int64 `xml:"Min,omitempty" json:"Min,omitempty"`
+	Max *int64 `xml:"Max,omitempty" json:"Max,omitempty"`
+}
+
+// UnmarshalXML - decodes XML data.
+func (sizeFilter *ObjectSizeFilter) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Make subtype to avoid recursive UnmarshalXML().
+	type objectSizeFilter ObjectSizeFilter
+	parsed := objectSizeFilter{}
+	if err := d.DecodeElement(&parsed, &start); err != nil {
+		return err
+	}
+
+	switch {
+	case parsed.Min == nil && parsed.Max == nil:
+		return &ErrInvalidFilterValue{"ObjectSize"}
+	case parsed.Min != nil && *parsed.Min < 0:
+		return &ErrInvalidFilterValue{fmt.Sprint(*parsed.Min)}
+	case parsed.Max != nil && *parsed.Max < 0:
+		return &ErrInvalidFilterValue{fmt.Sprint(*parsed.Max)}
+	case parsed.Min != nil && parsed.Max != nil && *parsed.Min > *parsed.Max:
+		return &ErrInvalidFilterValue{fmt.Sprintf("%d-%d", *parsed.Min, *parsed.Max)}
+	}
+
+	*sizeFilter = ObjectSizeFilter(parsed)
+	return nil
+}
+
+// KeyValueFilterRule - represents elements inside <FilterRule>...</FilterRule>
+// of metadata and tag filters.
+type KeyValueFilterRule struct {
+	Name  string `xml:"Name"`
+	Value string `xml:"Value"`
+}
+
+// KeyValueFilterRuleList - represents multiple <FilterRule>...</FilterRule>
+// of metadata and tag filters.
+type KeyValueFilterRuleList struct {
+	Rules []KeyValueFilterRule `xml:"FilterRule,omitempty"`
+}
+
+// UnmarshalXML - decodes XML data.
+func (ruleList *KeyValueFilterRuleList) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Make subtype to avoid recursive UnmarshalXML().
+	type keyValueFilterRuleList KeyValueFilterRuleList
+	rules := keyValueFilterRuleList{}
+	if err := d.DecodeElement(&rules, &start); err != nil {
+		return err
+	}
+
+	// Names must be unique, metadata names are case insensitive.
+	nameSet := set.NewStringSet()
+	for _, rule := range rules.Rules {
+		name := rule.Name
+		if start.Name.Local == "Metadata" {
+			name = strings.ToLower(name)
+		}
+		if name == "" || nameSet.Contains(name) {
+			return &ErrInvalidFilterName{rule.Name}
+		}
+		nameSet.Add(name)
+
+		if len(rule.Value) > 1024 || !utf8.ValidString(rule.Value) {
+			return &ErrInvalidFilterValue{rule.Value}
+		}
+	}
+
+	*ruleList = KeyValueFilterRuleList(rules)
+	return nil
+}
+
+func (ruleList KeyValueFilterRuleList) toMap(metadata bool) map[string]string {
+	if len(ruleList.Rules) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(ruleList.Rules))
+	for _, rule := range ruleList.Rules {
+		name := rule.Name
+		if metadata {
+			// User metadata is matched with its prefix.
+			name = strings.ToLower(name)
+			if !strings.HasPrefix(name, "x-amz-meta-") {
+				name = "x-amz-meta-" + name
+			}
+		}
+		m[name] = rule.Value
+	}
+	return m
+}
+
+// S3Key - represents elements inside <Filter>...</Filter>, the object
+// key filter inside <S3Key>...</S3Key> along with filters on other
+// object properties.
 type S3Key struct {
 	RuleList FilterRuleList `xml:"S3Key,omitempty" json:"S3Key,omitempty"`
+
+	ObjectSize   *ObjectSizeFilter      `xml:"ObjectSize,omitempty" json:"ObjectSize,omitempty"`
+	ContentTypes []string               `xml:"ContentType,omitempty" json:"ContentType,omitempty"`
+	Metadata     KeyValueFilterRuleList `xml:"Metadata,omitempty" json:"Metadata,omitempty"`
+	Tags         KeyValueFilterRuleList `xml:"Tags,omitempty" json:"Tags,omitempty"`
+}
+
+func (s3Key S3Key) isEmpty() bool {
+	return s3Key.RuleList.isEmpty() && s3Key.ObjectSize == nil && len(s3Key.ContentTypes) == 0 &&
+		len(s3Key.Metadata.Rules) == 0 && len(s3Key.Tags.Rules) == 0
 }
 
 // MarshalXML implements a custom marshaller to support `omitempty` feature.
 func (s3Key S3Key) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	if s3Key.RuleList.isEmpty() {
+	if s3Key.isEmpty() {
 		return nil
 	}
-	type s3KeyWrapper S3Key
-	return e.EncodeElement(s3KeyWrapper(s3Key), start)
+	type s3KeyWrapper struct {
+		RuleList     *FilterRuleList         `xml:"S3Key,omitempty"`
+		ObjectSize   *ObjectSizeFilter       `xml:"ObjectSize,omitempty"`
+		ContentTypes []string                `xml:"ContentType,omitempty"`
+		Metadata     *KeyValueFilterRuleList `xml:"Metadata,omitempty"`
+		Tags         *KeyValueFilterRuleList `xml:"Tags,omitempty"`
+	}
+	wrapper := s3KeyWrapper{
+		ObjectSize:   s3Key.ObjectSize,
+		ContentTypes: s3Key.ContentTypes,
+	}
+	if !s3Key.RuleList.isEmpty() {
+		wrapper.RuleList = &s3Key.RuleList
+	}
+	if len(s3Key.Metadata.Rules) > 0 {
+		wrapper.Metadata = &s3Key.Metadata
+	}
+	if len(s3Key.Tags.Rules) > 0 {
+		wrapper.Tags = &s3Key.Tags
+	}
+	return e.EncodeElement(wrapper, start)
+}
+
+// UnmarshalXML - decodes XML data.
+func (s3Key *S3Key) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Make subtype to avoid recursive UnmarshalXML().
+	type s3KeyFilter S3Key
+	parsed := s3KeyFilter{}
+	if err := d.DecodeElement(&parsed, &start); err != nil {
+		return err
+	}
+
+	for i, contentType := range parsed.ContentTypes {
+		contentType = strings.ToLower(strings.TrimSpace(contentType))
+		if contentType == "" || len(contentType) > 1024 {
+			return &ErrInvalidFilterValue{parsed.ContentTypes[i]}
+		}
+		parsed.ContentTypes[i] = contentType
+	}
+
+	*s3Key = S3Key(parsed)
+	return nil
+}
+
+// ObjectFilter - returns the filter on object properties other than
+// the object key.
+func (s3Key S3Key) ObjectFilter() ObjectFilter {
+	filter := ObjectFilter{
+		Metadata: s3Key.Metadata.toMap(true),
+		Tags:     s3Key.Tags.toMap(false),
+	}
+	if s3Key.ObjectSize != nil {
+		filter.MinSize = s3Key.ObjectSize.Min
+		filter.MaxSize = s3Key.ObjectSize.Max
+	}
+	if len(s3Key.ContentTypes) > 0 {
+		filter.ContentTypes = append([]string{}, s3Key.ContentTypes...)
+	}
+	return filter
 }
 
 // common - represents common elements inside <QueueConfiguration>, <CloudFunctionConfiguration>
@@ -220,7 +383,7 @@ func (q *Queue) SetRegion(region string) {
 // ToRulesMap - converts Queue to RulesMap
 func (q Queue) ToRulesMap() RulesMap {
 	pattern := q.Filter.RuleList.Pattern()
-	return NewRulesMap(q.Events, pattern, q.ARN.TargetID)
+	return NewFilteredRulesMap(q.Events, pattern, q.Filter.ObjectFilter(), q.ARN.TargetID)
 }
 
 // Unused.  Available for completion.
@@ -959,3 +959,113 @@ func TestParseConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestQueueObjectFilter(t *testing.T) {
+	data := []byte(`
+<QueueConfiguration>
+   <Id>1</Id>
+   <Filter>
+       <S3Key>
+           <FilterRule>
+               <Name>prefix</Name>
+               <Value>images/</Value>
+           </FilterRule>
+       </S3Key>
+       <ObjectSize>
+           <Min>1024</Min>
+           <Max>1048576</Max>
+       </ObjectSize>
+       <ContentType>Image/*</ContentType>
+       <ContentType>application/pdf</ContentType>
+       <Metadata>
+           <FilterRule>
+               <Name>Camera</Name>
+               <Value>nikon*</Value>
+           </FilterRule>
+       </Metadata>
+       <Tags>
+           <FilterRule>
+               <Name>env</Name>
+               <Value>prod</Value>
+           </FilterRule>
+       </Tags>
+   </Filter>
+   <Queue>arn:minio:sqs:us-east-1:1:webhook</Queue>
+   <Event>s3:ObjectCreated:Put</Event>
+</QueueConfiguration>`)
+	queue := &Queue{}
+	if err := xml.Unmarshal(data, queue); err != nil {
+		t.Fatal(err)
+	}
+
+	rulesMap := queue.ToRulesMap()
+	object := ObjectProperties{
+		Name:         "images/photo.jpg",
+		Size:         4096,
+		ContentType:  "image/jpeg",
+		UserMetadata: map[string]string{"X-Amz-Meta-Camera": "nikon-d750"},
+		Tags:         map[string]string{"env": "prod"},
+	}
+	targetID := TargetID{"1", "webhook"}
+
+	testCases := []struct {
+		object         func(ObjectProperties) ObjectProperties
+		expectedResult TargetIDSet
+	}{
+		{func(o ObjectProperties) ObjectProperties { return o }, NewTargetIDSet(targetID)},
+		{func(o ObjectProperties) ObjectProperties { o.Name = "docs/photo.jpg"; return o }, NewTargetIDSet()},
+		{func(o ObjectProperties) ObjectProperties { o.Size = 10; return o }, NewTargetIDSet()},
+		{func(o ObjectProperties) ObjectProperties { o.Size = 1 << 30; return o }, NewTargetIDSet()},
+		{func(o ObjectProperties) ObjectProperties { o.ContentType = "application/pdf"; return o }, NewTargetIDSet(targetID)},
+		{func(o ObjectProperties) ObjectProperties { o.ContentType = "text/plain"; return o }, NewTargetIDSet()},
+		{func(o ObjectProperties) ObjectProperties {
+			o.UserMetadata = map[string]string{"X-Amz-Meta-Camera": "canon"}
+			return o
+		}, NewTargetIDSet()},
+		{func(o ObjectProperties) ObjectProperties { o.Tags = nil; return o }, NewTargetIDSet()},
+	}
+
+	for i, testCase := range testCases {
+		result := rulesMap.MatchObject(ObjectCreatedPut, testCase.object(object))
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Errorf("test %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	// The filter must survive a round trip through XML.
+	out, err := xml.Marshal(queue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queue2 := &Queue{}
+	if err = xml.Unmarshal(out, queue2); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(queue2.ToRulesMap(), rulesMap) {
+		t.Errorf("expected %v, got %v", rulesMap, queue2.ToRulesMap())
+	}
+}
+
+func TestObjectFilterUnmarshalXML(t *testing.T) {
+	testCases := []struct {
+		filter    string
+		expectErr bool
+	}{
+		{`<ObjectSize><Min>10</Min></ObjectSize>`, false},
+		{`<ObjectSize><Max>0</Max></ObjectSize>`, false},
+		{`<ObjectSize></ObjectSize>`, true},
+		{`<ObjectSize><Min>-1</Min></ObjectSize>`, true},
+		{`<ObjectSize><Min>10</Min><Max>5</Max></ObjectSize>`, true},
+		{`<ContentType> </ContentType>`, true},
+		{`<Metadata><FilterRule><Name></Name><Value>x</Value></FilterRule></Metadata>`, true},
+		{`<Metadata><FilterRule><Name>a</Name><Value>x</Value></FilterRule><FilterRule><Name>A</Name><Value>y</Value></FilterRule></Metadata>`, true},
+		{`<Tags><FilterRule><Name>a</Name><Value>x</Value></FilterRule><FilterRule><Name>A</Name><Value>y</Value></FilterRule></Tags>`, false},
+	}
+
+	for i, testCase := range testCases {
+		err := xml.Unmarshal([]byte("<Filter>"+testCase.filter+"</Filter>"), &S3Key{})
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Errorf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
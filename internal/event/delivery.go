@@ -0,0 +1,184 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrTargetQueueFull - indicates that the queue store of a target is full
+// and the event could not be persisted.
+var ErrTargetQueueFull = errors.New("the maximum store limit reached")
+
+// TargetQueue - queue of undelivered events of a target.
+type TargetQueue interface {
+	// Len returns the number of queued events.
+	Len() int
+	// Oldest returns the time the oldest queued event was queued at,
+	// zero time if the queue is empty.
+	Oldest() time.Time
+}
+
+// TargetStats - delivery statistics of a target.
+type TargetStats struct {
+	Queued         uint64
+	Sent           uint64
+	Failed         uint64
+	Dropped        uint64
+	DeadLettered   uint64
+	QueueLength    int
+	OldestEventAge time.Duration
+}
+
+// DeliveryPolicy - defines what happens to events which cannot be delivered.
+type DeliveryPolicy struct {
+	// RetryBudget is the number of failed deliveries after which a queued
+	// event is discarded, zero retries forever.
+	RetryBudget int
+	// DeadLetter, if set, persists discarded events instead of dropping them.
+	DeadLetter func(id TargetID, ev Event, reason error) error
+}
+
+type targetCounters struct {
+	queued       uint64
+	sent         uint64
+	failed       uint64
+	dropped      uint64
+	deadLettered uint64
+	queue        TargetQueue
+}
+
+var (
+	deliveryMu     sync.RWMutex
+	deliveryPolicy DeliveryPolicy
+	targetCounts   = make(map[TargetID]*targetCounters)
+)
+
+func countersOf(id TargetID) *targetCounters {
+	deliveryMu.RLock()
+	c, ok := targetCounts[id]
+	deliveryMu.RUnlock()
+	if ok {
+		return c
+	}
+
+	deliveryMu.Lock()
+	defer deliveryMu.Unlock()
+	if c, ok = targetCounts[id]; !ok {
+		c = &targetCounters{}
+		targetCounts[id] = c
+	}
+	return c
+}
+
+// removeCounters - forgets the delivery statistics of a removed target.
+func removeCounters(id TargetID) {
+	deliveryMu.Lock()
+	defer deliveryMu.Unlock()
+	delete(targetCounts, id)
+}
+
+// SetDeliveryPolicy - sets the policy applied to undeliverable events.
+func SetDeliveryPolicy(policy DeliveryPolicy) {
+	deliveryMu.Lock()
+	defer deliveryMu.Unlock()
+	deliveryPolicy = policy
+}
+
+// GetDeliveryPolicy - returns the policy applied to undeliverable events.
+func GetDeliveryPolicy() DeliveryPolicy {
+	deliveryMu.RLock()
+	defer deliveryMu.RUnlock()
+	return deliveryPolicy
+}
+
+// RegisterTargetQueue - registers the queue store of a target so that its
+// length and age are reported with the target statistics.
+func RegisterTargetQueue(id TargetID, queue TargetQueue) {
+	c := countersOf(id)
+	deliveryMu.Lock()
+	c.queue = queue
+	deliveryMu.Unlock()
+}
+
+// AddQueued - counts events persisted in the queue store of a target.
+func AddQueued(id TargetID, n uint64) {
+	atomic.AddUint64(&countersOf(id).queued, n)
+}
+
+// AddSent - counts events delivered by a target.
+func AddSent(id TargetID, n uint64) {
+	atomic.AddUint64(&countersOf(id).sent, n)
+}
+
+// AddFailed - counts failed delivery attempts of a target.
+func AddFailed(id TargetID, n uint64) {
+	atomic.AddUint64(&countersOf(id).failed, n)
+}
+
+// Discard - hands an undeliverable event to the dead-letter handler of the
+// delivery policy, the event is dropped if there is none or it fails.
+func Discard(id TargetID, ev Event, reason error) error {
+	c := countersOf(id)
+	if deadLetter := GetDeliveryPolicy().DeadLetter; deadLetter != nil {
+		err := deadLetter(id, ev, reason)
+		if err == nil {
+			atomic.AddUint64(&c.deadLettered, 1)
+			return nil
+		}
+		atomic.AddUint64(&c.dropped, 1)
+		return err
+	}
+	atomic.AddUint64(&c.dropped, 1)
+	return nil
+}
+
+// GetTargetStats - returns the delivery statistics of all targets.
+func GetTargetStats() map[TargetID]TargetStats {
+	deliveryMu.RLock()
+	counters := make(map[TargetID]*targetCounters, len(targetCounts))
+	queues := make(map[TargetID]TargetQueue, len(targetCounts))
+	for id, c := range targetCounts {
+		counters[id] = c
+		queues[id] = c.queue
+	}
+	deliveryMu.RUnlock()
+
+	now := time.Now()
+	stats := make(map[TargetID]TargetStats, len(counters))
+	for id, c := range counters {
+		s := TargetStats{
+			Queued:       atomic.LoadUint64(&c.queued),
+			Sent:         atomic.LoadUint64(&c.sent),
+			Failed:       atomic.LoadUint64(&c.failed),
+			Dropped:      atomic.LoadUint64(&c.dropped),
+			DeadLettered: atomic.LoadUint64(&c.deadLettered),
+		}
+		if q := queues[id]; q != nil {
+			s.QueueLength = q.Len()
+			if oldest := q.Oldest(); !oldest.IsZero() {
+				s.OldestEventAge = now.Sub(oldest)
+			}
+		}
+		stats[id] = s
+	}
+	return stats
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type exampleQueue struct {
+	length int
+	oldest time.Time
+}
+
+func (q exampleQueue) Len() int          { return q.length }
+func (q exampleQueue) Oldest() time.Time { return q.oldest }
+
+func TestTargetListSendStats(t *testing.T) {
+	var deadLettered []TargetID
+	SetDeliveryPolicy(DeliveryPolicy{
+		DeadLetter: func(id TargetID, ev Event, reason error) error {
+			deadLettered = append(deadLettered, id)
+			return nil
+		},
+	})
+	defer SetDeliveryPolicy(DeliveryPolicy{})
+
+	okID := TargetID{"stats-ok", "testcase"}
+	failID := TargetID{"stats-fail", "testcase"}
+	targetList := NewTargetList()
+	if err := targetList.Add(&ExampleTarget{okID, false, false}, &ExampleTarget{failID, true, false}); err != nil {
+		t.Fatal(err)
+	}
+
+	resCh := make(chan TargetIDResult)
+	targetList.Send(Event{}, NewTargetIDSet(okID, failID), resCh)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-resCh:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for send results")
+		}
+	}
+
+	stats := GetTargetStats()
+	if s := stats[okID]; s.Sent != 1 || s.Failed != 0 || s.DeadLettered != 0 {
+		t.Fatalf("unexpected stats of %v: %+v", okID, s)
+	}
+	if s := stats[failID]; s.Sent != 0 || s.Failed != 1 || s.DeadLettered != 1 || s.Dropped != 0 {
+		t.Fatalf("unexpected stats of %v: %+v", failID, s)
+	}
+	if len(deadLettered) != 1 || deadLettered[0] != failID {
+		t.Fatalf("expected dead-lettered event of %v, got %v", failID, deadLettered)
+	}
+
+	// Statistics of removed targets are not reported anymore.
+	targetList.Remove(NewTargetIDSet(okID, failID))
+	stats = GetTargetStats()
+	if _, ok := stats[okID]; ok {
+		t.Fatalf("unexpected stats of removed target %v", okID)
+	}
+	if _, ok := stats[failID]; ok {
+		t.Fatalf("unexpected stats of removed target %v", failID)
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	defer SetDeliveryPolicy(DeliveryPolicy{})
+
+	id := TargetID{"discard", "testcase"}
+	if err := Discard(id, Event{}, errors.New("failed")); err != nil {
+		t.Fatal(err)
+	}
+
+	errDeadLetter := errors.New("dead-letter bucket unavailable")
+	SetDeliveryPolicy(DeliveryPolicy{
+		DeadLetter: func(id TargetID, ev Event, reason error) error {
+			return errDeadLetter
+		},
+	})
+	if err := Discard(id, Event{}, errors.New("failed")); err != errDeadLetter {
+		t.Fatalf("expected %v, got %v", errDeadLetter, err)
+	}
+
+	if s := GetTargetStats()[id]; s.Dropped != 2 || s.DeadLettered != 0 {
+		t.Fatalf("unexpected stats of %v: %+v", id, s)
+	}
+}
+
+func TestGetTargetStatsQueue(t *testing.T) {
+	id := TargetID{"queue", "testcase"}
+	RegisterTargetQueue(id, exampleQueue{length: 3, oldest: time.Now().Add(-time.Minute)})
+	AddQueued(id, 3)
+
+	s := GetTargetStats()[id]
+	if s.Queued != 3 || s.QueueLength != 3 {
+		t.Fatalf("unexpected stats of %v: %+v", id, s)
+	}
+	if s.OldestEventAge < time.Minute {
+		t.Fatalf("expected oldest event age of at least a minute, got %v", s.OldestEventAge)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/wildcard"
+)
+
+// ObjectProperties - properties of an object rules are matched on.
+type ObjectProperties struct {
+	Name         string
+	Size         int64
+	ContentType  string
+	UserMetadata map[string]string
+	Tags         map[string]string
+}
+
+// ObjectFilter - filters events on object properties other than the
+// object name, all given conditions must hold. Content type, metadata
+// and tag values may contain wildcards.
+type ObjectFilter struct {
+	MinSize      *int64            `json:"minSize,omitempty"`
+	MaxSize      *int64            `json:"maxSize,omitempty"`
+	ContentTypes []string          `json:"contentTypes,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+}
+
+// IsEmpty - returns whether the filter has no conditions.
+func (filter ObjectFilter) IsEmpty() bool {
+	return filter.MinSize == nil && filter.MaxSize == nil && len(filter.ContentTypes) == 0 &&
+		len(filter.Metadata) == 0 && len(filter.Tags) == 0
+}
+
+// Match - returns whether the object satisfies the filter.
+func (filter ObjectFilter) Match(object ObjectProperties) bool {
+	if filter.MinSize != nil && object.Size < *filter.MinSize {
+		return false
+	}
+	if filter.MaxSize != nil && object.Size > *filter.MaxSize {
+		return false
+	}
+
+	if len(filter.ContentTypes) > 0 {
+		contentType := strings.ToLower(object.ContentType)
+		matched := false
+		for _, pattern := range filter.ContentTypes {
+			if wildcard.MatchSimple(pattern, contentType) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	for name, pattern := range filter.Metadata {
+		if !matchKeyValue(object.UserMetadata, name, pattern, true) {
+			return false
+		}
+	}
+	for name, pattern := range filter.Tags {
+		if !matchKeyValue(object.Tags, name, pattern, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchKeyValue - returns whether m has a value for name matching
+// pattern. Metadata names are case insensitive.
+func matchKeyValue(m map[string]string, name, pattern string, foldCase bool) bool {
+	if !foldCase {
+		v, ok := m[name]
+		return ok && wildcard.MatchSimple(pattern, v)
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, name) && wildcard.MatchSimple(pattern, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleKey - returns the key of a rule in Rules. Rules without object
+// filter are keyed by their pattern, otherwise the encoded filter is
+// appended after a NUL byte, which JSON never contains, so that rules
+// with the same pattern and filter share their target IDs.
+func ruleKey(pattern string, filter ObjectFilter) string {
+	if filter.IsEmpty() {
+		return pattern
+	}
+
+	sort.Strings(filter.ContentTypes)
+	data, err := json.Marshal(filter)
+	if err != nil {
+		// Not reachable, the filter consists of strings and numbers.
+		return pattern
+	}
+	return pattern + "\x00" + string(data)
+}
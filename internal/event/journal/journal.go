@@ -0,0 +1,297 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/pkg/env"
+	"github.com/minio/pkg/wildcard"
+)
+
+// Event journal config constants.
+const (
+	Buckets   = "buckets"
+	Retention = "retention"
+
+	EnvEnable    = "MINIO_EVENT_JOURNAL_ENABLE"
+	EnvBuckets   = "MINIO_EVENT_JOURNAL_BUCKETS"
+	EnvRetention = "MINIO_EVENT_JOURNAL_RETENTION"
+)
+
+// Config - event journal settings.
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// Buckets are the names of the journaled buckets,
+	// names may contain wildcards.
+	Buckets []string `json:"buckets"`
+	// Retention is the time journaled events are kept for.
+	Retention time.Duration `json:"retention"`
+}
+
+// BucketEnabled - returns true if the events of the bucket are journaled.
+func (c Config) BucketEnabled(bucket string) bool {
+	if !c.Enabled {
+		return false
+	}
+	for _, pattern := range c.Buckets {
+		if wildcard.Match(pattern, bucket) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultKVS - default KV config for the event journal
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Buckets,
+			Value: "*",
+		},
+		config.KV{
+			Key:   Retention,
+			Value: "24h",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Buckets,
+			Description: `comma separated names of the buckets to journal events of, defaults to '*' (all buckets)`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         Retention,
+			Description: `duration journaled events are kept for, defaults to '24h'`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+)
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.EventJournalSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvEnable, kvs.GetWithDefault(config.Enable, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'event_journal:enable' value invalid: %w", err)
+	}
+	for _, bucket := range strings.Split(env.Get(EnvBuckets, kvs.GetWithDefault(Buckets, DefaultKVS)), config.ValueSeparator) {
+		if bucket = strings.TrimSpace(bucket); bucket != "" {
+			cfg.Buckets = append(cfg.Buckets, bucket)
+		}
+	}
+	cfg.Retention, err = time.ParseDuration(env.Get(EnvRetention, kvs.GetWithDefault(Retention, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'event_journal:retention' value invalid: %w", err)
+	}
+	if cfg.Retention <= 0 {
+		return cfg, config.Errorf("'event_journal:retention' must be positive")
+	}
+	return cfg, nil
+}
+
+// Record - a journaled bucket event.
+type Record struct {
+	// Seq is the sequence number of the event taken from the event
+	// sequencer, sequence numbers are strictly increasing per server.
+	Seq uint64 `json:"seq"`
+	// Node identifies the server which recorded the event, events
+	// of different servers may have the same sequence number.
+	Node  string      `json:"node"`
+	Event event.Event `json:"event"`
+}
+
+// Position - returns the position of the record in the journal.
+func (r Record) Position() Position {
+	return Position{Seq: r.Seq, Node: r.Node}
+}
+
+// Position - a position in the journal, records are ordered by
+// sequence number and then by node. A position without a node
+// is before every record with its sequence number.
+type Position struct {
+	Seq  uint64
+	Node string
+}
+
+// Less - returns true if p is before q.
+func (p Position) Less(q Position) bool {
+	if p.Seq != q.Seq {
+		return p.Seq < q.Seq
+	}
+	return p.Node < q.Node
+}
+
+// Seq - returns the sequence number of an event.
+func Seq(ev event.Event) uint64 {
+	seq, err := strconv.ParseUint(ev.S3.Object.Sequencer, 16, 64)
+	if err != nil {
+		return 0
+	}
+	return seq
+}
+
+// TimeSeq - returns the first sequence number of events at or after t.
+func TimeSeq(t time.Time) uint64 {
+	if t.UnixNano() <= 0 {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
+
+// EncodeRecords - encodes records as JSON lines.
+func EncodeRecords(records []Record) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeRecords - decodes records encoded by EncodeRecords.
+func DecodeRecords(data []byte) ([]Record, error) {
+	var records []Record
+	dec := json.NewDecoder(bufio.NewReader(bytes.NewReader(data)))
+	for dec.More() {
+		var record Record
+		if err := dec.Decode(&record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Segment - a stored part of a journal, holding the events recorded by
+// one server between the first and the last sequence number.
+type Segment struct {
+	Name  string
+	Node  string
+	First uint64
+	Last  uint64
+}
+
+const segmentExt = ".json"
+
+var errInvalidSegmentName = errors.New("invalid event journal segment name")
+
+// SegmentName - returns the object name of a segment, names sort by
+// the first sequence number of the segment.
+func SegmentName(first, last uint64, id string) string {
+	return fmt.Sprintf("%020d-%020d-%s%s", first, last, id, segmentExt)
+}
+
+// ParseSegmentName - parses a segment name returned by SegmentName.
+func ParseSegmentName(name string) (Segment, error) {
+	parts := strings.SplitN(strings.TrimSuffix(name, segmentExt), "-", 3)
+	if len(parts) != 3 || !strings.HasSuffix(name, segmentExt) {
+		return Segment{}, errInvalidSegmentName
+	}
+	first, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return Segment{}, errInvalidSegmentName
+	}
+	last, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil || last < first {
+		return Segment{}, errInvalidSegmentName
+	}
+	return Segment{Name: name, Node: parts[2], First: first, Last: last}, nil
+}
+
+// Head - returns the position of the last record held by the segments.
+func Head(segments []Segment) (head Position) {
+	for _, segment := range segments {
+		if last := (Position{Seq: segment.Last, Node: segment.Node}); head.Less(last) {
+			head = last
+		}
+	}
+	return head
+}
+
+// Page - returns up to limit records after the position from, in journal
+// order, read from the segments. A limit <= 0 returns all records.
+// Segments of different servers overlap, segments are read until no
+// unread segment can hold an earlier record.
+func Page(segments []Segment, from Position, limit int, read func(Segment) ([]Record, error)) ([]Record, error) {
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].First < segments[j].First
+	})
+
+	var records []Record
+	for _, segment := range segments {
+		if segment.Last < from.Seq {
+			continue
+		}
+		if limit > 0 && len(records) >= limit {
+			sortRecords(records)
+			if segment.First > records[limit-1].Seq {
+				break
+			}
+		}
+		segmentRecords, err := read(segment)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range segmentRecords {
+			if from.Less(record.Position()) {
+				records = append(records, record)
+			}
+		}
+	}
+
+	sortRecords(records)
+	if limit > 0 && len(records) > limit {
+		records = records[:limit]
+	}
+	return records, nil
+}
+
+func sortRecords(records []Record) {
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Position().Less(records[j].Position())
+	})
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package journal
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/event"
+)
+
+func newRecord(seq uint64, node string) Record {
+	var ev event.Event
+	ev.EventName = event.ObjectCreatedPut
+	ev.S3.Object.Sequencer = fmt.Sprintf("%X", seq)
+	return Record{Seq: seq, Node: node, Event: ev}
+}
+
+func TestSeq(t *testing.T) {
+	now := time.Now()
+	var ev event.Event
+	ev.S3.Object.Sequencer = fmt.Sprintf("%X", now.UnixNano())
+	if seq := Seq(ev); seq != TimeSeq(now) {
+		t.Errorf("expected %d, got %d", TimeSeq(now), seq)
+	}
+	ev.S3.Object.Sequencer = "invalid"
+	if seq := Seq(ev); seq != 0 {
+		t.Errorf("expected 0, got %d", seq)
+	}
+}
+
+func TestSegmentName(t *testing.T) {
+	name := SegmentName(5, 42, "4ca0c6e6-7b5e-4e61-b3fb-9d54c3f5d79e")
+	segment, err := ParseSegmentName(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if segment.First != 5 || segment.Last != 42 || segment.Name != name || segment.Node != "4ca0c6e6-7b5e-4e61-b3fb-9d54c3f5d79e" {
+		t.Errorf("unexpected segment %#v", segment)
+	}
+	if SegmentName(5, 42, "a") > SegmentName(10, 11, "a") {
+		t.Error("segment names do not sort by first sequence number")
+	}
+
+	for _, name := range []string{
+		"",
+		"00000000000000000005-00000000000000000042.json",
+		"00000000000000000005-00000000000000000042-id.log",
+		"00000000000000000042-00000000000000000005-id.json",
+		"x-00000000000000000042-id.json",
+	} {
+		if _, err := ParseSegmentName(name); err == nil {
+			t.Errorf("%q: expected error", name)
+		}
+	}
+}
+
+func TestEncodeRecords(t *testing.T) {
+	records := []Record{newRecord(1, "a"), newRecord(2, "a"), newRecord(3, "a")}
+	data, err := EncodeRecords(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeRecords(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(records, decoded) {
+		t.Errorf("expected %v, got %v", records, decoded)
+	}
+}
+
+func TestPage(t *testing.T) {
+	// Overlapping segments written by two servers,
+	// both recorded an event with sequence number 5.
+	stored := map[string][]Record{
+		"a1": {newRecord(1, "a"), newRecord(3, "a"), newRecord(5, "a")},
+		"b1": {newRecord(2, "b"), newRecord(4, "b"), newRecord(5, "b")},
+		"a2": {newRecord(6, "a"), newRecord(8, "a")},
+		"b2": {newRecord(7, "b"), newRecord(9, "b")},
+	}
+	segments := []Segment{
+		{Name: "b2", Node: "b", First: 7, Last: 9},
+		{Name: "a1", Node: "a", First: 1, Last: 5},
+		{Name: "a2", Node: "a", First: 6, Last: 8},
+		{Name: "b1", Node: "b", First: 2, Last: 5},
+	}
+
+	testCases := []struct {
+		from     Position
+		limit    int
+		expected []string
+		reads    int
+	}{
+		{Position{}, 0, []string{"1a", "2b", "3a", "4b", "5a", "5b", "6a", "7b", "8a", "9b"}, 4},
+		{Position{}, 3, []string{"1a", "2b", "3a"}, 2},
+		{Position{Seq: 3, Node: "a"}, 3, []string{"4b", "5a", "5b"}, 2},
+		{Position{Seq: 5, Node: "a"}, 2, []string{"5b", "6a"}, 3},
+		{Position{Seq: 5}, 2, []string{"5a", "5b"}, 2},
+		{Position{Seq: 8, Node: "a"}, 10, []string{"9b"}, 2},
+		{Position{Seq: 9, Node: "b"}, 10, nil, 1},
+	}
+
+	for i, testCase := range testCases {
+		reads := 0
+		records, err := Page(segments, testCase.from, testCase.limit, func(segment Segment) ([]Record, error) {
+			reads++
+			return stored[segment.Name], nil
+		})
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		var positions []string
+		for _, record := range records {
+			positions = append(positions, fmt.Sprintf("%d%s", record.Seq, record.Node))
+		}
+		if !reflect.DeepEqual(positions, testCase.expected) {
+			t.Errorf("test %d: expected %v, got %v", i+1, testCase.expected, positions)
+		}
+		if reads != testCase.reads {
+			t.Errorf("test %d: expected %d segment reads, got %d", i+1, testCase.reads, reads)
+		}
+	}
+
+	if head := Head(segments); head != (Position{Seq: 9, Node: "b"}) {
+		t.Errorf("expected head 9b, got %v", head)
+	}
+}
+
+func TestLookupConfig(t *testing.T) {
+	kvs := config.KVS{
+		config.KV{Key: config.Enable, Value: config.EnableOn},
+		config.KV{Key: Buckets, Value: "photos, logs-*"},
+		config.KV{Key: Retention, Value: "1h"},
+	}
+	cfg, err := LookupConfig(kvs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Retention != time.Hour {
+		t.Errorf("expected retention 1h, got %s", cfg.Retention)
+	}
+	for bucket, enabled := range map[string]bool{
+		"photos":      true,
+		"logs-2021":   true,
+		"photos-2021": false,
+	} {
+		if cfg.BucketEnabled(bucket) != enabled {
+			t.Errorf("%s: expected %v", bucket, enabled)
+		}
+	}
+
+	cfg, err = LookupConfig(DefaultKVS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.BucketEnabled("photos") {
+		t.Error("expected the event journal to be disabled by default")
+	}
+
+	kvs[2].Value = "0s"
+	if _, err = LookupConfig(kvs); err == nil {
+		t.Error("expected error for non-positive retention")
+	}
+}
@@ -46,18 +46,36 @@ func NewPattern(prefix, suffix string) (pattern string) {
 	return pattern
 }
 
-// Rules - event rules
-type Rules map[string]TargetIDSet
+// ruleEntry - object name pattern, object filter and target IDs of
+// a rule.
+type ruleEntry struct {
+	Pattern   string
+	Filter    *ObjectFilter
+	TargetIDs TargetIDSet
+}
+
+// Rules - event rules by rule key, which identifies the object name
+// pattern and the object filter of a rule.
+type Rules map[string]ruleEntry
 
 // Add - adds pattern and target ID.
 func (rules Rules) Add(pattern string, targetID TargetID) {
-	rules[pattern] = NewTargetIDSet(targetID).Union(rules[pattern])
+	rules.add(pattern, pattern, nil, targetID)
+}
+
+func (rules Rules) add(key, pattern string, filter *ObjectFilter, targetID TargetID) {
+	rules[key] = ruleEntry{
+		Pattern:   pattern,
+		Filter:    filter,
+		TargetIDs: NewTargetIDSet(targetID).Union(rules[key].TargetIDs),
+	}
 }
 
 // MatchSimple - returns true one of the matching object name in rules.
+// Object filters of the rules are not evaluated.
 func (rules Rules) MatchSimple(objectName string) bool {
-	for pattern := range rules {
-		if wildcard.MatchSimple(pattern, objectName) {
+	for _, rule := range rules {
+		if wildcard.MatchSimple(rule.Pattern, objectName) {
 			return true
 		}
 	}
@@ -66,12 +84,22 @@ func (rules Rules) MatchSimple(objectName string) bool {
 
 // Match - returns TargetIDSet matching object name in rules.
 func (rules Rules) Match(objectName string) TargetIDSet {
+	return rules.MatchObject(ObjectProperties{Name: objectName})
+}
+
+// MatchObject - returns TargetIDSet of the rules matching the object
+// name and the object filter of the rule, if any.
+func (rules Rules) MatchObject(object ObjectProperties) TargetIDSet {
 	targetIDs := NewTargetIDSet()
 
-	for pattern, targetIDSet := range rules {
-		if wildcard.MatchSimple(pattern, objectName) {
-			targetIDs = targetIDs.Union(targetIDSet)
+	for _, rule := range rules {
+		if !wildcard.MatchSimple(rule.Pattern, object.Name) {
+			continue
+		}
+		if rule.Filter != nil && !rule.Filter.Match(object) {
+			continue
 		}
+		targetIDs = targetIDs.Union(rule.TargetIDs)
 	}
 
 	return targetIDs
@@ -81,8 +109,9 @@ func (rules Rules) Match(objectName string) TargetIDSet {
 func (rules Rules) Clone() Rules {
 	rulesCopy := make(Rules)
 
-	for pattern, targetIDSet := range rules {
-		rulesCopy[pattern] = targetIDSet.Clone()
+	for key, rule := range rules {
+		rule.TargetIDs = rule.TargetIDs.Clone()
+		rulesCopy[key] = rule
 	}
 
 	return rulesCopy
@@ -92,8 +121,9 @@ func (rules Rules) Clone() Rules {
 func (rules Rules) Union(rules2 Rules) Rules {
 	nrules := rules.Clone()
 
-	for pattern, targetIDSet := range rules2 {
-		nrules[pattern] = nrules[pattern].Union(targetIDSet)
+	for key, rule := range rules2 {
+		rule.TargetIDs = nrules[key].TargetIDs.Union(rule.TargetIDs)
+		nrules[key] = rule
 	}
 
 	return nrules
@@ -103,9 +133,10 @@ func (rules Rules) Union(rules2 Rules) Rules {
 func (rules Rules) Difference(rules2 Rules) Rules {
 	nrules := make(Rules)
 
-	for pattern, targetIDSet := range rules {
-		if nv := targetIDSet.Difference(rules2[pattern]); len(nv) > 0 {
-			nrules[pattern] = nv
+	for key, rule := range rules {
+		if nv := rule.TargetIDs.Difference(rules2[key].TargetIDs); len(nv) > 0 {
+			rule.TargetIDs = nv
+			nrules[key] = rule
 		}
 	}
 
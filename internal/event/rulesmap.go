@@ -20,10 +20,20 @@ package event
 // RulesMap - map of rules for every event name.
 type RulesMap map[Name]Rules
 
-// add - adds event names, prefixes, suffixes and target ID to rules map.
+// add - adds event names, pattern and target ID to rules map.
 func (rulesMap RulesMap) add(eventNames []Name, pattern string, targetID TargetID) {
+	rulesMap.addFiltered(eventNames, pattern, ObjectFilter{}, targetID)
+}
+
+// addFiltered - adds event names, pattern, object filter and target
+// ID to rules map.
+func (rulesMap RulesMap) addFiltered(eventNames []Name, pattern string, filter ObjectFilter, targetID TargetID) {
 	rules := make(Rules)
-	rules.Add(pattern, targetID)
+	if filter.IsEmpty() {
+		rules.Add(pattern, targetID)
+	} else {
+		rules.add(ruleKey(pattern, filter), pattern, &filter, targetID)
+	}
 
 	for _, eventName := range eventNames {
 		for _, name := range eventName.Expand() {
@@ -71,6 +81,12 @@ func (rulesMap RulesMap) Match(eventName Name, objectName string) TargetIDSet {
 	return rulesMap[eventName].Match(objectName)
 }
 
+// MatchObject - returns TargetIDSet matching the object and event name
+// in rules map, evaluating the object filters of the rules.
+func (rulesMap RulesMap) MatchObject(eventName Name, object ObjectProperties) TargetIDSet {
+	return rulesMap[eventName].MatchObject(object)
+}
+
 // NewRulesMap - creates new rules map with given values.
 func NewRulesMap(eventNames []Name, pattern string, targetID TargetID) RulesMap {
 	// If pattern is empty, add '*' wildcard to match all.
@@ -82,3 +98,16 @@ func NewRulesMap(eventNames []Name, pattern string, targetID TargetID) RulesMap
 	rulesMap.add(eventNames, pattern, targetID)
 	return rulesMap
 }
+
+// NewFilteredRulesMap - creates new rules map with given values, events
+// are only matched for objects passing the filter.
+func NewFilteredRulesMap(eventNames []Name, pattern string, filter ObjectFilter, targetID TargetID) RulesMap {
+	// If pattern is empty, add '*' wildcard to match all.
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	rulesMap := make(RulesMap)
+	rulesMap.addFiltered(eventNames, pattern, filter, targetID)
+	return rulesMap
+}
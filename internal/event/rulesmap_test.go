@@ -18,6 +18,8 @@
 package event
 
 import (
+	"bytes"
+	"encoding/gob"
 	"reflect"
 	"testing"
 )
@@ -183,3 +185,43 @@ func TestNewRulesMap(t *testing.T) {
 		}
 	}
 }
+
+func TestFilteredRulesMapEncoding(t *testing.T) {
+	minSize := int64(10)
+	filter := ObjectFilter{MinSize: &minSize, ContentTypes: []string{"image/*"}}
+	rulesMap := NewFilteredRulesMap([]Name{ObjectCreatedPut}, "*", filter, TargetID{"1", "webhook"})
+	rulesMap.Add(NewRulesMap([]Name{ObjectCreatedPut}, "*", TargetID{"2", "amqp"}))
+
+	// Rules maps are sent to the peers gob encoded.
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(rulesMap); err != nil {
+		t.Fatal(err)
+	}
+	var decoded RulesMap
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		object         ObjectProperties
+		expectedResult TargetIDSet
+	}{
+		{ObjectProperties{Name: "photo.jpg", Size: 20, ContentType: "image/jpeg"}, NewTargetIDSet(TargetID{"1", "webhook"}, TargetID{"2", "amqp"})},
+		{ObjectProperties{Name: "photo.jpg", Size: 5, ContentType: "image/jpeg"}, NewTargetIDSet(TargetID{"2", "amqp"})},
+		{ObjectProperties{Name: "notes.txt", Size: 20, ContentType: "text/plain"}, NewTargetIDSet(TargetID{"2", "amqp"})},
+	}
+
+	for i, testCase := range testCases {
+		result := decoded.MatchObject(ObjectCreatedPut, testCase.object)
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Errorf("test %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	decoded.Remove(NewFilteredRulesMap([]Name{ObjectCreatedPut}, "*", filter, TargetID{"1", "webhook"}))
+	result := decoded.MatchObject(ObjectCreatedPut, testCases[0].object)
+	if expected := NewTargetIDSet(TargetID{"2", "amqp"}); !reflect.DeepEqual(result, expected) {
+		t.Errorf("result: expected: %v, got: %v", expected, result)
+	}
+}
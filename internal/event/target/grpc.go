@@ -0,0 +1,544 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+// gRPC constants
+const (
+	GRPCEndpoint      = "endpoint"
+	GRPCAuthToken     = "auth_token"
+	GRPCTLS           = "tls"
+	GRPCTLSSkipVerify = "tls_skip_verify"
+	GRPCCertAuthority = "cert_authority"
+	GRPCClientCert    = "client_cert"
+	GRPCClientKey     = "client_key"
+	GRPCQueueDir      = "queue_dir"
+	GRPCQueueLimit    = "queue_limit"
+
+	EnvGRPCEnable        = "MINIO_NOTIFY_GRPC_ENABLE"
+	EnvGRPCEndpoint      = "MINIO_NOTIFY_GRPC_ENDPOINT"
+	EnvGRPCAuthToken     = "MINIO_NOTIFY_GRPC_AUTH_TOKEN"
+	EnvGRPCTLS           = "MINIO_NOTIFY_GRPC_TLS"
+	EnvGRPCTLSSkipVerify = "MINIO_NOTIFY_GRPC_TLS_SKIP_VERIFY"
+	EnvGRPCCertAuthority = "MINIO_NOTIFY_GRPC_CERT_AUTHORITY"
+	EnvGRPCClientCert    = "MINIO_NOTIFY_GRPC_CLIENT_CERT"
+	EnvGRPCClientKey     = "MINIO_NOTIFY_GRPC_CLIENT_KEY"
+	EnvGRPCQueueDir      = "MINIO_NOTIFY_GRPC_QUEUE_DIR"
+	EnvGRPCQueueLimit    = "MINIO_NOTIFY_GRPC_QUEUE_LIMIT"
+)
+
+// The sink implements the `minio.notify.v1.EventSink` service
+// published in docs/bucket/notifications/grpc/eventsink.proto.
+const (
+	grpcPublishMethod = "/minio.notify.v1.EventSink/Publish"
+	grpcSendTimeout   = 10 * time.Second
+)
+
+var grpcPublishStream = &grpc.StreamDesc{
+	StreamName:    "Publish",
+	ServerStreams: true,
+	ClientStreams: true,
+}
+
+// GRPCArgs - gRPC target arguments.
+type GRPCArgs struct {
+	Enable        bool      `json:"enable"`
+	Endpoint      xnet.Host `json:"endpoint"`
+	AuthToken     string    `json:"authToken"`
+	TLS           bool      `json:"tls"`
+	TLSSkipVerify bool      `json:"tlsSkipVerify"`
+	CertAuthority string    `json:"certAuthority"`
+	ClientCert    string    `json:"clientCert"`
+	ClientKey     string    `json:"clientKey"`
+	QueueDir      string    `json:"queueDir"`
+	QueueLimit    uint64    `json:"queueLimit"`
+
+	RootCAs *x509.CertPool `json:"-"`
+}
+
+// Validate GRPCArgs fields
+func (g GRPCArgs) Validate() error {
+	if !g.Enable {
+		return nil
+	}
+
+	if g.Endpoint.IsEmpty() {
+		return errors.New("empty endpoint")
+	}
+
+	if g.ClientCert != "" && g.ClientKey == "" || g.ClientCert == "" && g.ClientKey != "" {
+		return errors.New("cert and key must be specified as a pair")
+	}
+
+	if !g.TLS && (g.ClientCert != "" || g.CertAuthority != "") {
+		return errors.New("tls must be enabled to use certificates")
+	}
+
+	if g.QueueDir != "" {
+		if !filepath.IsAbs(g.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+
+	return nil
+}
+
+// To obtain the gRPC dial options from args.
+func (g GRPCArgs) dialOptions() ([]grpc.DialOption, error) {
+	if !g.TLS {
+		opts := []grpc.DialOption{grpc.WithInsecure()}
+		if g.AuthToken != "" {
+			opts = append(opts, grpc.WithPerRPCCredentials(grpcAuthToken{token: g.AuthToken}))
+		}
+		return opts, nil
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:            g.RootCAs,
+		InsecureSkipVerify: g.TLSSkipVerify,
+	}
+	if g.CertAuthority != "" {
+		caCert, err := ioutil.ReadFile(g.CertAuthority)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in %s", g.CertAuthority)
+		}
+	}
+	if g.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(g.ClientCert, g.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	if g.AuthToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(grpcAuthToken{token: g.AuthToken, secure: true}))
+	}
+	return opts, nil
+}
+
+// grpcAuthToken sends the configured auth token as the `authorization`
+// metadata of every stream, in the same format as the webhook target.
+type grpcAuthToken struct {
+	token  string
+	secure bool
+}
+
+func (a grpcAuthToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	// Verify if the authToken already contains
+	// <Key> <Token> like format.
+	if len(strings.Fields(a.token)) == 2 {
+		return map[string]string{"authorization": a.token}, nil
+	}
+	return map[string]string{"authorization": "Bearer " + a.token}, nil
+}
+
+func (a grpcAuthToken) RequireTransportSecurity() bool {
+	return a.secure
+}
+
+// grpcEvent - wire representation of `minio.notify.v1.Event`.
+type grpcEvent struct {
+	ID        string
+	EventName string
+	Key       string
+	Records   []byte
+}
+
+func (e *grpcEvent) marshal() []byte {
+	var b []byte
+	b = appendProtoString(b, 1, e.ID)
+	b = appendProtoString(b, 2, e.EventName)
+	b = appendProtoString(b, 3, e.Key)
+	if len(e.Records) > 0 {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendBytes(b, e.Records)
+	}
+	return b
+}
+
+func (e *grpcEvent) unmarshal(b []byte) error {
+	return parseProto(b, func(num protowire.Number, v []byte) {
+		switch num {
+		case 1:
+			e.ID = string(v)
+		case 2:
+			e.EventName = string(v)
+		case 3:
+			e.Key = string(v)
+		case 4:
+			e.Records = append([]byte(nil), v...)
+		}
+	})
+}
+
+// grpcAck - wire representation of `minio.notify.v1.Ack`.
+type grpcAck struct {
+	ID    string
+	Error string
+}
+
+func (a *grpcAck) marshal() []byte {
+	var b []byte
+	b = appendProtoString(b, 1, a.ID)
+	b = appendProtoString(b, 2, a.Error)
+	return b
+}
+
+func (a *grpcAck) unmarshal(b []byte) error {
+	return parseProto(b, func(num protowire.Number, v []byte) {
+		switch num {
+		case 1:
+			a.ID = string(v)
+		case 2:
+			a.Error = string(v)
+		}
+	})
+}
+
+func appendProtoString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+// parseProto calls fn for every length delimited field in b,
+// other wire types are skipped as unknown fields.
+func parseProto(b []byte, fn func(num protowire.Number, v []byte)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		fn(num, v)
+		b = b[n:]
+	}
+	return nil
+}
+
+// grpcCodec marshals the EventSink messages in the protobuf wire
+// format, it is registered under the "proto" content-subtype so
+// that any standard gRPC server can decode them.
+type grpcCodec struct{}
+
+type grpcMessage interface {
+	marshal() []byte
+	unmarshal([]byte) error
+}
+
+func (grpcCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, ok := v.(grpcMessage)
+	if !ok {
+		return nil, fmt.Errorf("grpc: unexpected message type %T", v)
+	}
+	return msg.marshal(), nil
+}
+
+func (grpcCodec) Unmarshal(data []byte, v interface{}) error {
+	msg, ok := v.(grpcMessage)
+	if !ok {
+		return fmt.Errorf("grpc: unexpected message type %T", v)
+	}
+	return msg.unmarshal(data)
+}
+
+func (grpcCodec) Name() string {
+	return "proto"
+}
+
+// GRPCTarget - gRPC streaming sink target.
+type GRPCTarget struct {
+	id         event.TargetID
+	args       GRPCArgs
+	conn       *grpc.ClientConn
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+
+	// Publish stream, opened lazily and re-opened after failures.
+	mu     sync.Mutex
+	stream grpc.ClientStream
+	cancel context.CancelFunc
+}
+
+// ID - returns target ID.
+func (target *GRPCTarget) ID() event.TargetID {
+	return target.id
+}
+
+// HasQueueStore - Checks if the queueStore has been configured for the target
+func (target *GRPCTarget) HasQueueStore() bool {
+	return target.store != nil
+}
+
+// IsActive - Return true if target is up and active
+func (target *GRPCTarget) IsActive() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), reconnectInterval*time.Second)
+	defer cancel()
+
+	for {
+		state := target.conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return true, nil
+		case connectivity.Idle:
+			target.conn.Connect()
+		case connectivity.Shutdown:
+			return false, errNotConnected
+		}
+		if !target.conn.WaitForStateChange(ctx, state) {
+			return false, errNotConnected
+		}
+	}
+}
+
+// Save - saves the events to the store which will be replayed when the gRPC sink is active.
+func (target *GRPCTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	_, err := target.IsActive()
+	if err != nil {
+		return err
+	}
+	return target.send(eventData)
+}
+
+// send - sends an event to the gRPC sink and waits for its acknowledgement.
+func (target *GRPCTarget) send(eventData event.Event) error {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return err
+	}
+	key := eventData.S3.Bucket.Name + "/" + objectName
+
+	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	if err != nil {
+		return err
+	}
+
+	id, err := getNewUUID()
+	if err != nil {
+		return err
+	}
+
+	msg := &grpcEvent{
+		ID:        id,
+		EventName: eventData.EventName.String(),
+		Key:       key,
+		Records:   data,
+	}
+
+	target.mu.Lock()
+	defer target.mu.Unlock()
+
+	if target.stream == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := target.conn.NewStream(ctx, grpcPublishStream, grpcPublishMethod, grpc.ForceCodec(grpcCodec{}))
+		if err != nil {
+			cancel()
+			return grpcError(err)
+		}
+		target.stream, target.cancel = stream, cancel
+	}
+
+	// Abort the stream if the sink does not acknowledge in time.
+	timer := time.AfterFunc(grpcSendTimeout, target.cancel)
+	ack, err := target.exchange(msg)
+	timer.Stop()
+	if err != nil {
+		target.closeStream()
+		return grpcError(err)
+	}
+
+	if ack.ID != msg.ID {
+		target.closeStream()
+		return fmt.Errorf("unexpected acknowledgement %q for event %q", ack.ID, msg.ID)
+	}
+	if ack.Error != "" {
+		return fmt.Errorf("event rejected by gRPC sink: %s", ack.Error)
+	}
+	return nil
+}
+
+// exchange - sends msg on the publish stream and reads the next acknowledgement.
+func (target *GRPCTarget) exchange(msg *grpcEvent) (*grpcAck, error) {
+	ack := &grpcAck{}
+	if err := target.stream.SendMsg(msg); err != nil {
+		if err != io.EOF {
+			return nil, err
+		}
+		// The stream was closed by the server, the actual
+		// status is returned by RecvMsg.
+	}
+	if err := target.stream.RecvMsg(ack); err != nil {
+		return nil, err
+	}
+	return ack, nil
+}
+
+// closeStream - aborts the current publish stream, must be called with mu held.
+func (target *GRPCTarget) closeStream() {
+	if target.cancel != nil {
+		target.cancel()
+	}
+	target.stream, target.cancel = nil, nil
+}
+
+// grpcError - maps transport level failures to errNotConnected.
+func grpcError(err error) error {
+	if err == io.EOF {
+		return errNotConnected
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Canceled, codes.DeadlineExceeded:
+		return errNotConnected
+	}
+	return err
+}
+
+// Send - reads an event from store and sends it to the gRPC sink.
+func (target *GRPCTarget) Send(eventKey string) error {
+	_, err := target.IsActive()
+	if err != nil {
+		return err
+	}
+
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// Such events will not exist and wouldve been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - closes the publish stream and the underlying connection.
+func (target *GRPCTarget) Close() error {
+	target.mu.Lock()
+	target.closeStream()
+	target.mu.Unlock()
+	if target.conn != nil {
+		return target.conn.Close()
+	}
+	return nil
+}
+
+// NewGRPCTarget - creates new gRPC target.
+func NewGRPCTarget(id string, args GRPCArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), test bool) (*GRPCTarget, error) {
+	var store Store
+
+	target := &GRPCTarget{
+		id:         event.TargetID{ID: id, Name: "grpc"},
+		args:       args,
+		loggerOnce: loggerOnce,
+	}
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-grpc-"+id)
+		store = NewQueueStore(queueDir, args.QueueLimit)
+		if oErr := store.Open(); oErr != nil {
+			target.loggerOnce(context.Background(), oErr, target.ID())
+			return target, oErr
+		}
+		target.store = store
+	}
+
+	opts, err := args.dialOptions()
+	if err != nil {
+		target.loggerOnce(context.Background(), err, target.ID())
+		return target, err
+	}
+
+	conn, err := grpc.Dial(args.Endpoint.String(), opts...)
+	if err != nil {
+		target.loggerOnce(context.Background(), err, target.ID())
+		return target, err
+	}
+	target.conn = conn
+
+	if _, err = target.IsActive(); err != nil {
+		if target.store == nil || err != errNotConnected {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+	}
+
+	if target.store != nil && !test {
+		// Replays the events from the store.
+		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
+		// Start replaying events from the store.
+		go sendEvents(target, target.store, eventKeyCh, doneCh, target.loggerOnce)
+	}
+
+	return target, nil
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+// grpcTestSink - minimal EventSink server acknowledging every event,
+// events whose key ends with "reject" are rejected.
+type grpcTestSink struct {
+	mu     sync.Mutex
+	events []grpcEvent
+	tokens []string
+}
+
+func (s *grpcTestSink) publish(srv interface{}, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+	if method != grpcPublishMethod {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	for {
+		var ev grpcEvent
+		if err := stream.RecvMsg(&ev); err != nil {
+			return nil
+		}
+		s.mu.Lock()
+		s.events = append(s.events, ev)
+		s.tokens = append(s.tokens, md.Get("authorization")...)
+		s.mu.Unlock()
+
+		ack := &grpcAck{ID: ev.ID}
+		if len(ev.Key) > 6 && ev.Key[len(ev.Key)-6:] == "reject" {
+			ack.Error = "rejected"
+		}
+		if err := stream.SendMsg(ack); err != nil {
+			return err
+		}
+	}
+}
+
+func startGRPCTestSink(t *testing.T) (*grpcTestSink, xnet.Host, func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &grpcTestSink{}
+	srv := grpc.NewServer(grpc.ForceServerCodec(grpcCodec{}), grpc.UnknownServiceHandler(sink.publish))
+	go srv.Serve(l)
+
+	port := l.Addr().(*net.TCPAddr).Port
+	host := xnet.Host{Name: "127.0.0.1", Port: xnet.Port(port), IsPortSet: true}
+	return sink, host, srv.Stop
+}
+
+func TestGRPCTarget(t *testing.T) {
+	sink, host, stop := startGRPCTestSink(t)
+	defer stop()
+
+	args := GRPCArgs{
+		Enable:    true,
+		Endpoint:  host,
+		AuthToken: "secret",
+	}
+	if err := args.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	loggerOnce := func(ctx context.Context, err error, id interface{}, kind ...interface{}) {}
+	target, err := NewGRPCTarget("1", args, nil, loggerOnce, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+
+	if active, err := target.IsActive(); !active || err != nil {
+		t.Fatalf("expected target to be active, got %v, %v", active, err)
+	}
+
+	for _, key := range []string{"object1", "object2", "object-reject"} {
+		eventData := event.Event{
+			EventName: event.ObjectCreatedPut,
+			S3: event.Metadata{
+				Bucket: event.Bucket{Name: "bucket"},
+				Object: event.Object{Key: key},
+			},
+		}
+		err = target.Save(eventData)
+		if key == "object-reject" {
+			if err == nil {
+				t.Fatal("expected rejected event to fail")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+
+	if len(sink.events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(sink.events))
+	}
+	ev := sink.events[0]
+	if ev.Key != "bucket/object1" || ev.EventName != event.ObjectCreatedPut.String() || ev.ID == "" {
+		t.Fatalf("unexpected event %+v", ev)
+	}
+	var log event.Log
+	if err = json.Unmarshal(ev.Records, &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Key != "bucket/object1" || len(log.Records) != 1 {
+		t.Fatalf("unexpected records %s", ev.Records)
+	}
+	// All events share one stream.
+	if len(sink.tokens) != 3 || sink.tokens[0] != "Bearer secret" {
+		t.Fatalf("unexpected authorization %v", sink.tokens)
+	}
+}
+
+func TestGRPCTargetOffline(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	args := GRPCArgs{
+		Enable:   true,
+		Endpoint: xnet.Host{Name: "127.0.0.1", Port: xnet.Port(port), IsPortSet: true},
+		QueueDir: t.TempDir(),
+	}
+
+	loggerOnce := func(ctx context.Context, err error, id interface{}, kind ...interface{}) {}
+	target, err := NewGRPCTarget("1", args, nil, loggerOnce, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+
+	if err = target.Save(event.Event{EventName: event.ObjectRemovedDelete}); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := target.store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("expected 1 queued event, got %d", len(keys))
+	}
+}
+
+func TestGRPCCodec(t *testing.T) {
+	in := &grpcEvent{ID: "id", EventName: "s3:ObjectCreated:Put", Key: "bucket/object", Records: []byte(`{}`)}
+	data, err := grpcCodec{}.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := &grpcEvent{}
+	if err = (grpcCodec{}).Unmarshal(data, out); err != nil {
+		t.Fatal(err)
+	}
+	if out.ID != in.ID || out.EventName != in.EventName || out.Key != in.Key || string(out.Records) != string(in.Records) {
+		t.Fatalf("expected %+v, got %+v", in, out)
+	}
+	if _, err = (grpcCodec{}).Marshal(in.ID); err == nil {
+		t.Fatal("expected error for unknown message type")
+	}
+}
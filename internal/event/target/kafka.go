@@ -27,6 +27,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/minio/minio/internal/event"
 	xnet "github.com/minio/pkg/net"
@@ -51,6 +52,8 @@ const (
 	KafkaClientTLSCert = "client_tls_cert"
 	KafkaClientTLSKey  = "client_tls_key"
 	KafkaVersion       = "version"
+	KafkaMessageKey    = "message_key"
+	KafkaIdempotent    = "idempotent"
 
 	EnvKafkaEnable        = "MINIO_NOTIFY_KAFKA_ENABLE"
 	EnvKafkaBrokers       = "MINIO_NOTIFY_KAFKA_BROKERS"
@@ -67,8 +70,14 @@ const (
 	EnvKafkaClientTLSCert = "MINIO_NOTIFY_KAFKA_CLIENT_TLS_CERT"
 	EnvKafkaClientTLSKey  = "MINIO_NOTIFY_KAFKA_CLIENT_TLS_KEY"
 	EnvKafkaVersion       = "MINIO_NOTIFY_KAFKA_VERSION"
+	EnvKafkaMessageKey    = "MINIO_NOTIFY_KAFKA_MESSAGE_KEY"
+	EnvKafkaIdempotent    = "MINIO_NOTIFY_KAFKA_IDEMPOTENT"
 )
 
+// KafkaEventIDHeader - message header carrying the unique ID of an event,
+// the ID stays the same when a queued event is sent again.
+const KafkaEventIDHeader = "minio-event-id"
+
 // KafkaArgs - Kafka target arguments.
 type KafkaArgs struct {
 	Enable     bool        `json:"enable"`
@@ -77,6 +86,8 @@ type KafkaArgs struct {
 	QueueDir   string      `json:"queueDir"`
 	QueueLimit uint64      `json:"queueLimit"`
 	Version    string      `json:"version"`
+	MessageKey string      `json:"messageKey"`
+	Idempotent bool        `json:"idempotent"`
 	TLS        struct {
 		Enable        bool               `json:"enable"`
 		RootCAs       *x509.CertPool     `json:"-"`
@@ -112,13 +123,31 @@ func (k KafkaArgs) Validate() error {
 		}
 	}
 	if k.Version != "" {
-		if _, err := sarama.ParseKafkaVersion(k.Version); err != nil {
+		version, err := sarama.ParseKafkaVersion(k.Version)
+		if err != nil {
 			return err
 		}
+		if k.Idempotent && !version.IsAtLeast(sarama.V0_11_0_0) {
+			return errors.New("idempotent producer requires Kafka version 0.11.0.0 or later")
+		}
 	}
 	return nil
 }
 
+// messageKey - returns the message key of an event, bucket/object unless
+// a key template is configured. Templates may refer to ${bucket}, ${object}
+// and ${event}.
+func (k KafkaArgs) messageKey(bucket, object string, eventName event.Name) string {
+	if k.MessageKey == "" {
+		return bucket + "/" + object
+	}
+	return strings.NewReplacer(
+		"${bucket}", bucket,
+		"${object}", object,
+		"${event}", eventName.String(),
+	).Replace(k.MessageKey)
+}
+
 // KafkaTarget - Kafka target.
 type KafkaTarget struct {
 	id         event.TargetID
@@ -156,11 +185,15 @@ func (target *KafkaTarget) Save(eventData event.Event) error {
 	if err != nil {
 		return err
 	}
-	return target.send(eventData)
+	eventID, err := getNewUUID()
+	if err != nil {
+		return err
+	}
+	return target.send(eventData, eventID)
 }
 
 // send - sends an event to the kafka.
-func (target *KafkaTarget) send(eventData event.Event) error {
+func (target *KafkaTarget) send(eventData event.Event, eventID string) error {
 	if target.producer == nil {
 		return errNotConnected
 	}
@@ -177,9 +210,15 @@ func (target *KafkaTarget) send(eventData event.Event) error {
 
 	msg := sarama.ProducerMessage{
 		Topic: target.args.Topic,
-		Key:   sarama.StringEncoder(key),
+		Key:   sarama.StringEncoder(target.args.messageKey(eventData.S3.Bucket.Name, objectName, eventData.EventName)),
 		Value: sarama.ByteEncoder(data),
 	}
+	// Message headers are only supported by Kafka 0.11.0.0 and later.
+	if target.config.Version.IsAtLeast(sarama.V0_11_0_0) {
+		msg.Headers = []sarama.RecordHeader{
+			{Key: []byte(KafkaEventIDHeader), Value: []byte(eventID)},
+		}
+	}
 
 	_, _, err = target.producer.SendMessage(&msg)
 
@@ -218,7 +257,8 @@ func (target *KafkaTarget) Send(eventKey string) error {
 		return eErr
 	}
 
-	err = target.send(eventData)
+	// The store key identifies the event across retries.
+	err = target.send(eventData, eventKey)
 	if err != nil {
 		// Sarama opens the ciruit breaker after 3 consecutive connection failures.
 		if err == sarama.ErrLeaderNotAvailable || err.Error() == "circuit breaker is open" {
@@ -291,6 +331,12 @@ func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}, loggerOnc
 	config.Producer.RequiredAcks = sarama.WaitForAll
 	config.Producer.Retry.Max = 10
 	config.Producer.Return.Successes = true
+	if args.Idempotent {
+		// Retries of the producer neither duplicate nor reorder messages
+		// of a partition, which requires a single in-flight request.
+		config.Producer.Idempotent = true
+		config.Net.MaxOpenRequests = 1
+	}
 
 	target.config = config
 
@@ -324,7 +370,7 @@ func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}, loggerOnc
 		// Replays the events from the store.
 		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh, target.loggerOnce)
+		go sendEvents(target, target.store, eventKeyCh, doneCh, target.loggerOnce)
 	}
 
 	return target, nil
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"testing"
+
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+func TestKafkaArgsMessageKey(t *testing.T) {
+	testCases := []struct {
+		template string
+		expected string
+	}{
+		{"", "images/photos/a.jpg"},
+		{"${bucket}", "images"},
+		{"${object}", "photos/a.jpg"},
+		{"${event}:${bucket}/${object}", "s3:ObjectCreated:Put:images/photos/a.jpg"},
+		{"static", "static"},
+	}
+
+	for i, testCase := range testCases {
+		args := KafkaArgs{MessageKey: testCase.template}
+		if key := args.messageKey("images", "photos/a.jpg", event.ObjectCreatedPut); key != testCase.expected {
+			t.Errorf("test %d: expected %s, got %s", i+1, testCase.expected, key)
+		}
+	}
+}
+
+func TestKafkaArgsValidate(t *testing.T) {
+	broker := xnet.Host{Name: "localhost", Port: 9092, IsPortSet: true}
+	testCases := []struct {
+		args      KafkaArgs
+		expectErr bool
+	}{
+		{KafkaArgs{Enable: true, Brokers: []xnet.Host{broker}, Idempotent: true}, false},
+		{KafkaArgs{Enable: true, Brokers: []xnet.Host{broker}, Idempotent: true, Version: "2.2.0"}, false},
+		{KafkaArgs{Enable: true, Brokers: []xnet.Host{broker}, Idempotent: true, Version: "0.10.2.0"}, true},
+		{KafkaArgs{Enable: true, Brokers: []xnet.Host{broker}, Version: "0.10.2.0"}, false},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.args.Validate()
+		if testCase.expectErr && err == nil {
+			t.Errorf("test %d: expected error, got none", i+1)
+		}
+		if !testCase.expectErr && err != nil {
+			t.Errorf("test %d: unexpected error %v", i+1, err)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	pulsarlog "github.com/apache/pulsar-client-go/pulsar/log"
+
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+// Pulsar constants
+const (
+	PulsarBroker        = "broker"
+	PulsarTopic         = "topic"
+	PulsarAuthToken     = "auth_token"
+	PulsarTLSSkipVerify = "tls_skip_verify"
+	PulsarCertAuthority = "cert_authority"
+	PulsarClientCert    = "client_cert"
+	PulsarClientKey     = "client_key"
+	PulsarQueueDir      = "queue_dir"
+	PulsarQueueLimit    = "queue_limit"
+
+	EnvPulsarEnable        = "MINIO_NOTIFY_PULSAR_ENABLE"
+	EnvPulsarBroker        = "MINIO_NOTIFY_PULSAR_BROKER"
+	EnvPulsarTopic         = "MINIO_NOTIFY_PULSAR_TOPIC"
+	EnvPulsarAuthToken     = "MINIO_NOTIFY_PULSAR_AUTH_TOKEN"
+	EnvPulsarTLSSkipVerify = "MINIO_NOTIFY_PULSAR_TLS_SKIP_VERIFY"
+	EnvPulsarCertAuthority = "MINIO_NOTIFY_PULSAR_CERT_AUTHORITY"
+	EnvPulsarClientCert    = "MINIO_NOTIFY_PULSAR_CLIENT_CERT"
+	EnvPulsarClientKey     = "MINIO_NOTIFY_PULSAR_CLIENT_KEY"
+	EnvPulsarQueueDir      = "MINIO_NOTIFY_PULSAR_QUEUE_DIR"
+	EnvPulsarQueueLimit    = "MINIO_NOTIFY_PULSAR_QUEUE_LIMIT"
+)
+
+const (
+	pulsarScheme    = "pulsar"
+	pulsarTLSScheme = "pulsar+ssl"
+
+	pulsarOperationTimeout = 10 * time.Second
+)
+
+// PulsarArgs - Pulsar target arguments.
+type PulsarArgs struct {
+	Enable        bool     `json:"enable"`
+	Broker        xnet.URL `json:"broker"`
+	Topic         string   `json:"topic"`
+	AuthToken     string   `json:"authToken"`
+	TLSSkipVerify bool     `json:"tlsSkipVerify"`
+	CertAuthority string   `json:"certAuthority"`
+	ClientCert    string   `json:"clientCert"`
+	ClientKey     string   `json:"clientKey"`
+	QueueDir      string   `json:"queueDir"`
+	QueueLimit    uint64   `json:"queueLimit"`
+}
+
+// Validate PulsarArgs fields
+func (p PulsarArgs) Validate() error {
+	if !p.Enable {
+		return nil
+	}
+
+	if p.Broker.IsEmpty() {
+		return errors.New("empty broker")
+	}
+
+	if p.Broker.Scheme != pulsarScheme && p.Broker.Scheme != pulsarTLSScheme {
+		return errors.New("broker scheme should be 'pulsar' or 'pulsar+ssl'")
+	}
+
+	if p.Topic == "" {
+		return errors.New("empty topic")
+	}
+
+	if p.ClientCert != "" && p.ClientKey == "" || p.ClientCert == "" && p.ClientKey != "" {
+		return errors.New("cert and key must be specified as a pair")
+	}
+
+	if p.ClientCert != "" && p.AuthToken != "" {
+		return errors.New("auth token and client cert cannot be used together")
+	}
+
+	if p.QueueDir != "" {
+		if !filepath.IsAbs(p.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+
+	return nil
+}
+
+// To obtain a pulsar client from args.
+func (p PulsarArgs) newClient() (pulsar.Client, error) {
+	options := pulsar.ClientOptions{
+		URL:                        p.Broker.String(),
+		OperationTimeout:           pulsarOperationTimeout,
+		TLSTrustCertsFilePath:      p.CertAuthority,
+		TLSAllowInsecureConnection: p.TLSSkipVerify,
+		TLSValidateHostname:        !p.TLSSkipVerify,
+		Logger:                     pulsarlog.DefaultNopLogger(),
+	}
+	switch {
+	case p.AuthToken != "":
+		options.Authentication = pulsar.NewAuthenticationToken(p.AuthToken)
+	case p.ClientCert != "":
+		options.Authentication = pulsar.NewAuthenticationTLS(p.ClientCert, p.ClientKey)
+	}
+	return pulsar.NewClient(options)
+}
+
+// PulsarTarget - Pulsar target.
+type PulsarTarget struct {
+	id         event.TargetID
+	args       PulsarArgs
+	client     pulsar.Client
+	producerMu sync.Mutex
+	producer   pulsar.Producer
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+}
+
+// ID - returns target ID.
+func (target *PulsarTarget) ID() event.TargetID {
+	return target.id
+}
+
+// HasQueueStore - Checks if the queueStore has been configured for the target
+func (target *PulsarTarget) HasQueueStore() bool {
+	return target.store != nil
+}
+
+// IsActive - Return true if target is up and active
+func (target *PulsarTarget) IsActive() (bool, error) {
+	conn, err := net.DialTimeout("tcp", target.args.Broker.Host, reconnectInterval*time.Second)
+	if err != nil {
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return false, errNotConnected
+		}
+		return false, err
+	}
+	conn.Close()
+
+	if _, err = target.getProducer(); err != nil {
+		return false, errNotConnected
+	}
+	return true, nil
+}
+
+// getProducer - returns the producer of the topic, the producer is
+// created once the broker is reachable and shared by all the sends.
+func (target *PulsarTarget) getProducer() (pulsar.Producer, error) {
+	target.producerMu.Lock()
+	defer target.producerMu.Unlock()
+
+	if target.producer == nil {
+		producer, err := target.client.CreateProducer(pulsar.ProducerOptions{
+			Topic: target.args.Topic,
+		})
+		if err != nil {
+			return nil, err
+		}
+		target.producer = producer
+	}
+	return target.producer, nil
+}
+
+// Save - saves the events to the store which will be replayed when the Pulsar connection is active.
+func (target *PulsarTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	_, err := target.IsActive()
+	if err != nil {
+		return err
+	}
+	return target.send(eventData)
+}
+
+// send - sends an event to the Pulsar topic.
+func (target *PulsarTarget) send(eventData event.Event) error {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return err
+	}
+	key := eventData.S3.Bucket.Name + "/" + objectName
+
+	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	if err != nil {
+		return err
+	}
+
+	producer, err := target.getProducer()
+	if err != nil {
+		return errNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pulsarOperationTimeout)
+	defer cancel()
+
+	_, err = producer.Send(ctx, &pulsar.ProducerMessage{
+		Payload: data,
+		Key:     key,
+		Properties: map[string]string{
+			"eventName": eventData.EventName.String(),
+		},
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		return errNotConnected
+	}
+	return err
+}
+
+// Send - reads an event from store and sends it to Pulsar.
+func (target *PulsarTarget) Send(eventKey string) error {
+	_, err := target.IsActive()
+	if err != nil {
+		return err
+	}
+
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// Such events will not exist and wouldve been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - closes the producer and the underlying connections to the Pulsar cluster.
+func (target *PulsarTarget) Close() error {
+	target.producerMu.Lock()
+	defer target.producerMu.Unlock()
+
+	if target.producer != nil {
+		target.producer.Close()
+	}
+	if target.client != nil {
+		target.client.Close()
+	}
+	return nil
+}
+
+// NewPulsarTarget - creates new Pulsar target.
+func NewPulsarTarget(id string, args PulsarArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), test bool) (*PulsarTarget, error) {
+	var store Store
+
+	target := &PulsarTarget{
+		id:         event.TargetID{ID: id, Name: "pulsar"},
+		args:       args,
+		loggerOnce: loggerOnce,
+	}
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-pulsar-"+id)
+		store = NewQueueStore(queueDir, args.QueueLimit)
+		if oErr := store.Open(); oErr != nil {
+			target.loggerOnce(context.Background(), oErr, target.ID())
+			return target, oErr
+		}
+		target.store = store
+	}
+
+	client, err := args.newClient()
+	if err != nil {
+		target.loggerOnce(context.Background(), err, target.ID())
+		return target, err
+	}
+	target.client = client
+
+	if _, err = target.IsActive(); err != nil {
+		if target.store == nil || err != errNotConnected {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+	}
+
+	if target.store != nil && !test {
+		// Replays the events from the store.
+		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
+		// Start replaying events from the store.
+		go sendEvents(target, target.store, eventKeyCh, doneCh, target.loggerOnce)
+	}
+
+	return target, nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"testing"
+
+	xnet "github.com/minio/pkg/net"
+)
+
+func TestPulsarArgsValidate(t *testing.T) {
+	broker := func(s string) xnet.URL {
+		u, err := xnet.ParseURL(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return *u
+	}
+
+	testCases := []struct {
+		name    string
+		args    PulsarArgs
+		wantErr bool
+	}{
+		{
+			name: "disabled",
+			args: PulsarArgs{},
+		},
+		{
+			name: "ok",
+			args: PulsarArgs{
+				Enable: true,
+				Broker: broker("pulsar://localhost:6650"),
+				Topic:  "persistent://public/default/events",
+			},
+		},
+		{
+			name: "ok_tls",
+			args: PulsarArgs{
+				Enable:     true,
+				Broker:     broker("pulsar+ssl://localhost:6651"),
+				Topic:      "events",
+				ClientCert: "/tmp/client.crt",
+				ClientKey:  "/tmp/client.key",
+			},
+		},
+		{
+			name: "empty_broker",
+			args: PulsarArgs{
+				Enable: true,
+				Topic:  "events",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid_scheme",
+			args: PulsarArgs{
+				Enable: true,
+				Broker: broker("http://localhost:6650"),
+				Topic:  "events",
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty_topic",
+			args: PulsarArgs{
+				Enable: true,
+				Broker: broker("pulsar://localhost:6650"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "cert_without_key",
+			args: PulsarArgs{
+				Enable:     true,
+				Broker:     broker("pulsar+ssl://localhost:6651"),
+				Topic:      "events",
+				ClientCert: "/tmp/client.crt",
+			},
+			wantErr: true,
+		},
+		{
+			name: "token_and_cert",
+			args: PulsarArgs{
+				Enable:     true,
+				Broker:     broker("pulsar+ssl://localhost:6651"),
+				Topic:      "events",
+				AuthToken:  "token",
+				ClientCert: "/tmp/client.crt",
+				ClientKey:  "/tmp/client.key",
+			},
+			wantErr: true,
+		},
+		{
+			name: "relative_queue_dir",
+			args: PulsarArgs{
+				Enable:   true,
+				Broker:   broker("pulsar://localhost:6650"),
+				Topic:    "events",
+				QueueDir: "events",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := testCase.args.Validate(); (err != nil) != testCase.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
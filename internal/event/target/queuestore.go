@@ -25,6 +25,7 @@ import (
 	"path/filepath"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/minio/minio/internal/event"
 )
@@ -170,6 +171,32 @@ func (store *QueueStore) del(key string) error {
 	return nil
 }
 
+// Len - returns the number of events in the store.
+func (store *QueueStore) Len() int {
+	store.RLock()
+	defer store.RUnlock()
+	return int(store.currentEntries)
+}
+
+// Oldest - returns the modification time of the oldest event in the store.
+func (store *QueueStore) Oldest() time.Time {
+	store.RLock()
+	defer store.RUnlock()
+
+	files, err := ioutil.ReadDir(store.directory)
+	if err != nil {
+		return time.Time{}
+	}
+
+	var oldest time.Time
+	for _, file := range files {
+		if oldest.IsZero() || file.ModTime().Before(oldest) {
+			oldest = file.ModTime()
+		}
+	}
+	return oldest
+}
+
 // List - lists all files from the directory.
 func (store *QueueStore) List() ([]string, error) {
 	store.RLock()
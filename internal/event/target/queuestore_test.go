@@ -23,6 +23,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/minio/minio/internal/event"
 )
@@ -212,3 +213,32 @@ func TestQueueStoreListN(t *testing.T) {
 		t.Fatalf("Expected List() to fail with os.ErrNotExist, %s", err)
 	}
 }
+
+// TestQueueStoreLenOldest - tests for store.Len and store.Oldest.
+func TestQueueStoreLenOldest(t *testing.T) {
+	defer func() {
+		if err := tearDownStore(); err != nil {
+			t.Fatal("Failed to tear down store ", err)
+		}
+	}()
+	store, err := setUpStore(queueDir, 10)
+	if err != nil {
+		t.Fatal("Failed to create a queue store ", err)
+	}
+	if store.Len() != 0 || !store.Oldest().IsZero() {
+		t.Fatalf("Expected an empty store, got %d events since %v", store.Len(), store.Oldest())
+	}
+
+	before := time.Now().Add(-time.Second)
+	for i := 0; i < 5; i++ {
+		if err := store.Put(testEvent); err != nil {
+			t.Fatal("Failed to put to queue store ", err)
+		}
+	}
+	if store.Len() != 5 {
+		t.Fatalf("Len() Expected: 5, got %d", store.Len())
+	}
+	if oldest := store.Oldest(); oldest.Before(before) || oldest.After(time.Now()) {
+		t.Fatalf("Oldest() returned unexpected time %v", oldest)
+	}
+}
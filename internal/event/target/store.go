@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"syscall"
 	"time"
@@ -34,7 +35,7 @@ const retryInterval = 3 * time.Second
 var errNotConnected = errors.New("not connected to target server/service")
 
 // errLimitExceeded error is sent when the maximum limit is reached.
-var errLimitExceeded = errors.New("the maximum store limit reached")
+var errLimitExceeded = event.ErrTargetQueueFull
 
 // Store - To persist the events.
 type Store interface {
@@ -43,11 +44,14 @@ type Store interface {
 	List() ([]string, error)
 	Del(key string) error
 	Open() error
+	Len() int
+	Oldest() time.Time
 }
 
 // replayEvents - Reads the events from the store and replays.
 func replayEvents(store Store, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), id event.TargetID) <-chan string {
 	eventKeyCh := make(chan string)
+	event.RegisterTargetQueue(id, store)
 
 	go func() {
 		retryTicker := time.NewTicker(retryInterval)
@@ -97,17 +101,35 @@ func IsConnResetErr(err error) bool {
 	return errors.Is(err, syscall.ECONNRESET)
 }
 
+// discardEvent - removes an event which exhausted its retry budget from
+// the store and hands it to the delivery policy.
+func discardEvent(target event.Target, store Store, eventKey string, reason error) error {
+	ev, err := store.Get(eventKey)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err = event.Discard(target.ID(), ev, reason); err != nil {
+		return err
+	}
+	return store.Del(eventKey)
+}
+
 // sendEvents - Reads events from the store and re-plays.
-func sendEvents(target event.Target, eventKeyCh <-chan string, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})) {
+func sendEvents(target event.Target, store Store, eventKeyCh <-chan string, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})) {
 	retryTicker := time.NewTicker(retryInterval)
 	defer retryTicker.Stop()
 
 	send := func(eventKey string) bool {
-		for {
+		for attempts := 1; ; attempts++ {
 			err := target.Send(eventKey)
 			if err == nil {
+				event.AddSent(target.ID(), 1)
 				break
 			}
+			event.AddFailed(target.ID(), 1)
 
 			if err != errNotConnected && !IsConnResetErr(err) {
 				loggerOnce(context.Background(),
@@ -115,6 +137,15 @@ func sendEvents(target event.Target, eventKeyCh <-chan string, doneCh <-chan str
 					target.ID())
 			}
 
+			if budget := event.GetDeliveryPolicy().RetryBudget; budget > 0 && attempts >= budget {
+				if err = discardEvent(target, store, eventKey, err); err == nil {
+					break
+				}
+				loggerOnce(context.Background(),
+					fmt.Errorf("unable to discard event '%s': %w", eventKey, err),
+					target.ID())
+			}
+
 			// Retrying after 3secs back-off
 
 			select {
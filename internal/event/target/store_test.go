@@ -0,0 +1,95 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+)
+
+// failingTarget - target whose deliveries always fail.
+type failingTarget struct {
+	id    event.TargetID
+	store Store
+}
+
+func (target *failingTarget) ID() event.TargetID         { return target.id }
+func (target *failingTarget) IsActive() (bool, error)    { return true, nil }
+func (target *failingTarget) Save(e event.Event) error   { return target.store.Put(e) }
+func (target *failingTarget) Send(eventKey string) error { return errors.New("delivery failed") }
+func (target *failingTarget) Close() error               { return nil }
+func (target *failingTarget) HasQueueStore() bool        { return true }
+
+func TestSendEventsRetryBudget(t *testing.T) {
+	defer func() {
+		if err := tearDownStore(); err != nil {
+			t.Fatal("Failed to tear down store ", err)
+		}
+	}()
+	store, err := setUpStore(queueDir, 10)
+	if err != nil {
+		t.Fatal("Failed to create a queue store ", err)
+	}
+
+	deadLetterCh := make(chan event.Event, 1)
+	event.SetDeliveryPolicy(event.DeliveryPolicy{
+		RetryBudget: 1,
+		DeadLetter: func(id event.TargetID, ev event.Event, reason error) error {
+			deadLetterCh <- ev
+			return nil
+		},
+	})
+	defer event.SetDeliveryPolicy(event.DeliveryPolicy{})
+
+	target := &failingTarget{id: event.TargetID{ID: "budget", Name: "testcase"}, store: store}
+	if err = target.Save(testEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	loggerOnce := func(ctx context.Context, err error, id interface{}, kind ...interface{}) {}
+	eventKeyCh := replayEvents(store, doneCh, loggerOnce, target.ID())
+	go sendEvents(target, store, eventKeyCh, doneCh, loggerOnce)
+
+	select {
+	case ev := <-deadLetterCh:
+		if ev.EventName != testEvent.EventName {
+			t.Fatalf("Expected dead-lettered event %v, got %v", testEvent.EventName, ev.EventName)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for the event to be dead-lettered")
+	}
+
+	// The dead-lettered event is removed from the store.
+	deadline := time.Now().Add(5 * time.Second)
+	for store.Len() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected an empty store, got %d events", store.Len())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if s := event.GetTargetStats()[target.ID()]; s.Failed != 1 || s.DeadLettered != 1 {
+		t.Fatalf("Unexpected stats %+v", s)
+	}
+}
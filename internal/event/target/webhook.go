@@ -31,8 +31,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+
 	"github.com/minio/minio/internal/event"
 	"github.com/minio/pkg/certs"
 	xnet "github.com/minio/pkg/net"
@@ -40,22 +44,38 @@ import (
 
 // Webhook constants
 const (
-	WebhookEndpoint   = "endpoint"
-	WebhookAuthToken  = "auth_token"
-	WebhookQueueDir   = "queue_dir"
-	WebhookQueueLimit = "queue_limit"
-	WebhookClientCert = "client_cert"
-	WebhookClientKey  = "client_key"
-
-	EnvWebhookEnable     = "MINIO_NOTIFY_WEBHOOK_ENABLE"
-	EnvWebhookEndpoint   = "MINIO_NOTIFY_WEBHOOK_ENDPOINT"
-	EnvWebhookAuthToken  = "MINIO_NOTIFY_WEBHOOK_AUTH_TOKEN"
-	EnvWebhookQueueDir   = "MINIO_NOTIFY_WEBHOOK_QUEUE_DIR"
-	EnvWebhookQueueLimit = "MINIO_NOTIFY_WEBHOOK_QUEUE_LIMIT"
-	EnvWebhookClientCert = "MINIO_NOTIFY_WEBHOOK_CLIENT_CERT"
-	EnvWebhookClientKey  = "MINIO_NOTIFY_WEBHOOK_CLIENT_KEY"
+	WebhookEndpoint      = "endpoint"
+	WebhookAuthToken     = "auth_token"
+	WebhookQueueDir      = "queue_dir"
+	WebhookQueueLimit    = "queue_limit"
+	WebhookClientCert    = "client_cert"
+	WebhookClientKey     = "client_key"
+	WebhookBatchSize     = "batch_size"
+	WebhookFlushInterval = "flush_interval"
+	WebhookCompression   = "compression"
+
+	EnvWebhookEnable        = "MINIO_NOTIFY_WEBHOOK_ENABLE"
+	EnvWebhookEndpoint      = "MINIO_NOTIFY_WEBHOOK_ENDPOINT"
+	EnvWebhookAuthToken     = "MINIO_NOTIFY_WEBHOOK_AUTH_TOKEN"
+	EnvWebhookQueueDir      = "MINIO_NOTIFY_WEBHOOK_QUEUE_DIR"
+	EnvWebhookQueueLimit    = "MINIO_NOTIFY_WEBHOOK_QUEUE_LIMIT"
+	EnvWebhookClientCert    = "MINIO_NOTIFY_WEBHOOK_CLIENT_CERT"
+	EnvWebhookClientKey     = "MINIO_NOTIFY_WEBHOOK_CLIENT_KEY"
+	EnvWebhookBatchSize     = "MINIO_NOTIFY_WEBHOOK_BATCH_SIZE"
+	EnvWebhookFlushInterval = "MINIO_NOTIFY_WEBHOOK_FLUSH_INTERVAL"
+	EnvWebhookCompression   = "MINIO_NOTIFY_WEBHOOK_COMPRESSION"
 )
 
+// Webhook compression types
+const (
+	WebhookCompressionNone = "none"
+	WebhookCompressionGzip = "gzip"
+	WebhookCompressionZstd = "zstd"
+)
+
+// Encoder is safe for concurrent use with EncodeAll.
+var webhookZstdEncoder, _ = zstd.NewWriter(nil)
+
 // WebhookArgs - Webhook target arguments.
 type WebhookArgs struct {
 	Enable     bool            `json:"enable"`
@@ -66,6 +86,12 @@ type WebhookArgs struct {
 	QueueLimit uint64          `json:"queueLimit"`
 	ClientCert string          `json:"clientCert"`
 	ClientKey  string          `json:"clientKey"`
+
+	// Events are sent as a JSON array of up to BatchSize events,
+	// pending events are flushed at least every FlushInterval.
+	BatchSize     int           `json:"batchSize"`
+	FlushInterval time.Duration `json:"flushInterval"`
+	Compression   string        `json:"compression"`
 }
 
 // Validate WebhookArgs fields
@@ -84,16 +110,43 @@ func (w WebhookArgs) Validate() error {
 	if w.ClientCert != "" && w.ClientKey == "" || w.ClientCert == "" && w.ClientKey != "" {
 		return errors.New("cert and key must be specified as a pair")
 	}
+	if w.BatchSize < 0 {
+		return errors.New("batchSize should be positive")
+	}
+	if w.BatchSize > 1 {
+		if w.QueueDir == "" {
+			return errors.New("queueDir is required to batch events")
+		}
+		if w.FlushInterval <= 0 {
+			return errors.New("flushInterval should be positive")
+		}
+	}
+	switch w.Compression {
+	case "", WebhookCompressionNone, WebhookCompressionGzip, WebhookCompressionZstd:
+	default:
+		return fmt.Errorf("unsupported compression '%s'", w.Compression)
+	}
 	return nil
 }
 
+// batched - returns true if events are sent in batches.
+func (w WebhookArgs) batched() bool {
+	return w.BatchSize > 1
+}
+
 // WebhookTarget - Webhook target.
 type WebhookTarget struct {
+	// Number of events saved in the store and not yet sent, used
+	// to flush a batch as soon as it is full. Must be the first
+	// field for 64-bit alignment of atomic operations.
+	pending int64
+
 	id         event.TargetID
 	args       WebhookArgs
 	httpClient *http.Client
 	store      Store
 	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	batchCh    chan struct{}
 }
 
 // ID - returns target ID.
@@ -135,7 +188,16 @@ func (target *WebhookTarget) IsActive() (bool, error) {
 // Save - saves the events to the store if queuestore is configured, which will be replayed when the wenhook connection is active.
 func (target *WebhookTarget) Save(eventData event.Event) error {
 	if target.store != nil {
-		return target.store.Put(eventData)
+		if err := target.store.Put(eventData); err != nil {
+			return err
+		}
+		if target.args.batched() && atomic.AddInt64(&target.pending, 1) >= int64(target.args.BatchSize) {
+			select {
+			case target.batchCh <- struct{}{}:
+			default:
+			}
+		}
+		return nil
 	}
 	err := target.send(eventData)
 	if err != nil {
@@ -159,6 +221,27 @@ func (target *WebhookTarget) send(eventData event.Event) error {
 		return err
 	}
 
+	return target.post(data)
+}
+
+// post - sends the JSON encoded events to the webhook, compressing them if configured.
+func (target *WebhookTarget) post(data []byte) (err error) {
+	contentEncoding := ""
+	switch target.args.Compression {
+	case WebhookCompressionGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err = w.Write(data); err != nil {
+			return err
+		}
+		if err = w.Close(); err != nil {
+			return err
+		}
+		data, contentEncoding = buf.Bytes(), "gzip"
+	case WebhookCompressionZstd:
+		data, contentEncoding = webhookZstdEncoder.EncodeAll(data, nil), "zstd"
+	}
+
 	req, err := http.NewRequest("POST", target.args.Endpoint.String(), bytes.NewReader(data))
 	if err != nil {
 		return err
@@ -177,6 +260,9 @@ func (target *WebhookTarget) send(eventData event.Event) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
 
 	resp, err := target.httpClient.Do(req)
 	if err != nil {
@@ -217,6 +303,136 @@ func (target *WebhookTarget) Send(eventKey string) error {
 	return target.store.Del(eventKey)
 }
 
+// sendBatch - sends the oldest events in the store as a single request and
+// removes them from the store once delivered, a failed batch stays in the
+// store and is retried as a unit. If full is set only a complete batch is
+// sent. Returns the number of events sent.
+func (target *WebhookTarget) sendBatch(full bool) (int, error) {
+	names, err := target.store.List()
+	if err != nil {
+		return 0, err
+	}
+	if len(names) == 0 || full && len(names) < target.args.BatchSize {
+		return 0, nil
+	}
+	if len(names) > target.args.BatchSize {
+		names = names[:target.args.BatchSize]
+	}
+
+	keys := make([]string, 0, len(names))
+	logs := make([]event.Log, 0, len(names))
+	for _, name := range names {
+		key := strings.TrimSuffix(name, eventExt)
+		eventData, err := target.store.Get(key)
+		if err != nil {
+			// Entries which cannot be read are removed by the store.
+			if os.IsNotExist(err) {
+				continue
+			}
+			target.loggerOnce(context.Background(), err, target.ID())
+			continue
+		}
+		objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+		if err != nil {
+			objectName = eventData.S3.Object.Key
+		}
+		keys = append(keys, key)
+		logs = append(logs, event.Log{
+			EventName: eventData.EventName,
+			Key:       eventData.S3.Bucket.Name + "/" + objectName,
+			Records:   []event.Event{eventData},
+		})
+	}
+	if len(logs) == 0 {
+		return len(names), nil
+	}
+
+	data, err := json.Marshal(logs)
+	if err != nil {
+		return 0, err
+	}
+	if err = target.post(data); err != nil {
+		event.AddFailed(target.ID(), uint64(len(logs)))
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return 0, errNotConnected
+		}
+		return 0, err
+	}
+	event.AddSent(target.ID(), uint64(len(logs)))
+
+	for _, key := range keys {
+		if err = target.store.Del(key); err != nil && !os.IsNotExist(err) {
+			return 0, err
+		}
+	}
+	atomic.AddInt64(&target.pending, -int64(len(names)))
+	return len(names), nil
+}
+
+// discardBatch - discards the oldest batch of events in the store once it
+// exhausted its retry budget.
+func (target *WebhookTarget) discardBatch(reason error) error {
+	names, err := target.store.List()
+	if err != nil {
+		return err
+	}
+	if len(names) > target.args.BatchSize {
+		names = names[:target.args.BatchSize]
+	}
+	for _, name := range names {
+		if err = discardEvent(target, target.store, strings.TrimSuffix(name, eventExt), reason); err != nil {
+			return err
+		}
+		atomic.AddInt64(&target.pending, -1)
+	}
+	return nil
+}
+
+// sendBatches - replays the events from the store in batches, a batch is
+// sent as soon as it is full or at the latest after the flush interval.
+func (target *WebhookTarget) sendBatches(doneCh <-chan struct{}) {
+	flushTicker := time.NewTicker(target.args.FlushInterval)
+	defer flushTicker.Stop()
+
+	var failures int
+	for {
+		full := true
+		select {
+		case <-target.batchCh:
+		case <-flushTicker.C:
+			full = false
+		case <-doneCh:
+			return
+		}
+
+		for {
+			n, err := target.sendBatch(full)
+			if err != nil {
+				if err != errNotConnected && !IsConnResetErr(err) {
+					target.loggerOnce(context.Background(),
+						fmt.Errorf("target.sendBatch() failed with '%w'", err),
+						target.ID())
+				}
+				failures++
+				if budget := event.GetDeliveryPolicy().RetryBudget; budget > 0 && failures >= budget {
+					if err = target.discardBatch(err); err != nil {
+						target.loggerOnce(context.Background(),
+							fmt.Errorf("unable to discard events: %w", err),
+							target.ID())
+					} else {
+						failures = 0
+					}
+				}
+				break
+			}
+			failures = 0
+			if n < target.args.BatchSize {
+				break
+			}
+		}
+	}
+}
+
 // Close - does nothing and available for interface compatibility.
 func (target *WebhookTarget) Close() error {
 	// Close idle connection with "keep-alive" states
@@ -250,6 +466,17 @@ func NewWebhookTarget(ctx context.Context, id string, args WebhookArgs, loggerOn
 			return target, err
 		}
 		target.store = store
+
+		if args.batched() {
+			names, err := store.List()
+			if err != nil {
+				target.loggerOnce(context.Background(), err, target.ID())
+				return target, err
+			}
+			target.pending = int64(len(names))
+			target.batchCh = make(chan struct{}, 1)
+			event.RegisterTargetQueue(target.ID(), store)
+		}
 	}
 
 	_, err := target.IsActive()
@@ -261,10 +488,15 @@ func NewWebhookTarget(ctx context.Context, id string, args WebhookArgs, loggerOn
 	}
 
 	if target.store != nil && !test {
+		if args.batched() {
+			// Replays the events from the store in batches.
+			go target.sendBatches(ctx.Done())
+			return target, nil
+		}
 		// Replays the events from the store.
 		eventKeyCh := replayEvents(target.store, ctx.Done(), target.loggerOnce, target.ID())
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, ctx.Done(), target.loggerOnce)
+		go sendEvents(target, target.store, eventKeyCh, ctx.Done(), target.loggerOnce)
 	}
 
 	return target, nil
@@ -0,0 +1,242 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+// webhookTestServer - records the batches received, requests fail
+// with 503 while fail is set.
+type webhookTestServer struct {
+	mu        sync.Mutex
+	fail      bool
+	batches   [][]event.Log
+	encodings []string
+	received  chan struct{}
+}
+
+func (s *webhookTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	var body io.Reader = r.Body
+	switch r.Header.Get("Content-Encoding") {
+	case "gzip":
+		gr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body = gr
+	case "zstd":
+		zr, err := zstd.NewReader(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer zr.Close()
+		body = zr
+	}
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var batch []event.Log
+	if err = json.Unmarshal(data, &batch); err != nil {
+		var log event.Log
+		if err = json.Unmarshal(data, &log); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		batch = []event.Log{log}
+	}
+	s.batches = append(s.batches, batch)
+	s.encodings = append(s.encodings, r.Header.Get("Content-Encoding"))
+	s.received <- struct{}{}
+}
+
+func newWebhookTestTarget(t *testing.T, ctx context.Context, url string, args WebhookArgs, test bool) *WebhookTarget {
+	endpoint, err := xnet.ParseHTTPURL(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args.Enable = true
+	args.Endpoint = *endpoint
+	if err = args.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	loggerOnce := func(ctx context.Context, err error, id interface{}, kind ...interface{}) {}
+	target, err := NewWebhookTarget(ctx, "1", args, loggerOnce, &http.Transport{}, test)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return target
+}
+
+func webhookTestEvent(key string) event.Event {
+	return event.Event{
+		EventName: event.ObjectCreatedPut,
+		S3: event.Metadata{
+			Bucket: event.Bucket{Name: "bucket"},
+			Object: event.Object{Key: key},
+		},
+	}
+}
+
+func TestWebhookTargetBatch(t *testing.T) {
+	for _, compression := range []string{WebhookCompressionNone, WebhookCompressionGzip, WebhookCompressionZstd} {
+		t.Run(compression, func(t *testing.T) {
+			server := &webhookTestServer{received: make(chan struct{}, 10)}
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			target := newWebhookTestTarget(t, ctx, ts.URL, WebhookArgs{
+				QueueDir:      t.TempDir(),
+				BatchSize:     3,
+				FlushInterval: time.Hour,
+				Compression:   compression,
+			}, false)
+
+			// A full batch is sent without waiting for the flush interval.
+			for _, key := range []string{"object1", "object2", "object3", "object4"} {
+				if err := target.Save(webhookTestEvent(key)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			select {
+			case <-server.received:
+			case <-time.After(10 * time.Second):
+				t.Fatal("batch not delivered")
+			}
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if len(server.batches) != 1 || len(server.batches[0]) != 3 {
+				t.Fatalf("expected a single batch of 3 events, got %v", server.batches)
+			}
+			keys := make(map[string]bool)
+			for _, log := range server.batches[0] {
+				keys[log.Key] = true
+			}
+			if len(keys) != 3 {
+				t.Fatalf("expected 3 distinct events, got %v", server.batches[0])
+			}
+			expectedEncoding := compression
+			if compression == WebhookCompressionNone {
+				expectedEncoding = ""
+			}
+			if server.encodings[0] != expectedEncoding {
+				t.Fatalf("expected Content-Encoding %q, got %q", expectedEncoding, server.encodings[0])
+			}
+		})
+	}
+}
+
+func TestWebhookTargetBatchRetry(t *testing.T) {
+	server := &webhookTestServer{received: make(chan struct{}, 10)}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	target := newWebhookTestTarget(t, context.Background(), ts.URL, WebhookArgs{
+		QueueDir:      t.TempDir(),
+		BatchSize:     10,
+		FlushInterval: time.Second,
+	}, true)
+
+	for _, key := range []string{"object1", "object2"} {
+		if err := target.Save(webhookTestEvent(key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A failed batch is kept in the store as a whole.
+	server.fail = true
+	if _, err := target.sendBatch(false); err == nil {
+		t.Fatal("expected batch to fail")
+	}
+	names, err := target.store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 {
+		t.Fatalf("expected 2 queued events, got %d", len(names))
+	}
+
+	// Incomplete batches are only sent on flush.
+	server.fail = false
+	if n, err := target.sendBatch(true); err != nil || n != 0 {
+		t.Fatalf("expected no batch to be sent, got %d, %v", n, err)
+	}
+	if n, err := target.sendBatch(false); err != nil || n != 2 {
+		t.Fatalf("expected batch of 2 events to be sent, got %d, %v", n, err)
+	}
+	if names, _ = target.store.List(); len(names) != 0 {
+		t.Fatalf("expected empty store, got %d events", len(names))
+	}
+	if len(server.batches) != 1 || len(server.batches[0]) != 2 {
+		t.Fatalf("expected a single batch of 2 events, got %v", server.batches)
+	}
+}
+
+func TestWebhookArgsValidateBatch(t *testing.T) {
+	endpoint, _ := xnet.ParseHTTPURL("http://localhost:8080")
+	testCases := []struct {
+		args    WebhookArgs
+		wantErr bool
+	}{
+		{WebhookArgs{Enable: true, Endpoint: *endpoint, BatchSize: 10, QueueDir: "/tmp/events", FlushInterval: time.Second}, false},
+		{WebhookArgs{Enable: true, Endpoint: *endpoint, BatchSize: 10, FlushInterval: time.Second}, true},
+		{WebhookArgs{Enable: true, Endpoint: *endpoint, BatchSize: 10, QueueDir: "/tmp/events"}, true},
+		{WebhookArgs{Enable: true, Endpoint: *endpoint, BatchSize: -1}, true},
+		{WebhookArgs{Enable: true, Endpoint: *endpoint, Compression: WebhookCompressionZstd}, false},
+		{WebhookArgs{Enable: true, Endpoint: *endpoint, Compression: "lz4"}, true},
+	}
+
+	for i, testCase := range testCases {
+		if err := testCase.args.Validate(); (err != nil) != testCase.wantErr {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+	}
+}
@@ -18,6 +18,7 @@
 package event
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 )
@@ -71,7 +72,8 @@ type TargetIDResult struct {
 	Err error
 }
 
-// Remove - closes and removes targets by given target IDs.
+// Remove - closes and removes targets by given target IDs, along
+// with their delivery statistics.
 func (list *TargetList) Remove(targetIDSet TargetIDSet) {
 	list.Lock()
 	defer list.Unlock()
@@ -81,6 +83,7 @@ func (list *TargetList) Remove(targetIDSet TargetIDSet) {
 		if ok {
 			target.Close()
 			delete(list.targets, id)
+			removeCounters(id)
 		}
 	}
 }
@@ -137,6 +140,15 @@ func (list *TargetList) Send(event Event, targetIDset TargetIDSet, resCh chan<-
 					tgtRes := TargetIDResult{ID: id}
 					if err := target.Save(event); err != nil {
 						tgtRes.Err = err
+						AddFailed(id, 1)
+						// Events which could neither be sent nor queued are lost.
+						if !target.HasQueueStore() || errors.Is(err, ErrTargetQueueFull) {
+							Discard(id, event, err)
+						}
+					} else if target.HasQueueStore() {
+						AddQueued(id, 1)
+					} else {
+						AddSent(id, 1)
 					}
 					resCh <- tgtRes
 				}(id, target)
@@ -20,10 +20,12 @@ package logger
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -155,10 +157,39 @@ func GetAuditEntry(ctx context.Context) *audit.Entry {
 	return nil
 }
 
+// AccessLogger - receives the audit entries of incoming requests to
+// generate S3 server access logs of buckets.
+type AccessLogger interface {
+	// Enabled returns true if access logging is enabled for the bucket.
+	Enabled(bucket string) bool
+	// Log is called with the audit entry generated for a request
+	// to a bucket with access logging enabled.
+	Log(entry audit.Entry, r *http.Request)
+}
+
+type accessLoggerHolder struct {
+	AccessLogger
+}
+
+var accessLogger atomic.Value
+
+// SetAccessLogger - sets the access logger, which is called for
+// every incoming request regardless of the audit targets.
+func SetAccessLogger(l AccessLogger) {
+	accessLogger.Store(accessLoggerHolder{l})
+}
+
+func getAccessLogger() AccessLogger {
+	holder, _ := accessLogger.Load().(accessLoggerHolder)
+	return holder.AccessLogger
+}
+
 // AuditLog - logs audit logs to all audit targets.
 func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqClaims map[string]interface{}, filterKeys ...string) {
+	accessLogger := getAccessLogger()
+
 	// Fast exit if there is not audit target configured
-	if atomic.LoadInt32(&nAuditTargets) == 0 {
+	if atomic.LoadInt32(&nAuditTargets) == 0 && accessLogger == nil {
 		return
 	}
 
@@ -170,6 +201,11 @@ func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqCl
 			return
 		}
 
+		logAccess := accessLogger != nil && reqInfo.BucketName != "" && accessLogger.Enabled(reqInfo.BucketName)
+		if atomic.LoadInt32(&nAuditTargets) == 0 && !logAccess {
+			return
+		}
+
 		entry = audit.ToEntry(w, r, reqClaims, globalDeploymentID)
 		// indicates all requests for this API call are inbound
 		entry.Trigger = "incoming"
@@ -218,6 +254,11 @@ func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqCl
 		if timeToFirstByte != 0 {
 			entry.API.TimeToFirstByte = strconv.FormatInt(timeToFirstByte.Nanoseconds(), 10) + "ns"
 		}
+		entry.AccessKey = reqInfo.AccessKey
+
+		if logAccess {
+			accessLogger.Log(entry, r)
+		}
 	} else {
 		auditEntry := GetAuditEntry(ctx)
 		if auditEntry != nil {
@@ -227,6 +268,60 @@ func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqCl
 
 	// Send audit logs only to http targets.
 	for _, t := range AuditTargets() {
-		_ = t.Send(entry, string(All))
+		sendAuditEntry(t, entry)
+	}
+}
+
+// auditChains holds the hash chain of every audit target once
+// chaining of audit entries is enabled.
+var auditChains struct {
+	sync.Mutex
+	key          ed25519.PrivateKey
+	signInterval time.Duration
+	chains       map[Target]*audit.Chain
+}
+
+// EnableAuditChain - adds a sequence number and a hash chained to the
+// previous entry to every audit entry, entries are signed with key at
+// most once per sign interval.
+func EnableAuditChain(key ed25519.PrivateKey, signInterval time.Duration) {
+	auditChains.Lock()
+	defer auditChains.Unlock()
+	auditChains.key = key
+	auditChains.signInterval = signInterval
+	auditChains.chains = make(map[Target]*audit.Chain)
+}
+
+func auditChain(t Target) *audit.Chain {
+	auditChains.Lock()
+	defer auditChains.Unlock()
+	if auditChains.chains == nil {
+		return nil
+	}
+	c, ok := auditChains.chains[t]
+	if !ok {
+		c = audit.NewChain(auditChains.key, auditChains.signInterval)
+		auditChains.chains[t] = c
+	}
+	return c
+}
+
+// sendAuditEntry - sends an audit entry to a target, entries are filtered
+// and chained per target so that the entries received by every target form
+// a chain.
+func sendAuditEntry(t Target, entry audit.Entry) {
+	if f, ok := auditFilters.Load(t); ok {
+		if entry, ok = f.(audit.Filter).Apply(entry); !ok {
+			return
+		}
+	}
+	// The access key is only needed by the filter, it is not sent.
+	entry.AccessKey = ""
+	if c := auditChain(t); c != nil {
+		_ = c.Append(entry, func(entry audit.Entry) error {
+			return t.Send(entry, string(All))
+		})
+		return
 	}
+	_ = t.Send(entry, string(All))
 }
@@ -19,15 +19,23 @@ package logger
 
 import (
 	"crypto/tls"
+	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/pkg/env"
 	xnet "github.com/minio/pkg/net"
 
 	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/logger/message/audit"
+	"github.com/minio/minio/internal/logger/target/file"
 	"github.com/minio/minio/internal/logger/target/http"
 	"github.com/minio/minio/internal/logger/target/kafka"
+	"github.com/minio/minio/internal/logger/target/otlp"
+	"github.com/minio/minio/internal/logger/target/syslog"
 )
 
 // Console logger target
@@ -41,6 +49,14 @@ const (
 	AuthToken  = "auth_token"
 	ClientCert = "client_cert"
 	ClientKey  = "client_key"
+	QueueDir   = "queue_dir"
+	QueueLimit = "queue_limit"
+
+	FilterAPI        = "filter_api"
+	FilterBucket     = "filter_bucket"
+	FilterUser       = "filter_user"
+	FilterStatusCode = "filter_status_code"
+	Redact           = "redact"
 
 	KafkaBrokers       = "brokers"
 	KafkaTopic         = "topic"
@@ -55,15 +71,36 @@ const (
 	KafkaClientTLSKey  = "client_tls_key"
 	KafkaVersion       = "version"
 
-	EnvLoggerWebhookEnable    = "MINIO_LOGGER_WEBHOOK_ENABLE"
-	EnvLoggerWebhookEndpoint  = "MINIO_LOGGER_WEBHOOK_ENDPOINT"
-	EnvLoggerWebhookAuthToken = "MINIO_LOGGER_WEBHOOK_AUTH_TOKEN"
+	SyslogAddress       = "address"
+	SyslogTLS           = "tls"
+	SyslogTLSSkipVerify = "tls_skip_verify"
+	SyslogFacility      = "facility"
+	SyslogAppName       = "app_name"
+
+	OTLPServiceName = "service_name"
+
+	FileDir            = "dir"
+	FileMaxSize        = "max_size"
+	FileRotateInterval = "rotate_interval"
+	FileMaxFiles       = "max_files"
+	FileMaxAge         = "max_age"
+
+	ChainSigningKey   = "signing_key"
+	ChainSignInterval = "sign_interval"
+
+	EnvLoggerWebhookEnable     = "MINIO_LOGGER_WEBHOOK_ENABLE"
+	EnvLoggerWebhookEndpoint   = "MINIO_LOGGER_WEBHOOK_ENDPOINT"
+	EnvLoggerWebhookAuthToken  = "MINIO_LOGGER_WEBHOOK_AUTH_TOKEN"
+	EnvLoggerWebhookQueueDir   = "MINIO_LOGGER_WEBHOOK_QUEUE_DIR"
+	EnvLoggerWebhookQueueLimit = "MINIO_LOGGER_WEBHOOK_QUEUE_LIMIT"
 
 	EnvAuditWebhookEnable     = "MINIO_AUDIT_WEBHOOK_ENABLE"
 	EnvAuditWebhookEndpoint   = "MINIO_AUDIT_WEBHOOK_ENDPOINT"
 	EnvAuditWebhookAuthToken  = "MINIO_AUDIT_WEBHOOK_AUTH_TOKEN"
 	EnvAuditWebhookClientCert = "MINIO_AUDIT_WEBHOOK_CLIENT_CERT"
 	EnvAuditWebhookClientKey  = "MINIO_AUDIT_WEBHOOK_CLIENT_KEY"
+	EnvAuditWebhookQueueDir   = "MINIO_AUDIT_WEBHOOK_QUEUE_DIR"
+	EnvAuditWebhookQueueLimit = "MINIO_AUDIT_WEBHOOK_QUEUE_LIMIT"
 
 	EnvKafkaEnable        = "MINIO_AUDIT_KAFKA_ENABLE"
 	EnvKafkaBrokers       = "MINIO_AUDIT_KAFKA_BROKERS"
@@ -78,6 +115,43 @@ const (
 	EnvKafkaClientTLSCert = "MINIO_AUDIT_KAFKA_CLIENT_TLS_CERT"
 	EnvKafkaClientTLSKey  = "MINIO_AUDIT_KAFKA_CLIENT_TLS_KEY"
 	EnvKafkaVersion       = "MINIO_AUDIT_KAFKA_VERSION"
+	EnvKafkaQueueDir      = "MINIO_AUDIT_KAFKA_QUEUE_DIR"
+	EnvKafkaQueueLimit    = "MINIO_AUDIT_KAFKA_QUEUE_LIMIT"
+
+	EnvSyslogEnable        = "MINIO_AUDIT_SYSLOG_ENABLE"
+	EnvSyslogAddress       = "MINIO_AUDIT_SYSLOG_ADDRESS"
+	EnvSyslogTLS           = "MINIO_AUDIT_SYSLOG_TLS"
+	EnvSyslogTLSSkipVerify = "MINIO_AUDIT_SYSLOG_TLS_SKIP_VERIFY"
+	EnvSyslogClientCert    = "MINIO_AUDIT_SYSLOG_CLIENT_CERT"
+	EnvSyslogClientKey     = "MINIO_AUDIT_SYSLOG_CLIENT_KEY"
+	EnvSyslogFacility      = "MINIO_AUDIT_SYSLOG_FACILITY"
+	EnvSyslogAppName       = "MINIO_AUDIT_SYSLOG_APP_NAME"
+
+	EnvOTLPEnable      = "MINIO_AUDIT_OTLP_ENABLE"
+	EnvOTLPEndpoint    = "MINIO_AUDIT_OTLP_ENDPOINT"
+	EnvOTLPAuthToken   = "MINIO_AUDIT_OTLP_AUTH_TOKEN"
+	EnvOTLPClientCert  = "MINIO_AUDIT_OTLP_CLIENT_CERT"
+	EnvOTLPClientKey   = "MINIO_AUDIT_OTLP_CLIENT_KEY"
+	EnvOTLPServiceName = "MINIO_AUDIT_OTLP_SERVICE_NAME"
+
+	EnvFileEnable         = "MINIO_AUDIT_FILE_ENABLE"
+	EnvFileDir            = "MINIO_AUDIT_FILE_DIR"
+	EnvFileMaxSize        = "MINIO_AUDIT_FILE_MAX_SIZE"
+	EnvFileRotateInterval = "MINIO_AUDIT_FILE_ROTATE_INTERVAL"
+	EnvFileMaxFiles       = "MINIO_AUDIT_FILE_MAX_FILES"
+	EnvFileMaxAge         = "MINIO_AUDIT_FILE_MAX_AGE"
+
+	// Suffixes of the audit filter environment variables of every
+	// audit target type, e.g. MINIO_AUDIT_WEBHOOK_FILTER_API
+	envFilterAPI        = "_FILTER_API"
+	envFilterBucket     = "_FILTER_BUCKET"
+	envFilterUser       = "_FILTER_USER"
+	envFilterStatusCode = "_FILTER_STATUS_CODE"
+	envRedact           = "_REDACT"
+
+	EnvChainEnable       = "MINIO_AUDIT_CHAIN_ENABLE"
+	EnvChainSigningKey   = "MINIO_AUDIT_CHAIN_SIGNING_KEY"
+	EnvChainSignInterval = "MINIO_AUDIT_CHAIN_SIGN_INTERVAL"
 )
 
 // Default KVS for loggerHTTP and loggerAuditHTTP
@@ -95,6 +169,14 @@ var (
 			Key:   AuthToken,
 			Value: "",
 		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueLimit,
+			Value: "0",
+		},
 	}
 
 	DefaultAuditWebhookKVS = config.KVS{
@@ -118,6 +200,34 @@ var (
 			Key:   ClientKey,
 			Value: "",
 		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueLimit,
+			Value: "0",
+		},
+		config.KV{
+			Key:   FilterAPI,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterBucket,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterUser,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterStatusCode,
+			Value: "",
+		},
+		config.KV{
+			Key:   Redact,
+			Value: "",
+		},
 	}
 
 	DefaultAuditKafkaKVS = config.KVS{
@@ -173,15 +283,218 @@ var (
 			Key:   KafkaVersion,
 			Value: "",
 		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueLimit,
+			Value: "0",
+		},
+		config.KV{
+			Key:   FilterAPI,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterBucket,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterUser,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterStatusCode,
+			Value: "",
+		},
+		config.KV{
+			Key:   Redact,
+			Value: "",
+		},
+	}
+
+	DefaultAuditSyslogKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   SyslogAddress,
+			Value: "",
+		},
+		config.KV{
+			Key:   SyslogTLS,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   SyslogTLSSkipVerify,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   ClientCert,
+			Value: "",
+		},
+		config.KV{
+			Key:   ClientKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   SyslogFacility,
+			Value: "local0",
+		},
+		config.KV{
+			Key:   SyslogAppName,
+			Value: "minio",
+		},
+		config.KV{
+			Key:   FilterAPI,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterBucket,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterUser,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterStatusCode,
+			Value: "",
+		},
+		config.KV{
+			Key:   Redact,
+			Value: "",
+		},
+	}
+
+	DefaultAuditOTLPKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Endpoint,
+			Value: "",
+		},
+		config.KV{
+			Key:   AuthToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   ClientCert,
+			Value: "",
+		},
+		config.KV{
+			Key:   ClientKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   OTLPServiceName,
+			Value: "minio",
+		},
+		config.KV{
+			Key:   FilterAPI,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterBucket,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterUser,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterStatusCode,
+			Value: "",
+		},
+		config.KV{
+			Key:   Redact,
+			Value: "",
+		},
+	}
+
+	DefaultAuditFileKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   FileDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   FileMaxSize,
+			Value: "100MiB",
+		},
+		config.KV{
+			Key:   FileRotateInterval,
+			Value: "24h",
+		},
+		config.KV{
+			Key:   FileMaxFiles,
+			Value: "0",
+		},
+		config.KV{
+			Key:   FileMaxAge,
+			Value: "0s",
+		},
+		config.KV{
+			Key:   FilterAPI,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterBucket,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterUser,
+			Value: "",
+		},
+		config.KV{
+			Key:   FilterStatusCode,
+			Value: "",
+		},
+		config.KV{
+			Key:   Redact,
+			Value: "",
+		},
+	}
+
+	DefaultAuditChainKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   ChainSigningKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   ChainSignInterval,
+			Value: "1m",
+		},
 	}
 )
 
+// AuditChain - hash chaining of audit entries
+type AuditChain struct {
+	Enabled      bool          `json:"enabled"`
+	SigningKey   string        `json:"signingKey"`
+	SignInterval time.Duration `json:"signInterval"`
+}
+
 // Config console and http logger targets
 type Config struct {
-	Console      Console                 `json:"console"`
-	HTTP         map[string]http.Config  `json:"http"`
-	AuditWebhook map[string]http.Config  `json:"audit"`
-	AuditKafka   map[string]kafka.Config `json:"audit_kafka"`
+	Console      Console                  `json:"console"`
+	HTTP         map[string]http.Config   `json:"http"`
+	AuditWebhook map[string]http.Config   `json:"audit"`
+	AuditKafka   map[string]kafka.Config  `json:"audit_kafka"`
+	AuditSyslog  map[string]syslog.Config `json:"audit_syslog"`
+	AuditOTLP    map[string]otlp.Config   `json:"audit_otlp"`
+	AuditFile    map[string]file.Config   `json:"audit_file"`
+	AuditChain   AuditChain               `json:"audit_chain"`
 }
 
 // NewConfig - initialize new logger config.
@@ -194,11 +507,50 @@ func NewConfig() Config {
 		HTTP:         make(map[string]http.Config),
 		AuditWebhook: make(map[string]http.Config),
 		AuditKafka:   make(map[string]kafka.Config),
+		AuditSyslog:  make(map[string]syslog.Config),
+		AuditOTLP:    make(map[string]otlp.Config),
+		AuditFile:    make(map[string]file.Config),
 	}
 
 	return cfg
 }
 
+// parseQueue - validates the queue directory and limit of a target,
+// entries are kept in memory without a queue directory.
+func parseQueue(queueDir, queueLimit string) (string, uint64, error) {
+	if queueDir != "" && !filepath.IsAbs(queueDir) {
+		return "", 0, config.Errorf("'queue_dir' should be an absolute path")
+	}
+	if queueLimit == "" {
+		return queueDir, 0, nil
+	}
+	limit, err := strconv.ParseUint(queueLimit, 10, 64)
+	if err != nil {
+		return "", 0, config.Errorf("'queue_limit' value invalid: %s", err)
+	}
+	return queueDir, limit, nil
+}
+
+// lookupAuditFilter - returns the audit filter of an audit target, envPrefix
+// is the prefix of the environment variables of the target type.
+func lookupAuditFilter(kv config.KVS, envPrefix, target string) (f audit.Filter, err error) {
+	getEnv := func(suffix string) string {
+		if target != config.Default {
+			return envPrefix + suffix + config.Default + target
+		}
+		return envPrefix + suffix
+	}
+	f.API = audit.ParseFilterRules(env.Get(getEnv(envFilterAPI), kv.Get(FilterAPI)))
+	f.Bucket = audit.ParseFilterRules(env.Get(getEnv(envFilterBucket), kv.Get(FilterBucket)))
+	f.User = audit.ParseFilterRules(env.Get(getEnv(envFilterUser), kv.Get(FilterUser)))
+	f.StatusCode, err = audit.ParseStatusCodeRules(env.Get(getEnv(envFilterStatusCode), kv.Get(FilterStatusCode)))
+	if err != nil {
+		return f, config.Errorf("'%s' value invalid: %s", FilterStatusCode, err)
+	}
+	f.Redact = audit.ParseRedact(env.Get(getEnv(envRedact), kv.Get(Redact)))
+	return f, nil
+}
+
 func lookupLegacyConfig() (Config, error) {
 	cfg := NewConfig()
 
@@ -367,12 +719,299 @@ func GetAuditKafka(kafkaKVS map[string]config.KVS) (map[string]kafka.Config, err
 		kafkaArgs.SASL.Password = env.Get(saslPasswordEnv, kv.Get(KafkaSASLPassword))
 		kafkaArgs.SASL.Mechanism = env.Get(saslMechanismEnv, kv.Get(KafkaSASLMechanism))
 
+		queueDirEnv := EnvKafkaQueueDir
+		if k != config.Default {
+			queueDirEnv = queueDirEnv + config.Default + k
+		}
+		queueLimitEnv := EnvKafkaQueueLimit
+		if k != config.Default {
+			queueLimitEnv = queueLimitEnv + config.Default + k
+		}
+		kafkaArgs.Queue.Dir, kafkaArgs.Queue.Limit, err = parseQueue(env.Get(queueDirEnv, kv.Get(QueueDir)),
+			env.Get(queueLimitEnv, kv.Get(QueueLimit)))
+		if err != nil {
+			return nil, err
+		}
+
+		kafkaArgs.Filter, err = lookupAuditFilter(kv, "MINIO_AUDIT_KAFKA", k)
+		if err != nil {
+			return nil, err
+		}
+
 		kafkaTargets[k] = kafkaArgs
 	}
 
 	return kafkaTargets, nil
 }
 
+// GetAuditSyslog - returns a map of registered audit 'syslog' targets
+func GetAuditSyslog(syslogKVS map[string]config.KVS) (map[string]syslog.Config, error) {
+	syslogTargets := make(map[string]syslog.Config)
+	for k, kv := range config.Merge(syslogKVS, EnvSyslogEnable, DefaultAuditSyslogKVS) {
+		enableEnv := EnvSyslogEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		addressEnv := EnvSyslogAddress
+		if k != config.Default {
+			addressEnv = addressEnv + config.Default + k
+		}
+		address := env.Get(addressEnv, kv.Get(SyslogAddress))
+		if address == "" {
+			return nil, config.Errorf("syslog 'address' cannot be empty")
+		}
+		if _, err = xnet.ParseHost(address); err != nil {
+			return nil, err
+		}
+
+		facilityEnv := EnvSyslogFacility
+		if k != config.Default {
+			facilityEnv = facilityEnv + config.Default + k
+		}
+		facility, err := syslog.ParseFacility(env.Get(facilityEnv, kv.Get(SyslogFacility)))
+		if err != nil {
+			return nil, err
+		}
+
+		tlsEnableEnv := EnvSyslogTLS
+		if k != config.Default {
+			tlsEnableEnv = tlsEnableEnv + config.Default + k
+		}
+		tlsSkipVerifyEnv := EnvSyslogTLSSkipVerify
+		if k != config.Default {
+			tlsSkipVerifyEnv = tlsSkipVerifyEnv + config.Default + k
+		}
+		clientCertEnv := EnvSyslogClientCert
+		if k != config.Default {
+			clientCertEnv = clientCertEnv + config.Default + k
+		}
+		clientKeyEnv := EnvSyslogClientKey
+		if k != config.Default {
+			clientKeyEnv = clientKeyEnv + config.Default + k
+		}
+		appNameEnv := EnvSyslogAppName
+		if k != config.Default {
+			appNameEnv = appNameEnv + config.Default + k
+		}
+
+		syslogArgs := syslog.Config{
+			Enabled:       enabled,
+			Address:       address,
+			TLS:           env.Get(tlsEnableEnv, kv.Get(SyslogTLS)) == config.EnableOn,
+			TLSSkipVerify: env.Get(tlsSkipVerifyEnv, kv.Get(SyslogTLSSkipVerify)) == config.EnableOn,
+			ClientCert:    env.Get(clientCertEnv, kv.Get(ClientCert)),
+			ClientKey:     env.Get(clientKeyEnv, kv.Get(ClientKey)),
+			Facility:      facility,
+			AppName:       env.Get(appNameEnv, kv.Get(SyslogAppName)),
+		}
+		if err = config.EnsureCertAndKey(syslogArgs.ClientCert, syslogArgs.ClientKey); err != nil {
+			return nil, err
+		}
+
+		syslogArgs.Filter, err = lookupAuditFilter(kv, "MINIO_AUDIT_SYSLOG", k)
+		if err != nil {
+			return nil, err
+		}
+
+		syslogTargets[k] = syslogArgs
+	}
+
+	return syslogTargets, nil
+}
+
+// GetAuditOTLP - returns a map of registered audit 'otlp' targets
+func GetAuditOTLP(otlpKVS map[string]config.KVS) (map[string]otlp.Config, error) {
+	otlpTargets := make(map[string]otlp.Config)
+	for k, kv := range config.Merge(otlpKVS, EnvOTLPEnable, DefaultAuditOTLPKVS) {
+		enableEnv := EnvOTLPEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		endpointEnv := EnvOTLPEndpoint
+		if k != config.Default {
+			endpointEnv = endpointEnv + config.Default + k
+		}
+		endpoint := env.Get(endpointEnv, kv.Get(Endpoint))
+		if endpoint == "" {
+			return nil, config.Errorf("otlp 'endpoint' cannot be empty")
+		}
+
+		authTokenEnv := EnvOTLPAuthToken
+		if k != config.Default {
+			authTokenEnv = authTokenEnv + config.Default + k
+		}
+		clientCertEnv := EnvOTLPClientCert
+		if k != config.Default {
+			clientCertEnv = clientCertEnv + config.Default + k
+		}
+		clientKeyEnv := EnvOTLPClientKey
+		if k != config.Default {
+			clientKeyEnv = clientKeyEnv + config.Default + k
+		}
+		serviceNameEnv := EnvOTLPServiceName
+		if k != config.Default {
+			serviceNameEnv = serviceNameEnv + config.Default + k
+		}
+
+		otlpArgs := otlp.Config{
+			Enabled:     enabled,
+			Endpoint:    endpoint,
+			AuthToken:   env.Get(authTokenEnv, kv.Get(AuthToken)),
+			ClientCert:  env.Get(clientCertEnv, kv.Get(ClientCert)),
+			ClientKey:   env.Get(clientKeyEnv, kv.Get(ClientKey)),
+			ServiceName: env.Get(serviceNameEnv, kv.Get(OTLPServiceName)),
+		}
+		if err = config.EnsureCertAndKey(otlpArgs.ClientCert, otlpArgs.ClientKey); err != nil {
+			return nil, err
+		}
+
+		otlpArgs.Filter, err = lookupAuditFilter(kv, "MINIO_AUDIT_OTLP", k)
+		if err != nil {
+			return nil, err
+		}
+
+		otlpTargets[k] = otlpArgs
+	}
+
+	return otlpTargets, nil
+}
+
+// GetAuditFile - returns a map of registered audit 'file' targets
+func GetAuditFile(fileKVS map[string]config.KVS) (map[string]file.Config, error) {
+	fileTargets := make(map[string]file.Config)
+	for k, kv := range config.Merge(fileKVS, EnvFileEnable, DefaultAuditFileKVS) {
+		enableEnv := EnvFileEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		dirEnv := EnvFileDir
+		if k != config.Default {
+			dirEnv = dirEnv + config.Default + k
+		}
+		dir := env.Get(dirEnv, kv.Get(FileDir))
+		if dir == "" {
+			return nil, config.Errorf("file 'dir' cannot be empty")
+		}
+		if !filepath.IsAbs(dir) {
+			return nil, config.Errorf("file 'dir' should be an absolute path")
+		}
+
+		maxSizeEnv := EnvFileMaxSize
+		if k != config.Default {
+			maxSizeEnv = maxSizeEnv + config.Default + k
+		}
+		maxSize, err := humanize.ParseBytes(env.Get(maxSizeEnv, kv.Get(FileMaxSize)))
+		if err != nil {
+			return nil, err
+		}
+
+		rotateIntervalEnv := EnvFileRotateInterval
+		if k != config.Default {
+			rotateIntervalEnv = rotateIntervalEnv + config.Default + k
+		}
+		rotateInterval, err := time.ParseDuration(env.Get(rotateIntervalEnv, kv.Get(FileRotateInterval)))
+		if err != nil {
+			return nil, err
+		}
+
+		maxFilesEnv := EnvFileMaxFiles
+		if k != config.Default {
+			maxFilesEnv = maxFilesEnv + config.Default + k
+		}
+		maxFiles, err := strconv.Atoi(env.Get(maxFilesEnv, kv.Get(FileMaxFiles)))
+		if err != nil {
+			return nil, err
+		}
+
+		maxAgeEnv := EnvFileMaxAge
+		if k != config.Default {
+			maxAgeEnv = maxAgeEnv + config.Default + k
+		}
+		maxAge, err := time.ParseDuration(env.Get(maxAgeEnv, kv.Get(FileMaxAge)))
+		if err != nil {
+			return nil, err
+		}
+
+		if rotateInterval < 0 || maxFiles < 0 || maxAge < 0 {
+			return nil, config.Errorf("file rotation and retention settings cannot be negative")
+		}
+
+		fileArgs := file.Config{
+			Enabled:        enabled,
+			Dir:            dir,
+			MaxSize:        int64(maxSize),
+			RotateInterval: rotateInterval,
+			MaxFiles:       maxFiles,
+			MaxAge:         maxAge,
+		}
+		if k != config.Default {
+			fileArgs.Name = k
+		}
+
+		fileArgs.Filter, err = lookupAuditFilter(kv, "MINIO_AUDIT_FILE", k)
+		if err != nil {
+			return nil, err
+		}
+
+		fileTargets[k] = fileArgs
+	}
+
+	return fileTargets, nil
+}
+
+// GetAuditChain - returns the audit chain settings
+func GetAuditChain(kvs config.KVS) (cfg AuditChain, err error) {
+	if err = config.CheckValidKeys(config.AuditChainSubSys, kvs, DefaultAuditChainKVS); err != nil {
+		return cfg, err
+	}
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvChainEnable, kvs.GetWithDefault(config.Enable, DefaultAuditChainKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if !cfg.Enabled {
+		return cfg, nil
+	}
+	cfg.SigningKey = env.Get(EnvChainSigningKey, kvs.Get(ChainSigningKey))
+	if cfg.SigningKey != "" && !filepath.IsAbs(cfg.SigningKey) {
+		return cfg, config.Errorf("audit chain 'signing_key' should be an absolute path")
+	}
+	if cfg.Enabled && cfg.SigningKey == "" {
+		return cfg, config.Errorf("audit chain 'signing_key' is required")
+	}
+	cfg.SignInterval, err = time.ParseDuration(env.Get(EnvChainSignInterval, kvs.GetWithDefault(ChainSignInterval, DefaultAuditChainKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'audit_chain:sign_interval' value invalid: %w", err)
+	}
+	if cfg.SignInterval < 0 {
+		return cfg, config.Errorf("audit chain 'sign_interval' cannot be negative")
+	}
+	return cfg, nil
+}
+
 // LookupConfig - lookup logger config, override with ENVs if set.
 func LookupConfig(scfg config.Config) (Config, error) {
 	// Lookup for legacy environment variables first
@@ -424,10 +1063,24 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if target != config.Default {
 			authTokenEnv = EnvLoggerWebhookAuthToken + config.Default + target
 		}
+		queueDirEnv := EnvLoggerWebhookQueueDir
+		if target != config.Default {
+			queueDirEnv = EnvLoggerWebhookQueueDir + config.Default + target
+		}
+		queueLimitEnv := EnvLoggerWebhookQueueLimit
+		if target != config.Default {
+			queueLimitEnv = EnvLoggerWebhookQueueLimit + config.Default + target
+		}
+		queueDir, queueLimit, err := parseQueue(env.Get(queueDirEnv, ""), env.Get(queueLimitEnv, ""))
+		if err != nil {
+			return cfg, err
+		}
 		cfg.HTTP[target] = http.Config{
-			Enabled:   true,
-			Endpoint:  env.Get(endpointEnv, ""),
-			AuthToken: env.Get(authTokenEnv, ""),
+			Enabled:    true,
+			Endpoint:   env.Get(endpointEnv, ""),
+			AuthToken:  env.Get(authTokenEnv, ""),
+			QueueDir:   queueDir,
+			QueueLimit: queueLimit,
 		}
 	}
 
@@ -461,16 +1114,35 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if target != config.Default {
 			clientKeyEnv = EnvAuditWebhookClientKey + config.Default + target
 		}
+		queueDirEnv := EnvAuditWebhookQueueDir
+		if target != config.Default {
+			queueDirEnv = EnvAuditWebhookQueueDir + config.Default + target
+		}
+		queueLimitEnv := EnvAuditWebhookQueueLimit
+		if target != config.Default {
+			queueLimitEnv = EnvAuditWebhookQueueLimit + config.Default + target
+		}
 		err = config.EnsureCertAndKey(env.Get(clientCertEnv, ""), env.Get(clientKeyEnv, ""))
 		if err != nil {
 			return cfg, err
 		}
+		queueDir, queueLimit, err := parseQueue(env.Get(queueDirEnv, ""), env.Get(queueLimitEnv, ""))
+		if err != nil {
+			return cfg, err
+		}
+		filter, err := lookupAuditFilter(config.KVS{}, "MINIO_AUDIT_WEBHOOK", target)
+		if err != nil {
+			return cfg, err
+		}
 		cfg.AuditWebhook[target] = http.Config{
 			Enabled:    true,
 			Endpoint:   env.Get(endpointEnv, ""),
 			AuthToken:  env.Get(authTokenEnv, ""),
 			ClientCert: env.Get(clientCertEnv, ""),
 			ClientKey:  env.Get(clientKeyEnv, ""),
+			QueueDir:   queueDir,
+			QueueLimit: queueLimit,
+			Filter:     filter,
 		}
 	}
 
@@ -495,10 +1167,16 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if !enabled {
 			continue
 		}
+		queueDir, queueLimit, err := parseQueue(kv.Get(QueueDir), kv.Get(QueueLimit))
+		if err != nil {
+			return cfg, err
+		}
 		cfg.HTTP[starget] = http.Config{
-			Enabled:   true,
-			Endpoint:  kv.Get(Endpoint),
-			AuthToken: kv.Get(AuthToken),
+			Enabled:    true,
+			Endpoint:   kv.Get(Endpoint),
+			AuthToken:  kv.Get(AuthToken),
+			QueueDir:   queueDir,
+			QueueLimit: queueLimit,
 		}
 	}
 
@@ -527,12 +1205,23 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if err != nil {
 			return cfg, err
 		}
+		queueDir, queueLimit, err := parseQueue(kv.Get(QueueDir), kv.Get(QueueLimit))
+		if err != nil {
+			return cfg, err
+		}
+		filter, err := lookupAuditFilter(kv, "MINIO_AUDIT_WEBHOOK", starget)
+		if err != nil {
+			return cfg, err
+		}
 		cfg.AuditWebhook[starget] = http.Config{
 			Enabled:    true,
 			Endpoint:   kv.Get(Endpoint),
 			AuthToken:  kv.Get(AuthToken),
 			ClientCert: kv.Get(ClientCert),
 			ClientKey:  kv.Get(ClientKey),
+			QueueDir:   queueDir,
+			QueueLimit: queueLimit,
+			Filter:     filter,
 		}
 	}
 
@@ -541,5 +1230,25 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		return cfg, err
 	}
 
+	cfg.AuditSyslog, err = GetAuditSyslog(scfg[config.AuditSyslogSubSys])
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.AuditOTLP, err = GetAuditOTLP(scfg[config.AuditOTLPSubSys])
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.AuditFile, err = GetAuditFile(scfg[config.AuditFileSubSys])
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.AuditChain, err = GetAuditChain(scfg[config.AuditChainSubSys][config.Default])
+	if err != nil {
+		return cfg, err
+	}
+
 	return cfg, nil
 }
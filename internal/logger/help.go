@@ -37,6 +37,18 @@ var (
 			Type:        "string",
 			Sensitive:   true,
 		},
+		config.HelpKV{
+			Key:         QueueDir,
+			Description: `staging dir for undelivered log entries e.g. '/home/logs', entries are kept in memory if not set`,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         QueueLimit,
+			Description: `maximum limit for undelivered log entries, defaults to '100000'`,
+			Optional:    true,
+			Type:        "number",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -73,6 +85,48 @@ var (
 			Type:        "string",
 			Sensitive:   true,
 		},
+		config.HelpKV{
+			Key:         QueueDir,
+			Description: `staging dir for undelivered log entries e.g. '/home/logs', entries are kept in memory if not set`,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         QueueLimit,
+			Description: `maximum limit for undelivered log entries, defaults to '100000'`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         FilterAPI,
+			Description: `comma separated API names of audit entries to send, names prefixed with '!' are excluded e.g. "!ListObjects*,!HeadObject"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterBucket,
+			Description: `comma separated bucket names of audit entries to send, names prefixed with '!' are excluded e.g. "finance*,!tmp"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterUser,
+			Description: `comma separated access keys of audit entries to send, keys prefixed with '!' are excluded`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterStatusCode,
+			Description: `comma separated status codes of audit entries to send, codes prefixed with '!' are excluded e.g. "4xx,5xx,!404"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         Redact,
+			Description: `comma separated header and query parameter names whose values are masked e.g. "Authorization,X-Amz-Signature"`,
+			Optional:    true,
+			Type:        "csv",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -157,6 +211,290 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         QueueDir,
+			Description: `staging dir for undelivered log entries e.g. '/home/logs', entries are kept in memory if not set`,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         QueueLimit,
+			Description: `maximum limit for undelivered log entries, defaults to '100000'`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         FilterAPI,
+			Description: `comma separated API names of audit entries to send, names prefixed with '!' are excluded e.g. "!ListObjects*,!HeadObject"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterBucket,
+			Description: `comma separated bucket names of audit entries to send, names prefixed with '!' are excluded e.g. "finance*,!tmp"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterUser,
+			Description: `comma separated access keys of audit entries to send, keys prefixed with '!' are excluded`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterStatusCode,
+			Description: `comma separated status codes of audit entries to send, codes prefixed with '!' are excluded e.g. "4xx,5xx,!404"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         Redact,
+			Description: `comma separated header and query parameter names whose values are masked e.g. "Authorization,X-Amz-Signature"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpSyslog = config.HelpKVS{
+		config.HelpKV{
+			Key:         SyslogAddress,
+			Description: `syslog server address e.g. "localhost:6514"`,
+			Type:        "address",
+		},
+		config.HelpKV{
+			Key:         SyslogTLS,
+			Description: "set to 'on' to connect to the syslog server over TLS",
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         SyslogTLSSkipVerify,
+			Description: `trust server TLS without verification, defaults to "off" (verify)`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         ClientCert,
+			Description: "mTLS certificate for syslog authentication",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         ClientKey,
+			Description: "mTLS certificate key for syslog authentication",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         SyslogFacility,
+			Description: `syslog facility of audit messages, defaults to "local0"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         SyslogAppName,
+			Description: `APP-NAME of audit messages, defaults to "minio"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         FilterAPI,
+			Description: `comma separated API names of audit entries to send, names prefixed with '!' are excluded e.g. "!ListObjects*,!HeadObject"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterBucket,
+			Description: `comma separated bucket names of audit entries to send, names prefixed with '!' are excluded e.g. "finance*,!tmp"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterUser,
+			Description: `comma separated access keys of audit entries to send, keys prefixed with '!' are excluded`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterStatusCode,
+			Description: `comma separated status codes of audit entries to send, codes prefixed with '!' are excluded e.g. "4xx,5xx,!404"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         Redact,
+			Description: `comma separated header and query parameter names whose values are masked e.g. "Authorization,X-Amz-Signature"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpOTLP = config.HelpKVS{
+		config.HelpKV{
+			Key:         Endpoint,
+			Description: `OTLP/HTTP logs endpoint e.g. "http://localhost:4318/v1/logs"`,
+			Type:        "url",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         AuthToken,
+			Description: `opaque string or JWT authorization token`,
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         ClientCert,
+			Description: "mTLS certificate for OTLP collector authentication",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         ClientKey,
+			Description: "mTLS certificate key for OTLP collector authentication",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         OTLPServiceName,
+			Description: `service.name resource attribute of audit logs, defaults to "minio"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         FilterAPI,
+			Description: `comma separated API names of audit entries to send, names prefixed with '!' are excluded e.g. "!ListObjects*,!HeadObject"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterBucket,
+			Description: `comma separated bucket names of audit entries to send, names prefixed with '!' are excluded e.g. "finance*,!tmp"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterUser,
+			Description: `comma separated access keys of audit entries to send, keys prefixed with '!' are excluded`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterStatusCode,
+			Description: `comma separated status codes of audit entries to send, codes prefixed with '!' are excluded e.g. "4xx,5xx,!404"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         Redact,
+			Description: `comma separated header and query parameter names whose values are masked e.g. "Authorization,X-Amz-Signature"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpChain = config.HelpKVS{
+		config.HelpKV{
+			Key:         ChainSigningKey,
+			Description: `path to a PEM encoded PKCS#8 Ed25519 private key to sign audit entries with`,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         ChainSignInterval,
+			Description: `sign the audit chain at most once per interval, defaults to "1m"`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpFile = config.HelpKVS{
+		config.HelpKV{
+			Key:         FileDir,
+			Description: `absolute path of the directory to write audit logs to e.g. "/var/log/minio"`,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         FileMaxSize,
+			Description: `rotate the log file once it exceeds this size, defaults to "100MiB"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         FileRotateInterval,
+			Description: `rotate the log file after this interval, defaults to "24h"`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         FileMaxFiles,
+			Description: `maximum number of rotated log files to keep, defaults to "0" (unlimited)`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         FileMaxAge,
+			Description: `remove rotated log files older than this duration, defaults to "0s" (never)`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         FilterAPI,
+			Description: `comma separated API names of audit entries to send, names prefixed with '!' are excluded e.g. "!ListObjects*,!HeadObject"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterBucket,
+			Description: `comma separated bucket names of audit entries to send, names prefixed with '!' are excluded e.g. "finance*,!tmp"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterUser,
+			Description: `comma separated access keys of audit entries to send, keys prefixed with '!' are excluded`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FilterStatusCode,
+			Description: `comma separated status codes of audit entries to send, codes prefixed with '!' are excluded e.g. "4xx,5xx,!404"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         Redact,
+			Description: `comma separated header and query parameter names whose values are masked e.g. "Authorization,X-Amz-Signature"`,
+			Optional:    true,
+			Type:        "csv",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
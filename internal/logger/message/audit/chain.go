@@ -0,0 +1,368 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChainInfo - position of an entry in a hash chain of audit entries.
+type ChainInfo struct {
+	// ID identifies the chain, every audit target of every server
+	// process has its own chain.
+	ID string `json:"id"`
+	// Seq is the sequence number of the entry in the chain, starting at 1.
+	Seq uint64 `json:"seq"`
+	// PrevHash is the hash of the previous entry in the chain.
+	PrevHash string `json:"prevHash,omitempty"`
+	// KeyID identifies the key the chain is signed with, so that
+	// entries signed before a key rotation can still be verified.
+	KeyID string `json:"keyId,omitempty"`
+	// Hash is the hex encoded SHA-256 of the entry, computed with an
+	// empty hash and without signature.
+	Hash string `json:"hash"`
+	// Signature is the base64 encoded Ed25519 signature of the hash,
+	// only present on periodically signed entries.
+	Signature string `json:"signature,omitempty"`
+}
+
+// canonicalJSON - re-encodes JSON data with sorted object keys and
+// numbers kept as is, so that the same document always results in
+// the same bytes regardless of how it was produced.
+func canonicalJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// KeyID - returns the ID of a signing key, the hex encoded first 8
+// bytes of the SHA-256 of its public key.
+func KeyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Chain - hash chain of the audit entries sent to one target.
+type Chain struct {
+	mu           sync.Mutex
+	id           string
+	seq          uint64
+	prevHash     string
+	key          ed25519.PrivateKey
+	keyID        string
+	signInterval time.Duration
+	lastSigned   time.Time
+}
+
+// NewChain - returns a new hash chain, entries are signed with key at most
+// once per sign interval, a zero interval signs every entry.
+func NewChain(key ed25519.PrivateKey, signInterval time.Duration) *Chain {
+	c := &Chain{
+		id:           uuid.New().String(),
+		key:          key,
+		signInterval: signInterval,
+	}
+	if key != nil {
+		c.keyID = KeyID(key.Public().(ed25519.PublicKey))
+	}
+	return c
+}
+
+// Append - adds the entry to the chain and calls send with the chained
+// entry. Entries are sent in chain order, an entry which cannot be sent
+// shows up as a gap in the chain.
+func (c *Chain) Append(entry Entry, send func(Entry) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.seq++
+	entry.Chain = &ChainInfo{
+		ID:       c.id,
+		Seq:      c.seq,
+		PrevHash: c.prevHash,
+		KeyID:    c.keyID,
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data, err = canonicalJSON(data)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(data)
+	entry.Chain.Hash = hex.EncodeToString(hash[:])
+
+	if now := time.Now(); c.key != nil && (c.seq == 1 || now.Sub(c.lastSigned) >= c.signInterval) {
+		entry.Chain.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(c.key, hash[:]))
+		c.lastSigned = now
+	}
+	c.prevHash = entry.Chain.Hash
+
+	return send(entry)
+}
+
+// SeqRange - inclusive range of sequence numbers.
+type SeqRange struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+// ChainReport - verification result of a single chain.
+type ChainReport struct {
+	ID         string `json:"id"`
+	First      uint64 `json:"first"`
+	Last       uint64 `json:"last"`
+	Entries    int    `json:"entries"`
+	Signatures int    `json:"signatures"`
+	// LastSigned is the highest sequence number with a valid signature,
+	// entries after it are not covered by a signature yet.
+	LastSigned uint64 `json:"lastSigned"`
+	// Gaps are missing entries.
+	Gaps []SeqRange `json:"gaps,omitempty"`
+	// Duplicates are sequence numbers found more than once.
+	Duplicates []uint64 `json:"duplicates,omitempty"`
+	// Modified are entries whose content does not match their hash.
+	Modified []uint64 `json:"modified,omitempty"`
+	// Unlinked are entries not referring to the hash of their predecessor.
+	Unlinked []uint64 `json:"unlinked,omitempty"`
+	// BadSignatures are entries with an invalid signature.
+	BadSignatures []uint64 `json:"badSignatures,omitempty"`
+}
+
+// valid - returns true if no problem was found in the chain.
+func (r ChainReport) valid() bool {
+	return len(r.Gaps) == 0 && len(r.Duplicates) == 0 && len(r.Modified) == 0 &&
+		len(r.Unlinked) == 0 && len(r.BadSignatures) == 0
+}
+
+// VerifyReport - verification result of an audit log stream.
+type VerifyReport struct {
+	Valid   bool `json:"valid"`
+	Entries int  `json:"entries"`
+	// Unchained are entries without chain information.
+	Unchained int           `json:"unchained"`
+	Chains    []ChainReport `json:"chains"`
+	// KeyIDs are the IDs of the keys signatures were checked against.
+	KeyIDs []string `json:"keyIds,omitempty"`
+}
+
+type chainedEntry struct {
+	seq      uint64
+	prevHash string
+	hash     string
+	modified bool
+	validSig bool
+	hasSig   bool
+}
+
+// Verifier - verifies the hash chains of audit entries.
+type Verifier struct {
+	keys      map[string]ed25519.PublicKey
+	entries   int
+	unchained int
+	chains    map[string][]chainedEntry
+}
+
+// NewVerifier - returns a verifier checking signatures against the
+// given public keys, such as the current and the previous signing keys.
+func NewVerifier(pubs ...ed25519.PublicKey) *Verifier {
+	v := &Verifier{
+		keys:   make(map[string]ed25519.PublicKey),
+		chains: make(map[string][]chainedEntry),
+	}
+	for _, pub := range pubs {
+		if len(pub) == ed25519.PublicKeySize {
+			v.keys[KeyID(pub)] = pub
+		}
+	}
+	return v
+}
+
+// verify - returns true if sig is a valid signature of hash by the key
+// with the given ID, entries without key ID are checked against all keys.
+func (v *Verifier) verify(keyID string, hash, sig []byte) bool {
+	if keyID != "" {
+		pub, ok := v.keys[keyID]
+		return ok && ed25519.Verify(pub, hash, sig)
+	}
+	for _, pub := range v.keys {
+		if ed25519.Verify(pub, hash, sig) {
+			return true
+		}
+	}
+	return false
+}
+
+var errInvalidChain = errors.New("invalid audit chain information")
+
+// Add - adds a JSON encoded audit entry to the verification.
+func (v *Verifier) Add(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		return err
+	}
+	v.entries++
+
+	chain, ok := m["chain"].(map[string]interface{})
+	if !ok {
+		v.unchained++
+		return nil
+	}
+	id, _ := chain["id"].(string)
+	seqNum, _ := chain["seq"].(json.Number)
+	seq, err := strconv.ParseUint(seqNum.String(), 10, 64)
+	if err != nil || id == "" {
+		return errInvalidChain
+	}
+	prevHash, _ := chain["prevHash"].(string)
+	keyID, _ := chain["keyId"].(string)
+	hash, _ := chain["hash"].(string)
+	signature, _ := chain["signature"].(string)
+
+	// Recompute the hash the way it was computed when chaining.
+	chain["hash"] = ""
+	delete(chain, "signature")
+	canonical, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	computed := sha256.Sum256(canonical)
+
+	e := chainedEntry{
+		seq:      seq,
+		prevHash: prevHash,
+		hash:     hash,
+		modified: hex.EncodeToString(computed[:]) != hash,
+	}
+	if signature != "" {
+		e.hasSig = true
+		sig, err := base64.StdEncoding.DecodeString(signature)
+		hashBytes, herr := hex.DecodeString(hash)
+		e.validSig = err == nil && herr == nil && v.verify(keyID, hashBytes, sig)
+	}
+	v.chains[id] = append(v.chains[id], e)
+	return nil
+}
+
+// Report - returns the result of the verification.
+func (v *Verifier) Report() VerifyReport {
+	report := VerifyReport{
+		Valid:     v.unchained == 0,
+		Entries:   v.entries,
+		Unchained: v.unchained,
+		Chains:    []ChainReport{},
+	}
+	for keyID := range v.keys {
+		report.KeyIDs = append(report.KeyIDs, keyID)
+	}
+	sort.Strings(report.KeyIDs)
+
+	ids := make([]string, 0, len(v.chains))
+	for id := range v.chains {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		entries := v.chains[id]
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].seq < entries[j].seq
+		})
+
+		r := ChainReport{
+			ID:      id,
+			First:   entries[0].seq,
+			Last:    entries[len(entries)-1].seq,
+			Entries: len(entries),
+		}
+		if r.First > 1 {
+			r.Gaps = append(r.Gaps, SeqRange{From: 1, To: r.First - 1})
+		}
+		for i, e := range entries {
+			if e.modified {
+				r.Modified = append(r.Modified, e.seq)
+			}
+			if e.hasSig {
+				if e.validSig && !e.modified {
+					r.Signatures++
+					r.LastSigned = e.seq
+				} else {
+					r.BadSignatures = append(r.BadSignatures, e.seq)
+				}
+			}
+
+			switch {
+			case i == 0:
+				if e.seq == 1 && e.prevHash != "" {
+					r.Unlinked = append(r.Unlinked, e.seq)
+				}
+			case e.seq == entries[i-1].seq:
+				r.Duplicates = append(r.Duplicates, e.seq)
+			case e.seq > entries[i-1].seq+1:
+				r.Gaps = append(r.Gaps, SeqRange{From: entries[i-1].seq + 1, To: e.seq - 1})
+			case e.prevHash != entries[i-1].hash:
+				r.Unlinked = append(r.Unlinked, e.seq)
+			}
+		}
+
+		report.Valid = report.Valid && r.valid()
+		report.Chains = append(report.Chains, r)
+	}
+	return report
+}
+
+// VerifyStream - verifies a stream of JSON encoded audit entries, such
+// as JSON lines written by the audit file target.
+func VerifyStream(r io.Reader, pubs ...ed25519.PublicKey) (VerifyReport, error) {
+	v := NewVerifier(pubs...)
+	dec := json.NewDecoder(r)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return VerifyReport{}, err
+		}
+		if err := v.Add(raw); err != nil {
+			return VerifyReport{}, err
+		}
+	}
+	return v.Report(), nil
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestChainLog(t *testing.T, n int) (ed25519.PublicKey, [][]byte) {
+	pub, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines [][]byte
+	c := NewChain(key, 0)
+	for i := 0; i < n; i++ {
+		entry := NewEntry("test-deployment")
+		entry.API.Name = "PutObject"
+		entry.API.Bucket = "bucket"
+		entry.API.StatusCode = 200
+		entry.Tags = map[string]interface{}{"ratio": 0.1, "size": 1 << 40}
+		err = c.Append(entry, func(entry Entry) error {
+			data, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			lines = append(lines, data)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return pub, lines
+}
+
+func verifyLines(t *testing.T, pub ed25519.PublicKey, lines [][]byte) VerifyReport {
+	report, err := VerifyStream(bytes.NewReader(bytes.Join(lines, []byte("\n"))), pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+func TestChainVerify(t *testing.T) {
+	pub, lines := newTestChainLog(t, 5)
+
+	report := verifyLines(t, pub, lines)
+	if !report.Valid || report.Entries != 5 || len(report.Chains) != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if r := report.Chains[0]; r.First != 1 || r.Last != 5 || r.Signatures != 5 || r.LastSigned != 5 {
+		t.Fatalf("unexpected chain report %+v", r)
+	}
+}
+
+func TestChainVerifyTampered(t *testing.T) {
+	pub, lines := newTestChainLog(t, 5)
+
+	// Modified entry
+	modified := append([][]byte{}, lines...)
+	modified[2] = bytes.Replace(modified[2], []byte(`"PutObject"`), []byte(`"GetObject"`), 1)
+	report := verifyLines(t, pub, modified)
+	if report.Valid {
+		t.Fatal("expected modified log to be invalid")
+	}
+	if r := report.Chains[0]; len(r.Modified) != 1 || r.Modified[0] != 3 {
+		t.Fatalf("expected entry 3 to be modified, got %+v", r)
+	}
+
+	// Removed entry
+	removed := append(append([][]byte{}, lines[:1]...), lines[3:]...)
+	report = verifyLines(t, pub, removed)
+	if report.Valid {
+		t.Fatal("expected log with removed entries to be invalid")
+	}
+	if r := report.Chains[0]; len(r.Gaps) != 1 || r.Gaps[0] != (SeqRange{From: 2, To: 3}) {
+		t.Fatalf("expected gap 2-3, got %+v", r)
+	}
+
+	// Duplicated entry
+	duplicated := append(append([][]byte{}, lines...), lines[4])
+	report = verifyLines(t, pub, duplicated)
+	if r := report.Chains[0]; report.Valid || len(r.Duplicates) != 1 || r.Duplicates[0] != 5 {
+		t.Fatalf("expected entry 5 to be duplicated, got %+v", r)
+	}
+}
+
+func TestChainVerifySignature(t *testing.T) {
+	_, lines := newTestChainLog(t, 3)
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := verifyLines(t, otherPub, lines)
+	if r := report.Chains[0]; report.Valid || len(r.BadSignatures) != 3 || r.LastSigned != 0 {
+		t.Fatalf("expected all signatures to be invalid, got %+v", r)
+	}
+}
+
+func TestChainSignInterval(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewChain(key, time.Hour)
+	var signed int
+	for i := 0; i < 3; i++ {
+		if err = c.Append(NewEntry("test-deployment"), func(entry Entry) error {
+			if entry.Chain.Signature != "" {
+				signed++
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if signed != 1 {
+		t.Fatalf("expected only the first entry to be signed, got %d", signed)
+	}
+}
+
+func TestVerifyStreamUnchained(t *testing.T) {
+	report, err := VerifyStream(strings.NewReader(`{"version":"1"}`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Valid || report.Unchained != 1 {
+		t.Fatalf("expected unchained entry to be reported, got %+v", report)
+	}
+
+	if _, err = VerifyStream(strings.NewReader(`{"chain":{"seq":1}}`), nil); err == nil {
+		t.Fatal("expected chain without id to fail")
+	}
+}
+
+func TestChainVerifyKeyRotation(t *testing.T) {
+	oldPub, oldLines := newTestChainLog(t, 3)
+	newPub, newLines := newTestChainLog(t, 3)
+	lines := append(append([][]byte{}, oldLines...), newLines...)
+
+	report := verifyLines(t, newPub, lines)
+	if report.Valid || len(report.Chains) != 2 {
+		t.Fatalf("expected entries signed with the old key to be invalid, got %+v", report)
+	}
+
+	// Entries carry the ID of their key, so both keys can be given.
+	report, err := VerifyStream(bytes.NewReader(bytes.Join(lines, []byte("\n"))), newPub, oldPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Valid || len(report.KeyIDs) != 2 {
+		t.Fatalf("expected entries of both keys to be valid, got %+v", report)
+	}
+	for _, r := range report.Chains {
+		if r.Signatures != 3 {
+			t.Fatalf("unexpected chain report %+v", r)
+		}
+	}
+}
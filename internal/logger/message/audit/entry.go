@@ -46,14 +46,18 @@ type Entry struct {
 		TimeToFirstByte string `json:"timeToFirstByte,omitempty"`
 		TimeToResponse  string `json:"timeToResponse,omitempty"`
 	} `json:"api"`
-	RemoteHost string                 `json:"remotehost,omitempty"`
-	RequestID  string                 `json:"requestID,omitempty"`
+	RemoteHost string `json:"remotehost,omitempty"`
+	RequestID  string `json:"requestID,omitempty"`
+	// AccessKey is only used by the bucket access logs and the audit
+	// filters, it is not sent to the audit targets.
+	AccessKey  string                 `json:"-"`
 	UserAgent  string                 `json:"userAgent,omitempty"`
 	ReqClaims  map[string]interface{} `json:"requestClaims,omitempty"`
 	ReqQuery   map[string]string      `json:"requestQuery,omitempty"`
 	ReqHeader  map[string]string      `json:"requestHeader,omitempty"`
 	RespHeader map[string]string      `json:"responseHeader,omitempty"`
 	Tags       map[string]interface{} `json:"tags,omitempty"`
+	Chain      *ChainInfo             `json:"chain,omitempty"`
 }
 
 // NewEntry - constructs an audit entry object with some fields filled
@@ -0,0 +1,160 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/minio/pkg/wildcard"
+)
+
+// RedactedValue replaces the values of redacted headers and query parameters.
+const RedactedValue = "*REDACTED*"
+
+// FilterRules - include and exclude patterns of a single entry field.
+// Patterns may contain '*' and '?' wildcards, patterns prefixed with '!'
+// exclude matching entries.
+type FilterRules struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// ParseFilterRules - parses comma separated patterns, patterns
+// prefixed with '!' are exclude patterns.
+func ParseFilterRules(s string) FilterRules {
+	var rules FilterRules
+	for _, pattern := range strings.Split(s, ",") {
+		pattern = strings.TrimSpace(pattern)
+		switch {
+		case pattern == "" || pattern == "!":
+		case strings.HasPrefix(pattern, "!"):
+			rules.Exclude = append(rules.Exclude, pattern[1:])
+		default:
+			rules.Include = append(rules.Include, pattern)
+		}
+	}
+	return rules
+}
+
+// match - returns true if the value matches any include pattern,
+// or there are none, and no exclude pattern.
+func (r FilterRules) match(value string) bool {
+	for _, pattern := range r.Exclude {
+		if wildcard.Match(pattern, value) {
+			return false
+		}
+	}
+	if len(r.Include) == 0 {
+		return true
+	}
+	for _, pattern := range r.Include {
+		if wildcard.Match(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter - selects the audit entries sent to a target and the headers
+// and query parameters masked in them.
+type Filter struct {
+	API        FilterRules `json:"api"`
+	Bucket     FilterRules `json:"bucket"`
+	User       FilterRules `json:"user"`
+	StatusCode FilterRules `json:"statusCode"`
+	// Redact are names of headers and query parameters whose
+	// values are masked, names are matched case-insensitively.
+	Redact []string `json:"redact,omitempty"`
+}
+
+// ParseStatusCodeRules - parses comma separated status codes, an 'x'
+// matches any digit e.g. "2xx,!404" includes all successful responses
+// and excludes responses with status 404.
+func ParseStatusCodeRules(s string) (FilterRules, error) {
+	rules := ParseFilterRules(s)
+	for _, patterns := range [][]string{rules.Include, rules.Exclude} {
+		for i, pattern := range patterns {
+			if len(pattern) != 3 {
+				return rules, fmt.Errorf("invalid status code pattern '%s'", pattern)
+			}
+			pattern = strings.ToLower(pattern)
+			for _, c := range pattern {
+				if (c < '0' || c > '9') && c != 'x' {
+					return rules, fmt.Errorf("invalid status code pattern '%s'", pattern)
+				}
+			}
+			patterns[i] = strings.Replace(pattern, "x", "?", -1)
+		}
+	}
+	return rules, nil
+}
+
+// ParseRedact - parses a comma separated list of header and query names.
+func ParseRedact(s string) []string {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Match - returns true if the entry should be sent.
+func (f Filter) Match(entry Entry) bool {
+	return f.API.match(entry.API.Name) &&
+		f.Bucket.match(entry.API.Bucket) &&
+		f.User.match(entry.AccessKey) &&
+		f.StatusCode.match(strconv.Itoa(entry.API.StatusCode))
+}
+
+// Apply - returns the entry with redacted values and
+// false if the entry should not be sent.
+func (f Filter) Apply(entry Entry) (Entry, bool) {
+	if !f.Match(entry) {
+		return entry, false
+	}
+	if len(f.Redact) == 0 {
+		return entry, true
+	}
+	// The maps are shared with the entries sent to other
+	// targets, redact on copies.
+	entry.ReqHeader = f.redact(entry.ReqHeader)
+	entry.ReqQuery = f.redact(entry.ReqQuery)
+	entry.RespHeader = f.redact(entry.RespHeader)
+	return entry, true
+}
+
+func (f Filter) redact(values map[string]string) map[string]string {
+	if len(values) == 0 {
+		return values
+	}
+	redacted := make(map[string]string, len(values))
+	for k, v := range values {
+		for _, name := range f.Redact {
+			if strings.EqualFold(k, name) {
+				v = RedactedValue
+				break
+			}
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"testing"
+)
+
+func TestFilterMatch(t *testing.T) {
+	statusRules, err := ParseStatusCodeRules("4xx,5xx,!404")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := Filter{
+		API:        ParseFilterRules("!ListObjects*,!HeadObject"),
+		Bucket:     ParseFilterRules("finance*, !finance-tmp"),
+		User:       ParseFilterRules("!console"),
+		StatusCode: statusRules,
+	}
+
+	testCases := []struct {
+		api, bucket, user string
+		statusCode        int
+		match             bool
+	}{
+		{"PutObject", "finance", "minio", 403, true},
+		{"PutObject", "finance-2021", "minio", 500, true},
+		{"PutObject", "finance", "minio", 200, false},
+		{"PutObject", "finance", "minio", 404, false},
+		{"ListObjectsV2", "finance", "minio", 403, false},
+		{"HeadObject", "finance", "minio", 403, false},
+		{"PutObject", "finance-tmp", "minio", 403, false},
+		{"PutObject", "other", "minio", 403, false},
+		{"PutObject", "finance", "console", 403, false},
+	}
+	for i, tc := range testCases {
+		entry := NewEntry("test-deployment")
+		entry.API.Name = tc.api
+		entry.API.Bucket = tc.bucket
+		entry.AccessKey = tc.user
+		entry.API.StatusCode = tc.statusCode
+		if match := f.Match(entry); match != tc.match {
+			t.Errorf("case %d: expected match %t, got %t", i+1, tc.match, match)
+		}
+	}
+
+	// An empty filter matches every entry.
+	if !(Filter{}).Match(NewEntry("test-deployment")) {
+		t.Error("expected empty filter to match")
+	}
+}
+
+func TestParseStatusCodeRules(t *testing.T) {
+	for _, s := range []string{"20", "2xxx", "abc", "!4y4"} {
+		if _, err := ParseStatusCodeRules(s); err == nil {
+			t.Errorf("expected '%s' to be invalid", s)
+		}
+	}
+	rules, err := ParseStatusCodeRules("2XX, !204")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules.Include) != 1 || rules.Include[0] != "2??" || len(rules.Exclude) != 1 || rules.Exclude[0] != "204" {
+		t.Fatalf("unexpected rules %+v", rules)
+	}
+}
+
+func TestFilterRedact(t *testing.T) {
+	f := Filter{Redact: ParseRedact("authorization, X-Amz-Signature")}
+
+	entry := NewEntry("test-deployment")
+	entry.ReqHeader = map[string]string{"Authorization": "AWS4-HMAC-SHA256 secret", "Content-Type": "text/plain"}
+	entry.ReqQuery = map[string]string{"X-Amz-Signature": "secret", "prefix": "a"}
+
+	redacted, ok := f.Apply(entry)
+	if !ok {
+		t.Fatal("expected entry to match")
+	}
+	if redacted.ReqHeader["Authorization"] != RedactedValue || redacted.ReqQuery["X-Amz-Signature"] != RedactedValue {
+		t.Fatalf("expected values to be redacted, got %v %v", redacted.ReqHeader, redacted.ReqQuery)
+	}
+	if redacted.ReqHeader["Content-Type"] != "text/plain" || redacted.ReqQuery["prefix"] != "a" {
+		t.Fatalf("expected other values to be kept, got %v %v", redacted.ReqHeader, redacted.ReqQuery)
+	}
+	// The original entry is shared with other targets and must not change.
+	if entry.ReqHeader["Authorization"] == RedactedValue {
+		t.Fatal("expected original entry to be unchanged")
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+// Time format of rotated file names, sorts in chronological order.
+const rotateTimeFormat = "2006-01-02T15-04-05.000000000"
+
+// logExt - extension of log files.
+const logExt = ".log"
+
+// Config file logger target
+type Config struct {
+	Enabled        bool          `json:"enabled"`
+	Name           string        `json:"name"`
+	Dir            string        `json:"dir"`
+	MaxSize        int64         `json:"maxSize"`
+	RotateInterval time.Duration `json:"rotateInterval"`
+	MaxFiles       int           `json:"maxFiles"`
+	MaxAge         time.Duration `json:"maxAge"`
+
+	// Audit entries sent to the target, applied by the logger
+	Filter audit.Filter `json:"filter"`
+
+	// Custom logger
+	LogOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{}) `json:"-"`
+}
+
+// Target implements logger.Target and writes log entries as JSON
+// lines to a local file. The file is rotated once it exceeds the
+// maximum size or the rotation interval, rotated files are removed
+// once they exceed the maximum number of files or the maximum age.
+type Target struct {
+	// Channel of log entries
+	logCh chan interface{}
+
+	config   Config
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// Endpoint returns the backend endpoint
+func (h *Target) Endpoint() string {
+	return h.path()
+}
+
+func (h *Target) String() string {
+	return h.config.Name
+}
+
+// baseName - returns the name of the log file without extension.
+func (h *Target) baseName() string {
+	if h.config.Name == "" {
+		return "audit"
+	}
+	return "audit-" + h.config.Name
+}
+
+func (h *Target) path() string {
+	return filepath.Join(h.config.Dir, h.baseName()+logExt)
+}
+
+func (h *Target) rotatedPath(t time.Time) string {
+	return filepath.Join(h.config.Dir, h.baseName()+"-"+t.UTC().Format(rotateTimeFormat)+logExt)
+}
+
+func (h *Target) open() error {
+	f, err := os.OpenFile(h.path(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	h.file = f
+	h.size = fi.Size()
+	h.openedAt = time.Now()
+	return nil
+}
+
+// Init validate and initialize the file target
+func (h *Target) Init() error {
+	if h.config.Dir == "" {
+		return errors.New("file 'dir' cannot be empty")
+	}
+	if !filepath.IsAbs(h.config.Dir) {
+		return errors.New("file 'dir' should be an absolute path")
+	}
+	if err := os.MkdirAll(h.config.Dir, 0750); err != nil {
+		return err
+	}
+	if err := h.open(); err != nil {
+		return err
+	}
+
+	go h.startFileLogger()
+	return nil
+}
+
+// rotate - renames the current log file and opens a new one.
+func (h *Target) rotate(now time.Time) error {
+	if err := h.file.Close(); err != nil {
+		return err
+	}
+	// Never overwrite a file rotated at the same time.
+	var rotated string
+	for t := now; ; t = t.Add(time.Nanosecond) {
+		rotated = h.rotatedPath(t)
+		if _, err := os.Stat(rotated); os.IsNotExist(err) {
+			break
+		}
+	}
+	if err := os.Rename(h.path(), rotated); err != nil {
+		return err
+	}
+	if err := h.open(); err != nil {
+		return err
+	}
+	return h.removeExpired(now)
+}
+
+// removeExpired - removes rotated files exceeding the retention limits.
+func (h *Target) removeExpired(now time.Time) error {
+	rotated, err := filepath.Glob(filepath.Join(h.config.Dir, h.baseName()+"-*"+logExt))
+	if err != nil {
+		return err
+	}
+	// Rotated files of other targets may share the prefix.
+	prefix := filepath.Join(h.config.Dir, h.baseName()+"-")
+	files := rotated[:0]
+	for _, name := range rotated {
+		if _, err := time.Parse(rotateTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), logExt)); err == nil {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(files)
+
+	for i, name := range files {
+		expired := h.config.MaxFiles > 0 && i < len(files)-h.config.MaxFiles
+		if !expired && h.config.MaxAge > 0 {
+			fi, err := os.Stat(name)
+			expired = err == nil && now.Sub(fi.ModTime()) > h.config.MaxAge
+		}
+		if expired {
+			if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// shouldRotate - whether the current log file is due for rotation
+// before n more bytes are written to it.
+func (h *Target) shouldRotate(n int64, now time.Time) bool {
+	if h.size == 0 {
+		return false
+	}
+	if h.config.MaxSize > 0 && h.size+n > h.config.MaxSize {
+		return true
+	}
+	return h.config.RotateInterval > 0 && now.Sub(h.openedAt) >= h.config.RotateInterval
+}
+
+func (h *Target) write(entry interface{}) error {
+	logJSON, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+	logJSON = append(logJSON, '\n')
+
+	if now := time.Now(); h.shouldRotate(int64(len(logJSON)), now) {
+		if err = h.rotate(now); err != nil {
+			return err
+		}
+	}
+
+	n, err := h.file.Write(logJSON)
+	h.size += int64(n)
+	return err
+}
+
+func (h *Target) startFileLogger() {
+	// Create a routine which writes json logs received
+	// from an internal channel.
+	go func() {
+		var tickerCh <-chan time.Time
+		if h.config.RotateInterval > 0 {
+			ticker := time.NewTicker(h.config.RotateInterval)
+			defer ticker.Stop()
+			tickerCh = ticker.C
+		}
+
+		for {
+			select {
+			case entry, ok := <-h.logCh:
+				if !ok {
+					h.file.Close()
+					return
+				}
+				if err := h.write(entry); err != nil {
+					h.config.LogOnce(context.Background(), err, h.path())
+				}
+			case now := <-tickerCh:
+				// Rotate idle files as well.
+				if h.shouldRotate(0, now) {
+					if err := h.rotate(now); err != nil {
+						h.config.LogOnce(context.Background(), err, h.path())
+					}
+				}
+			}
+		}
+	}()
+}
+
+// New initializes a new logger target which
+// writes logs to files in the specified directory
+func New(config Config) *Target {
+	h := &Target{
+		logCh:  make(chan interface{}, 10000),
+		config: config,
+	}
+
+	return h
+}
+
+// Send log message 'e' to file target.
+func (h *Target) Send(entry interface{}, errKind string) error {
+	select {
+	case h.logCh <- entry:
+	default:
+		// log channel is full, do not wait and return
+		// an error immediately to the caller
+		return errors.New("log buffer full")
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package file
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestTarget(t *testing.T, config Config) *Target {
+	config.Dir = t.TempDir()
+	config.LogOnce = func(ctx context.Context, err error, id interface{}, errKind ...interface{}) {
+		t.Error(err)
+	}
+	h := New(config)
+	if err := h.open(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { h.file.Close() })
+	return h
+}
+
+func countLines(t *testing.T, name string) int {
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var n int
+	for s := bufio.NewScanner(f); s.Scan(); n++ {
+	}
+	return n
+}
+
+func TestFileTargetRotateSize(t *testing.T) {
+	h := newTestTarget(t, Config{MaxSize: 64})
+	entry := map[string]string{"api": "PutObject", "bucket": "testbucket"}
+	for i := 0; i < 4; i++ {
+		if err := h.write(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rotated, err := filepath.Glob(filepath.Join(h.config.Dir, "audit-*.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rotated) == 0 {
+		t.Fatal("expected the log file to be rotated")
+	}
+
+	lines := countLines(t, h.path())
+	for _, name := range rotated {
+		lines += countLines(t, name)
+	}
+	if lines != 4 {
+		t.Fatalf("expected 4 log lines, got %d", lines)
+	}
+}
+
+func TestFileTargetRotateInterval(t *testing.T) {
+	h := newTestTarget(t, Config{Name: "target1", RotateInterval: time.Hour})
+	if err := h.write("first"); err != nil {
+		t.Fatal(err)
+	}
+	if h.shouldRotate(0, time.Now()) {
+		t.Fatal("unexpected rotation before the rotation interval")
+	}
+	if !h.shouldRotate(0, time.Now().Add(time.Hour)) {
+		t.Fatal("expected rotation after the rotation interval")
+	}
+	if err := h.rotate(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(h.config.Dir, "audit-target1.log")); err != nil {
+		t.Fatal(err)
+	}
+	rotated, _ := filepath.Glob(filepath.Join(h.config.Dir, "audit-target1-*.log"))
+	if len(rotated) != 1 {
+		t.Fatalf("expected one rotated file, got %v", rotated)
+	}
+}
+
+func TestFileTargetRetention(t *testing.T) {
+	h := newTestTarget(t, Config{MaxFiles: 2, MaxAge: time.Hour})
+
+	now := time.Now()
+	var names []string
+	for i := 0; i < 4; i++ {
+		name := filepath.Join(h.config.Dir, "audit-"+now.Add(time.Duration(i)*time.Second).UTC().Format(rotateTimeFormat)+logExt)
+		if err := os.WriteFile(name, []byte("{}\n"), 0640); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+	// The newest file is expired by age.
+	if err := os.Chtimes(names[3], now.Add(-2*time.Hour), now.Add(-2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	// Rotated files of other targets are not touched.
+	other := filepath.Join(h.config.Dir, "audit-target1-"+now.UTC().Format(rotateTimeFormat)+logExt)
+	if err := os.WriteFile(other, []byte("{}\n"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.removeExpired(now); err != nil {
+		t.Fatal(err)
+	}
+	for i, name := range names {
+		_, err := os.Stat(name)
+		if kept := i == 2; kept != (err == nil) {
+			t.Errorf("file %d: expected kept=%v, got err=%v", i, kept, err)
+		}
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Fatal(err)
+	}
+}
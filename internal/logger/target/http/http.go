@@ -25,13 +25,23 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger/message/audit"
+	"github.com/minio/minio/internal/logger/target/store"
+	"github.com/minio/minio/internal/logger/target/types"
 )
 
-// Timeout for the webhook http call
-const webhookCallTimeout = 5 * time.Second
+const (
+	// Timeout for the webhook http call
+	webhookCallTimeout = 5 * time.Second
+
+	// Interval to retry sending queued log entries after a failure
+	retryInterval = 3 * time.Second
+)
 
 // Config http logger target
 type Config struct {
@@ -42,8 +52,13 @@ type Config struct {
 	AuthToken  string            `json:"authToken"`
 	ClientCert string            `json:"clientCert"`
 	ClientKey  string            `json:"clientKey"`
+	QueueDir   string            `json:"queueDir"`
+	QueueLimit uint64            `json:"queueLimit"`
 	Transport  http.RoundTripper `json:"-"`
 
+	// Audit entries sent to the target, applied by the logger
+	Filter audit.Filter `json:"filter"`
+
 	// Custom logger
 	LogOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{}) `json:"-"`
 }
@@ -52,11 +67,26 @@ type Config struct {
 // format of a log entry to the configured http endpoint.
 // An internal buffer of logs is maintained but when the
 // buffer is full, new logs are just ignored and an error
-// is returned to the caller.
+// is returned to the caller. With a queue directory the
+// log entries are persisted until they are sent instead.
 type Target struct {
+	// Delivery statistics, accessed atomically and
+	// kept first for 64-bit alignment.
+	sent    int64
+	failed  int64
+	dropped int64
+
 	// Channel of log entries
 	logCh chan interface{}
 
+	// Persistent queue of log entries, nil without a queue directory
+	store    *store.QueueStore
+	notifyCh chan struct{}
+
+	// Closed by Cancel to stop replaying the queued entries
+	doneCh     chan struct{}
+	cancelOnce sync.Once
+
 	config Config
 }
 
@@ -69,8 +99,46 @@ func (h *Target) String() string {
 	return h.config.Name
 }
 
+// Stats returns the delivery statistics of the target
+func (h *Target) Stats() types.TargetStats {
+	stats := types.TargetStats{
+		Pending: len(h.logCh),
+		Sent:    atomic.LoadInt64(&h.sent),
+		Failed:  atomic.LoadInt64(&h.failed),
+		Dropped: atomic.LoadInt64(&h.dropped),
+	}
+	if h.store != nil {
+		stats.Pending = h.store.Len()
+	}
+	return stats
+}
+
 // Init validate and initialize the http target
 func (h *Target) Init() error {
+	if h.config.QueueDir != "" {
+		h.store = store.NewQueueStore(h.config.QueueDir, h.config.QueueLimit)
+		if err := h.store.Open(); err != nil {
+			return err
+		}
+		// Queued log entries are kept until the endpoint is
+		// reachable, do not fail if it is not reachable yet.
+		if err := h.ping(); err != nil {
+			h.config.LogOnce(context.Background(), err, h.config.Endpoint)
+		}
+		go h.store.Replay(h.send, retryInterval, h.notifyCh, h.doneCh)
+		return nil
+	}
+
+	if err := h.ping(); err != nil {
+		return err
+	}
+
+	go h.startHTTPLogger()
+	return nil
+}
+
+// ping checks that the endpoint accepts log entries
+func (h *Target) ping() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*webhookCallTimeout)
 	defer cancel()
 
@@ -108,7 +176,6 @@ func (h *Target) Init() error {
 			h.config.Endpoint, resp.Status)
 	}
 
-	go h.startHTTPLogger()
 	return nil
 }
 
@@ -128,53 +195,68 @@ func (h *Target) startHTTPLogger() {
 			if err != nil {
 				continue
 			}
+			h.send(logJSON)
+		}
+	}()
+}
 
-			ctx, cancel := context.WithTimeout(context.Background(), webhookCallTimeout)
-			req, err := http.NewRequestWithContext(ctx, http.MethodPost,
-				h.config.Endpoint, bytes.NewReader(logJSON))
-			if err != nil {
-				cancel()
-				continue
-			}
-			req.Header.Set(xhttp.ContentType, "application/json")
+// send posts a json log entry to the endpoint
+func (h *Target) send(logJSON []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookCallTimeout)
+	defer cancel()
 
-			// Set user-agent to indicate MinIO release
-			// version to the configured log endpoint
-			req.Header.Set("User-Agent", h.config.UserAgent)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		h.config.Endpoint, bytes.NewReader(logJSON))
+	if err != nil {
+		atomic.AddInt64(&h.failed, 1)
+		return err
+	}
+	req.Header.Set(xhttp.ContentType, "application/json")
 
-			if h.config.AuthToken != "" {
-				req.Header.Set("Authorization", h.config.AuthToken)
-			}
+	// Set user-agent to indicate MinIO release
+	// version to the configured log endpoint
+	req.Header.Set("User-Agent", h.config.UserAgent)
 
-			client := http.Client{Transport: h.config.Transport}
-			resp, err := client.Do(req)
-			cancel()
-			if err != nil {
-				h.config.LogOnce(ctx, fmt.Errorf("%s returned '%w', please check your endpoint configuration", h.config.Endpoint, err), h.config.Endpoint)
-				continue
-			}
+	if h.config.AuthToken != "" {
+		req.Header.Set("Authorization", h.config.AuthToken)
+	}
 
-			// Drain any response.
-			xhttp.DrainBody(resp.Body)
+	client := http.Client{Transport: h.config.Transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		atomic.AddInt64(&h.failed, 1)
+		err = fmt.Errorf("%s returned '%w', please check your endpoint configuration", h.config.Endpoint, err)
+		h.config.LogOnce(ctx, err, h.config.Endpoint)
+		return err
+	}
 
-			if resp.StatusCode != http.StatusOK {
-				switch resp.StatusCode {
-				case http.StatusForbidden:
-					h.config.LogOnce(ctx, fmt.Errorf("%s returned '%s', please check if your auth token is correctly set", h.config.Endpoint, resp.Status), h.config.Endpoint)
-				default:
-					h.config.LogOnce(ctx, fmt.Errorf("%s returned '%s', please check your endpoint configuration", h.config.Endpoint, resp.Status), h.config.Endpoint)
-				}
-			}
+	// Drain any response.
+	xhttp.DrainBody(resp.Body)
+
+	if !acceptedResponseStatusCode(resp.StatusCode) {
+		atomic.AddInt64(&h.failed, 1)
+		switch resp.StatusCode {
+		case http.StatusForbidden:
+			err = fmt.Errorf("%s returned '%s', please check if your auth token is correctly set", h.config.Endpoint, resp.Status)
+		default:
+			err = fmt.Errorf("%s returned '%s', please check your endpoint configuration", h.config.Endpoint, resp.Status)
 		}
-	}()
+		h.config.LogOnce(ctx, err, h.config.Endpoint)
+		return err
+	}
+
+	atomic.AddInt64(&h.sent, 1)
+	return nil
 }
 
 // New initializes a new logger target which
 // sends log over http to the specified endpoint
 func New(config Config) *Target {
 	h := &Target{
-		logCh:  make(chan interface{}, 10000),
-		config: config,
+		logCh:    make(chan interface{}, 10000),
+		notifyCh: make(chan struct{}, 1),
+		doneCh:   make(chan struct{}),
+		config:   config,
 	}
 
 	return h
@@ -182,13 +264,38 @@ func New(config Config) *Target {
 
 // Send log message 'e' to http target.
 func (h *Target) Send(entry interface{}, errKind string) error {
+	if h.store != nil {
+		logJSON, err := json.Marshal(&entry)
+		if err != nil {
+			return err
+		}
+		if err = h.store.Put(logJSON); err != nil {
+			atomic.AddInt64(&h.dropped, 1)
+			return err
+		}
+		select {
+		case h.notifyCh <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+
 	select {
 	case h.logCh <- entry:
 	default:
 		// log channel is full, do not wait and return
 		// an error immediately to the caller
+		atomic.AddInt64(&h.dropped, 1)
 		return errors.New("log buffer full")
 	}
 
 	return nil
 }
+
+// Cancel stops replaying the queued log entries, the
+// entries left in the queue directory are kept.
+func (h *Target) Cancel() {
+	h.cancelOnce.Do(func() {
+		close(h.doneCh)
+	})
+}
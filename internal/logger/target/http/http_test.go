@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHTTPTargetQueue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log-queue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var mu sync.Mutex
+	online := false
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !online {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		received = append(received, string(body))
+	}))
+	defer server.Close()
+
+	target := New(Config{
+		Enabled:    true,
+		Endpoint:   server.URL,
+		QueueDir:   dir,
+		QueueLimit: 2,
+		Transport:  http.DefaultTransport,
+		LogOnce:    func(ctx context.Context, err error, id interface{}, errKind ...interface{}) {},
+	})
+	// The target starts while the endpoint is offline.
+	if err = target.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer target.Cancel()
+
+	for _, entry := range []string{"a", "b", "c"} {
+		target.Send(entry, "")
+	}
+	if stats := target.Stats(); stats.Pending != 2 || stats.Dropped != 1 {
+		t.Fatalf("expected 2 pending and 1 dropped entry, got %+v", stats)
+	}
+
+	mu.Lock()
+	online = true
+	mu.Unlock()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for target.Stats().Pending > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for queued entries, stats %+v", target.Stats())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 2 || received[0] != `"a"` || received[1] != `"b"` {
+		t.Fatalf("unexpected entries received %v", received)
+	}
+	if stats := target.Stats(); stats.Sent != 2 {
+		t.Fatalf("expected 2 sent entries, got %+v", stats)
+	}
+}
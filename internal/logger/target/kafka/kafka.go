@@ -24,19 +24,41 @@ import (
 	"encoding/json"
 	"errors"
 	"net"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	sarama "github.com/Shopify/sarama"
 	saramatls "github.com/Shopify/sarama/tools/tls"
 
 	"github.com/minio/minio/internal/logger/message/audit"
+	"github.com/minio/minio/internal/logger/target/store"
+	"github.com/minio/minio/internal/logger/target/types"
 	xnet "github.com/minio/pkg/net"
 )
 
+// Interval to retry sending queued log entries after a failure
+const retryInterval = 3 * time.Second
+
 // Target - Kafka target.
 type Target struct {
+	// Delivery statistics, accessed atomically and
+	// kept first for 64-bit alignment.
+	sent    int64
+	failed  int64
+	dropped int64
+
 	// Channel of log entries
 	logCh chan interface{}
 
+	// Persistent queue of log entries, nil without a queue directory
+	store    *store.QueueStore
+	notifyCh chan struct{}
+
+	// Closed by Cancel to stop replaying the queued entries
+	doneCh     chan struct{}
+	cancelOnce sync.Once
+
 	producer sarama.SyncProducer
 	kconfig  Config
 	config   *sarama.Config
@@ -44,17 +66,53 @@ type Target struct {
 
 // Send log message 'e' to kafka target.
 func (h *Target) Send(entry interface{}, errKind string) error {
+	if h.store != nil {
+		// Only audit entries are sent to kafka.
+		ae, ok := entry.(audit.Entry)
+		if !ok {
+			return nil
+		}
+		logJSON, err := json.Marshal(&ae)
+		if err != nil {
+			return err
+		}
+		if err = h.store.Put(logJSON); err != nil {
+			atomic.AddInt64(&h.dropped, 1)
+			return err
+		}
+		select {
+		case h.notifyCh <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+
 	select {
 	case h.logCh <- entry:
 	default:
 		// log channel is full, do not wait and return
 		// an error immediately to the caller
+		atomic.AddInt64(&h.dropped, 1)
 		return errors.New("log buffer full")
 	}
 
 	return nil
 }
 
+// Stats returns the delivery statistics of the target
+func (h *Target) Stats() types.TargetStats {
+	stats := types.TargetStats{
+		Pending: len(h.logCh),
+		Sent:    atomic.LoadInt64(&h.sent),
+		Failed:  atomic.LoadInt64(&h.failed),
+		Dropped: atomic.LoadInt64(&h.dropped),
+	}
+	if h.store != nil {
+		stats.Pending = h.store.Len()
+	}
+	return stats
+}
+
 func (h *Target) startKakfaLogger() {
 	// Create a routine which sends json logs received
 	// from an internal channel.
@@ -67,22 +125,51 @@ func (h *Target) startKakfaLogger() {
 
 			ae, ok := entry.(audit.Entry)
 			if ok {
-				msg := sarama.ProducerMessage{
-					Topic: h.kconfig.Topic,
-					Key:   sarama.StringEncoder(ae.RequestID),
-					Value: sarama.ByteEncoder(logJSON),
-				}
-
-				_, _, err = h.producer.SendMessage(&msg)
-				if err != nil {
-					h.kconfig.LogOnce(context.Background(), err, h.kconfig.Topic)
-					continue
-				}
+				h.send(ae.RequestID, logJSON)
 			}
 		}
 	}()
 }
 
+// send produces a json audit entry to the topic
+func (h *Target) send(key string, logJSON []byte) error {
+	if h.producer == nil {
+		// The brokers were not reachable when the target
+		// was initialized, connect now.
+		if err := h.initProducer(); err != nil {
+			atomic.AddInt64(&h.failed, 1)
+			h.kconfig.LogOnce(context.Background(), err, h.kconfig.Topic)
+			return err
+		}
+	}
+
+	msg := sarama.ProducerMessage{
+		Topic: h.kconfig.Topic,
+		Key:   sarama.StringEncoder(key),
+		Value: sarama.ByteEncoder(logJSON),
+	}
+
+	if _, _, err := h.producer.SendMessage(&msg); err != nil {
+		atomic.AddInt64(&h.failed, 1)
+		h.kconfig.LogOnce(context.Background(), err, h.kconfig.Topic)
+		return err
+	}
+	atomic.AddInt64(&h.sent, 1)
+	return nil
+}
+
+// sendQueued produces an audit entry read from the queue
+func (h *Target) sendQueued(logJSON []byte) error {
+	var ae struct {
+		RequestID string `json:"requestID"`
+	}
+	if err := json.Unmarshal(logJSON, &ae); err != nil {
+		// Never retry entries which cannot be decoded.
+		return nil
+	}
+	return h.send(ae.RequestID, logJSON)
+}
+
 // Config - kafka target arguments.
 type Config struct {
 	Enabled bool        `json:"enable"`
@@ -103,6 +190,13 @@ type Config struct {
 		Password  string `json:"password"`
 		Mechanism string `json:"mechanism"`
 	} `json:"sasl"`
+	Queue struct {
+		Dir   string `json:"dir"`
+		Limit uint64 `json:"limit"`
+	} `json:"queue"`
+
+	// Audit entries sent to the target, applied by the logger
+	Filter audit.Filter `json:"filter"`
 
 	// Custom logger
 	LogOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{}) `json:"-"`
@@ -146,6 +240,31 @@ func (h *Target) Init() error {
 			return err
 		}
 	}
+
+	if h.kconfig.Queue.Dir != "" {
+		h.store = store.NewQueueStore(h.kconfig.Queue.Dir, h.kconfig.Queue.Limit)
+		if err := h.store.Open(); err != nil {
+			return err
+		}
+		// Queued log entries are kept until the brokers are
+		// reachable, do not fail if they are not reachable yet.
+		if err := h.initProducer(); err != nil {
+			h.kconfig.LogOnce(context.Background(), err, h.kconfig.Topic)
+		}
+		go h.store.Replay(h.sendQueued, retryInterval, h.notifyCh, h.doneCh)
+		return nil
+	}
+
+	if err := h.initProducer(); err != nil {
+		return err
+	}
+
+	go h.startKakfaLogger()
+	return nil
+}
+
+// initProducer connects to the kafka brokers
+func (h *Target) initProducer() error {
 	if err := h.kconfig.pingBrokers(); err != nil {
 		return err
 	}
@@ -192,8 +311,6 @@ func (h *Target) Init() error {
 	}
 
 	h.producer = producer
-
-	go h.startKakfaLogger()
 	return nil
 }
 
@@ -201,8 +318,18 @@ func (h *Target) Init() error {
 // sends log over http to the specified endpoint
 func New(config Config) *Target {
 	target := &Target{
-		logCh:   make(chan interface{}, 10000),
-		kconfig: config,
+		logCh:    make(chan interface{}, 10000),
+		notifyCh: make(chan struct{}, 1),
+		doneCh:   make(chan struct{}),
+		kconfig:  config,
 	}
 	return target
 }
+
+// Cancel stops replaying the queued audit entries, the
+// entries left in the queue directory are kept.
+func (h *Target) Cancel() {
+	h.cancelOnce.Do(func() {
+		close(h.doneCh)
+	})
+}
@@ -0,0 +1,309 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+// Timeout for the collector http call
+const otlpCallTimeout = 5 * time.Second
+
+// Maximum number of log records sent in one request.
+const maxBatchSize = 100
+
+// Severity of the audit log records, OpenTelemetry INFO.
+const (
+	severityNumberInfo = 9
+	severityTextInfo   = "INFO"
+)
+
+// Config otlp logger target
+type Config struct {
+	Enabled     bool              `json:"enabled"`
+	Name        string            `json:"name"`
+	UserAgent   string            `json:"userAgent"`
+	Endpoint    string            `json:"endpoint"`
+	AuthToken   string            `json:"authToken"`
+	ClientCert  string            `json:"clientCert"`
+	ClientKey   string            `json:"clientKey"`
+	ServiceName string            `json:"serviceName"`
+	Transport   http.RoundTripper `json:"-"`
+
+	// Audit entries sent to the target, applied by the logger
+	Filter audit.Filter `json:"filter"`
+
+	// Custom logger
+	LogOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{}) `json:"-"`
+}
+
+// Target implements logger.Target and exports log entries to an
+// OpenTelemetry collector using the OTLP/HTTP JSON encoding.
+type Target struct {
+	// Channel of log entries
+	logCh chan interface{}
+
+	config Config
+}
+
+// OTLP/HTTP JSON encoding of an ExportLogsServiceRequest.
+type (
+	anyValue struct {
+		StringValue *string `json:"stringValue,omitempty"`
+		IntValue    *string `json:"intValue,omitempty"`
+	}
+
+	keyValue struct {
+		Key   string   `json:"key"`
+		Value anyValue `json:"value"`
+	}
+
+	logRecord struct {
+		TimeUnixNano         string     `json:"timeUnixNano"`
+		ObservedTimeUnixNano string     `json:"observedTimeUnixNano"`
+		SeverityNumber       int        `json:"severityNumber"`
+		SeverityText         string     `json:"severityText"`
+		Body                 anyValue   `json:"body"`
+		Attributes           []keyValue `json:"attributes,omitempty"`
+	}
+
+	scopeLogs struct {
+		Scope struct {
+			Name string `json:"name"`
+		} `json:"scope"`
+		LogRecords []logRecord `json:"logRecords"`
+	}
+
+	resourceLogs struct {
+		Resource struct {
+			Attributes []keyValue `json:"attributes"`
+		} `json:"resource"`
+		ScopeLogs []scopeLogs `json:"scopeLogs"`
+	}
+
+	exportLogsRequest struct {
+		ResourceLogs []resourceLogs `json:"resourceLogs"`
+	}
+)
+
+func stringAttr(key, value string) keyValue {
+	return keyValue{Key: key, Value: anyValue{StringValue: &value}}
+}
+
+func intAttr(key string, value int64) keyValue {
+	v := strconv.FormatInt(value, 10)
+	return keyValue{Key: key, Value: anyValue{IntValue: &v}}
+}
+
+// Endpoint returns the backend endpoint
+func (h *Target) Endpoint() string {
+	return h.config.Endpoint
+}
+
+func (h *Target) String() string {
+	return h.config.Name
+}
+
+func (h *Target) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(xhttp.ContentType, "application/json")
+
+	// Set user-agent to indicate MinIO release
+	// version to the configured collector
+	req.Header.Set("User-Agent", h.config.UserAgent)
+
+	if h.config.AuthToken != "" {
+		req.Header.Set("Authorization", h.config.AuthToken)
+	}
+
+	client := http.Client{Transport: h.config.Transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	// Drain any response.
+	xhttp.DrainBody(resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode > 299 {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return fmt.Errorf("%s returned '%s', please check if your auth token is correctly set",
+				h.config.Endpoint, resp.Status)
+		}
+		return fmt.Errorf("%s returned '%s', please check your endpoint configuration",
+			h.config.Endpoint, resp.Status)
+	}
+	return nil
+}
+
+// Init validate and initialize the otlp target
+func (h *Target) Init() error {
+	u, err := url.Parse(h.config.Endpoint)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid OTLP endpoint '%s'", h.config.Endpoint)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*otlpCallTimeout)
+	defer cancel()
+
+	// An empty request is accepted by every collector.
+	if err = h.post(ctx, []byte(`{}`)); err != nil {
+		return err
+	}
+
+	go h.startOTLPLogger()
+	return nil
+}
+
+// toLogRecord - converts a log entry to an OTLP log record, audit
+// entries are annotated with the most relevant fields as attributes.
+func toLogRecord(entry interface{}, observed time.Time) (logRecord, error) {
+	logJSON, err := json.Marshal(&entry)
+	if err != nil {
+		return logRecord{}, err
+	}
+	body := string(logJSON)
+
+	record := logRecord{
+		TimeUnixNano:         strconv.FormatInt(observed.UnixNano(), 10),
+		ObservedTimeUnixNano: strconv.FormatInt(observed.UnixNano(), 10),
+		SeverityNumber:       severityNumberInfo,
+		SeverityText:         severityTextInfo,
+		Body:                 anyValue{StringValue: &body},
+	}
+
+	if ae, ok := entry.(audit.Entry); ok {
+		if t, err := time.Parse(time.RFC3339Nano, ae.Time); err == nil {
+			record.TimeUnixNano = strconv.FormatInt(t.UnixNano(), 10)
+		}
+		record.Attributes = append(record.Attributes,
+			stringAttr("minio.api.name", ae.API.Name),
+			intAttr("http.status_code", int64(ae.API.StatusCode)))
+		for _, attr := range []keyValue{
+			stringAttr("minio.bucket", ae.API.Bucket),
+			stringAttr("minio.object", ae.API.Object),
+			stringAttr("minio.request_id", ae.RequestID),
+			stringAttr("minio.deployment_id", ae.DeploymentID),
+			stringAttr("net.peer.ip", ae.RemoteHost),
+		} {
+			if *attr.Value.StringValue != "" {
+				record.Attributes = append(record.Attributes, attr)
+			}
+		}
+	}
+	return record, nil
+}
+
+// export - sends a batch of log entries to the collector.
+func (h *Target) export(entries []interface{}) error {
+	now := time.Now()
+	scope := scopeLogs{}
+	scope.Scope.Name = "minio.audit"
+	for _, entry := range entries {
+		record, err := toLogRecord(entry, now)
+		if err != nil {
+			continue
+		}
+		scope.LogRecords = append(scope.LogRecords, record)
+	}
+	if len(scope.LogRecords) == 0 {
+		return nil
+	}
+
+	resource := resourceLogs{ScopeLogs: []scopeLogs{scope}}
+	resource.Resource.Attributes = []keyValue{stringAttr("service.name", h.config.ServiceName)}
+	body, err := json.Marshal(exportLogsRequest{ResourceLogs: []resourceLogs{resource}})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), otlpCallTimeout)
+	defer cancel()
+	return h.post(ctx, body)
+}
+
+func (h *Target) startOTLPLogger() {
+	// Create a routine which exports logs received from an internal
+	// channel, entries which are already waiting are sent together.
+	go func() {
+		for entry := range h.logCh {
+			entries := []interface{}{entry}
+		drain:
+			for len(entries) < maxBatchSize {
+				select {
+				case entry, ok := <-h.logCh:
+					if !ok {
+						break drain
+					}
+					entries = append(entries, entry)
+				default:
+					break drain
+				}
+			}
+
+			if err := h.export(entries); err != nil {
+				h.config.LogOnce(context.Background(), err, h.config.Endpoint)
+			}
+		}
+	}()
+}
+
+// New initializes a new logger target which exports
+// logs to the specified OpenTelemetry collector
+func New(config Config) *Target {
+	if config.ServiceName == "" {
+		config.ServiceName = "minio"
+	}
+	h := &Target{
+		logCh:  make(chan interface{}, 10000),
+		config: config,
+	}
+
+	return h
+}
+
+// Send log message 'e' to otlp target.
+func (h *Target) Send(entry interface{}, errKind string) error {
+	select {
+	case h.logCh <- entry:
+	default:
+		// log channel is full, do not wait and return
+		// an error immediately to the caller
+		return errors.New("log buffer full")
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+// collector - stub OTLP/HTTP collector recording the export requests.
+type collector struct {
+	mu       sync.Mutex
+	status   int
+	headers  []http.Header
+	requests []exportLogsRequest
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.status != 0 {
+		w.WriteHeader(c.status)
+		return
+	}
+	var req exportLogsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.headers = append(c.headers, r.Header.Clone())
+	c.requests = append(c.requests, req)
+}
+
+// records - returns the log records of the requests received so far,
+// requests without records are skipped.
+func (c *collector) records() (batches [][]logRecord) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, req := range c.requests {
+		for _, rl := range req.ResourceLogs {
+			for _, sl := range rl.ScopeLogs {
+				batches = append(batches, sl.LogRecords)
+			}
+		}
+	}
+	return batches
+}
+
+func waitForRecords(t *testing.T, c *collector, n int) [][]logRecord {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		batches := c.records()
+		total := 0
+		for _, batch := range batches {
+			total += len(batch)
+		}
+		if total >= n {
+			return batches
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d records, got %d", n, total)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func newTestTarget(endpoint string, logOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})) *Target {
+	if logOnce == nil {
+		logOnce = func(ctx context.Context, err error, id interface{}, errKind ...interface{}) {}
+	}
+	return New(Config{
+		Enabled:   true,
+		Name:      "otlp",
+		UserAgent: "MinIO-test",
+		Endpoint:  endpoint,
+		AuthToken: "Bearer secret",
+		Transport: http.DefaultTransport,
+		LogOnce:   logOnce,
+	})
+}
+
+func attrs(record logRecord) map[string]string {
+	m := make(map[string]string)
+	for _, attr := range record.Attributes {
+		switch {
+		case attr.Value.StringValue != nil:
+			m[attr.Key] = *attr.Value.StringValue
+		case attr.Value.IntValue != nil:
+			m[attr.Key] = *attr.Value.IntValue
+		}
+	}
+	return m
+}
+
+func TestOTLPTargetExport(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	target := newTestTarget(server.URL, nil)
+	if err := target.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := audit.NewEntry("deployment")
+	entry.Time = "2021-11-05T10:00:00Z"
+	entry.API.Name = "PutObject"
+	entry.API.Bucket = "bucket"
+	entry.API.Object = "object"
+	entry.API.StatusCode = http.StatusOK
+	entry.RequestID = "request"
+	if err := target.Send(entry, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	batches := waitForRecords(t, c, 1)
+	record := batches[0][0]
+	if record.TimeUnixNano != "1636106400000000000" {
+		t.Errorf("unexpected record time %s", record.TimeUnixNano)
+	}
+	if record.SeverityText != severityTextInfo || record.SeverityNumber != severityNumberInfo {
+		t.Errorf("unexpected record severity %s %d", record.SeverityText, record.SeverityNumber)
+	}
+	if record.Body.StringValue == nil || !strings.Contains(*record.Body.StringValue, `"name":"PutObject"`) {
+		t.Errorf("unexpected record body %v", record.Body.StringValue)
+	}
+	expected := map[string]string{
+		"minio.api.name":      "PutObject",
+		"http.status_code":    "200",
+		"minio.bucket":        "bucket",
+		"minio.object":        "object",
+		"minio.request_id":    "request",
+		"minio.deployment_id": "deployment",
+	}
+	got := attrs(record)
+	for k, v := range expected {
+		if got[k] != v {
+			t.Errorf("expected attribute %s=%s, got %q", k, v, got[k])
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// The first request is the empty request sent by Init.
+	if len(c.requests) != 2 || len(c.requests[0].ResourceLogs) != 0 {
+		t.Fatalf("unexpected requests %+v", c.requests)
+	}
+	resource := c.requests[1].ResourceLogs[0].Resource
+	if len(resource.Attributes) != 1 || *resource.Attributes[0].Value.StringValue != "minio" {
+		t.Errorf("unexpected resource attributes %+v", resource.Attributes)
+	}
+	h := c.headers[1]
+	if h.Get("Authorization") != "Bearer secret" || h.Get("User-Agent") != "MinIO-test" || h.Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected request headers %v", h)
+	}
+}
+
+func TestOTLPTargetBatching(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	// Entries sent before the target starts are waiting together.
+	target := newTestTarget(server.URL, nil)
+	n := maxBatchSize + maxBatchSize/2
+	for i := 0; i < n; i++ {
+		if err := target.Send(audit.NewEntry("deployment"), ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := target.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	batches := waitForRecords(t, c, n)
+	if len(batches) != 2 || len(batches[0]) != maxBatchSize || len(batches[1]) != n-maxBatchSize {
+		sizes := make([]int, len(batches))
+		for i, batch := range batches {
+			sizes[i] = len(batch)
+		}
+		t.Fatalf("expected batches of %d and %d records, got %v", maxBatchSize, n-maxBatchSize, sizes)
+	}
+}
+
+func TestOTLPTargetErrors(t *testing.T) {
+	if err := newTestTarget("udp://localhost:4318", nil).Init(); err == nil {
+		t.Fatal("expected an error for an invalid endpoint scheme")
+	}
+
+	c := &collector{status: http.StatusUnauthorized}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	if err := newTestTarget(server.URL, nil).Init(); err == nil || !strings.Contains(err.Error(), "auth token") {
+		t.Fatalf("expected an auth token error, got %v", err)
+	}
+
+	c.mu.Lock()
+	c.status = 0
+	c.mu.Unlock()
+
+	errCh := make(chan error, 1)
+	target := newTestTarget(server.URL, func(ctx context.Context, err error, id interface{}, errKind ...interface{}) {
+		select {
+		case errCh <- err:
+		default:
+		}
+	})
+	if err := target.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Export failures are reported to the logger.
+	c.mu.Lock()
+	c.status = http.StatusInternalServerError
+	c.mu.Unlock()
+	if err := target.Send(audit.NewEntry("deployment"), ""); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errCh:
+		if !strings.Contains(err.Error(), "500") {
+			t.Fatalf("unexpected export error %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the export error")
+	}
+
+	// Entries are rejected once the buffer is full.
+	full := &Target{logCh: make(chan interface{}, 1), config: target.config}
+	if err := full.Send("a", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := full.Send("b", ""); err == nil || err.Error() != "log buffer full" {
+		t.Fatalf("expected a full buffer error, got %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLimit = 100000 // Default store limit.
+	entryExt     = ".log"
+)
+
+// ErrLimitExceeded - the queue holds the maximum number of entries.
+var ErrLimitExceeded = errors.New("the maximum log queue limit reached")
+
+// QueueStore - persists log entries in a directory until they are sent.
+type QueueStore struct {
+	sync.RWMutex
+	currentEntries uint64
+	entryLimit     uint64
+	directory      string
+	lastKey        int64
+}
+
+// NewQueueStore - creates an instance for QueueStore.
+func NewQueueStore(directory string, limit uint64) *QueueStore {
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	return &QueueStore{
+		directory:  directory,
+		entryLimit: limit,
+	}
+}
+
+// Open - creates the directory if not present and counts the
+// entries left over from previous runs.
+func (store *QueueStore) Open() error {
+	store.Lock()
+	defer store.Unlock()
+
+	if err := os.MkdirAll(store.directory, os.FileMode(0770)); err != nil {
+		return err
+	}
+
+	names, err := store.list()
+	if err != nil {
+		return err
+	}
+
+	store.currentEntries = uint64(len(names))
+	return nil
+}
+
+// Put - puts a JSON encoded log entry to the store, entries
+// are listed in the order they were put.
+func (store *QueueStore) Put(data []byte) error {
+	store.Lock()
+	defer store.Unlock()
+	if store.currentEntries >= store.entryLimit {
+		return ErrLimitExceeded
+	}
+
+	// Keys start with a strictly increasing timestamp, such that the
+	// entries are replayed in order even if the clock goes backwards.
+	now := time.Now().UnixNano()
+	if now <= store.lastKey {
+		now = store.lastKey + 1
+	}
+	key := fmt.Sprintf("%019d-%s", now, uuid.New().String())
+
+	path := filepath.Join(store.directory, key+entryExt)
+	if err := ioutil.WriteFile(path, data, os.FileMode(0640)); err != nil {
+		return err
+	}
+	store.lastKey = now
+	store.currentEntries++
+	return nil
+}
+
+// Get - gets a log entry from the store, entries which
+// cannot be read are removed.
+func (store *QueueStore) Get(key string) (data []byte, err error) {
+	store.RLock()
+
+	defer func(store *QueueStore) {
+		store.RUnlock()
+		if err != nil {
+			// Upon error we remove the entry.
+			store.Del(key)
+		}
+	}(store)
+
+	data, err = ioutil.ReadFile(filepath.Join(store.directory, key+entryExt))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return data, nil
+}
+
+// Del - deletes an entry from the store.
+func (store *QueueStore) Del(key string) error {
+	store.Lock()
+	defer store.Unlock()
+
+	if err := os.Remove(filepath.Join(store.directory, key+entryExt)); err != nil {
+		return err
+	}
+
+	// Decrement the current entries count.
+	store.currentEntries--
+
+	// Current entries can underflow if an entry was removed
+	// outside of this store, never go below zero.
+	if store.currentEntries == math.MaxUint64 {
+		store.currentEntries = 0
+	}
+	return nil
+}
+
+// Len - returns the number of entries in the store.
+func (store *QueueStore) Len() int {
+	store.RLock()
+	defer store.RUnlock()
+	return int(store.currentEntries)
+}
+
+// List - lists the keys of all entries in the order they were put.
+func (store *QueueStore) List() ([]string, error) {
+	store.RLock()
+	defer store.RUnlock()
+	return store.list()
+}
+
+// list lock less.
+func (store *QueueStore) list() ([]string, error) {
+	var keys []string
+	files, err := ioutil.ReadDir(store.directory)
+	if err != nil {
+		return keys, err
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), entryExt) {
+			continue
+		}
+		keys = append(keys, strings.TrimSuffix(file.Name(), entryExt))
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// Replay - sends the entries of the store in order until doneCh is closed,
+// entries are removed once sent. After a failed send the remaining entries
+// are retried after retryInterval, otherwise new entries are sent once
+// signaled on notifyCh.
+func (store *QueueStore) Replay(send func(data []byte) error, retryInterval time.Duration, notifyCh <-chan struct{}, doneCh <-chan struct{}) {
+	retryTimer := time.NewTimer(retryInterval)
+	defer retryTimer.Stop()
+
+	for {
+		failed := false
+		keys, err := store.List()
+		if err != nil {
+			failed = true
+		}
+		for _, key := range keys {
+			data, err := store.Get(key)
+			if err != nil {
+				continue
+			}
+			if err = send(data); err != nil {
+				failed = true
+				break
+			}
+			store.Del(key)
+		}
+
+		if failed {
+			if !retryTimer.Stop() {
+				select {
+				case <-retryTimer.C:
+				default:
+				}
+			}
+			retryTimer.Reset(retryInterval)
+			select {
+			case <-retryTimer.C:
+			case <-doneCh:
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-notifyCh:
+		case <-doneCh:
+			return
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestQueueStorePutList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log-queue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := NewQueueStore(dir, 3)
+	if err = store.Open(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err = store.Put([]byte(fmt.Sprintf(`{"n":%d}`, i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = store.Put([]byte(`{"n":3}`)); err != ErrLimitExceeded {
+		t.Fatalf("expected %v, got %v", ErrLimitExceeded, err)
+	}
+
+	keys, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 || store.Len() != 3 {
+		t.Fatalf("expected 3 entries, got %d keys and length %d", len(keys), store.Len())
+	}
+	for i, key := range keys {
+		data, err := store.Get(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != fmt.Sprintf(`{"n":%d}`, i) {
+			t.Fatalf("entry %d: unexpected order, got %s", i, data)
+		}
+	}
+
+	// Entries left over are found when reopening the store.
+	store = NewQueueStore(dir, 3)
+	if err = store.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if store.Len() != 3 {
+		t.Fatalf("expected 3 entries after reopening, got %d", store.Len())
+	}
+	if err = store.Del(keys[0]); err != nil {
+		t.Fatal(err)
+	}
+	if store.Len() != 2 {
+		t.Fatalf("expected 2 entries after delete, got %d", store.Len())
+	}
+}
+
+func TestQueueStoreReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log-queue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := NewQueueStore(dir, 0)
+	if err = store.Open(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err = store.Put([]byte(fmt.Sprintf("%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notifyCh := make(chan struct{}, 1)
+	doneCh := make(chan struct{})
+	sentCh := make(chan string, 10)
+	failures := 1
+	go store.Replay(func(data []byte) error {
+		if failures > 0 {
+			failures--
+			return errors.New("endpoint offline")
+		}
+		sentCh <- string(data)
+		return nil
+	}, 10*time.Millisecond, notifyCh, doneCh)
+	defer close(doneCh)
+
+	var sent []string
+	for len(sent) < 3 {
+		select {
+		case data := <-sentCh:
+			sent = append(sent, data)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for replayed entries, got %v", sent)
+		}
+	}
+	if fmt.Sprint(sent) != "[0 1 2]" {
+		t.Fatalf("unexpected replay order %v", sent)
+	}
+
+	// New entries are sent once signaled.
+	if err = store.Put([]byte("3")); err != nil {
+		t.Fatal(err)
+	}
+	notifyCh <- struct{}{}
+	select {
+	case data := <-sentCh:
+		if data != "3" {
+			t.Fatalf("expected entry 3, got %s", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for new entry")
+	}
+}
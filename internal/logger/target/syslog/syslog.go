@@ -0,0 +1,223 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package syslog
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+// Timeout for connecting and writing to the syslog server.
+const syslogTimeout = 5 * time.Second
+
+// Severity of the audit messages, RFC5424 informational.
+const severityInfo = 6
+
+// facilities - RFC5424 facilities which may be used for audit messages.
+var facilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"authpriv": 10,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// ParseFacility - parses a syslog facility name such as "local0".
+func ParseFacility(s string) (int, error) {
+	facility, ok := facilities[strings.ToLower(s)]
+	if !ok {
+		return 0, fmt.Errorf("unknown syslog facility '%s'", s)
+	}
+	return facility, nil
+}
+
+// Config - syslog target arguments.
+type Config struct {
+	Enabled       bool           `json:"enabled"`
+	Name          string         `json:"name"`
+	Address       string         `json:"address"`
+	TLS           bool           `json:"tls"`
+	TLSSkipVerify bool           `json:"tlsSkipVerify"`
+	ClientCert    string         `json:"clientCert"`
+	ClientKey     string         `json:"clientKey"`
+	RootCAs       *x509.CertPool `json:"-"`
+	Facility      int            `json:"facility"`
+	AppName       string         `json:"appName"`
+
+	// Audit entries sent to the target, applied by the logger
+	Filter audit.Filter `json:"filter"`
+
+	// Custom logger
+	LogOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{}) `json:"-"`
+}
+
+// Target implements logger.Target and sends log entries as RFC5424
+// messages to a syslog server over TCP or TLS. Messages are framed
+// using octet counting as described in RFC6587.
+type Target struct {
+	// Channel of log entries
+	logCh chan interface{}
+
+	config   Config
+	hostname string
+	conn     net.Conn
+}
+
+// Endpoint returns the backend endpoint
+func (h *Target) Endpoint() string {
+	return h.config.Address
+}
+
+func (h *Target) String() string {
+	return h.config.Name
+}
+
+func (h *Target) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: syslogTimeout}
+	if !h.config.TLS {
+		return dialer.Dial("tcp", h.config.Address)
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: h.config.TLSSkipVerify,
+		RootCAs:            h.config.RootCAs,
+	}
+	if h.config.ClientCert != "" && h.config.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(h.config.ClientCert, h.config.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tls.DialWithDialer(dialer, "tcp", h.config.Address, tlsConfig)
+}
+
+// Init validate and initialize the syslog target
+func (h *Target) Init() error {
+	if h.config.Address == "" {
+		return errors.New("syslog 'address' cannot be empty")
+	}
+	if _, _, err := net.SplitHostPort(h.config.Address); err != nil {
+		return err
+	}
+
+	conn, err := h.dial()
+	if err != nil {
+		return err
+	}
+	h.conn = conn
+
+	go h.startSyslogLogger()
+	return nil
+}
+
+// format - returns the RFC5424 message of a log entry with the JSON
+// encoded entry as message body.
+func (h *Target) format(logJSON []byte, t time.Time) []byte {
+	pri := h.config.Facility*8 + severityInfo
+	msg := fmt.Sprintf("<%d>1 %s %s %s %d audit - %s",
+		pri, t.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		h.hostname, h.config.AppName, os.Getpid(), logJSON)
+	return []byte(fmt.Sprintf("%d %s", len(msg), msg))
+}
+
+func (h *Target) write(msg []byte) (err error) {
+	// Reconnect once if the server closed the connection.
+	for i := 0; i < 2; i++ {
+		if h.conn == nil {
+			if h.conn, err = h.dial(); err != nil {
+				return err
+			}
+		}
+		h.conn.SetWriteDeadline(time.Now().Add(syslogTimeout))
+		if _, err = h.conn.Write(msg); err == nil {
+			return nil
+		}
+		h.conn.Close()
+		h.conn = nil
+	}
+	return err
+}
+
+func (h *Target) startSyslogLogger() {
+	// Create a routine which sends json logs received
+	// from an internal channel.
+	go func() {
+		for entry := range h.logCh {
+			logJSON, err := json.Marshal(&entry)
+			if err != nil {
+				continue
+			}
+
+			if err = h.write(h.format(logJSON, time.Now())); err != nil {
+				h.config.LogOnce(context.Background(), fmt.Errorf("%s returned '%w', please check your syslog configuration", h.config.Address, err), h.config.Address)
+			}
+		}
+	}()
+}
+
+// New initializes a new logger target which
+// sends log to the specified syslog server
+func New(config Config) *Target {
+	if config.AppName == "" {
+		config.AppName = "minio"
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	h := &Target{
+		logCh:    make(chan interface{}, 10000),
+		config:   config,
+		hostname: hostname,
+	}
+
+	return h
+}
+
+// Send log message 'e' to syslog target.
+func (h *Target) Send(entry interface{}, errKind string) error {
+	select {
+	case h.logCh <- entry:
+	default:
+		// log channel is full, do not wait and return
+		// an error immediately to the caller
+		return errors.New("log buffer full")
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package syslog
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFacility(t *testing.T) {
+	if f, err := ParseFacility("LOCAL3"); err != nil || f != 19 {
+		t.Fatalf("expected facility 19, got %d, %v", f, err)
+	}
+	if _, err := ParseFacility("local8"); err == nil {
+		t.Fatal("expected error for unknown facility")
+	}
+}
+
+func TestSyslogTarget(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	msgCh := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		// Octet counting framing, "MSG-LEN SP SYSLOG-MSG".
+		length, err := r.ReadString(' ')
+		if err != nil {
+			return
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(length))
+		if err != nil {
+			return
+		}
+		msg := make([]byte, n)
+		if _, err = io.ReadFull(r, msg); err != nil {
+			return
+		}
+		msgCh <- string(msg)
+	}()
+
+	h := New(Config{
+		Address:  l.Addr().String(),
+		Facility: 16,
+		LogOnce: func(ctx context.Context, err error, id interface{}, errKind ...interface{}) {
+			t.Error(err)
+		},
+	})
+	if err = h.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if err = h.Send(map[string]string{"api": "PutObject"}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-msgCh:
+		prefix := fmt.Sprintf("<%d>1 ", 16*8+severityInfo)
+		if !strings.HasPrefix(msg, prefix) {
+			t.Fatalf("expected message to start with %q, got %q", prefix, msg)
+		}
+		if !strings.Contains(msg, " minio ") || !strings.HasSuffix(msg, ` audit - {"api":"PutObject"}`) {
+			t.Fatalf("unexpected message %q", msg)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for syslog message")
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+// TargetStats - delivery statistics of a log target.
+type TargetStats struct {
+	// Pending is the number of entries waiting to be sent.
+	Pending int `json:"pending"`
+	// Sent is the number of entries sent.
+	Sent int64 `json:"sent"`
+	// Failed is the number of failed send attempts.
+	Failed int64 `json:"failed"`
+	// Dropped is the number of entries dropped because the queue
+	// of the target was full.
+	Dropped int64 `json:"dropped"`
+}
@@ -20,6 +20,9 @@ package logger
 import (
 	"sync"
 	"sync/atomic"
+
+	"github.com/minio/minio/internal/logger/message/audit"
+	"github.com/minio/minio/internal/logger/target/types"
 )
 
 // Target is the entity that we will receive
@@ -32,6 +35,13 @@ type Target interface {
 	Send(entry interface{}, errKind string) error
 }
 
+// StatsTarget is a target which reports the
+// statistics of its log entry deliveries.
+type StatsTarget interface {
+	Target
+	Stats() types.TargetStats
+}
+
 // swapMu must be held while reading slice info or swapping targets or auditTargets.
 var swapMu sync.Mutex
 
@@ -89,6 +99,22 @@ func AddAuditTarget(t Target) error {
 	return nil
 }
 
+// auditFilters holds the filters of the audit targets
+// added with AddAuditTargetWithFilter.
+var auditFilters sync.Map
+
+// AddAuditTargetWithFilter adds a new audit logger target which
+// only receives the audit entries passing the filter, with the
+// values redacted by the filter masked.
+func AddAuditTargetWithFilter(t Target, f audit.Filter) error {
+	auditFilters.Store(t, f)
+	if err := AddAuditTarget(t); err != nil {
+		auditFilters.Delete(t)
+		return err
+	}
+	return nil
+}
+
 // AddTarget adds a new logger target to the
 // list of enabled loggers
 func AddTarget(t Target) error {
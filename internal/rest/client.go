@@ -124,6 +124,18 @@ func (e restError) Timeout() bool {
 
 // Call - make a REST call with context.
 func (c *Client) Call(ctx context.Context, method string, values url.Values, body io.Reader, length int64) (reply io.ReadCloser, err error) {
+	resp, err := c.CallWithHeader(ctx, method, values, nil, body, length)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CallWithHeader - make a REST call with context and additional request
+// headers, the full response is returned and the caller must close its
+// body. A partial content response is only accepted when a Range
+// header was sent.
+func (c *Client) CallWithHeader(ctx context.Context, method string, values url.Values, header http.Header, body io.Reader, length int64) (resp *http.Response, err error) {
 	if !c.IsOnline() {
 		return nil, &NetworkError{Err: &url.Error{Op: method, URL: c.url.String(), Err: restError("remote server offline")}}
 	}
@@ -133,13 +145,16 @@ func (c *Client) Call(ctx context.Context, method string, values url.Values, bod
 	}
 	req.Header.Set("Authorization", "Bearer "+c.newAuthToken(req.URL.RawQuery))
 	req.Header.Set("X-Minio-Time", time.Now().UTC().Format(time.RFC3339))
+	for k, v := range header {
+		req.Header[k] = v
+	}
 	if body != nil {
 		req.Header.Set("Expect", "100-continue")
 	}
 	if length > 0 {
 		req.ContentLength = length
 	}
-	resp, err := c.httpClient.Do(req)
+	resp, err = c.httpClient.Do(req)
 	if err != nil {
 		if xnet.IsNetworkOrHostDown(err, c.ExpectTimeouts) {
 			if !c.NoMetrics {
@@ -158,7 +173,8 @@ func (c *Client) Call(ctx context.Context, method string, values url.Values, bod
 		return nil, errors.New(final)
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	partial := resp.StatusCode == http.StatusPartialContent && header.Get(xhttp.Range) != ""
+	if resp.StatusCode != http.StatusOK && !partial {
 		// If server returns 412 pre-condition failed, it would
 		// mean that authentication succeeded, but another
 		// side-channel check has failed, we shall take
@@ -191,7 +207,7 @@ func (c *Client) Call(ctx context.Context, method string, values url.Values, bod
 		}
 		return nil, errors.New(resp.Status)
 	}
-	return resp.Body, nil
+	return resp, nil
 }
 
 // Close closes all idle connections of the underlying http client
@@ -0,0 +1,506 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package avro
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/bcicen/jstream"
+	"github.com/klauspost/compress/flate"
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+	jsonfmt "github.com/minio/minio/internal/s3select/json"
+	"github.com/minio/minio/internal/s3select/sql"
+)
+
+var errTruncated = errors.New("truncated data")
+
+// decoder - decodes Avro binary encoded values.
+type decoder struct {
+	b []byte
+}
+
+func (d *decoder) long() (int64, error) {
+	v, n := binary.Varint(d.b)
+	if n <= 0 {
+		return 0, errTruncated
+	}
+	d.b = d.b[n:]
+	return v, nil
+}
+
+func (d *decoder) read(n int64) ([]byte, error) {
+	if n < 0 || int64(len(d.b)) < n {
+		return nil, errTruncated
+	}
+	b := d.b[:n]
+	d.b = d.b[n:]
+	return b, nil
+}
+
+func (d *decoder) bytes() ([]byte, error) {
+	n, err := d.long()
+	if err != nil {
+		return nil, err
+	}
+	return d.read(n)
+}
+
+// blockCount - reads the item count of an array or map block, a
+// negative count is followed by the size of the block in bytes.
+func (d *decoder) blockCount() (count, size int64, err error) {
+	if count, err = d.long(); err != nil {
+		return 0, 0, err
+	}
+	if count < 0 {
+		count = -count
+		if size, err = d.long(); err != nil {
+			return 0, 0, err
+		}
+	}
+	return count, size, nil
+}
+
+// decimal - converts the big endian two's complement unscaled value
+// of a decimal.
+func decimal(b []byte, scale int) float64 {
+	unscaled := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	f, _ := new(big.Rat).SetFrac(unscaled, denom).Float64()
+	return f
+}
+
+func (d *decoder) decode(s *schema) (interface{}, error) {
+	switch s.kind {
+	case "null":
+		return nil, nil
+	case "boolean":
+		b, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case "int", "long":
+		v, err := d.long()
+		if err != nil {
+			return nil, err
+		}
+		switch s.logicalType {
+		case "date":
+			return sql.FormatSQLTimestamp(time.Unix(60*60*24*v, 0).UTC()), nil
+		case "timestamp-millis":
+			return sql.FormatSQLTimestamp(time.Unix(0, 0).Add(time.Duration(v) * time.Millisecond).UTC()), nil
+		case "timestamp-micros":
+			return sql.FormatSQLTimestamp(time.Unix(0, 0).Add(time.Duration(v) * time.Microsecond).UTC()), nil
+		}
+		return v, nil
+	case "float":
+		b, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), nil
+	case "double":
+		b, err := d.read(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case "bytes", "string", "fixed":
+		var b []byte
+		var err error
+		if s.kind == "fixed" {
+			b, err = d.read(int64(s.size))
+		} else {
+			b, err = d.bytes()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if s.logicalType == "decimal" {
+			return decimal(b, s.scale), nil
+		}
+		return string(b), nil
+	case "enum":
+		i, err := d.long()
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= int64(len(s.symbols)) {
+			return nil, fmt.Errorf("invalid enum index %d", i)
+		}
+		return s.symbols[i], nil
+	case "union":
+		i, err := d.long()
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= int64(len(s.branches)) {
+			return nil, fmt.Errorf("invalid union index %d", i)
+		}
+		return d.decode(s.branches[i])
+	case "record":
+		kvs := make(jstream.KVS, 0, len(s.fields))
+		for _, f := range s.fields {
+			v, err := d.decode(f.schema)
+			if err != nil {
+				return nil, err
+			}
+			kvs = append(kvs, jstream.KV{Key: f.name, Value: v})
+		}
+		return kvs, nil
+	case "array":
+		values := []interface{}{}
+		for {
+			count, _, err := d.blockCount()
+			if err != nil {
+				return nil, err
+			}
+			if count == 0 {
+				return values, nil
+			}
+			for i := int64(0); i < count; i++ {
+				v, err := d.decode(s.items)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, v)
+			}
+		}
+	case "map":
+		kvs := jstream.KVS{}
+		for {
+			count, _, err := d.blockCount()
+			if err != nil {
+				return nil, err
+			}
+			if count == 0 {
+				return kvs, nil
+			}
+			for i := int64(0); i < count; i++ {
+				k, err := d.bytes()
+				if err != nil {
+					return nil, err
+				}
+				v, err := d.decode(s.items)
+				if err != nil {
+					return nil, err
+				}
+				kvs = append(kvs, jstream.KV{Key: string(k), Value: v})
+			}
+		}
+	}
+	return nil, fmt.Errorf("unsupported type %s", s.kind)
+}
+
+// skip - skips a value without decoding it, used for the fields
+// which are not projected.
+func (d *decoder) skip(s *schema) (err error) {
+	switch s.kind {
+	case "null":
+	case "boolean":
+		_, err = d.read(1)
+	case "int", "long", "enum":
+		_, err = d.long()
+	case "float":
+		_, err = d.read(4)
+	case "double":
+		_, err = d.read(8)
+	case "bytes", "string":
+		_, err = d.bytes()
+	case "fixed":
+		_, err = d.read(int64(s.size))
+	case "union":
+		var i int64
+		if i, err = d.long(); err != nil {
+			return err
+		}
+		if i < 0 || i >= int64(len(s.branches)) {
+			return fmt.Errorf("invalid union index %d", i)
+		}
+		err = d.skip(s.branches[i])
+	case "record":
+		for _, f := range s.fields {
+			if err = d.skip(f.schema); err != nil {
+				return err
+			}
+		}
+	case "array", "map":
+		for {
+			count, size, err := d.blockCount()
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return nil
+			}
+			if size > 0 {
+				if _, err = d.read(size); err != nil {
+					return err
+				}
+				continue
+			}
+			for i := int64(0); i < count; i++ {
+				if s.kind == "map" {
+					if _, err = d.bytes(); err != nil {
+						return err
+					}
+				}
+				if err = d.skip(s.items); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		err = fmt.Errorf("unsupported type %s", s.kind)
+	}
+	return err
+}
+
+var zstdDecoder, _ = zstd.NewReader(nil)
+
+// Reader - Avro object container file record reader for S3Select.
+type Reader struct {
+	args       *ReaderArgs
+	readCloser io.ReadCloser
+	r          *bufio.Reader
+
+	schema *schema
+	codec  string
+	sync   [syncSize]byte
+	// Whether the fields of the top level record are read.
+	projected []bool
+
+	block     decoder
+	remaining int64
+}
+
+func (r *Reader) readLong() (int64, error) {
+	v, err := binary.ReadVarint(r.r)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return v, err
+}
+
+func (r *Reader) readBytes() ([]byte, error) {
+	n, err := r.readLong()
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, errTruncated
+	}
+	b := make([]byte, n)
+	_, err = io.ReadFull(r.r, b)
+	return b, err
+}
+
+func (r *Reader) readHeader() error {
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(r.r, header); err != nil {
+		return err
+	}
+	if string(header) != magic {
+		return errors.New("not an Avro object container file")
+	}
+
+	meta := make(map[string][]byte)
+	for {
+		count, err := r.readLong()
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			break
+		}
+		if count < 0 {
+			count = -count
+			if _, err = r.readLong(); err != nil {
+				return err
+			}
+		}
+		for i := int64(0); i < count; i++ {
+			k, err := r.readBytes()
+			if err != nil {
+				return err
+			}
+			if meta[string(k)], err = r.readBytes(); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := io.ReadFull(r.r, r.sync[:]); err != nil {
+		return err
+	}
+
+	var err error
+	if r.schema, err = parseSchema(meta["avro.schema"]); err != nil {
+		return err
+	}
+	r.codec = string(meta["avro.codec"])
+	switch r.codec {
+	case "":
+		r.codec = "null"
+	case "null", "deflate", "snappy", "zstandard":
+	default:
+		return fmt.Errorf("unsupported codec %q", r.codec)
+	}
+	return nil
+}
+
+// nextBlock - reads and decompresses the next block of objects.
+func (r *Reader) nextBlock() error {
+	count, err := binary.ReadVarint(r.r)
+	if err != nil {
+		// The file ends after the sync marker of a block.
+		return err
+	}
+	size, err := r.readLong()
+	if err != nil {
+		return err
+	}
+	if count < 0 || size < 0 {
+		return errTruncated
+	}
+	data := make([]byte, size)
+	if _, err = io.ReadFull(r.r, data); err != nil {
+		return err
+	}
+	var sync [syncSize]byte
+	if _, err = io.ReadFull(r.r, sync[:]); err != nil {
+		return err
+	}
+	if sync != r.sync {
+		return errors.New("invalid sync marker")
+	}
+
+	switch r.codec {
+	case "deflate":
+		data, err = ioutil.ReadAll(flate.NewReader(bytes.NewReader(data)))
+	case "snappy":
+		// The compressed data is followed by the CRC32 of the
+		// uncompressed data.
+		if len(data) < 4 {
+			return errTruncated
+		}
+		checksum := binary.BigEndian.Uint32(data[len(data)-4:])
+		if data, err = s2.Decode(nil, data[:len(data)-4]); err == nil && crc32.ChecksumIEEE(data) != checksum {
+			err = errors.New("invalid snappy block checksum")
+		}
+	case "zstandard":
+		data, err = zstdDecoder.DecodeAll(data, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.block = decoder{b: data}
+	r.remaining = count
+	return nil
+}
+
+// Read - reads single record.
+func (r *Reader) Read(dst sql.Record) (sql.Record, error) {
+	for r.remaining == 0 {
+		if err := r.nextBlock(); err != nil {
+			if err == io.EOF {
+				return nil, err
+			}
+			return nil, errAvroParsingError(err)
+		}
+	}
+	r.remaining--
+
+	var kvs jstream.KVS
+	if r.schema.kind == "record" {
+		kvs = make(jstream.KVS, 0, len(r.schema.fields))
+		for i, f := range r.schema.fields {
+			if !r.projected[i] {
+				if err := r.block.skip(f.schema); err != nil {
+					return nil, errAvroParsingError(err)
+				}
+				continue
+			}
+			v, err := r.block.decode(f.schema)
+			if err != nil {
+				return nil, errAvroParsingError(err)
+			}
+			kvs = append(kvs, jstream.KV{Key: f.name, Value: v})
+		}
+	} else {
+		// Like JSON, other values are output as a single column.
+		v, err := r.block.decode(r.schema)
+		if err != nil {
+			return nil, errAvroParsingError(err)
+		}
+		kvs = jstream.KVS{jstream.KV{Key: "_1", Value: v}}
+	}
+
+	// Reuse destination if we can.
+	dstRec, ok := dst.(*jsonfmt.Record)
+	if !ok {
+		dstRec = &jsonfmt.Record{}
+	}
+	dstRec.SelectFormat = sql.SelectFmtAvro
+	dstRec.KVS = kvs
+	return dstRec, nil
+}
+
+// Close - closes underlying reader.
+func (r *Reader) Close() error {
+	return r.readCloser.Close()
+}
+
+// NewReader - creates new Avro reader using readCloser. Only the given
+// fields of top level records are decoded, all fields are decoded if
+// columns is nil.
+func NewReader(readCloser io.ReadCloser, args *ReaderArgs, columns []string) (*Reader, error) {
+	r := &Reader{
+		args:       args,
+		readCloser: readCloser,
+		r:          bufio.NewReader(readCloser),
+	}
+	if err := r.readHeader(); err != nil {
+		return nil, errAvroParsingError(err)
+	}
+
+	r.projected = make([]bool, len(r.schema.fields))
+	for i, f := range r.schema.fields {
+		r.projected[i] = columns == nil
+		for _, c := range columns {
+			if c == f.name {
+				r.projected[i] = true
+			}
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package avro
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/bcicen/jstream"
+	"github.com/klauspost/compress/flate"
+	jsonfmt "github.com/minio/minio/internal/s3select/json"
+	"github.com/minio/minio/internal/s3select/typed"
+)
+
+func readAll(t *testing.T, r *Reader) []jstream.KVS {
+	var records []jstream.KVS
+	for {
+		rec, err := r.Read(nil)
+		if err == io.EOF {
+			return records
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, rec.(*jsonfmt.Record).KVS)
+	}
+}
+
+func TestReaderRoundtrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, records := range [][]*typed.Record{
+		{{Columns: []typed.Column{{Name: "name", Value: "alice"}, {Name: "age", Value: int64(30)}}}},
+		{{Columns: []typed.Column{{Name: "name", Value: "bob"}, {Name: "age", Value: nil}}}},
+	} {
+		if err := w.Write(records); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(ioutil.NopCloser(&buf), &ReaderArgs{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	expected := []jstream.KVS{
+		{{Key: "name", Value: "alice"}, {Key: "age", Value: int64(30)}},
+		{{Key: "name", Value: "bob"}, {Key: "age", Value: nil}},
+	}
+	if got := readAll(t, r); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+const testSchema = `{
+  "type": "record",
+  "name": "Event",
+  "namespace": "test",
+  "fields": [
+    {"name": "id", "type": "long"},
+    {"name": "kind", "type": {"type": "enum", "name": "Kind", "symbols": ["PUT", "DELETE"]}},
+    {"name": "tags", "type": {"type": "array", "items": "string"}},
+    {"name": "meta", "type": {"type": "map", "values": "int"}},
+    {"name": "day", "type": {"type": "int", "logicalType": "date"}},
+    {"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 5, "scale": 2}},
+    {"name": "parent", "type": ["null", "Event"]}
+  ]
+}`
+
+func appendLongs(b []byte, values ...int64) []byte {
+	for _, v := range values {
+		b = appendLong(b, v)
+	}
+	return b
+}
+
+// encodeEvent - encodes a record of testSchema without parent.
+func encodeEvent(id int64, kind int64, tags []string) []byte {
+	b := appendLongs(nil, id, kind)
+	if len(tags) > 0 {
+		// A block with a negative count is followed by its size.
+		var items []byte
+		for _, tag := range tags {
+			items = appendBytes(items, []byte(tag))
+		}
+		b = appendLongs(b, -int64(len(tags)), int64(len(items)))
+		b = append(b, items...)
+	}
+	b = appendLong(b, 0)
+	b = appendLong(b, 1)
+	b = appendBytes(b, []byte("size"))
+	b = appendLongs(b, 42, 0)
+	b = appendLong(b, 18000)
+	// -1.50 as two's complement unscaled value -150.
+	return appendBytes(b, []byte{0xff, 0x6a})
+}
+
+func buildFile(t *testing.T, codec string, blocks [][][]byte) []byte {
+	b := []byte(magic)
+	b = appendLong(b, 2)
+	b = appendBytes(b, []byte("avro.schema"))
+	b = appendBytes(b, []byte(testSchema))
+	b = appendBytes(b, []byte("avro.codec"))
+	b = appendBytes(b, []byte(codec))
+	b = appendLong(b, 0)
+	sync := bytes.Repeat([]byte{0xab}, syncSize)
+	b = append(b, sync...)
+
+	for _, objects := range blocks {
+		data := bytes.Join(objects, nil)
+		if codec == "deflate" {
+			var buf bytes.Buffer
+			w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w.Write(data)
+			w.Close()
+			data = buf.Bytes()
+		}
+		b = appendLong(b, int64(len(objects)))
+		b = appendBytes(b, data)
+		b = append(b, sync...)
+	}
+	return b
+}
+
+func TestReader(t *testing.T) {
+	// The second event has the first one as parent.
+	nested := append(encodeEvent(2, 1, nil), appendLong(nil, 1)...)
+	nested = append(nested, encodeEvent(1, 0, nil)...)
+	nested = append(nested, appendLong(nil, 0)...)
+
+	blocks := [][][]byte{
+		{append(encodeEvent(1, 0, []string{"a", "b"}), appendLong(nil, 0)...)},
+		{nested},
+	}
+
+	parent := jstream.KVS{
+		{Key: "id", Value: int64(1)},
+		{Key: "kind", Value: "PUT"},
+		{Key: "tags", Value: []interface{}{}},
+		{Key: "meta", Value: jstream.KVS{{Key: "size", Value: int64(42)}}},
+		{Key: "day", Value: "2019-04-14T"},
+		{Key: "amount", Value: -1.5},
+		{Key: "parent", Value: nil},
+	}
+	expected := []jstream.KVS{
+		{
+			{Key: "id", Value: int64(1)},
+			{Key: "kind", Value: "PUT"},
+			{Key: "tags", Value: []interface{}{"a", "b"}},
+			{Key: "meta", Value: jstream.KVS{{Key: "size", Value: int64(42)}}},
+			{Key: "day", Value: "2019-04-14T"},
+			{Key: "amount", Value: -1.5},
+			{Key: "parent", Value: nil},
+		},
+		{
+			{Key: "id", Value: int64(2)},
+			{Key: "kind", Value: "DELETE"},
+			{Key: "tags", Value: []interface{}{}},
+			{Key: "meta", Value: jstream.KVS{{Key: "size", Value: int64(42)}}},
+			{Key: "day", Value: "2019-04-14T"},
+			{Key: "amount", Value: -1.5},
+			{Key: "parent", Value: parent},
+		},
+	}
+
+	for _, codec := range []string{"null", "deflate"} {
+		r, err := NewReader(ioutil.NopCloser(bytes.NewReader(buildFile(t, codec, blocks))), &ReaderArgs{}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := readAll(t, r); !reflect.DeepEqual(got, expected) {
+			t.Errorf("codec %s: expected %v, got %v", codec, expected, got)
+		}
+		r.Close()
+	}
+
+	// Only projected fields are decoded, the others are skipped.
+	r, err := NewReader(ioutil.NopCloser(bytes.NewReader(buildFile(t, "null", blocks))), &ReaderArgs{}, []string{"kind", "amount"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	projected := []jstream.KVS{
+		{{Key: "kind", Value: "PUT"}, {Key: "amount", Value: -1.5}},
+		{{Key: "kind", Value: "DELETE"}, {Key: "amount", Value: -1.5}},
+	}
+	if got := readAll(t, r); !reflect.DeepEqual(got, projected) {
+		t.Errorf("expected %v, got %v", projected, got)
+	}
+}
+
+func TestReaderInvalid(t *testing.T) {
+	if _, err := NewReader(ioutil.NopCloser(bytes.NewReader([]byte("not avro"))), &ReaderArgs{}, nil); err == nil {
+		t.Fatal("expected error reading invalid file")
+	}
+
+	// Corrupt the sync marker of the block.
+	b := buildFile(t, "null", [][][]byte{{append(encodeEvent(1, 0, nil), appendLong(nil, 0)...)}})
+	b[len(b)-1] = 0
+	r, err := NewReader(ioutil.NopCloser(bytes.NewReader(b)), &ReaderArgs{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.Read(nil); err == nil || err == io.EOF {
+		t.Fatalf("expected error reading corrupted block, got %v", err)
+	}
+}
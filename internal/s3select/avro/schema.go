@@ -0,0 +1,183 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// schema - is a parsed Avro schema, named types referenced by name
+// share the same schema.
+type schema struct {
+	kind string
+
+	// Record fields.
+	fields []field
+	// Enum symbols.
+	symbols []string
+	// Array items and map values.
+	items *schema
+	// Fixed size.
+	size int
+	// Union branches.
+	branches []*schema
+
+	logicalType string
+	scale       int
+}
+
+type field struct {
+	name   string
+	schema *schema
+}
+
+var primitiveTypes = map[string]bool{
+	"null":    true,
+	"boolean": true,
+	"int":     true,
+	"long":    true,
+	"float":   true,
+	"double":  true,
+	"bytes":   true,
+	"string":  true,
+}
+
+// parseSchema - parses a JSON schema as found in the file header.
+func parseSchema(b []byte) (*schema, error) {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return (&schemaParser{named: make(map[string]*schema)}).parse(v, "")
+}
+
+type schemaParser struct {
+	named map[string]*schema
+}
+
+// fullName - returns the full name of a named type in namespace.
+func fullName(name, namespace string) string {
+	if strings.Contains(name, ".") || namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+func (p *schemaParser) parse(v interface{}, namespace string) (*schema, error) {
+	switch t := v.(type) {
+	case string:
+		if primitiveTypes[t] {
+			return &schema{kind: t}, nil
+		}
+		if s, ok := p.named[fullName(t, namespace)]; ok {
+			return s, nil
+		}
+		if s, ok := p.named[t]; ok {
+			return s, nil
+		}
+		return nil, fmt.Errorf("unknown type %q", t)
+	case []interface{}:
+		s := &schema{kind: "union"}
+		for _, branch := range t {
+			b, err := p.parse(branch, namespace)
+			if err != nil {
+				return nil, err
+			}
+			s.branches = append(s.branches, b)
+		}
+		return s, nil
+	case map[string]interface{}:
+		return p.parseComplex(t, namespace)
+	}
+	return nil, fmt.Errorf("invalid schema %v", v)
+}
+
+func (p *schemaParser) parseComplex(m map[string]interface{}, namespace string) (*schema, error) {
+	kind, ok := m["type"].(string)
+	if !ok {
+		// The type itself is a schema, as in {"type": {"type": "array", ...}}.
+		return p.parse(m["type"], namespace)
+	}
+
+	s := &schema{kind: kind}
+	s.logicalType, _ = m["logicalType"].(string)
+	if scale, ok := m["scale"].(float64); ok {
+		s.scale = int(scale)
+	}
+
+	switch kind {
+	case "record", "error", "enum", "fixed":
+		name, _ := m["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("%s type without name", kind)
+		}
+		if ns, ok := m["namespace"].(string); ok {
+			namespace = ns
+		}
+		name = fullName(name, namespace)
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			namespace = name[:i]
+		}
+		// Register before parsing the fields to allow recursive types.
+		p.named[name] = s
+	}
+
+	switch kind {
+	case "record", "error":
+		s.kind = "record"
+		fields, _ := m["fields"].([]interface{})
+		for _, f := range fields {
+			fm, ok := f.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid record field %v", f)
+			}
+			name, _ := fm["name"].(string)
+			fs, err := p.parse(fm["type"], namespace)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+			s.fields = append(s.fields, field{name: name, schema: fs})
+		}
+	case "enum":
+		symbols, _ := m["symbols"].([]interface{})
+		for _, symbol := range symbols {
+			name, _ := symbol.(string)
+			s.symbols = append(s.symbols, name)
+		}
+	case "array", "map":
+		key := "items"
+		if kind == "map" {
+			key = "values"
+		}
+		items, err := p.parse(m[key], namespace)
+		if err != nil {
+			return nil, err
+		}
+		s.items = items
+	case "fixed":
+		size, _ := m["size"].(float64)
+		s.size = int(size)
+	default:
+		if !primitiveTypes[kind] {
+			return p.parse(kind, namespace)
+		}
+	}
+	return s, nil
+}
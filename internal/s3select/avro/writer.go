@@ -0,0 +1,183 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package avro
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+
+	"github.com/minio/minio/internal/s3select/typed"
+)
+
+const (
+	magic = "Obj\x01"
+
+	syncSize = 16
+
+	// Name of the record type of the output schema.
+	recordName = "Record"
+)
+
+type schemaField struct {
+	Name    string      `json:"name"`
+	Type    []string    `json:"type"`
+	Default interface{} `json:"default"`
+}
+
+type recordSchema struct {
+	Type   string        `json:"type"`
+	Name   string        `json:"name"`
+	Fields []schemaField `json:"fields"`
+}
+
+func avroType(t typed.Type) string {
+	switch t {
+	case typed.Bool:
+		return "boolean"
+	case typed.Int:
+		return "long"
+	case typed.Float:
+		return "double"
+	}
+	return "string"
+}
+
+// Writer - writes select output records as an Apache Avro object
+// container file. All fields are nullable, blocks are not compressed
+// and each call to Write produces one block.
+type Writer struct {
+	w      io.Writer
+	schema typed.Schema
+	sync   [syncSize]byte
+	init   bool
+}
+
+func appendLong(b []byte, v int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+func appendBytes(b []byte, v []byte) []byte {
+	b = appendLong(b, int64(len(v)))
+	return append(b, v...)
+}
+
+func (w *Writer) writeHeader(records []*typed.Record) error {
+	w.schema = typed.InferSchema(records)
+
+	s := recordSchema{Type: "record", Name: recordName, Fields: []schemaField{}}
+	for _, f := range w.schema.Fields {
+		s.Fields = append(s.Fields, schemaField{
+			Name: typed.FieldName(f.Name),
+			Type: []string{"null", avroType(f.Type)},
+		})
+	}
+	schema, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if _, err = rand.Read(w.sync[:]); err != nil {
+		return err
+	}
+
+	b := []byte(magic)
+	// File metadata is a map of two entries, terminated by an
+	// empty block.
+	b = appendLong(b, 2)
+	b = appendBytes(b, []byte("avro.schema"))
+	b = appendBytes(b, schema)
+	b = appendBytes(b, []byte("avro.codec"))
+	b = appendBytes(b, []byte("null"))
+	b = appendLong(b, 0)
+	b = append(b, w.sync[:]...)
+
+	w.init = true
+	_, err = w.w.Write(b)
+	return err
+}
+
+// appendValue - appends a nullable value, encoded as the index of the
+// union branch followed by the value.
+func appendValue(b []byte, v interface{}) []byte {
+	switch x := v.(type) {
+	case nil:
+		return appendLong(b, 0)
+	case bool:
+		b = appendLong(b, 1)
+		if x {
+			return append(b, 1)
+		}
+		return append(b, 0)
+	case int64:
+		return appendLong(appendLong(b, 1), x)
+	case float64:
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(x))
+		return append(appendLong(b, 1), buf[:]...)
+	case string:
+		return appendBytes(appendLong(b, 1), []byte(x))
+	}
+	return b
+}
+
+// Write - writes records as a single block, the schema of the file
+// is inferred from the records passed to the first call.
+func (w *Writer) Write(records []*typed.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if !w.init {
+		if err := w.writeHeader(records); err != nil {
+			return err
+		}
+	}
+
+	var data []byte
+	for _, r := range records {
+		values, err := w.schema.Values(r)
+		if err != nil {
+			return err
+		}
+		for _, v := range values {
+			data = appendValue(data, v)
+		}
+	}
+
+	var block bytes.Buffer
+	block.Write(appendLong(nil, int64(len(records))))
+	block.Write(appendBytes(nil, data))
+	block.Write(w.sync[:])
+	_, err := w.w.Write(block.Bytes())
+	return err
+}
+
+// Close - completes the file. Blocks are self contained, so there is
+// nothing left to write.
+func (w *Writer) Close() error {
+	return nil
+}
+
+// NewWriter - creates new Avro writer writing to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
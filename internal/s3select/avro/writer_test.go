@@ -0,0 +1,167 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package avro
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/internal/s3select/typed"
+)
+
+func readBytes(t *testing.T, r *bufio.Reader) []byte {
+	n, err := binary.ReadVarint(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, n)
+	if _, err = io.ReadFull(r, b); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	records := []*typed.Record{
+		{Columns: []typed.Column{{Name: "name", Value: "alice"}, {Name: "age", Value: int64(30)}, {Name: "score", Value: 1.5}, {Name: "active", Value: true}}},
+		{Columns: []typed.Column{{Name: "name", Value: "bob"}, {Name: "age", Value: nil}, {Name: "score", Value: int64(2)}, {Name: "active", Value: false}}},
+	}
+	if err := w.Write(records); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := bufio.NewReader(&buf)
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(r, header); err != nil || string(header) != magic {
+		t.Fatalf("invalid magic %q: %v", header, err)
+	}
+	meta := make(map[string]string)
+	for {
+		n, err := binary.ReadVarint(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n == 0 {
+			break
+		}
+		for ; n > 0; n-- {
+			k := readBytes(t, r)
+			meta[string(k)] = string(readBytes(t, r))
+		}
+	}
+	if meta["avro.codec"] != "null" {
+		t.Errorf("unexpected codec %q", meta["avro.codec"])
+	}
+	var schema recordSchema
+	if err := json.Unmarshal([]byte(meta["avro.schema"]), &schema); err != nil {
+		t.Fatal(err)
+	}
+	expectedFields := []schemaField{
+		{Name: "name", Type: []string{"null", "string"}},
+		{Name: "age", Type: []string{"null", "long"}},
+		{Name: "score", Type: []string{"null", "double"}},
+		{Name: "active", Type: []string{"null", "boolean"}},
+	}
+	if !reflect.DeepEqual(schema.Fields, expectedFields) {
+		t.Fatalf("expected fields %v, got %v", expectedFields, schema.Fields)
+	}
+	sync := make([]byte, syncSize)
+	if _, err := io.ReadFull(r, sync); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := binary.ReadVarint(r)
+	if err != nil || count != 2 {
+		t.Fatalf("expected 2 records, got %d: %v", count, err)
+	}
+	block := bufio.NewReader(bytes.NewReader(readBytes(t, r)))
+	var got [][]interface{}
+	for i := int64(0); i < count; i++ {
+		var row []interface{}
+		for _, f := range schema.Fields {
+			branch, err := binary.ReadVarint(block)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if branch == 0 {
+				row = append(row, nil)
+				continue
+			}
+			switch f.Type[1] {
+			case "string":
+				row = append(row, string(readBytes(t, block)))
+			case "long":
+				v, err := binary.ReadVarint(block)
+				if err != nil {
+					t.Fatal(err)
+				}
+				row = append(row, v)
+			case "double":
+				b := make([]byte, 8)
+				if _, err = io.ReadFull(block, b); err != nil {
+					t.Fatal(err)
+				}
+				row = append(row, math.Float64frombits(binary.LittleEndian.Uint64(b)))
+			case "boolean":
+				b, err := block.ReadByte()
+				if err != nil {
+					t.Fatal(err)
+				}
+				row = append(row, b == 1)
+			}
+		}
+		got = append(got, row)
+	}
+	expected := [][]interface{}{
+		{"alice", int64(30), 1.5, true},
+		{"bob", nil, 2.0, false},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	blockSync := make([]byte, syncSize)
+	if _, err = io.ReadFull(r, blockSync); err != nil || !bytes.Equal(sync, blockSync) {
+		t.Fatalf("invalid block sync marker: %v", err)
+	}
+	if _, err = r.ReadByte(); err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestWriterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %d bytes", buf.Len())
+	}
+}
@@ -86,9 +86,8 @@ func (r *Record) Reset() {
 	if len(r.csvRecord) > 0 {
 		r.csvRecord = r.csvRecord[:0]
 	}
-	for k := range r.nameIndexMap {
-		delete(r.nameIndexMap, k)
-	}
+	// The name index is shared with the reader and other records.
+	r.nameIndexMap = nil
 }
 
 // Clone the record.
@@ -105,6 +104,7 @@ func (r *Record) Clone(dst sql.Record) sql.Record {
 	}
 	other.columnNames = append(other.columnNames, r.columnNames...)
 	other.csvRecord = append(other.csvRecord, r.csvRecord...)
+	other.nameIndexMap = r.nameIndexMap
 	return other
 }
 
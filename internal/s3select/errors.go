@@ -128,6 +128,15 @@ func errMissingRequiredParameter(err error) *s3Error {
 	}
 }
 
+func errDecompressedObjectTooLarge(err error) *s3Error {
+	return &s3Error{
+		code:       "EntityTooLarge",
+		message:    "The decompressed object is too large to be queried, query the uncompressed object instead.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
+
 func errTruncatedInput(err error) *s3Error {
 	return &s3Error{
 		code:       "TruncatedInput",
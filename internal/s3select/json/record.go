@@ -124,7 +124,7 @@ func (r *Record) WriteCSV(writer io.Writer, opts sql.WriteCSVOpts) error {
 			columnValue = ""
 		case RawJSON:
 			columnValue = string([]byte(val))
-		case []interface{}:
+		case []interface{}, jstream.KVS:
 			b, err := json.Marshal(val)
 			if err != nil {
 				return err
@@ -0,0 +1,352 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package orc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/bcicen/jstream"
+	"github.com/minio/minio/internal/s3select/sql"
+)
+
+// Timestamps are stored as seconds since the ORC epoch.
+var orcEpoch = time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type streamKey struct {
+	column uint64
+	kind   uint64
+}
+
+// stripe - holds the decompressed streams of the columns read from a stripe.
+type stripe struct {
+	streams   map[streamKey][]byte
+	encodings []columnEncoding
+}
+
+func (s *stripe) stream(column uint32, kind uint64) []byte {
+	return s.streams[streamKey{column: uint64(column), kind: kind}]
+}
+
+// column - reads the values of a column, nil is returned for null
+// values.
+type column struct {
+	present *boolRLE
+	value   func() (interface{}, error)
+}
+
+func (c *column) next() (interface{}, error) {
+	if c.present != nil {
+		ok, err := c.present.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, nil
+		}
+	}
+	return c.value()
+}
+
+// nextInt - reads the next value of an integer stream, which is
+// expected to hold a value.
+func nextInt(r intReader) (int64, error) {
+	v, err := r.next()
+	if err == io.EOF {
+		err = errTruncatedStream
+	}
+	return v, err
+}
+
+func newByteRLE(b []byte) *byteRLE {
+	return &byteRLE{s: byteStream{b: b}}
+}
+
+func newBoolRLE(b []byte) *boolRLE {
+	return &boolRLE{bytes: byteRLE{s: byteStream{b: b}}}
+}
+
+func (s *byteStream) bigVarint() (*big.Int, error) {
+	v := new(big.Int)
+	var shift uint
+	for {
+		c, err := s.readByte()
+		if err != nil {
+			return nil, err
+		}
+		v.Or(v, new(big.Int).Lsh(big.NewInt(int64(c&0x7f)), shift))
+		if c < 0x80 {
+			break
+		}
+		shift += 7
+	}
+	// Undo zigzag encoding.
+	negative := v.Bit(0) == 1
+	v.Rsh(v, 1)
+	if negative {
+		v.Neg(v).Sub(v, big.NewInt(1))
+	}
+	return v, nil
+}
+
+func decodeNanos(v int64) int64 {
+	nanos := v >> 3
+	if zeros := v & 7; zeros != 0 {
+		for i := int64(0); i <= zeros; i++ {
+			nanos *= 10
+		}
+	}
+	return nanos
+}
+
+// newColumn - creates the reader of the column with the given id from
+// the streams of a stripe.
+func newColumn(types []orcType, s *stripe, id uint32) (*column, error) {
+	if int(id) >= len(types) || int(id) >= len(s.encodings) {
+		return nil, fmt.Errorf("invalid column id %d", id)
+	}
+	t := types[id]
+	encoding := s.encodings[id]
+	v2 := encoding.kind == encodingDirectV2 || encoding.kind == encodingDictionaryV2
+
+	c := &column{}
+	if b := s.stream(id, streamPresent); b != nil {
+		c.present = newBoolRLE(b)
+	}
+
+	children := make([]*column, len(t.subtypes))
+	for i, sub := range t.subtypes {
+		child, err := newColumn(types, s, sub)
+		if err != nil {
+			return nil, err
+		}
+		children[i] = child
+	}
+
+	switch t.kind {
+	case kindBoolean:
+		data := newBoolRLE(s.stream(id, streamData))
+		c.value = func() (interface{}, error) {
+			v, err := data.next()
+			if err == io.EOF {
+				err = errTruncatedStream
+			}
+			return v, err
+		}
+	case kindByte:
+		data := newByteRLE(s.stream(id, streamData))
+		c.value = func() (interface{}, error) {
+			v, err := data.next()
+			if err == io.EOF {
+				err = errTruncatedStream
+			}
+			return int64(int8(v)), err
+		}
+	case kindShort, kindInt, kindLong:
+		data := newIntReader(s.stream(id, streamData), v2, true)
+		c.value = func() (interface{}, error) {
+			return nextInt(data)
+		}
+	case kindFloat:
+		data := &byteStream{b: s.stream(id, streamData)}
+		c.value = func() (interface{}, error) {
+			b, err := data.read(4)
+			if err != nil {
+				return nil, err
+			}
+			return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), nil
+		}
+	case kindDouble:
+		data := &byteStream{b: s.stream(id, streamData)}
+		c.value = func() (interface{}, error) {
+			b, err := data.read(8)
+			if err != nil {
+				return nil, err
+			}
+			return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+		}
+	case kindString, kindBinary, kindVarchar, kindChar:
+		if encoding.kind == encodingDictionary || encoding.kind == encodingDictionaryV2 {
+			lengths := newIntReader(s.stream(id, streamLength), v2, false)
+			data := &byteStream{b: s.stream(id, streamDictionaryData)}
+			dictionary := make([]string, encoding.dictionarySize)
+			for i := range dictionary {
+				n, err := nextInt(lengths)
+				if err != nil {
+					return nil, err
+				}
+				b, err := data.read(int(n))
+				if err != nil {
+					return nil, err
+				}
+				dictionary[i] = string(b)
+			}
+			indexes := newIntReader(s.stream(id, streamData), v2, false)
+			c.value = func() (interface{}, error) {
+				i, err := nextInt(indexes)
+				if err != nil {
+					return nil, err
+				}
+				if i < 0 || i >= int64(len(dictionary)) {
+					return nil, errors.New("invalid dictionary index")
+				}
+				return dictionary[i], nil
+			}
+			break
+		}
+		lengths := newIntReader(s.stream(id, streamLength), v2, false)
+		data := &byteStream{b: s.stream(id, streamData)}
+		c.value = func() (interface{}, error) {
+			n, err := nextInt(lengths)
+			if err != nil {
+				return nil, err
+			}
+			b, err := data.read(int(n))
+			if err != nil {
+				return nil, err
+			}
+			return string(b), nil
+		}
+	case kindDate:
+		data := newIntReader(s.stream(id, streamData), v2, true)
+		c.value = func() (interface{}, error) {
+			days, err := nextInt(data)
+			if err != nil {
+				return nil, err
+			}
+			return sql.FormatSQLTimestamp(time.Unix(60*60*24*days, 0).UTC()), nil
+		}
+	case kindTimestamp, kindTimestampInstant:
+		// Only UTC is supported, the writer timezone is ignored.
+		seconds := newIntReader(s.stream(id, streamData), v2, true)
+		nanos := newIntReader(s.stream(id, streamSecondary), v2, false)
+		c.value = func() (interface{}, error) {
+			sec, err := nextInt(seconds)
+			if err != nil {
+				return nil, err
+			}
+			ns, err := nextInt(nanos)
+			if err != nil {
+				return nil, err
+			}
+			t := orcEpoch.Add(time.Duration(sec) * time.Second).Add(time.Duration(decodeNanos(ns)))
+			return sql.FormatSQLTimestamp(t), nil
+		}
+	case kindDecimal:
+		data := &byteStream{b: s.stream(id, streamData)}
+		scales := newIntReader(s.stream(id, streamSecondary), v2, true)
+		c.value = func() (interface{}, error) {
+			unscaled, err := data.bigVarint()
+			if err != nil {
+				return nil, err
+			}
+			scale, err := nextInt(scales)
+			if err != nil {
+				return nil, err
+			}
+			if scale < 0 {
+				return nil, errors.New("invalid decimal scale")
+			}
+			denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(scale), nil)
+			f, _ := new(big.Rat).SetFrac(unscaled, denom).Float64()
+			return f, nil
+		}
+	case kindStruct:
+		if len(t.fieldNames) != len(children) {
+			return nil, fmt.Errorf("invalid struct column %d", id)
+		}
+		c.value = func() (interface{}, error) {
+			kvs := make(jstream.KVS, 0, len(children))
+			for i, child := range children {
+				v, err := child.next()
+				if err != nil {
+					return nil, err
+				}
+				kvs = append(kvs, jstream.KV{Key: t.fieldNames[i], Value: v})
+			}
+			return kvs, nil
+		}
+	case kindList:
+		if len(children) != 1 {
+			return nil, fmt.Errorf("invalid list column %d", id)
+		}
+		lengths := newIntReader(s.stream(id, streamLength), v2, false)
+		c.value = func() (interface{}, error) {
+			n, err := nextInt(lengths)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]interface{}, 0, n)
+			for i := int64(0); i < n; i++ {
+				v, err := children[0].next()
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, v)
+			}
+			return values, nil
+		}
+	case kindMap:
+		if len(children) != 2 {
+			return nil, fmt.Errorf("invalid map column %d", id)
+		}
+		lengths := newIntReader(s.stream(id, streamLength), v2, false)
+		c.value = func() (interface{}, error) {
+			n, err := nextInt(lengths)
+			if err != nil {
+				return nil, err
+			}
+			kvs := make(jstream.KVS, 0, n)
+			for i := int64(0); i < n; i++ {
+				k, err := children[0].next()
+				if err != nil {
+					return nil, err
+				}
+				v, err := children[1].next()
+				if err != nil {
+					return nil, err
+				}
+				kvs = append(kvs, jstream.KV{Key: fmt.Sprint(k), Value: v})
+			}
+			return kvs, nil
+		}
+	case kindUnion:
+		tags := newByteRLE(s.stream(id, streamData))
+		c.value = func() (interface{}, error) {
+			tag, err := tags.next()
+			if err != nil {
+				if err == io.EOF {
+					err = errTruncatedStream
+				}
+				return nil, err
+			}
+			if int(tag) >= len(children) {
+				return nil, errors.New("invalid union tag")
+			}
+			return children[tag].next()
+		}
+	default:
+		return nil, fmt.Errorf("unsupported column kind %d", t.kind)
+	}
+	return c, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package orc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/klauspost/compress/flate"
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
+)
+
+var zstdDecoder, _ = zstd.NewReader(nil)
+
+// decompress - decodes the compression chunks of a stream, each chunk
+// is prefixed by a 3 bytes header holding its length and whether it
+// is stored uncompressed.
+func decompress(compression, blockSize uint64, b []byte) ([]byte, error) {
+	if compression == compressionNone {
+		return b, nil
+	}
+
+	var out []byte
+	for len(b) > 0 {
+		if len(b) < 3 {
+			return nil, errors.New("truncated compression chunk header")
+		}
+		header := uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+		n := int(header >> 1)
+		b = b[3:]
+		if len(b) < n {
+			return nil, errors.New("truncated compression chunk")
+		}
+		chunk := b[:n]
+		b = b[n:]
+
+		if header&1 == 1 {
+			out = append(out, chunk...)
+			continue
+		}
+
+		var err error
+		switch compression {
+		case compressionZlib:
+			var data []byte
+			data, err = ioutil.ReadAll(flate.NewReader(bytes.NewReader(chunk)))
+			out = append(out, data...)
+		case compressionSnappy:
+			var data []byte
+			data, err = s2.Decode(nil, chunk)
+			out = append(out, data...)
+		case compressionZstd:
+			out, err = zstdDecoder.DecodeAll(chunk, out)
+		case compressionLZ4:
+			data := make([]byte, blockSize)
+			n, err = lz4.UncompressBlock(chunk, data)
+			out = append(out, data[:n]...)
+		default:
+			return nil, fmt.Errorf("unsupported compression kind %d", compression)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
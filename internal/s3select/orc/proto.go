@@ -0,0 +1,372 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package orc
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Only the parts of the ORC metadata needed to read the file are
+// decoded, see https://orc.apache.org/specification/ORCv1/ for the
+// protobuf definitions.
+
+var errInvalidProto = errors.New("invalid protobuf message")
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// protoReader - decodes the fields of a protobuf message.
+type protoReader struct {
+	b []byte
+}
+
+func (p *protoReader) varint() (uint64, error) {
+	v, n := binary.Uvarint(p.b)
+	if n <= 0 {
+		return 0, errInvalidProto
+	}
+	p.b = p.b[n:]
+	return v, nil
+}
+
+func (p *protoReader) bytes() ([]byte, error) {
+	n, err := p.varint()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(p.b)) < n {
+		return nil, errInvalidProto
+	}
+	b := p.b[:n]
+	p.b = p.b[n:]
+	return b, nil
+}
+
+// next - returns the number and wire type of the next field.
+func (p *protoReader) next() (field, wire int, err error) {
+	key, err := p.varint()
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(key >> 3), int(key & 7), nil
+}
+
+func (p *protoReader) skip(wire int) (err error) {
+	switch wire {
+	case wireVarint:
+		_, err = p.varint()
+	case wireBytes:
+		_, err = p.bytes()
+	case wireFixed64, wireFixed32:
+		n := 8
+		if wire == wireFixed32 {
+			n = 4
+		}
+		if len(p.b) < n {
+			return errInvalidProto
+		}
+		p.b = p.b[n:]
+	default:
+		err = errInvalidProto
+	}
+	return err
+}
+
+// uint32s - decodes a packed or unpacked repeated varint field.
+func (p *protoReader) uint32s(wire int, dst []uint32) ([]uint32, error) {
+	if wire == wireVarint {
+		v, err := p.varint()
+		return append(dst, uint32(v)), err
+	}
+	b, err := p.bytes()
+	if err != nil {
+		return nil, err
+	}
+	packed := protoReader{b: b}
+	for len(packed.b) > 0 {
+		v, err := packed.varint()
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, uint32(v))
+	}
+	return dst, nil
+}
+
+// decodeMessage - calls fn for every field of the message, fn must
+// consume the field value and returns false for unknown fields,
+// which are skipped.
+func decodeMessage(b []byte, fn func(p *protoReader, field, wire int) (bool, error)) error {
+	p := &protoReader{b: b}
+	for len(p.b) > 0 {
+		field, wire, err := p.next()
+		if err != nil {
+			return err
+		}
+		ok, err := fn(p, field, wire)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if err = p.skip(wire); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Compression kinds of the postscript.
+const (
+	compressionNone   = 0
+	compressionZlib   = 1
+	compressionSnappy = 2
+	compressionLZO    = 3
+	compressionLZ4    = 4
+	compressionZstd   = 5
+)
+
+type postScript struct {
+	footerLength         uint64
+	compression          uint64
+	compressionBlockSize uint64
+	magic                string
+}
+
+func (ps *postScript) unmarshal(b []byte) error {
+	return decodeMessage(b, func(p *protoReader, field, wire int) (ok bool, err error) {
+		switch field {
+		case 1:
+			ps.footerLength, err = p.varint()
+		case 2:
+			ps.compression, err = p.varint()
+		case 3:
+			ps.compressionBlockSize, err = p.varint()
+		case 8000:
+			var b []byte
+			b, err = p.bytes()
+			ps.magic = string(b)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
+
+type stripeInformation struct {
+	offset       uint64
+	indexLength  uint64
+	dataLength   uint64
+	footerLength uint64
+	numberOfRows uint64
+}
+
+func (s *stripeInformation) unmarshal(b []byte) error {
+	return decodeMessage(b, func(p *protoReader, field, wire int) (ok bool, err error) {
+		switch field {
+		case 1:
+			s.offset, err = p.varint()
+		case 2:
+			s.indexLength, err = p.varint()
+		case 3:
+			s.dataLength, err = p.varint()
+		case 4:
+			s.footerLength, err = p.varint()
+		case 5:
+			s.numberOfRows, err = p.varint()
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
+
+// Kinds of column types.
+const (
+	kindBoolean          = 0
+	kindByte             = 1
+	kindShort            = 2
+	kindInt              = 3
+	kindLong             = 4
+	kindFloat            = 5
+	kindDouble           = 6
+	kindString           = 7
+	kindBinary           = 8
+	kindTimestamp        = 9
+	kindList             = 10
+	kindMap              = 11
+	kindStruct           = 12
+	kindUnion            = 13
+	kindDecimal          = 14
+	kindDate             = 15
+	kindVarchar          = 16
+	kindChar             = 17
+	kindTimestampInstant = 18
+)
+
+type orcType struct {
+	kind       uint64
+	subtypes   []uint32
+	fieldNames []string
+}
+
+func (t *orcType) unmarshal(b []byte) error {
+	return decodeMessage(b, func(p *protoReader, field, wire int) (ok bool, err error) {
+		switch field {
+		case 1:
+			t.kind, err = p.varint()
+		case 2:
+			t.subtypes, err = p.uint32s(wire, t.subtypes)
+		case 3:
+			var b []byte
+			b, err = p.bytes()
+			t.fieldNames = append(t.fieldNames, string(b))
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
+
+type footer struct {
+	stripes      []stripeInformation
+	types        []orcType
+	numberOfRows uint64
+}
+
+func (f *footer) unmarshal(b []byte) error {
+	return decodeMessage(b, func(p *protoReader, field, wire int) (ok bool, err error) {
+		switch field {
+		case 3:
+			var b []byte
+			if b, err = p.bytes(); err != nil {
+				return true, err
+			}
+			var s stripeInformation
+			err = s.unmarshal(b)
+			f.stripes = append(f.stripes, s)
+		case 4:
+			var b []byte
+			if b, err = p.bytes(); err != nil {
+				return true, err
+			}
+			var t orcType
+			err = t.unmarshal(b)
+			f.types = append(f.types, t)
+		case 6:
+			f.numberOfRows, err = p.varint()
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
+
+// Kinds of stripe streams.
+const (
+	streamPresent        = 0
+	streamData           = 1
+	streamLength         = 2
+	streamDictionaryData = 3
+	streamSecondary      = 5
+)
+
+type streamInfo struct {
+	kind   uint64
+	column uint64
+	length uint64
+}
+
+func (s *streamInfo) unmarshal(b []byte) error {
+	return decodeMessage(b, func(p *protoReader, field, wire int) (ok bool, err error) {
+		switch field {
+		case 1:
+			s.kind, err = p.varint()
+		case 2:
+			s.column, err = p.varint()
+		case 3:
+			s.length, err = p.varint()
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
+
+// Kinds of column encodings.
+const (
+	encodingDirect       = 0
+	encodingDictionary   = 1
+	encodingDirectV2     = 2
+	encodingDictionaryV2 = 3
+)
+
+type columnEncoding struct {
+	kind           uint64
+	dictionarySize uint64
+}
+
+func (c *columnEncoding) unmarshal(b []byte) error {
+	return decodeMessage(b, func(p *protoReader, field, wire int) (ok bool, err error) {
+		switch field {
+		case 1:
+			c.kind, err = p.varint()
+		case 2:
+			c.dictionarySize, err = p.varint()
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
+
+type stripeFooter struct {
+	streams []streamInfo
+	columns []columnEncoding
+}
+
+func (f *stripeFooter) unmarshal(b []byte) error {
+	return decodeMessage(b, func(p *protoReader, field, wire int) (ok bool, err error) {
+		switch field {
+		case 1:
+			var b []byte
+			if b, err = p.bytes(); err != nil {
+				return true, err
+			}
+			var s streamInfo
+			err = s.unmarshal(b)
+			f.streams = append(f.streams, s)
+		case 2:
+			var b []byte
+			if b, err = p.bytes(); err != nil {
+				return true, err
+			}
+			var c columnEncoding
+			err = c.unmarshal(b)
+			f.columns = append(f.columns, c)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package orc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/bcicen/jstream"
+	jsonfmt "github.com/minio/minio/internal/s3select/json"
+	"github.com/minio/minio/internal/s3select/sql"
+)
+
+const (
+	magic = "ORC"
+
+	// Size of the first read of the file tail, large enough to
+	// hold the footer of most files.
+	tailSize = 16 << 10
+)
+
+// Reader - ORC record reader for S3Select.
+type Reader struct {
+	args          *ReaderArgs
+	getReaderFunc func(offset, length int64) (io.ReadCloser, error)
+	postScript    postScript
+	footer        footer
+
+	// Names and ids of the top level columns read.
+	names []string
+	ids   []uint32
+	// Ids of the columns whose streams are read.
+	needed map[uint32]bool
+
+	stripe  int
+	rows    uint64
+	columns []*column
+}
+
+func (r *Reader) read(offset, length int64) ([]byte, error) {
+	rc, err := r.getReaderFunc(offset, length)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	if length >= 0 {
+		return ioutil.ReadAll(io.LimitReader(rc, length))
+	}
+	return ioutil.ReadAll(rc)
+}
+
+func (r *Reader) readTail() error {
+	tail, err := r.read(-tailSize, -1)
+	if err != nil {
+		return err
+	}
+	if len(tail) == 0 {
+		return errors.New("empty file")
+	}
+	psLen := int(tail[len(tail)-1])
+	if psLen+1 > len(tail) {
+		return errors.New("invalid postscript length")
+	}
+	if err = r.postScript.unmarshal(tail[len(tail)-1-psLen : len(tail)-1]); err != nil {
+		return err
+	}
+	if r.postScript.magic != magic {
+		return errors.New("not an ORC file")
+	}
+
+	need := int64(r.postScript.footerLength) + int64(psLen) + 1
+	if need > int64(len(tail)) {
+		if tail, err = r.read(-need, -1); err != nil {
+			return err
+		}
+		if int64(len(tail)) < need {
+			return errors.New("invalid footer length")
+		}
+	}
+	end := len(tail) - 1 - psLen
+	b, err := decompress(r.postScript.compression, r.postScript.compressionBlockSize, tail[end-int(r.postScript.footerLength):end])
+	if err != nil {
+		return err
+	}
+	return r.footer.unmarshal(b)
+}
+
+// need - marks a column and its children as needed.
+func (r *Reader) need(id uint32) error {
+	if int(id) >= len(r.footer.types) || r.needed[id] {
+		return fmt.Errorf("invalid column id %d", id)
+	}
+	r.needed[id] = true
+	for _, sub := range r.footer.types[id].subtypes {
+		if err := r.need(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextStripe - reads the streams of the needed columns of the next
+// stripe, contiguous streams are fetched in a single request.
+func (r *Reader) nextStripe() error {
+	info := r.footer.stripes[r.stripe]
+	r.stripe++
+
+	b, err := r.read(int64(info.offset+info.indexLength+info.dataLength), int64(info.footerLength))
+	if err != nil {
+		return err
+	}
+	if b, err = decompress(r.postScript.compression, r.postScript.compressionBlockSize, b); err != nil {
+		return err
+	}
+	var sf stripeFooter
+	if err = sf.unmarshal(b); err != nil {
+		return err
+	}
+
+	type span struct {
+		offset, length uint64
+		streams        []streamInfo
+	}
+	var spans []*span
+	offset := info.offset
+	for _, s := range sf.streams {
+		streamOffset := offset
+		offset += s.length
+		switch s.kind {
+		case streamPresent, streamData, streamLength, streamDictionaryData, streamSecondary:
+		default:
+			continue
+		}
+		if !r.needed[uint32(s.column)] {
+			continue
+		}
+		if n := len(spans); n > 0 && spans[n-1].offset+spans[n-1].length == streamOffset {
+			spans[n-1].length += s.length
+			spans[n-1].streams = append(spans[n-1].streams, s)
+			continue
+		}
+		spans = append(spans, &span{offset: streamOffset, length: s.length, streams: []streamInfo{s}})
+	}
+
+	st := &stripe{streams: make(map[streamKey][]byte), encodings: sf.columns}
+	for _, sp := range spans {
+		b, err := r.read(int64(sp.offset), int64(sp.length))
+		if err != nil {
+			return err
+		}
+		if uint64(len(b)) != sp.length {
+			return errTruncatedStream
+		}
+		for _, s := range sp.streams {
+			data, err := decompress(r.postScript.compression, r.postScript.compressionBlockSize, b[:s.length])
+			if err != nil {
+				return err
+			}
+			b = b[s.length:]
+			st.streams[streamKey{column: s.column, kind: s.kind}] = data
+		}
+	}
+
+	r.columns = r.columns[:0]
+	for _, id := range r.ids {
+		c, err := newColumn(r.footer.types, st, id)
+		if err != nil {
+			return err
+		}
+		r.columns = append(r.columns, c)
+	}
+	r.rows = info.numberOfRows
+	return nil
+}
+
+// Read - reads single record.
+func (r *Reader) Read(dst sql.Record) (sql.Record, error) {
+	for r.rows == 0 {
+		if r.stripe == len(r.footer.stripes) {
+			return nil, io.EOF
+		}
+		if err := r.nextStripe(); err != nil {
+			return nil, errORCParsingError(err)
+		}
+	}
+	r.rows--
+
+	kvs := make(jstream.KVS, 0, len(r.columns))
+	for i, c := range r.columns {
+		v, err := c.next()
+		if err != nil {
+			return nil, errORCParsingError(err)
+		}
+		kvs = append(kvs, jstream.KV{Key: r.names[i], Value: v})
+	}
+
+	// Reuse destination if we can.
+	dstRec, ok := dst.(*jsonfmt.Record)
+	if !ok {
+		dstRec = &jsonfmt.Record{}
+	}
+	dstRec.SelectFormat = sql.SelectFmtORC
+	dstRec.KVS = kvs
+	return dstRec, nil
+}
+
+// Close - closes underlying readers.
+func (r *Reader) Close() error {
+	return nil
+}
+
+// NewReader - creates new ORC reader using readerFunc callback. Only
+// the given top level columns are read, all columns are read if
+// columns is nil.
+func NewReader(getReaderFunc func(offset, length int64) (io.ReadCloser, error), args *ReaderArgs, columns []string) (*Reader, error) {
+	r := &Reader{
+		args:          args,
+		getReaderFunc: getReaderFunc,
+		needed:        make(map[uint32]bool),
+	}
+	if err := r.readTail(); err != nil {
+		return nil, errORCParsingError(err)
+	}
+	if len(r.footer.types) == 0 || r.footer.types[0].kind != kindStruct {
+		return nil, errORCParsingError(errors.New("root column is not a struct"))
+	}
+
+	root := r.footer.types[0]
+	if len(root.fieldNames) != len(root.subtypes) {
+		return nil, errORCParsingError(errors.New("invalid root column"))
+	}
+	r.needed[0] = true
+	for i, id := range root.subtypes {
+		if columns != nil && !contains(columns, root.fieldNames[i]) {
+			continue
+		}
+		if err := r.need(id); err != nil {
+			return nil, errORCParsingError(err)
+		}
+		r.names = append(r.names, root.fieldNames[i])
+		r.ids = append(r.ids, id)
+	}
+	return r, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
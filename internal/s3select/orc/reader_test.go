@@ -0,0 +1,300 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package orc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/bcicen/jstream"
+	"github.com/klauspost/compress/flate"
+	jsonfmt "github.com/minio/minio/internal/s3select/json"
+)
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+func appendVarint(b []byte, v int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutVarint(buf[:], v)]...)
+}
+
+type protoWriter struct {
+	b []byte
+}
+
+func (w *protoWriter) varint(field int, v uint64) *protoWriter {
+	w.b = appendUvarint(w.b, uint64(field)<<3|wireVarint)
+	w.b = appendUvarint(w.b, v)
+	return w
+}
+
+func (w *protoWriter) bytes(field int, b []byte) *protoWriter {
+	w.b = appendUvarint(w.b, uint64(field)<<3|wireBytes)
+	w.b = appendUvarint(w.b, uint64(len(b)))
+	w.b = append(w.b, b...)
+	return w
+}
+
+// intLiterals - encodes signed values as version 1 literals.
+func intLiterals(values ...int64) []byte {
+	b := []byte{byte(256 - len(values))}
+	for _, v := range values {
+		b = appendVarint(b, v)
+	}
+	return b
+}
+
+// uintLiterals - encodes unsigned values as version 1 literals.
+func uintLiterals(values ...uint64) []byte {
+	b := []byte{byte(256 - len(values))}
+	for _, v := range values {
+		b = appendUvarint(b, v)
+	}
+	return b
+}
+
+func bools(values ...bool) []byte {
+	var c byte
+	for i, v := range values {
+		if v {
+			c |= 0x80 >> uint(i)
+		}
+	}
+	return []byte{0xff, c}
+}
+
+func doubles(values ...float64) []byte {
+	var b []byte
+	for _, v := range values {
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		b = append(b, buf[:]...)
+	}
+	return b
+}
+
+type testStream struct {
+	column, kind uint64
+	data         []byte
+}
+
+type testStripe struct {
+	rows    uint64
+	streams []testStream
+	dict    uint64
+}
+
+// buildFile - builds an ORC file with the columns
+// id BIGINT, name STRING, score DOUBLE, flag BOOLEAN, point STRUCT<x INT, y INT>.
+func buildFile(t *testing.T, compression uint64, stripes []testStripe) []byte {
+	compress := func(b []byte) []byte {
+		if compression == compressionNone {
+			return b
+		}
+		var buf bytes.Buffer
+		w, err := flate.NewWriter(&buf, flate.BestCompression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(b)
+		w.Close()
+		header := uint32(buf.Len()) << 1
+		return append([]byte{byte(header), byte(header >> 8), byte(header >> 16)}, buf.Bytes()...)
+	}
+
+	file := []byte(magic)
+	footer := &protoWriter{}
+	var numberOfRows uint64
+	for _, s := range stripes {
+		offset := uint64(len(file))
+		sf := &protoWriter{}
+		// Row index stream, which is not read.
+		index := compress([]byte{1, 2, 3})
+		file = append(file, index...)
+		sf.bytes(1, (&protoWriter{}).varint(1, 6).varint(2, 1).varint(3, uint64(len(index))).b)
+		dataOffset := uint64(len(file))
+		for _, st := range s.streams {
+			data := compress(st.data)
+			file = append(file, data...)
+			sf.bytes(1, (&protoWriter{}).varint(1, st.kind).varint(2, st.column).varint(3, uint64(len(data))).b)
+		}
+		dataLength := uint64(len(file)) - dataOffset
+		for column := 0; column < 8; column++ {
+			encoding := &protoWriter{}
+			encoding.varint(1, encodingDirect)
+			if column == 2 {
+				encoding = (&protoWriter{}).varint(1, encodingDictionary).varint(2, s.dict)
+			}
+			sf.bytes(2, encoding.b)
+		}
+		sfData := compress(sf.b)
+		file = append(file, sfData...)
+
+		info := &protoWriter{}
+		info.varint(1, offset).varint(2, dataOffset-offset).varint(3, dataLength).varint(4, uint64(len(sfData))).varint(5, s.rows)
+		footer.bytes(3, info.b)
+		numberOfRows += s.rows
+	}
+
+	root := (&protoWriter{}).varint(1, kindStruct).bytes(2, []byte{1, 2, 3, 4, 5})
+	for _, name := range []string{"id", "name", "score", "flag", "point"} {
+		root.bytes(3, []byte(name))
+	}
+	footer.bytes(4, root.b)
+	for _, kind := range []uint64{kindLong, kindString, kindDouble, kindBoolean} {
+		footer.bytes(4, (&protoWriter{}).varint(1, kind).b)
+	}
+	footer.bytes(4, (&protoWriter{}).varint(1, kindStruct).varint(2, 6).varint(2, 7).bytes(3, []byte("x")).bytes(3, []byte("y")).b)
+	footer.bytes(4, (&protoWriter{}).varint(1, kindInt).b)
+	footer.bytes(4, (&protoWriter{}).varint(1, kindInt).b)
+	footer.varint(6, numberOfRows)
+	footerData := compress(footer.b)
+	file = append(file, footerData...)
+
+	ps := (&protoWriter{}).varint(1, uint64(len(footerData))).varint(2, compression).varint(3, 256<<10).bytes(8000, []byte(magic))
+	file = append(file, ps.b...)
+	return append(file, byte(len(ps.b)))
+}
+
+func testStripes() []testStripe {
+	return []testStripe{
+		{
+			rows: 2,
+			dict: 2,
+			streams: []testStream{
+				{1, streamData, intLiterals(1, 2)},
+				{2, streamData, uintLiterals(0, 1)},
+				{2, streamDictionaryData, []byte("ab")},
+				{2, streamLength, uintLiterals(1, 1)},
+				{3, streamPresent, bools(true, false)},
+				{3, streamData, doubles(1.5)},
+				{4, streamData, bools(true, false)},
+				{5, streamPresent, bools(true, false)},
+				{6, streamData, intLiterals(1)},
+				{7, streamData, intLiterals(-2)},
+			},
+		},
+		{
+			rows: 1,
+			dict: 1,
+			streams: []testStream{
+				{1, streamData, intLiterals(3)},
+				{2, streamData, uintLiterals(0)},
+				{2, streamDictionaryData, []byte("c")},
+				{2, streamLength, uintLiterals(1)},
+				{3, streamData, doubles(2.5)},
+				{4, streamData, bools(true)},
+				{6, streamData, intLiterals(3)},
+				{7, streamData, intLiterals(4)},
+			},
+		},
+	}
+}
+
+func newBytesReader(t *testing.T, data []byte, columns []string, requests *int) *Reader {
+	r, err := NewReader(func(offset, length int64) (io.ReadCloser, error) {
+		if requests != nil {
+			*requests++
+		}
+		if offset < 0 {
+			offset = int64(len(data)) + offset
+			if offset < 0 {
+				offset = 0
+			}
+		}
+		if length < 0 {
+			length = int64(len(data)) - offset
+		}
+		return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+	}, &ReaderArgs{}, columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func readAll(t *testing.T, r *Reader) []jstream.KVS {
+	var records []jstream.KVS
+	for {
+		rec, err := r.Read(nil)
+		if err == io.EOF {
+			return records
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, rec.(*jsonfmt.Record).KVS)
+	}
+}
+
+func TestReader(t *testing.T) {
+	expected := []jstream.KVS{
+		{{Key: "id", Value: int64(1)}, {Key: "name", Value: "a"}, {Key: "score", Value: 1.5}, {Key: "flag", Value: true},
+			{Key: "point", Value: jstream.KVS{{Key: "x", Value: int64(1)}, {Key: "y", Value: int64(-2)}}}},
+		{{Key: "id", Value: int64(2)}, {Key: "name", Value: "b"}, {Key: "score", Value: nil}, {Key: "flag", Value: false},
+			{Key: "point", Value: nil}},
+		{{Key: "id", Value: int64(3)}, {Key: "name", Value: "c"}, {Key: "score", Value: 2.5}, {Key: "flag", Value: true},
+			{Key: "point", Value: jstream.KVS{{Key: "x", Value: int64(3)}, {Key: "y", Value: int64(4)}}}},
+	}
+
+	for _, compression := range []uint64{compressionNone, compressionZlib} {
+		r := newBytesReader(t, buildFile(t, compression, testStripes()), nil, nil)
+		if got := readAll(t, r); !reflect.DeepEqual(got, expected) {
+			t.Errorf("compression %d: expected %v, got %v", compression, expected, got)
+		}
+		r.Close()
+	}
+}
+
+func TestReaderProjection(t *testing.T) {
+	data := buildFile(t, compressionNone, testStripes())
+
+	var requests int
+	r := newBytesReader(t, data, []string{"name", "flag"}, &requests)
+	defer r.Close()
+	expected := []jstream.KVS{
+		{{Key: "name", Value: "a"}, {Key: "flag", Value: true}},
+		{{Key: "name", Value: "b"}, {Key: "flag", Value: false}},
+		{{Key: "name", Value: "c"}, {Key: "flag", Value: true}},
+	}
+	if got := readAll(t, r); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	// The tail, then per stripe the footer, the contiguous name
+	// streams and the flag stream.
+	if requests != 7 {
+		t.Errorf("expected 7 requests, got %d", requests)
+	}
+}
+
+func TestReaderInvalid(t *testing.T) {
+	_, err := NewReader(func(offset, length int64) (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader([]byte("not an orc file"))), nil
+	}, &ReaderArgs{}, nil)
+	if err == nil {
+		t.Fatal("expected error reading invalid file")
+	}
+}
@@ -0,0 +1,428 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package orc
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+var errTruncatedStream = errors.New("truncated stream")
+
+// byteStream - reads the bytes of a decompressed stream.
+type byteStream struct {
+	b []byte
+}
+
+func (s *byteStream) readByte() (byte, error) {
+	if len(s.b) == 0 {
+		return 0, errTruncatedStream
+	}
+	c := s.b[0]
+	s.b = s.b[1:]
+	return c, nil
+}
+
+func (s *byteStream) read(n int) ([]byte, error) {
+	if n < 0 || len(s.b) < n {
+		return nil, errTruncatedStream
+	}
+	b := s.b[:n]
+	s.b = s.b[n:]
+	return b, nil
+}
+
+func (s *byteStream) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(s.b)
+	if n <= 0 {
+		return 0, errTruncatedStream
+	}
+	s.b = s.b[n:]
+	return v, nil
+}
+
+func (s *byteStream) varint() (int64, error) {
+	v, err := s.uvarint()
+	return unZigzag(v), err
+}
+
+// bigEndian - reads an unsigned big endian integer of n bytes.
+func (s *byteStream) bigEndian(n int) (uint64, error) {
+	b, err := s.read(n)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
+
+func unZigzag(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+// byteRLE - decodes the byte run length encoding, used by byte
+// columns and as the base of boolean streams.
+type byteRLE struct {
+	s      byteStream
+	values []byte
+	run    []byte
+}
+
+func (r *byteRLE) next() (byte, error) {
+	if len(r.values) == 0 {
+		if len(r.s.b) == 0 {
+			return 0, io.EOF
+		}
+		control, err := r.s.readByte()
+		if err != nil {
+			return 0, err
+		}
+		if control < 0x80 {
+			v, err := r.s.readByte()
+			if err != nil {
+				return 0, err
+			}
+			r.run = r.run[:0]
+			for i := 0; i < int(control)+3; i++ {
+				r.run = append(r.run, v)
+			}
+			r.values = r.run
+		} else if r.values, err = r.s.read(256 - int(control)); err != nil {
+			return 0, err
+		}
+	}
+	v := r.values[0]
+	r.values = r.values[1:]
+	return v, nil
+}
+
+// boolRLE - decodes boolean streams, stored most significant bit
+// first in byte run length encoded bytes.
+type boolRLE struct {
+	bytes byteRLE
+	b     byte
+	bits  int
+}
+
+func (r *boolRLE) next() (bool, error) {
+	if r.bits == 0 {
+		b, err := r.bytes.next()
+		if err != nil {
+			return false, err
+		}
+		r.b, r.bits = b, 8
+	}
+	r.bits--
+	return r.b>>uint(r.bits)&1 == 1, nil
+}
+
+// intReader - decodes integer streams.
+type intReader interface {
+	next() (int64, error)
+}
+
+// intRLEv1 - decodes the version 1 integer run length encoding.
+type intRLEv1 struct {
+	s      byteStream
+	signed bool
+	values []int64
+}
+
+func (r *intRLEv1) varint() (int64, error) {
+	if r.signed {
+		return r.s.varint()
+	}
+	v, err := r.s.uvarint()
+	return int64(v), err
+}
+
+func (r *intRLEv1) next() (int64, error) {
+	if len(r.values) == 0 {
+		if len(r.s.b) == 0 {
+			return 0, io.EOF
+		}
+		control, err := r.s.readByte()
+		if err != nil {
+			return 0, err
+		}
+		r.values = r.values[:0]
+		if control < 0x80 {
+			delta, err := r.s.readByte()
+			if err != nil {
+				return 0, err
+			}
+			base, err := r.varint()
+			if err != nil {
+				return 0, err
+			}
+			for i := 0; i < int(control)+3; i++ {
+				r.values = append(r.values, base+int64(i)*int64(int8(delta)))
+			}
+		} else {
+			for i := 0; i < 256-int(control); i++ {
+				v, err := r.varint()
+				if err != nil {
+					return 0, err
+				}
+				r.values = append(r.values, v)
+			}
+		}
+	}
+	v := r.values[0]
+	r.values = r.values[1:]
+	return v, nil
+}
+
+// Sub encodings of the version 2 integer run length encoding.
+const (
+	shortRepeat = 0
+	direct      = 1
+	patchedBase = 2
+	delta       = 3
+)
+
+// decodeBitWidth - decodes the 5 bits bit width of version 2 runs.
+func decodeBitWidth(code byte) int {
+	switch {
+	case code <= 23:
+		return int(code) + 1
+	case code == 24:
+		return 26
+	case code == 25:
+		return 28
+	case code == 26:
+		return 30
+	case code == 27:
+		return 32
+	case code == 28:
+		return 40
+	case code == 29:
+		return 48
+	case code == 30:
+		return 56
+	}
+	return 64
+}
+
+// closestFixedBits - rounds a bit width up to one which can be
+// encoded in 5 bits.
+func closestFixedBits(n int) int {
+	switch {
+	case n <= 24:
+		return n
+	case n <= 26:
+		return 26
+	case n <= 28:
+		return 28
+	case n <= 30:
+		return 30
+	case n <= 32:
+		return 32
+	case n <= 40:
+		return 40
+	case n <= 48:
+		return 48
+	case n <= 56:
+		return 56
+	}
+	return 64
+}
+
+// readBits - reads n big endian bit packed values of the given
+// width, the values end at a byte boundary.
+func (s *byteStream) readBits(n, width int, dst []uint64) ([]uint64, error) {
+	b, err := s.read((n*width + 7) / 8)
+	if err != nil {
+		return nil, err
+	}
+	var pos int
+	for i := 0; i < n; i++ {
+		var v uint64
+		for j := 0; j < width; j++ {
+			bit := b[pos/8] >> uint(7-pos%8) & 1
+			v = v<<1 | uint64(bit)
+			pos++
+		}
+		dst = append(dst, v)
+	}
+	return dst, nil
+}
+
+// intRLEv2 - decodes the version 2 integer run length encoding.
+type intRLEv2 struct {
+	s      byteStream
+	signed bool
+	values []int64
+	bits   []uint64
+}
+
+func (r *intRLEv2) decode(v uint64) int64 {
+	if r.signed {
+		return unZigzag(v)
+	}
+	return int64(v)
+}
+
+func (r *intRLEv2) readRun() error {
+	header, err := r.s.readByte()
+	if err != nil {
+		return err
+	}
+	r.values = r.values[:0]
+
+	if header>>6 == shortRepeat {
+		width := int(header>>3&7) + 1
+		count := int(header&7) + 3
+		v, err := r.s.bigEndian(width)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < count; i++ {
+			r.values = append(r.values, r.decode(v))
+		}
+		return nil
+	}
+
+	second, err := r.s.readByte()
+	if err != nil {
+		return err
+	}
+	code := header >> 1 & 0x1f
+	length := (int(header&1)<<8 | int(second)) + 1
+
+	switch header >> 6 {
+	case direct:
+		if r.bits, err = r.s.readBits(length, decodeBitWidth(code), r.bits[:0]); err != nil {
+			return err
+		}
+		for _, v := range r.bits {
+			r.values = append(r.values, r.decode(v))
+		}
+	case patchedBase:
+		width := decodeBitWidth(code)
+		third, err := r.s.readByte()
+		if err != nil {
+			return err
+		}
+		fourth, err := r.s.readByte()
+		if err != nil {
+			return err
+		}
+		baseWidth := int(third>>5) + 1
+		patchWidth := decodeBitWidth(third & 0x1f)
+		patchGapWidth := int(fourth>>5) + 1
+		patchListLength := int(fourth & 0x1f)
+
+		// The base is stored in sign magnitude form.
+		u, err := r.s.bigEndian(baseWidth)
+		if err != nil {
+			return err
+		}
+		base := int64(u)
+		if mask := uint64(1) << uint(baseWidth*8-1); u&mask != 0 {
+			base = -int64(u &^ mask)
+		}
+
+		if r.bits, err = r.s.readBits(length, width, r.bits[:0]); err != nil {
+			return err
+		}
+		entryWidth := closestFixedBits(patchGapWidth + patchWidth)
+		if entryWidth > 64 {
+			return errors.New("invalid patch list entry width")
+		}
+		patches, err := r.s.readBits(patchListLength, entryWidth, nil)
+		if err != nil {
+			return err
+		}
+		var pos int
+		for _, p := range patches {
+			pos += int(p >> uint(patchWidth))
+			if pos >= len(r.bits) {
+				return errors.New("invalid patch position")
+			}
+			r.bits[pos] |= (p & (1<<uint(patchWidth) - 1)) << uint(width)
+		}
+		for _, v := range r.bits {
+			r.values = append(r.values, base+int64(v))
+		}
+	case delta:
+		var base int64
+		if r.signed {
+			base, err = r.s.varint()
+		} else {
+			var u uint64
+			u, err = r.s.uvarint()
+			base = int64(u)
+		}
+		if err != nil {
+			return err
+		}
+		deltaBase, err := r.s.varint()
+		if err != nil {
+			return err
+		}
+		r.values = append(r.values, base)
+		if length == 1 {
+			return nil
+		}
+		r.values = append(r.values, base+deltaBase)
+		if code == 0 {
+			for i := 2; i < length; i++ {
+				r.values = append(r.values, r.values[i-1]+deltaBase)
+			}
+			return nil
+		}
+		if r.bits, err = r.s.readBits(length-2, decodeBitWidth(code), r.bits[:0]); err != nil {
+			return err
+		}
+		for i, d := range r.bits {
+			prev := r.values[i+1]
+			if deltaBase < 0 {
+				r.values = append(r.values, prev-int64(d))
+			} else {
+				r.values = append(r.values, prev+int64(d))
+			}
+		}
+	}
+	return nil
+}
+
+func (r *intRLEv2) next() (int64, error) {
+	for len(r.values) == 0 {
+		if len(r.s.b) == 0 {
+			return 0, io.EOF
+		}
+		if err := r.readRun(); err != nil {
+			return 0, err
+		}
+	}
+	v := r.values[0]
+	r.values = r.values[1:]
+	return v, nil
+}
+
+func newIntReader(b []byte, v2, signed bool) intReader {
+	if v2 {
+		return &intRLEv2{s: byteStream{b: b}, signed: signed}
+	}
+	return &intRLEv1{s: byteStream{b: b}, signed: signed}
+}
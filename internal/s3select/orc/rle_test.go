@@ -0,0 +1,103 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package orc
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Examples of the ORC specification.
+func TestIntRLEv2(t *testing.T) {
+	testCases := []struct {
+		data     []byte
+		expected []int64
+	}{
+		// Short repeat.
+		{[]byte{0x0a, 0x27, 0x10}, []int64{10000, 10000, 10000, 10000, 10000}},
+		// Direct.
+		{[]byte{0x5e, 0x03, 0x5c, 0xa1, 0xab, 0x1e, 0xde, 0xad, 0xbe, 0xef}, []int64{23713, 43806, 57005, 48879}},
+		// Patched base.
+		{
+			[]byte{0x8e, 0x13, 0x2b, 0x21, 0x07, 0xd0, 0x1e, 0x00, 0x14, 0x70, 0x28, 0x32, 0x3c, 0x46, 0x50, 0x5a, 0x64, 0x6e, 0x78, 0x82, 0x8c, 0x96, 0xa0, 0xaa, 0xb4, 0xbe, 0xfc, 0xe8},
+			[]int64{2030, 2000, 2020, 1000000, 2040, 2050, 2060, 2070, 2080, 2090, 2100, 2110, 2120, 2130, 2140, 2150, 2160, 2170, 2180, 2190},
+		},
+		// Delta.
+		{[]byte{0xc6, 0x09, 0x02, 0x02, 0x22, 0x42, 0x42, 0x46}, []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}},
+	}
+
+	for i, testCase := range testCases {
+		r := newIntReader(testCase.data, true, false)
+		var got []int64
+		for range testCase.expected {
+			v, err := r.next()
+			if err != nil {
+				t.Fatalf("Test %d: %v", i+1, err)
+			}
+			got = append(got, v)
+		}
+		if !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+		if _, err := r.next(); err == nil {
+			t.Errorf("Test %d: expected end of stream", i+1)
+		}
+	}
+}
+
+func TestIntRLEv1(t *testing.T) {
+	// A run of 100 values from 7 with a delta of -1, followed by the
+	// literals 2, 3, 6, 7, 11, taken from the ORC specification.
+	r := newIntReader([]byte{0x61, 0xff, 0x64, 0xfb, 0x02, 0x03, 0x06, 0x07, 0x0b}, false, false)
+	var expected []int64
+	for i := 0; i < 100; i++ {
+		expected = append(expected, int64(100-i))
+	}
+	expected = append(expected, 2, 3, 6, 7, 11)
+	for i, want := range expected {
+		v, err := r.next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != want {
+			t.Fatalf("value %d: expected %d, got %d", i, want, v)
+		}
+	}
+}
+
+func TestByteRLE(t *testing.T) {
+	r := newBoolRLE([]byte{0xff, 0x80})
+	expected := []bool{true, false, false, false, false, false, false, false}
+	for i, want := range expected {
+		v, err := r.next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != want {
+			t.Fatalf("value %d: expected %v, got %v", i, want, v)
+		}
+	}
+
+	b := newByteRLE([]byte{0x61, 0x00})
+	for i := 0; i < 100; i++ {
+		v, err := b.next()
+		if err != nil || v != 0 {
+			t.Fatalf("value %d: expected 0, got %d: %v", i, v, err)
+		}
+	}
+}
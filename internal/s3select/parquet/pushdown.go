@@ -0,0 +1,308 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package parquet
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"strings"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio/internal/s3select/sql"
+	parquetgen "github.com/minio/parquet-go/gen-go/parquet"
+)
+
+func readFileMetaData(getReaderFunc func(offset, length int64) (io.ReadCloser, error)) (*parquetgen.FileMetaData, error) {
+	read := func(offset, length int64) ([]byte, error) {
+		rc, err := getReaderFunc(offset, length)
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return ioutil.ReadAll(io.LimitReader(rc, length))
+	}
+
+	b, err := read(-8, 8)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 8 || string(b[4:]) != magic {
+		return nil, errors.New("not a Parquet file")
+	}
+	size := int64(binary.LittleEndian.Uint32(b))
+	if b, err = read(-(8 + size), size); err != nil {
+		return nil, err
+	}
+
+	ts := thrift.NewTDeserializer()
+	ts.Protocol = thrift.NewTCompactProtocolFactory().GetProtocol(ts.Transport)
+	footer := parquetgen.NewFileMetaData()
+	if err = ts.Read(context.Background(), footer, b); err != nil {
+		return nil, err
+	}
+	return footer, nil
+}
+
+// topLevelElements - returns the schema elements of the top level
+// columns by name.
+func topLevelElements(schema []*parquetgen.SchemaElement) map[string]*parquetgen.SchemaElement {
+	elements := make(map[string]*parquetgen.SchemaElement)
+	if len(schema) == 0 {
+		return elements
+	}
+	// Skip the root and the children of nested columns.
+	for i := 1; i < len(schema); {
+		elements[schema[i].Name] = schema[i]
+		i += subtreeSize(schema, i)
+	}
+	return elements
+}
+
+// subtreeSize - returns the number of elements of the subtree of the
+// schema element at i, including it.
+func subtreeSize(schema []*parquetgen.SchemaElement, i int) int {
+	n := 1
+	if i < len(schema) {
+		for c := int32(0); c < schema[i].GetNumChildren(); c++ {
+			n += subtreeSize(schema, i+n)
+		}
+	}
+	return n
+}
+
+// compareStat - compares a statistics value with the predicate value,
+// ok is false if they can not be compared the way the statement
+// compares column values.
+func compareStat(stat []byte, element *parquetgen.SchemaElement, v *sql.Value) (cmp int, ok bool) {
+	switch element.GetType() {
+	case parquetgen.Type_INT32, parquetgen.Type_INT64:
+		if element.ConvertedType != nil {
+			switch element.GetConvertedType() {
+			case parquetgen.ConvertedType_INT_8, parquetgen.ConvertedType_INT_16,
+				parquetgen.ConvertedType_INT_32, parquetgen.ConvertedType_INT_64:
+			default:
+				// Dates, timestamps, decimals and unsigned
+				// values are not compared as plain integers.
+				return 0, false
+			}
+		}
+		var x int64
+		switch {
+		case element.GetType() == parquetgen.Type_INT32 && len(stat) == 4:
+			x = int64(int32(binary.LittleEndian.Uint32(stat)))
+		case element.GetType() == parquetgen.Type_INT64 && len(stat) == 8:
+			x = int64(binary.LittleEndian.Uint64(stat))
+		default:
+			return 0, false
+		}
+		if i, ok := v.ToInt(); ok {
+			return compareInt(x, i), true
+		}
+		if f, ok := v.ToFloat(); ok {
+			return compareFloat(float64(x), f)
+		}
+	case parquetgen.Type_FLOAT, parquetgen.Type_DOUBLE:
+		var x float64
+		switch {
+		case element.GetType() == parquetgen.Type_FLOAT && len(stat) == 4:
+			x = float64(math.Float32frombits(binary.LittleEndian.Uint32(stat)))
+		case element.GetType() == parquetgen.Type_DOUBLE && len(stat) == 8:
+			x = math.Float64frombits(binary.LittleEndian.Uint64(stat))
+		default:
+			return 0, false
+		}
+		if f, ok := v.ToFloat(); ok {
+			return compareFloat(x, f)
+		}
+	case parquetgen.Type_BYTE_ARRAY:
+		if element.ConvertedType != nil && element.GetConvertedType() != parquetgen.ConvertedType_UTF8 {
+			return 0, false
+		}
+		if s, ok := v.ToString(); ok {
+			return strings.Compare(string(stat), s), true
+		}
+	}
+	return 0, false
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat(a, b float64) (int, bool) {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return 0, false
+	}
+	switch {
+	case a < b:
+		return -1, true
+	case a > b:
+		return 1, true
+	}
+	return 0, true
+}
+
+// mayMatch - returns false if the statistics of a column chunk prove
+// that none of its values satisfies the predicate.
+func mayMatch(meta *parquetgen.ColumnMetaData, element *parquetgen.SchemaElement, p sql.Predicate) bool {
+	stats := meta.GetStatistics()
+	if stats == nil {
+		return true
+	}
+	min, max := stats.MinValue, stats.MaxValue
+	if min == nil || max == nil {
+		if meta.GetType() == parquetgen.Type_BYTE_ARRAY {
+			// The deprecated statistics of byte arrays were
+			// computed using a signed comparison.
+			return true
+		}
+		min, max = stats.Min, stats.Max
+	}
+	if min == nil || max == nil {
+		return true
+	}
+
+	cmpMin, ok := compareStat(min, element, p.Value)
+	if !ok {
+		return true
+	}
+	cmpMax, ok := compareStat(max, element, p.Value)
+	if !ok {
+		return true
+	}
+	switch p.Operator {
+	case "=":
+		return cmpMin <= 0 && cmpMax >= 0
+	case "<":
+		return cmpMin < 0
+	case "<=":
+		return cmpMin <= 0
+	case ">":
+		return cmpMax > 0
+	case ">=":
+		return cmpMax >= 0
+	}
+	return true
+}
+
+// filterRowGroups - returns the row groups which may hold rows
+// satisfying all predicates.
+func filterRowGroups(footer *parquetgen.FileMetaData, predicates []sql.Predicate) []*parquetgen.RowGroup {
+	if len(predicates) == 0 {
+		return footer.RowGroups
+	}
+
+	elements := topLevelElements(footer.Schema)
+	var rowGroups []*parquetgen.RowGroup
+	for _, rowGroup := range footer.RowGroups {
+		match := true
+		for _, p := range predicates {
+			element, ok := elements[p.Column]
+			if !ok || element.Type == nil {
+				continue
+			}
+			for _, chunk := range rowGroup.Columns {
+				meta := chunk.GetMetaData()
+				if meta == nil || len(meta.PathInSchema) != 1 || meta.PathInSchema[0] != p.Column {
+					continue
+				}
+				if !mayMatch(meta, element, p) {
+					match = false
+				}
+				break
+			}
+			if !match {
+				break
+			}
+		}
+		if match {
+			rowGroups = append(rowGroups, rowGroup)
+		}
+	}
+	return rowGroups
+}
+
+// projectColumns - returns the paths of the leaf columns of the given
+// top level columns. At least one column is kept, so rows are still
+// counted when none is referenced.
+func projectColumns(footer *parquetgen.FileMetaData, columns []string) set.StringSet {
+	if columns == nil || len(footer.RowGroups) == 0 {
+		return nil
+	}
+
+	names := set.CreateStringSet(columns...)
+	paths := set.NewStringSet()
+	var first string
+	for _, chunk := range footer.RowGroups[0].Columns {
+		meta := chunk.GetMetaData()
+		if meta == nil || len(meta.PathInSchema) == 0 {
+			continue
+		}
+		path := strings.Join(meta.PathInSchema, ".")
+		if first == "" {
+			first = path
+		}
+		if names.Contains(meta.PathInSchema[0]) {
+			paths.Add(path)
+		}
+	}
+	if paths.IsEmpty() && first != "" {
+		paths.Add(first)
+	}
+	return paths
+}
+
+// footerReaderFunc - returns a reader function serving the file with
+// footer as file metadata. Only the file tail differs, data pages are
+// read from the original file.
+func footerReaderFunc(getReaderFunc func(offset, length int64) (io.ReadCloser, error), footer *parquetgen.FileMetaData) (func(offset, length int64) (io.ReadCloser, error), error) {
+	b, err := serialize(footer)
+	if err != nil {
+		return nil, err
+	}
+	tail := make([]byte, 4)
+	binary.LittleEndian.PutUint32(tail, uint32(len(b)))
+	tail = append(append(b, tail...), magic...)
+
+	return func(offset, length int64) (io.ReadCloser, error) {
+		if offset >= 0 {
+			return getReaderFunc(offset, length)
+		}
+		start := int64(len(tail)) + offset
+		if start < 0 {
+			return nil, errors.New("invalid offset in file metadata")
+		}
+		end := int64(len(tail))
+		if length >= 0 && start+length < end {
+			end = start + length
+		}
+		return ioutil.NopCloser(bytes.NewReader(tail[start:end])), nil
+	}, nil
+}
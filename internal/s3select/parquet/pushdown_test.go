@@ -0,0 +1,122 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	jsonfmt "github.com/minio/minio/internal/s3select/json"
+	"github.com/minio/minio/internal/s3select/sql"
+	"github.com/minio/minio/internal/s3select/typed"
+)
+
+// pushdownTestFile - returns a file of three row groups with ids
+// increasing across row groups.
+func pushdownTestFile(t *testing.T) []byte {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for i := 0; i < 3*rowGroupRows; i++ {
+		err := w.Write([]*typed.Record{{Columns: []typed.Column{
+			{Name: "id", Value: int64(i)},
+			{Name: "name", Value: fmt.Sprintf("name-%05d", i)},
+			{Name: "score", Value: float64(i) / 2},
+		}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReaderPushdown(t *testing.T) {
+	data := pushdownTestFile(t)
+
+	testCases := []struct {
+		query   string
+		columns []string
+		rows    int
+		firstID int64
+	}{
+		{"SELECT * FROM S3Object s", []string{"id", "name", "score"}, 3 * rowGroupRows, 0},
+		{"SELECT s.id FROM S3Object s WHERE s.id >= 25000", []string{"id"}, rowGroupRows, 2 * rowGroupRows},
+		{"SELECT s.id FROM S3Object s WHERE 15000 > s.id", []string{"id"}, 2 * rowGroupRows, 0},
+		{"SELECT s.name FROM S3Object s WHERE s.id = 10000", []string{"id", "name"}, rowGroupRows, rowGroupRows},
+		{"SELECT s.id FROM S3Object s WHERE s.name < 'name-05000'", []string{"id", "name"}, rowGroupRows, 0},
+		{"SELECT s.id FROM S3Object s WHERE s.score BETWEEN 6000 AND 12000.5", []string{"id", "score"}, 2 * rowGroupRows, rowGroupRows},
+		{"SELECT s.id FROM S3Object s WHERE s.id > 30000", []string{"id"}, 0, 0},
+		{"SELECT s.id FROM S3Object s WHERE s.id > 25000 OR s.id < 5", []string{"id"}, 3 * rowGroupRows, 0},
+		{"SELECT COUNT(*) FROM S3Object", []string{"id"}, 3 * rowGroupRows, 0},
+	}
+
+	for i, testCase := range testCases {
+		statement, err := sql.ParseSelectStatement(testCase.query)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+
+		r, err := NewReader(func(offset, length int64) (io.ReadCloser, error) {
+			if offset < 0 {
+				offset = int64(len(data)) + offset
+			}
+			if length < 0 {
+				length = int64(len(data)) - offset
+			}
+			return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+		}, &ReaderArgs{}, statement.Columns(), statement.Predicates())
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+
+		rows := 0
+		for {
+			rec, err := r.Read(nil)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Test %d: %v", i+1, err)
+			}
+			kvs := rec.(*jsonfmt.Record).KVS
+			if rows == 0 {
+				var columns []string
+				for _, kv := range kvs {
+					columns = append(columns, kv.Key)
+				}
+				if fmt.Sprint(columns) != fmt.Sprint(testCase.columns) {
+					t.Errorf("Test %d: expected columns %v, got %v", i+1, testCase.columns, columns)
+				}
+				if kvs[0].Value != testCase.firstID {
+					t.Errorf("Test %d: expected first id %d, got %v", i+1, testCase.firstID, kvs[0].Value)
+				}
+			}
+			rows++
+		}
+		r.Close()
+
+		if rows != testCase.rows {
+			t.Errorf("Test %d: expected %d rows, got %d", i+1, testCase.rows, rows)
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/bcicen/jstream"
+	"github.com/minio/minio-go/v7/pkg/set"
 	jsonfmt "github.com/minio/minio/internal/s3select/json"
 	"github.com/minio/minio/internal/s3select/sql"
 	parquetgo "github.com/minio/parquet-go"
@@ -116,13 +117,36 @@ func (r *Reader) Close() error {
 }
 
 // NewReader - creates new Parquet reader using readerFunc callback.
-func NewReader(getReaderFunc func(offset, length int64) (io.ReadCloser, error), args *ReaderArgs) (r *Reader, err error) {
+// Only the given top level columns are decoded, all columns if nil,
+// and row groups whose column statistics rule out every predicate
+// are skipped.
+func NewReader(getReaderFunc func(offset, length int64) (io.ReadCloser, error), args *ReaderArgs, columns []string, predicates []sql.Predicate) (r *Reader, err error) {
 	defer func() {
 		if rec := recover(); rec != nil {
 			err = fmt.Errorf("panic reading parquet header: %v", rec)
 		}
 	}()
-	reader, err := parquetgo.NewReader(getReaderFunc, nil)
+
+	var columnNames set.StringSet
+	if columns != nil || len(predicates) > 0 {
+		footer, err := readFileMetaData(getReaderFunc)
+		if err != nil {
+			return nil, errParquetParsingError(err)
+		}
+		columnNames = projectColumns(footer, columns)
+		if rowGroups := filterRowGroups(footer, predicates); len(rowGroups) != len(footer.RowGroups) {
+			footer.RowGroups = rowGroups
+			footer.NumRows = 0
+			for _, rowGroup := range rowGroups {
+				footer.NumRows += rowGroup.NumRows
+			}
+			if getReaderFunc, err = footerReaderFunc(getReaderFunc, footer); err != nil {
+				return nil, errParquetParsingError(err)
+			}
+		}
+	}
+
+	reader, err := parquetgo.NewReader(getReaderFunc, columnNames)
 	if err != nil {
 		if err != io.EOF {
 			return nil, errParquetParsingError(err)
@@ -0,0 +1,298 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package parquet
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/minio/minio/internal/s3select/typed"
+	"github.com/minio/parquet-go/encoding"
+	parquetgen "github.com/minio/parquet-go/gen-go/parquet"
+)
+
+const (
+	magic = "PAR1"
+
+	// Number of rows buffered before a row group is written.
+	rowGroupRows = 10000
+)
+
+// Writer - writes select output records as an Apache Parquet file.
+// All columns are optional, written uncompressed with plain encoding,
+// one data page per row group.
+type Writer struct {
+	w      io.Writer
+	schema typed.Schema
+	footer *parquetgen.FileMetaData
+	offset int64
+
+	// Rows of the current row group.
+	rows [][]interface{}
+}
+
+func (w *Writer) write(b []byte) error {
+	n, err := w.w.Write(b)
+	w.offset += int64(n)
+	return err
+}
+
+func serialize(msg thrift.TStruct) ([]byte, error) {
+	ts := thrift.NewTSerializer()
+	ts.Protocol = thrift.NewTCompactProtocolFactory().GetProtocol(ts.Transport)
+	return ts.Write(context.Background(), msg)
+}
+
+func parquetType(t typed.Type) parquetgen.Type {
+	switch t {
+	case typed.Bool:
+		return parquetgen.Type_BOOLEAN
+	case typed.Int:
+		return parquetgen.Type_INT64
+	case typed.Float:
+		return parquetgen.Type_DOUBLE
+	}
+	return parquetgen.Type_BYTE_ARRAY
+}
+
+func (w *Writer) init(records []*typed.Record) error {
+	w.schema = typed.InferSchema(records)
+
+	root := parquetgen.NewSchemaElement()
+	root.Name = "schema"
+	numChildren := int32(len(w.schema.Fields))
+	root.NumChildren = &numChildren
+	elements := []*parquetgen.SchemaElement{root}
+	for _, f := range w.schema.Fields {
+		element := parquetgen.NewSchemaElement()
+		element.Name = typed.FieldName(f.Name)
+		element.Type = parquetgen.TypePtr(parquetType(f.Type))
+		element.RepetitionType = parquetgen.FieldRepetitionTypePtr(parquetgen.FieldRepetitionType_OPTIONAL)
+		if f.Type == typed.String {
+			element.ConvertedType = parquetgen.ConvertedTypePtr(parquetgen.ConvertedType_UTF8)
+		}
+		elements = append(elements, element)
+	}
+
+	w.footer = parquetgen.NewFileMetaData()
+	w.footer.Version = 1
+	w.footer.Schema = elements
+	return w.write([]byte(magic))
+}
+
+// statistics - returns the min/max statistics of the non-null values
+// of a column, used by readers to skip row groups.
+func statistics(values interface{}, nulls int64) *parquetgen.Statistics {
+	stats := parquetgen.NewStatistics()
+	stats.NullCount = &nulls
+	switch v := values.(type) {
+	case []int64:
+		for i, x := range v {
+			if i == 0 || x < int64(binary.LittleEndian.Uint64(stats.MinValue)) {
+				stats.MinValue = encoding.PlainEncode([]int64{x}, parquetgen.Type_INT64)
+			}
+			if i == 0 || x > int64(binary.LittleEndian.Uint64(stats.MaxValue)) {
+				stats.MaxValue = encoding.PlainEncode([]int64{x}, parquetgen.Type_INT64)
+			}
+		}
+	case []float64:
+		for _, x := range v {
+			if math.IsNaN(x) {
+				// NaN is unordered, no statistics can be given.
+				stats.MinValue, stats.MaxValue = nil, nil
+				break
+			}
+			if stats.MinValue == nil || x < math.Float64frombits(binary.LittleEndian.Uint64(stats.MinValue)) {
+				stats.MinValue = encoding.PlainEncode([]float64{x}, parquetgen.Type_DOUBLE)
+			}
+			if stats.MaxValue == nil || x > math.Float64frombits(binary.LittleEndian.Uint64(stats.MaxValue)) {
+				stats.MaxValue = encoding.PlainEncode([]float64{x}, parquetgen.Type_DOUBLE)
+			}
+		}
+	case [][]byte:
+		for i, x := range v {
+			if i == 0 || bytes.Compare(x, stats.MinValue) < 0 {
+				stats.MinValue = x
+			}
+			if i == 0 || bytes.Compare(x, stats.MaxValue) > 0 {
+				stats.MaxValue = x
+			}
+		}
+	}
+	return stats
+}
+
+// writeColumn - writes the values of a column of the current row
+// group as a single data page.
+func (w *Writer) writeColumn(i int, f typed.Field) (*parquetgen.ColumnChunk, error) {
+	var (
+		levels = make([]int64, len(w.rows))
+		bools  []bool
+		ints   []int64
+		floats []float64
+		strs   [][]byte
+	)
+	for j, row := range w.rows {
+		switch v := row[i].(type) {
+		case nil:
+			continue
+		case bool:
+			bools = append(bools, v)
+		case int64:
+			ints = append(ints, v)
+		case float64:
+			floats = append(floats, v)
+		case string:
+			strs = append(strs, []byte(v))
+		}
+		levels[j] = 1
+	}
+
+	var values interface{}
+	switch f.Type {
+	case typed.Bool:
+		values = bools
+	case typed.Int:
+		values = ints
+	case typed.Float:
+		values = floats
+	default:
+		values = strs
+	}
+
+	data := encoding.RLEBitPackedHybridEncode(levels, 1, parquetgen.Type_INT64)
+	data = append(data, encoding.PlainEncode(values, parquetType(f.Type))...)
+
+	header := parquetgen.NewPageHeader()
+	header.Type = parquetgen.PageType_DATA_PAGE
+	header.UncompressedPageSize = int32(len(data))
+	header.CompressedPageSize = int32(len(data))
+	header.DataPageHeader = parquetgen.NewDataPageHeader()
+	header.DataPageHeader.NumValues = int32(len(w.rows))
+	header.DataPageHeader.Encoding = parquetgen.Encoding_PLAIN
+	header.DataPageHeader.DefinitionLevelEncoding = parquetgen.Encoding_RLE
+	header.DataPageHeader.RepetitionLevelEncoding = parquetgen.Encoding_RLE
+	headerData, err := serialize(header)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := parquetgen.NewColumnMetaData()
+	meta.Type = parquetType(f.Type)
+	meta.Encodings = []parquetgen.Encoding{parquetgen.Encoding_PLAIN, parquetgen.Encoding_RLE}
+	meta.PathInSchema = []string{typed.FieldName(f.Name)}
+	meta.Codec = parquetgen.CompressionCodec_UNCOMPRESSED
+	meta.NumValues = int64(len(w.rows))
+	meta.TotalUncompressedSize = int64(len(headerData) + len(data))
+	meta.TotalCompressedSize = meta.TotalUncompressedSize
+	meta.DataPageOffset = w.offset
+	meta.Statistics = statistics(values, int64(len(w.rows)-len(bools)-len(ints)-len(floats)-len(strs)))
+
+	chunk := parquetgen.NewColumnChunk()
+	chunk.FileOffset = w.offset
+	chunk.MetaData = meta
+
+	if err = w.write(headerData); err != nil {
+		return nil, err
+	}
+	return chunk, w.write(data)
+}
+
+// flush - writes the current row group.
+func (w *Writer) flush() error {
+	if len(w.rows) == 0 {
+		return nil
+	}
+
+	rowGroup := parquetgen.NewRowGroup()
+	rowGroup.NumRows = int64(len(w.rows))
+	for i, f := range w.schema.Fields {
+		chunk, err := w.writeColumn(i, f)
+		if err != nil {
+			return err
+		}
+		rowGroup.Columns = append(rowGroup.Columns, chunk)
+		rowGroup.TotalByteSize += chunk.MetaData.TotalUncompressedSize
+	}
+
+	w.footer.RowGroups = append(w.footer.RowGroups, rowGroup)
+	w.footer.NumRows += rowGroup.NumRows
+	w.rows = w.rows[:0]
+	return nil
+}
+
+// Write - writes records, the schema of the file is inferred from
+// the records passed to the first call.
+func (w *Writer) Write(records []*typed.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if w.footer == nil {
+		if err := w.init(records); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range records {
+		values, err := w.schema.Values(r)
+		if err != nil {
+			return err
+		}
+		w.rows = append(w.rows, values)
+		if len(w.rows) == rowGroupRows {
+			if err = w.flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Close - writes the remaining rows and the file footer. Nothing
+// is written if no records were written.
+func (w *Writer) Close() error {
+	if w.footer == nil {
+		return nil
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	footer, err := serialize(w.footer)
+	if err != nil {
+		return err
+	}
+	if err = w.write(footer); err != nil {
+		return err
+	}
+	footerLen := make([]byte, 4)
+	binary.LittleEndian.PutUint32(footerLen, uint32(len(footer)))
+	if err = w.write(footerLen); err != nil {
+		return err
+	}
+	return w.write([]byte(magic))
+}
+
+// NewWriter - creates new Parquet writer writing to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package parquet
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	jsonfmt "github.com/minio/minio/internal/s3select/json"
+	"github.com/minio/minio/internal/s3select/typed"
+)
+
+func newBytesReader(t *testing.T, data []byte) *Reader {
+	r, err := NewReader(func(offset, length int64) (io.ReadCloser, error) {
+		if offset < 0 {
+			offset = int64(len(data)) + offset
+		}
+		if length < 0 {
+			length = int64(len(data)) - offset
+		}
+		return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+	}, &ReaderArgs{}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestWriter(t *testing.T) {
+	records := []*typed.Record{
+		{Columns: []typed.Column{{Name: "name", Value: "alice"}, {Name: "age", Value: int64(30)}, {Name: "score", Value: 1.5}, {Name: "active", Value: true}}},
+		{Columns: []typed.Column{{Name: "name", Value: "bob"}, {Name: "age", Value: nil}, {Name: "score", Value: int64(2)}, {Name: "active", Value: false}}},
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.Write(records[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(records[1:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := newBytesReader(t, buf.Bytes())
+	defer r.Close()
+
+	expected := []map[string]interface{}{
+		{"name": "alice", "age": int64(30), "score": 1.5, "active": true},
+		{"name": "bob", "age": nil, "score": 2.0, "active": false},
+	}
+	for i, want := range expected {
+		rec, err := r.Read(nil)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		got := make(map[string]interface{})
+		for _, kv := range rec.(*jsonfmt.Record).KVS {
+			got[kv.Key] = kv.Value
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+	if _, err := r.Read(nil); err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestWriterSchemaMismatch(t *testing.T) {
+	w := NewWriter(ioutil.Discard)
+	if err := w.Write([]*typed.Record{{Columns: []typed.Column{{Name: "flag", Value: true}}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write([]*typed.Record{{Columns: []typed.Column{{Name: "flag", Value: "yes"}}}}); err == nil {
+		t.Fatal("expected error writing a string into a boolean column")
+	}
+}
+
+func TestWriterRowGroups(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	n := 2*rowGroupRows + 1
+	for i := 0; i < n; i++ {
+		if err := w.Write([]*typed.Record{{Columns: []typed.Column{{Name: "id", Value: int64(i)}}}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := newBytesReader(t, buf.Bytes())
+	defer r.Close()
+	for i := 0; i < n; i++ {
+		rec, err := r.Read(nil)
+		if err != nil {
+			t.Fatalf("row %d: %v", i, err)
+		}
+		if v := rec.(*jsonfmt.Record).KVS[0].Value; v != int64(i) {
+			t.Fatalf("row %d: expected %d, got %v", i, i, v)
+		}
+	}
+	if _, err := r.Read(nil); err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestWriterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.Write(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %d bytes", buf.Len())
+	}
+}
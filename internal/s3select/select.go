@@ -34,11 +34,14 @@ import (
 	"github.com/klauspost/compress/s2"
 	"github.com/klauspost/compress/zstd"
 	gzip "github.com/klauspost/pgzip"
+	"github.com/minio/minio/internal/s3select/avro"
 	"github.com/minio/minio/internal/s3select/csv"
 	"github.com/minio/minio/internal/s3select/json"
+	"github.com/minio/minio/internal/s3select/orc"
 	"github.com/minio/minio/internal/s3select/parquet"
 	"github.com/minio/minio/internal/s3select/simdj"
 	"github.com/minio/minio/internal/s3select/sql"
+	"github.com/minio/minio/internal/s3select/typed"
 	"github.com/minio/simdjson-go"
 	"github.com/pierrec/lz4"
 )
@@ -50,10 +53,19 @@ type recordReader interface {
 	Close() error
 }
 
+// recordWriter - writes records of binary output formats, which
+// need a schema and can not be marshaled one record at a time.
+type recordWriter interface {
+	Write(records []*typed.Record) error
+	Close() error
+}
+
 const (
 	csvFormat     = "csv"
 	jsonFormat    = "json"
 	parquetFormat = "parquet"
+	orcFormat     = "orc"
+	avroFormat    = "avro"
 )
 
 // CompressionType - represents value inside <CompressionType/> in request XML.
@@ -74,6 +86,10 @@ const (
 	maxRecordSize = 1 << 20 // 1 MiB
 )
 
+// maxDecompressedORCSize - compressed ORC objects are decompressed in
+// memory, objects larger than this once decompressed are rejected.
+var maxDecompressedORCSize int64 = 256 << 20 // 256 MiB
+
 var bufPool = sync.Pool{
 	New: func() interface{} {
 		// make a buffer with a reasonable capacity.
@@ -117,6 +133,8 @@ type InputSerialization struct {
 	CSVArgs         csv.ReaderArgs     `xml:"CSV"`
 	JSONArgs        json.ReaderArgs    `xml:"JSON"`
 	ParquetArgs     parquet.ReaderArgs `xml:"Parquet"`
+	ORCArgs         orc.ReaderArgs     `xml:"ORC"`
+	AvroArgs        avro.ReaderArgs    `xml:"Avro"`
 	unmarshaled     bool
 	format          string
 }
@@ -157,6 +175,14 @@ func (input *InputSerialization) UnmarshalXML(d *xml.Decoder, start xml.StartEle
 		parsedInput.format = parquetFormat
 		found++
 	}
+	if !parsedInput.ORCArgs.IsEmpty() {
+		parsedInput.format = orcFormat
+		found++
+	}
+	if !parsedInput.AvroArgs.IsEmpty() {
+		parsedInput.format = avroFormat
+		found++
+	}
 
 	if found != 1 {
 		return errInvalidDataSource(nil)
@@ -169,8 +195,10 @@ func (input *InputSerialization) UnmarshalXML(d *xml.Decoder, start xml.StartEle
 
 // OutputSerialization - represents elements inside <OutputSerialization/> in request XML.
 type OutputSerialization struct {
-	CSVArgs     csv.WriterArgs  `xml:"CSV"`
-	JSONArgs    json.WriterArgs `xml:"JSON"`
+	CSVArgs     csv.WriterArgs     `xml:"CSV"`
+	JSONArgs    json.WriterArgs    `xml:"JSON"`
+	ParquetArgs parquet.WriterArgs `xml:"Parquet"`
+	AvroArgs    avro.WriterArgs    `xml:"Avro"`
 	unmarshaled bool
 	format      string
 }
@@ -198,8 +226,16 @@ func (output *OutputSerialization) UnmarshalXML(d *xml.Decoder, start xml.StartE
 		parsedOutput.format = jsonFormat
 		found++
 	}
+	if !parsedOutput.ParquetArgs.IsEmpty() {
+		parsedOutput.format = parquetFormat
+		found++
+	}
+	if !parsedOutput.AvroArgs.IsEmpty() {
+		parsedOutput.format = avroFormat
+		found++
+	}
 	if found != 1 {
-		return errObjectSerializationConflict(fmt.Errorf("either CSV or JSON should be present in OutputSerialization"))
+		return errObjectSerializationConflict(fmt.Errorf("one of CSV, JSON, Parquet or Avro should be present in OutputSerialization"))
 	}
 
 	*output = OutputSerialization(parsedOutput)
@@ -284,11 +320,26 @@ func (s3Select *S3Select) outputRecord() sql.Record {
 		return csv.NewRecord()
 	case jsonFormat:
 		return json.NewRecord(sql.SelectFmtJSON)
+	case parquetFormat, avroFormat:
+		return typed.NewRecord()
 	}
 
 	panic(fmt.Errorf("unknown output format '%v'", s3Select.Output.format))
 }
 
+// recordWriter - returns the writer of binary output formats, nil
+// for formats marshaled one record at a time.
+func (s3Select *S3Select) recordWriter(w io.Writer) recordWriter {
+	switch s3Select.Output.format {
+	case parquetFormat:
+		return parquet.NewWriter(w)
+	case avroFormat:
+		return avro.NewWriter(w)
+	}
+
+	return nil
+}
+
 func (s3Select *S3Select) getProgress() (bytesScanned, bytesProcessed int64) {
 	if s3Select.progressReader != nil {
 		return s3Select.progressReader.Stats()
@@ -298,7 +349,8 @@ func (s3Select *S3Select) getProgress() (bytesScanned, bytesProcessed int64) {
 }
 
 // Open - opens S3 object by using callback for SQL selection query.
-// Currently CSV, JSON and Apache Parquet formats are supported.
+// Currently CSV, JSON, Apache Parquet, Apache ORC and Apache Avro
+// formats are supported.
 func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadCloser, error)) error {
 	switch s3Select.Input.format {
 	case csvFormat:
@@ -365,8 +417,67 @@ func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadClos
 			return errors.New("parquet format parsing not enabled on server")
 		}
 		var err error
-		s3Select.recordReader, err = parquet.NewReader(getReader, &s3Select.Input.ParquetArgs)
+		s3Select.recordReader, err = parquet.NewReader(getReader, &s3Select.Input.ParquetArgs, s3Select.statement.Columns(), s3Select.statement.Predicates())
 		return err
+	case orcFormat:
+		if s3Select.Input.CompressionType != noneType {
+			// ORC needs random access to the object, compressed
+			// objects are decompressed in memory.
+			rc, err := getReader(0, -1)
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+
+			s3Select.progressReader, err = newProgressReader(rc, s3Select.Input.CompressionType)
+			if err != nil {
+				return err
+			}
+			data, err := ioutil.ReadAll(io.LimitReader(s3Select.progressReader, maxDecompressedORCSize+1))
+			if err != nil {
+				return err
+			}
+			if int64(len(data)) > maxDecompressedORCSize {
+				return errDecompressedObjectTooLarge(fmt.Errorf("decompressed ORC object exceeds %d bytes", maxDecompressedORCSize))
+			}
+			getReader = func(offset, length int64) (io.ReadCloser, error) {
+				if offset < 0 {
+					offset += int64(len(data))
+					if offset < 0 {
+						offset = 0
+					}
+				}
+				if offset > int64(len(data)) {
+					offset = int64(len(data))
+				}
+				if length < 0 || offset+length > int64(len(data)) {
+					length = int64(len(data)) - offset
+				}
+				return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+			}
+		}
+		var err error
+		s3Select.recordReader, err = orc.NewReader(getReader, &s3Select.Input.ORCArgs, s3Select.statement.Columns())
+		return err
+	case avroFormat:
+		rc, err := getReader(0, -1)
+		if err != nil {
+			return err
+		}
+
+		s3Select.progressReader, err = newProgressReader(rc, s3Select.Input.CompressionType)
+		if err != nil {
+			rc.Close()
+			return err
+		}
+
+		s3Select.recordReader, err = avro.NewReader(s3Select.progressReader, &s3Select.Input.AvroArgs, s3Select.statement.Columns())
+		if err != nil {
+			rc.Close()
+			return err
+		}
+		s3Select.close = rc.Close
+		return nil
 	}
 
 	panic(fmt.Errorf("unknown input format '%v'", s3Select.Input.format))
@@ -434,16 +545,64 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 	}
 	writer := newMessageWriter(w, getProgressFunc)
 
-	var outputQueue []sql.Record
+	outputQueue := make([]sql.Record, 0, 100)
+	var err error
 
-	// Create queue based on the type.
-	if s3Select.statement.IsAggregated() {
-		outputQueue = make([]sql.Record, 0, 1)
-	} else {
-		outputQueue = make([]sql.Record, 0, 100)
+	// Binary formats are written to output, which is sent as is
+	// in the record messages.
+	var output bytes.Buffer
+	outputWriter := s3Select.recordWriter(&output)
+	sendOutput := func() bool {
+		buf := bufPool.Get().(*bytes.Buffer)
+		buf.Reset()
+		buf.Write(output.Bytes())
+		output.Reset()
+
+		if err = writer.SendRecord(buf); err != nil {
+			// FIXME: log this error.
+			err = nil
+			bufPool.Put(buf)
+			return false
+		}
+		return true
 	}
-	var err error
+	// closeOutput - writes the trailer of binary formats.
+	closeOutput := func() bool {
+		if outputWriter == nil {
+			return true
+		}
+		if err = outputWriter.Close(); err != nil {
+			return false
+		}
+		if output.Len() == 0 {
+			return true
+		}
+		return sendOutput()
+	}
+
 	sendRecord := func() bool {
+		if outputWriter != nil {
+			records := make([]*typed.Record, 0, len(outputQueue))
+			for _, outputRecord := range outputQueue {
+				if outputRecord == nil {
+					continue
+				}
+				var r *typed.Record
+				if r, err = typed.FromRecord(outputRecord); err != nil {
+					return false
+				}
+				records = append(records, r)
+			}
+			if err = outputWriter.Write(records); err != nil {
+				return false
+			}
+			outputQueue = outputQueue[:0]
+			if output.Len() == 0 {
+				return true
+			}
+			return sendOutput()
+		}
+
 		buf := bufPool.Get().(*bytes.Buffer)
 		buf.Reset()
 
@@ -477,7 +636,7 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 OuterLoop:
 	for {
 		if s3Select.statement.LimitReached() {
-			if !sendRecord() {
+			if !sendRecord() || !closeOutput() {
 				break
 			}
 			if err = writer.Finish(s3Select.getProgress()); err != nil {
@@ -492,15 +651,20 @@ OuterLoop:
 				break
 			}
 
-			if s3Select.statement.IsAggregated() {
-				outputRecord := s3Select.outputRecord()
-				if err = s3Select.statement.AggregateResult(outputRecord); err != nil {
+			if s3Select.statement.IsAggregated() || s3Select.statement.IsOrdered() {
+				var results []sql.Record
+				if results, err = s3Select.statement.Results(s3Select.outputRecord); err != nil {
 					break
 				}
-				outputQueue = append(outputQueue, outputRecord)
+				for _, outputRecord := range results {
+					outputQueue = append(outputQueue, outputRecord)
+					if len(outputQueue) == cap(outputQueue) && !sendRecord() {
+						break OuterLoop
+					}
+				}
 			}
 
-			if !sendRecord() {
+			if !sendRecord() || !closeOutput() {
 				break
 			}
 
@@ -521,6 +685,10 @@ OuterLoop:
 				if err = s3Select.statement.AggregateRow(*inputRecord); err != nil {
 					break OuterLoop
 				}
+			} else if s3Select.statement.IsOrdered() {
+				if err = s3Select.statement.OrderRow(*inputRecord, s3Select.outputRecord()); err != nil {
+					break OuterLoop
+				}
 			} else {
 				var outputRecord sql.Record
 				// We will attempt to reuse the records in the table.
@@ -549,7 +717,7 @@ OuterLoop:
 
 				outputQueue[len(outputQueue)-1] = outputRecord
 				if s3Select.statement.LimitReached() {
-					if !sendRecord() {
+					if !sendRecord() || !closeOutput() {
 						break
 					}
 					if err = writer.Finish(s3Select.getProgress()); err != nil {
@@ -571,10 +739,20 @@ OuterLoop:
 	}
 
 	if err != nil {
+		if serr, ok := err.(SelectError); ok {
+			_ = writer.FinishWithError(serr.ErrorCode(), serr.ErrorMessage())
+			return
+		}
 		_ = writer.FinishWithError("InternalError", err.Error())
 	}
 }
 
+// SetMemoryLimit - sets the limit of the memory held to group and sort
+// the query result, sql.DefaultMemoryLimit is used if not set.
+func (s3Select *S3Select) SetMemoryLimit(limit int64) {
+	s3Select.statement.SetMemoryLimit(limit)
+}
+
 // Close - closes opened S3 object.
 func (s3Select *S3Select) Close() error {
 	return s3Select.recordReader.Close()
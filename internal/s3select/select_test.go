@@ -30,7 +30,12 @@ import (
 	"testing"
 
 	"github.com/klauspost/cpuid/v2"
+	gzip "github.com/klauspost/pgzip"
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio/internal/s3select/avro"
+	"github.com/minio/minio/internal/s3select/json"
+	"github.com/minio/minio/internal/s3select/parquet"
+	"github.com/minio/minio/internal/s3select/typed"
 	"github.com/minio/simdjson-go"
 )
 
@@ -682,6 +687,151 @@ func TestCSVQueries(t *testing.T) {
 	}
 }
 
+func TestGroupByQueries(t *testing.T) {
+	input := `name,dept,salary,city
+alice,eng,120,sf
+bob,eng,100,nyc
+carol,sales,90,sf
+dave,sales,80,sf
+erin,eng,110,sf
+frank,hr,70,nyc
+`
+
+	var testTable = []struct {
+		name        string
+		query       string
+		memoryLimit int64
+		wantResult  string
+		wantErr     string
+	}{
+		{
+			name:       "group-by",
+			query:      `SELECT s.dept, COUNT(*) AS n, SUM(s.salary) AS total FROM S3Object s GROUP BY s.dept`,
+			wantResult: "eng,3,330\nsales,2,170\nhr,1,70",
+		},
+		{
+			name:       "group-by-several-keys",
+			query:      `SELECT s.dept, s.city, COUNT(*) FROM S3Object s GROUP BY s.dept, s.city ORDER BY s.dept, s.city`,
+			wantResult: "eng,nyc,1\neng,sf,2\nhr,nyc,1\nsales,sf,2",
+		},
+		{
+			name:       "having",
+			query:      `SELECT s.dept, AVG(s.salary) AS a FROM S3Object s GROUP BY s.dept HAVING COUNT(*) > 1 AND AVG(s.salary) > 90`,
+			wantResult: "eng,110",
+		},
+		{
+			name:       "order-by-alias-desc-limit",
+			query:      `SELECT s.dept, MAX(s.salary) AS m FROM S3Object s GROUP BY s.dept ORDER BY m DESC LIMIT 2`,
+			wantResult: "eng,120\nsales,90",
+		},
+		{
+			name:       "order-by-aggregate",
+			query:      `SELECT s.dept FROM S3Object s GROUP BY s.dept ORDER BY MIN(s.salary)`,
+			wantResult: "hr\nsales\neng",
+		},
+		{
+			name:       "order-by-rows",
+			query:      `SELECT s.name FROM S3Object s WHERE s.city = 'sf' ORDER BY CAST(s.salary AS INT) DESC`,
+			wantResult: "alice\nerin\ncarol\ndave",
+		},
+		{
+			name:       "order-by-rows-limit",
+			query:      `SELECT * FROM S3Object s ORDER BY s.name DESC LIMIT 2`,
+			wantResult: "frank,hr,70,nyc\nerin,eng,110,sf",
+		},
+		{
+			name:       "count-distinct",
+			query:      `SELECT COUNT(DISTINCT s.city), COUNT(DISTINCT s.dept), COUNT(s.city) FROM S3Object s`,
+			wantResult: "2,3,6",
+		},
+		{
+			name:       "count-distinct-group-by",
+			query:      `SELECT s.city, COUNT(DISTINCT s.dept) FROM S3Object s GROUP BY s.city ORDER BY s.city`,
+			wantResult: "nyc,2\nsf,2",
+		},
+		{
+			name:       "aggregate-having-false",
+			query:      `SELECT COUNT(*) FROM S3Object s HAVING COUNT(*) > 10`,
+			wantResult: "",
+		},
+		{
+			name:        "group-by-over-memory-limit",
+			query:       `SELECT s.name, COUNT(*) FROM S3Object s GROUP BY s.name`,
+			memoryLimit: 512,
+			wantErr:     "OverMaxQueryMemory",
+		},
+		{
+			name:        "order-by-over-memory-limit",
+			query:       `SELECT * FROM S3Object s ORDER BY s.name`,
+			memoryLimit: 256,
+			wantErr:     "OverMaxQueryMemory",
+		},
+	}
+
+	defRequest := `<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>%s</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        <CSV>
+        	<FileHeaderInfo>USE</FileHeaderInfo>
+        </CSV>
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+    <RequestProgress>
+        <Enabled>FALSE</Enabled>
+    </RequestProgress>
+</SelectObjectContentRequest>`
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			s3Select, err := NewS3Select(bytes.NewReader([]byte(fmt.Sprintf(defRequest, testCase.query))))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if testCase.memoryLimit > 0 {
+				s3Select.SetMemoryLimit(testCase.memoryLimit)
+			}
+
+			if err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+				return ioutil.NopCloser(bytes.NewBufferString(input)), nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			w := &testResponseWriter{}
+			s3Select.Evaluate(w)
+			s3Select.Close()
+			resp := http.Response{
+				StatusCode:    http.StatusOK,
+				Body:          ioutil.NopCloser(bytes.NewReader(w.response)),
+				ContentLength: int64(len(w.response)),
+			}
+			res, err := minio.NewSelectResults(&resp, "testbucket")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(res)
+			if testCase.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.wantErr) {
+					t.Fatalf("expected error %s, got %v", testCase.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotS := strings.TrimSpace(string(got)); gotS != testCase.wantResult {
+				t.Errorf("received response does not match with expected reply. Query: %s\ngot: %s\nwant:%s", testCase.query, gotS, testCase.wantResult)
+			}
+		})
+	}
+}
+
 func TestCSVQueries2(t *testing.T) {
 	input := `id,time,num,num2,text
 1,2010-01-01T,7867786,4565.908123,"a text, with comma"
@@ -1230,6 +1380,113 @@ func TestJSONInput(t *testing.T) {
 	}
 }
 
+func TestBinaryOutput(t *testing.T) {
+	var jsonData = []byte(`{"three":true,"two":"foo","one":-1}
+{"three":false,"two":"bar","one":null}
+{"three":true,"two":"baz","one":2.5}
+`)
+
+	evaluate := func(t *testing.T, format string) []byte {
+		requestXML := []byte(`
+<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT one, two, three from S3Object</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        <JSON>
+            <Type>DOCUMENT</Type>
+        </JSON>
+    </InputSerialization>
+    <OutputSerialization>
+        <` + format + `>
+        </` + format + `>
+    </OutputSerialization>
+    <RequestProgress>
+        <Enabled>FALSE</Enabled>
+    </RequestProgress>
+</SelectObjectContentRequest>
+`)
+		s3Select, err := NewS3Select(bytes.NewReader(requestXML))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(jsonData)), nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		w := &testResponseWriter{}
+		s3Select.Evaluate(w)
+		s3Select.Close()
+
+		resp := http.Response{
+			StatusCode:    http.StatusOK,
+			Body:          ioutil.NopCloser(bytes.NewReader(w.response)),
+			ContentLength: int64(len(w.response)),
+		}
+		res, err := minio.NewSelectResults(&resp, "testbucket")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	t.Run("Parquet", func(t *testing.T) {
+		data := evaluate(t, "Parquet")
+		r, err := parquet.NewReader(func(offset, length int64) (io.ReadCloser, error) {
+			if offset < 0 {
+				offset = int64(len(data)) + offset
+			}
+			if length < 0 {
+				length = int64(len(data)) - offset
+			}
+			return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+		}, &parquet.ReaderArgs{}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Close()
+
+		expected := []map[string]interface{}{
+			{"one": -1.0, "two": "foo", "three": true},
+			{"one": nil, "two": "bar", "three": false},
+			{"one": 2.5, "two": "baz", "three": true},
+		}
+		for i, want := range expected {
+			rec, err := r.Read(nil)
+			if err != nil {
+				t.Fatalf("record %d: %v", i, err)
+			}
+			got := make(map[string]interface{})
+			for _, kv := range rec.(*json.Record).KVS {
+				got[kv.Key] = kv.Value
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("record %d: expected %v, got %v", i, want, got)
+			}
+		}
+		if _, err = r.Read(nil); err != io.EOF {
+			t.Errorf("expected EOF, got %v", err)
+		}
+	})
+
+	t.Run("Avro", func(t *testing.T) {
+		data := evaluate(t, "Avro")
+		if !bytes.HasPrefix(data, []byte("Obj\x01")) {
+			t.Fatalf("expected an Avro object container file, got %q", data)
+		}
+		if !bytes.Contains(data, []byte(`"name":"one","type":["null","double"]`)) {
+			t.Errorf("expected inferred schema in header, got %q", data)
+		}
+	})
+}
+
 func TestParquetInput(t *testing.T) {
 	os.Setenv("MINIO_API_SELECT_PARQUET", "on")
 	defer os.Setenv("MINIO_API_SELECT_PARQUET", "off")
@@ -1347,6 +1604,190 @@ func TestParquetInput(t *testing.T) {
 	}
 }
 
+// selectDecoded - evaluates the request and returns the decoded records.
+func selectDecoded(t *testing.T, requestXML []byte, getReader func(offset, length int64) (io.ReadCloser, error)) string {
+	s3Select, err := NewS3Select(bytes.NewReader(requestXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s3Select.Open(getReader); err != nil {
+		t.Fatal(err)
+	}
+
+	w := &testResponseWriter{}
+	s3Select.Evaluate(w)
+	s3Select.Close()
+
+	resp := http.Response{
+		StatusCode:    http.StatusOK,
+		Body:          ioutil.NopCloser(bytes.NewReader(w.response)),
+		ContentLength: int64(len(w.response)),
+	}
+	res, err := minio.NewSelectResults(&resp, "testbucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(got)
+}
+
+func TestORCInput(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/testdata.orc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	gw.Write(data)
+	gw.Close()
+
+	testCases := []struct {
+		expression  string
+		compression string
+		expected    string
+	}{
+		{"SELECT * FROM S3Object", "NONE", "1,a,1.5,true,\"{\"\"x\"\":1,\"\"y\"\":-2}\"\n2,b,,false,\n3,c,2.5,true,\"{\"\"x\"\":3,\"\"y\"\":4}\"\n"},
+		{"SELECT s.name, s.point.x FROM S3Object s WHERE s.flag = true", "NONE", "a,1\nc,3\n"},
+		{"SELECT COUNT(*) FROM S3Object", "NONE", "3\n"},
+		{"SELECT SUM(score) FROM S3Object", "GZIP", "4\n"},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			object := data
+			if testCase.compression == "GZIP" {
+				object = gzipped.Bytes()
+			}
+			getReader := func(offset, length int64) (io.ReadCloser, error) {
+				if offset < 0 {
+					offset = int64(len(object)) + offset
+					if offset < 0 {
+						offset = 0
+					}
+				}
+				if length < 0 {
+					length = int64(len(object)) - offset
+				}
+				return ioutil.NopCloser(bytes.NewReader(object[offset : offset+length])), nil
+			}
+
+			requestXML := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>` + testCase.expression + `</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>` + testCase.compression + `</CompressionType>
+        <ORC>
+        </ORC>
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+</SelectObjectContentRequest>`)
+			if got := selectDecoded(t, requestXML, getReader); got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestORCInputTooLarge(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/testdata.orc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	gw.Write(data)
+	gw.Close()
+
+	defer func(size int64) { maxDecompressedORCSize = size }(maxDecompressedORCSize)
+	maxDecompressedORCSize = int64(len(data)) - 1
+
+	requestXML := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT * FROM S3Object</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>GZIP</CompressionType>
+        <ORC>
+        </ORC>
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+</SelectObjectContentRequest>`)
+	s3Select, err := NewS3Select(bytes.NewReader(requestXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(gzipped.Bytes())), nil
+	})
+	if serr, ok := err.(*s3Error); !ok || serr.ErrorCode() != "EntityTooLarge" {
+		t.Fatalf("expected EntityTooLarge, got %v", err)
+	}
+}
+
+func TestAvroInput(t *testing.T) {
+	var data bytes.Buffer
+	w := avro.NewWriter(&data)
+	if err := w.Write([]*typed.Record{
+		{Columns: []typed.Column{{Name: "name", Value: "alice"}, {Name: "age", Value: int64(30)}}},
+		{Columns: []typed.Column{{Name: "name", Value: "bob"}, {Name: "age", Value: int64(25)}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	gw.Write(data.Bytes())
+	gw.Close()
+
+	testCases := []struct {
+		expression  string
+		compression string
+		object      []byte
+		expected    string
+	}{
+		{"SELECT * FROM S3Object", "NONE", data.Bytes(), "alice,30\nbob,25\n"},
+		{"SELECT name FROM S3Object WHERE age > 26", "NONE", data.Bytes(), "alice\n"},
+		{"SELECT AVG(age) FROM S3Object", "GZIP", gzipped.Bytes(), "27.5\n"},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			requestXML := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>` + testCase.expression + `</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>` + testCase.compression + `</CompressionType>
+        <Avro>
+        </Avro>
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+</SelectObjectContentRequest>`)
+			got := selectDecoded(t, requestXML, func(offset, length int64) (io.ReadCloser, error) {
+				return ioutil.NopCloser(bytes.NewReader(testCase.object)), nil
+			})
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
 func TestParquetInputSchema(t *testing.T) {
 	os.Setenv("MINIO_API_SELECT_PARQUET", "on")
 	defer os.Setenv("MINIO_API_SELECT_PARQUET", "off")
@@ -45,6 +45,10 @@ type aggVal struct {
 
 	// Stores if at least one record has been seen
 	seen bool
+
+	// Values seen by COUNT(DISTINCT) and their total size.
+	distinct     map[string]struct{}
+	distinctSize int64
 }
 
 func newAggVal(fn FuncName) *aggVal {
@@ -124,6 +128,17 @@ func (e *FuncExpr) evalAggregationNode(r Record, tableAlias string) error {
 
 	switch funcName {
 	case aggFnCount:
+		if e.Count.Distinct {
+			key := valueKey(argVal)
+			if _, ok := e.aggregate.distinct[key]; ok {
+				return nil
+			}
+			if e.aggregate.distinct == nil {
+				e.aggregate.distinct = make(map[string]struct{})
+			}
+			e.aggregate.distinct[key] = struct{}{}
+			e.aggregate.distinctSize += int64(len(key)) + mapEntryOverhead
+		}
 		// For all non-null values, the count is incremented.
 		e.aggregate.runningCount++
 
@@ -301,8 +316,11 @@ func (e *FuncExpr) getAggregate() (*Value, error) {
 			// No rows were seen by AVG.
 			return FromNull(), nil
 		}
-		err := e.aggregate.runningSum.arithOp(opDivide, FromInt(e.aggregate.runningCount))
-		return e.aggregate.runningSum, err
+		// Divide a copy, the result may be evaluated more than
+		// once by HAVING and ORDER BY.
+		avg := *e.aggregate.runningSum
+		err := avg.arithOp(opDivide, FromInt(e.aggregate.runningCount))
+		return &avg, err
 
 	case aggFnMin:
 		if !e.aggregate.seen {
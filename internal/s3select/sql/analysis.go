@@ -184,6 +184,12 @@ func (e *PrimaryTerm) analyze(s *Select) (result qProp) {
 				return
 			}
 		}
+		if len(s.GroupBy) > 0 {
+			// Keypaths are evaluated once per group when grouping,
+			// so they may be combined with aggregations.
+			result = qProp{}
+			return
+		}
 		result = qProp{isRowFunc: true}
 
 	case e.ListExpr != nil:
@@ -228,6 +234,9 @@ func (e *FuncExpr) analyze(s *Select) (result qProp) {
 
 		var exprA qProp
 		if funcName == aggFnCount {
+			if e.Count.Distinct && (e.Count.StarArg || e.Count.ExprArg == nil) {
+				return qProp{err: errors.New("COUNT(DISTINCT) requires an expression argument")}
+			}
 			if e.Count.StarArg {
 				return qProp{isAggregation: true}
 			}
@@ -322,3 +331,134 @@ func (e *FuncExpr) analyze(s *Select) (result qProp) {
 	// TODO: implement other functions
 	return qProp{err: errFunctionNotImplemented}
 }
+
+// Predicate - is a comparison of a top level column with a literal
+// value, which holds for every row passing the WHERE clause. Readers
+// use predicates to skip data which can not match.
+type Predicate struct {
+	Column string
+	// One of "=", "<", "<=", ">" and ">=".
+	Operator string
+	Value    *Value
+}
+
+// flipOperator - returns the operator for swapped operands.
+func flipOperator(op string) string {
+	switch op {
+	case opLt:
+		return opGt
+	case opLte:
+		return opGte
+	case opGt:
+		return opLt
+	case opGte:
+		return opLte
+	}
+	return op
+}
+
+// predicateColumn - returns the top level column an operand consists of.
+func (e *Operand) predicateColumn(tableAlias string) (string, bool) {
+	if len(e.Right) > 0 || len(e.Left.Right) > 0 || e.Left.Left.Primary == nil {
+		return "", false
+	}
+	jpath := e.Left.Left.Primary.JPathExpr
+	if jpath == nil {
+		return "", false
+	}
+	pathExpr := jpath.StripTableAlias(tableAlias)
+	if len(pathExpr) != 1 || pathExpr[0].Key == nil {
+		return "", false
+	}
+	return pathExpr[0].Key.keyString(), true
+}
+
+// predicateValue - returns the value of a literal operand.
+func (e *Operand) predicateValue() (*Value, bool) {
+	if len(e.Right) > 0 || len(e.Left.Right) > 0 {
+		return nil, false
+	}
+	term, negated := e.Left.Left.Primary, false
+	if e.Left.Left.Negated != nil {
+		term, negated = e.Left.Left.Negated.Term, true
+	}
+	if term == nil || term.Value == nil || term.Value.Null {
+		return nil, false
+	}
+	v, err := term.Value.evalNode(nil)
+	if err != nil {
+		return nil, false
+	}
+	if negated {
+		if !v.isNumeric() {
+			return nil, false
+		}
+		v.negate()
+	}
+	return v, true
+}
+
+// comparison - returns the predicate of a `column op literal` or
+// `literal op column` comparison.
+func comparison(left *Operand, op string, right *Operand, tableAlias string) (Predicate, bool) {
+	switch op {
+	case opEq, opLt, opLte, opGt, opGte:
+	default:
+		return Predicate{}, false
+	}
+	if column, ok := left.predicateColumn(tableAlias); ok {
+		if v, ok := right.predicateValue(); ok {
+			return Predicate{Column: column, Operator: op, Value: v}, true
+		}
+	}
+	if column, ok := right.predicateColumn(tableAlias); ok {
+		if v, ok := left.predicateValue(); ok {
+			return Predicate{Column: column, Operator: flipOperator(op), Value: v}, true
+		}
+	}
+	return Predicate{}, false
+}
+
+// predicates - returns the comparisons of columns with literals which
+// are joined by AND at the top level of the expression. Other
+// conditions are ignored, so the predicates are only necessary, not
+// sufficient, for a row to match.
+func (e *Expression) predicates(tableAlias string) (preds []Predicate) {
+	if len(e.And) != 1 {
+		return nil
+	}
+	for _, cond := range e.And[0].Condition {
+		if cond.Operand == nil {
+			continue
+		}
+		operand, rhs := cond.Operand.Operand, cond.Operand.ConditionRHS
+		if rhs == nil {
+			// Parenthesized conditions are parsed as a list of one
+			// element.
+			if len(operand.Right) > 0 || len(operand.Left.Right) > 0 || operand.Left.Left.Primary == nil {
+				continue
+			}
+			switch primary := operand.Left.Left.Primary; {
+			case primary.SubExpression != nil:
+				preds = append(preds, primary.SubExpression.predicates(tableAlias)...)
+			case primary.ListExpr != nil && len(primary.ListExpr.Elements) == 1:
+				preds = append(preds, primary.ListExpr.Elements[0].predicates(tableAlias)...)
+			}
+			continue
+		}
+		switch {
+		case rhs.Compare != nil:
+			if p, ok := comparison(operand, rhs.Compare.Operator, rhs.Compare.Operand, tableAlias); ok {
+				preds = append(preds, p)
+			}
+		case rhs.Between != nil && !rhs.Between.Not:
+			if p, ok := comparison(operand, opGte, rhs.Between.Start, tableAlias); ok {
+				preds = append(preds, p)
+			}
+			if p, ok := comparison(operand, opLte, rhs.Between.End, tableAlias); ok {
+				preds = append(preds, p)
+			}
+		}
+	}
+	return preds
+}
@@ -108,3 +108,11 @@ func errDataSource(err error) *s3Error {
 		cause:      err,
 	}
 }
+
+func errOverMaxMemory(limit int64) *s3Error {
+	return &s3Error{
+		code:       "OverMaxQueryMemory",
+		message:    fmt.Sprintf("The memory needed to group or sort the query result exceeds the limit of %d bytes.", limit),
+		statusCode: 400,
+	}
+}
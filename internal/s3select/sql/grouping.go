@@ -0,0 +1,443 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Grouping and ordering - GROUP BY, HAVING and ORDER BY need all input
+// rows to be processed before the first result row is known, so
+// groups and rows to be sorted are held in memory. Each group keeps
+// its own aggregation state, which is bound to the aggregation
+// functions of the AST while the group is processed, and the first
+// row of the group to evaluate keypaths in the select expressions.
+//
+// The memory held is estimated and the query fails once it exceeds
+// the limit, instead of exhausting the memory of the server.
+
+// DefaultMemoryLimit - is the default limit of the memory held to
+// group and sort the result of a query.
+const DefaultMemoryLimit = 64 << 20
+
+// Estimated overheads of the structures holding groups and rows.
+const (
+	mapEntryOverhead = 48
+	groupOverhead    = 128
+	rowOverhead      = 64
+)
+
+type group struct {
+	// First row of the group, nil without GROUP BY.
+	row  Record
+	aggs []*aggVal
+}
+
+type orderedRow struct {
+	record Record
+	keys   []*Value
+}
+
+// countingWriter - counts the bytes written to it.
+type countingWriter int64
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	*w += countingWriter(len(p))
+	return len(p), nil
+}
+
+// recordSize - returns the estimated memory held by a record.
+func recordSize(r Record) int64 {
+	var w countingWriter
+	if err := r.WriteJSON(&w); err != nil {
+		return rowOverhead
+	}
+	return int64(w) + rowOverhead
+}
+
+// valueKey - returns a string identifying a value, values of different
+// types never have the same key.
+func valueKey(v *Value) string {
+	return v.GetTypeString() + ":" + v.CSVString()
+}
+
+var funcExprType = reflect.TypeOf(&FuncExpr{})
+
+// collectAggregates - returns the aggregation functions of the AST
+// below v.
+func collectAggregates(v reflect.Value) (aggs []*FuncExpr) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type() == funcExprType {
+			switch e := v.Interface().(*FuncExpr); e.getFunctionName() {
+			case aggFnAvg, aggFnCount, aggFnMax, aggFnMin, aggFnSum:
+				// Aggregations can not be nested.
+				return []*FuncExpr{e}
+			}
+		}
+		return collectAggregates(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			aggs = append(aggs, collectAggregates(v.Field(i))...)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			aggs = append(aggs, collectAggregates(v.Index(i))...)
+		}
+	}
+	return aggs
+}
+
+// bareIdentifier - returns the name of an expression consisting of an
+// identifier only.
+func bareIdentifier(e *Expression) (string, bool) {
+	if len(e.And) != 1 || len(e.And[0].Condition) != 1 {
+		return "", false
+	}
+	cond := e.And[0].Condition[0]
+	if cond.Operand == nil || cond.Operand.ConditionRHS != nil {
+		return "", false
+	}
+	operand := cond.Operand.Operand
+	if len(operand.Right) > 0 || len(operand.Left.Right) > 0 || operand.Left.Left.Primary == nil {
+		return "", false
+	}
+	jpath := operand.Left.Left.Primary.JPathExpr
+	if jpath == nil || len(jpath.PathExpr) > 0 {
+		return "", false
+	}
+	return jpath.BaseKey.String(), true
+}
+
+// analyzeGrouping - validates the GROUP BY, HAVING and ORDER BY
+// clauses and prepares the statement to evaluate them.
+func (e *SelectStatement) analyzeGrouping() error {
+	s := e.selectAST
+	grouped := len(s.GroupBy) > 0
+	if grouped && s.Expression.All {
+		return errors.New("SELECT * cannot be used with GROUP BY")
+	}
+	for _, expr := range s.GroupBy {
+		qp := expr.analyze(s)
+		if qp.err != nil {
+			return fmt.Errorf("GROUP BY clause error: %w", qp.err)
+		}
+		if qp.isAggregation {
+			return errors.New("GROUP BY clause cannot have an aggregation")
+		}
+	}
+
+	aggregated := grouped || e.selectQProp.isAggregation
+	if s.Having != nil {
+		if !aggregated {
+			return errors.New("HAVING clause requires GROUP BY or an aggregation")
+		}
+		qp := s.Having.analyze(s)
+		if qp.err != nil {
+			return fmt.Errorf("HAVING clause error: %w", qp.err)
+		}
+		if qp.isRowFunc {
+			return errors.New("HAVING clause cannot refer to columns without GROUP BY")
+		}
+	}
+
+	e.orderAliases = make([]int, len(s.OrderBy))
+	for i, term := range s.OrderBy {
+		e.orderAliases[i] = -1
+		if name, ok := bareIdentifier(term.Expression); ok {
+			for j, expr := range s.Expression.Expressions {
+				if expr.As == name {
+					e.orderAliases[i] = j
+					break
+				}
+			}
+			if e.orderAliases[i] >= 0 {
+				continue
+			}
+		}
+
+		qp := term.Expression.analyze(s)
+		switch {
+		case qp.err != nil:
+			return fmt.Errorf("ORDER BY clause error: %w", qp.err)
+		case qp.isAggregation && !aggregated:
+			return errors.New("ORDER BY clause cannot have an aggregation without GROUP BY")
+		case qp.isRowFunc && aggregated:
+			return errors.New("ORDER BY clause cannot refer to columns without GROUP BY")
+		}
+	}
+
+	if aggregated {
+		e.aggregates = collectAggregates(reflect.ValueOf(s.Expression))
+		e.aggregates = append(e.aggregates, collectAggregates(reflect.ValueOf(s.Having))...)
+		e.aggregates = append(e.aggregates, collectAggregates(reflect.ValueOf(s.OrderBy))...)
+		e.groups = make(map[string]*group)
+	}
+	return nil
+}
+
+// SetMemoryLimit - sets the limit of the memory held to group and
+// sort the result of the query.
+func (e *SelectStatement) SetMemoryLimit(limit int64) {
+	e.memoryLimit = limit
+}
+
+// IsOrdered returns if the statement has an ORDER BY clause, the
+// result can only be returned once all input records are processed.
+func (e *SelectStatement) IsOrdered() bool {
+	return len(e.selectAST.OrderBy) > 0
+}
+
+func (e *SelectStatement) useMemory(n int64) error {
+	e.memoryUsed += n
+	limit := e.memoryLimit
+	if limit <= 0 {
+		limit = DefaultMemoryLimit
+	}
+	if e.memoryUsed > limit {
+		return errOverMaxMemory(limit)
+	}
+	return nil
+}
+
+func (e *SelectStatement) newGroup(row Record) *group {
+	g := &group{row: row, aggs: make([]*aggVal, len(e.aggregates))}
+	for i, agg := range e.aggregates {
+		g.aggs[i] = newAggVal(agg.getFunctionName())
+	}
+	e.groupOrder = append(e.groupOrder, g)
+	return g
+}
+
+// bind - makes the aggregation functions use the state of the group.
+func (e *SelectStatement) bind(g *group) {
+	for i, agg := range e.aggregates {
+		agg.aggregate = g.aggs[i]
+	}
+}
+
+func (g *group) distinctSize() (n int64) {
+	for _, agg := range g.aggs {
+		n += agg.distinctSize
+	}
+	return n
+}
+
+// findGroup - returns the group of the input record, creating it if
+// needed.
+func (e *SelectStatement) findGroup(input Record) (*group, error) {
+	if len(e.selectAST.GroupBy) == 0 {
+		if len(e.groupOrder) == 0 {
+			e.newGroup(nil)
+		}
+		return e.groupOrder[0], nil
+	}
+
+	var key strings.Builder
+	for _, expr := range e.selectAST.GroupBy {
+		v, err := expr.evalNode(input, e.tableAlias)
+		if err != nil {
+			return nil, err
+		}
+		key.WriteString(valueKey(v))
+		key.WriteByte(0)
+	}
+	if g, ok := e.groups[key.String()]; ok {
+		return g, nil
+	}
+
+	row := input.Clone(nil)
+	if err := e.useMemory(int64(key.Len()) + recordSize(row) + groupOverhead + int64(len(e.aggregates))*rowOverhead); err != nil {
+		return nil, err
+	}
+	g := e.newGroup(row)
+	e.groups[key.String()] = g
+	return g, nil
+}
+
+// orderKeys - returns the values the ORDER BY clause sorts a row by.
+func (e *SelectStatement) orderKeys(input Record, values []*Value) ([]*Value, error) {
+	keys := make([]*Value, len(e.selectAST.OrderBy))
+	for i, term := range e.selectAST.OrderBy {
+		if j := e.orderAliases[i]; j >= 0 {
+			keys[i] = values[j]
+			continue
+		}
+		v, err := term.Expression.evalNode(input, e.tableAlias)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = v
+	}
+	return keys, nil
+}
+
+// compareValues - orders values for ORDER BY. NULL sorts first, values
+// which can not be compared are ordered by their type.
+func compareValues(a, b *Value) int {
+	switch an, bn := a.IsNull(), b.IsNull(); {
+	case an && bn:
+		return 0
+	case an:
+		return -1
+	case bn:
+		return 1
+	}
+
+	// Comparison may infer the types of untyped values, so
+	// compare copies.
+	for _, c := range []struct {
+		op     string
+		result int
+	}{{opLt, -1}, {opGt, 1}, {opEq, 0}} {
+		x, y := *a, *b
+		if ok, err := x.compareOp(c.op, &y); err == nil && ok {
+			return c.result
+		}
+	}
+	if c := strings.Compare(a.GetTypeString(), b.GetTypeString()); c != 0 {
+		return c
+	}
+	return strings.Compare(a.CSVString(), b.CSVString())
+}
+
+// sortRows - sorts rows as per the ORDER BY clause.
+func (e *SelectStatement) sortRows(rows []*orderedRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, term := range e.selectAST.OrderBy {
+			c := compareValues(rows[i].keys[k], rows[j].keys[k])
+			if term.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// OrderRow - evaluates the input record and keeps the result to be
+// sorted. Applies only to non-aggregation queries with ORDER BY.
+func (e *SelectStatement) OrderRow(input, output Record) error {
+	ok, err := e.isPassingWhereClause(input)
+	if err != nil || !ok {
+		return err
+	}
+
+	output, values, err := e.evalOutput(input, output)
+	if err != nil {
+		return err
+	}
+	keys, err := e.orderKeys(input, values)
+	if err != nil {
+		return err
+	}
+	row := &orderedRow{record: output, keys: keys}
+	e.rows = append(e.rows, row)
+	if err = e.useMemory(e.rowSize(row)); err != nil {
+		return err
+	}
+
+	// Only the first rows of the LIMIT clause are returned, so drop
+	// the others from time to time.
+	if e.limitValue >= 0 && int64(len(e.rows)) > 2*e.limitValue {
+		e.sortRows(e.rows)
+		for i := e.limitValue; i < int64(len(e.rows)); i++ {
+			e.memoryUsed -= e.rowSize(e.rows[i])
+			e.rows[i] = nil
+		}
+		e.rows = e.rows[:e.limitValue]
+	}
+	return nil
+}
+
+func (e *SelectStatement) rowSize(row *orderedRow) int64 {
+	n := recordSize(row.record)
+	for _, v := range row.keys {
+		n += int64(len(v.CSVString())) + rowOverhead
+	}
+	return n
+}
+
+// Results - returns the result records once all input records are
+// processed. Applies only to aggregation queries and queries with
+// ORDER BY, newRecord returns an empty output record.
+func (e *SelectStatement) Results(newRecord func() Record) ([]Record, error) {
+	rows := e.rows
+	if e.IsAggregated() {
+		if len(e.selectAST.GroupBy) == 0 && len(e.groupOrder) == 0 {
+			// Aggregations have a result without input rows.
+			e.newGroup(nil)
+		}
+
+		rows = make([]*orderedRow, 0, len(e.groupOrder))
+		for _, g := range e.groupOrder {
+			e.bind(g)
+			if e.selectAST.Having != nil {
+				v, err := e.selectAST.Having.evalNode(g.row, e.tableAlias)
+				if err != nil {
+					return nil, err
+				}
+				b, ok := v.ToBool()
+				if !ok {
+					return nil, errors.New("HAVING expression did not return bool")
+				}
+				if !b {
+					continue
+				}
+			}
+
+			output, values, err := e.evalOutput(g.row, newRecord())
+			if err != nil {
+				return nil, err
+			}
+			row := &orderedRow{record: output}
+			if e.IsOrdered() {
+				if row.keys, err = e.orderKeys(g.row, values); err != nil {
+					return nil, err
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	if e.IsOrdered() {
+		e.sortRows(rows)
+	}
+	if e.limitValue >= 0 && int64(len(rows)) > e.limitValue {
+		rows = rows[:e.limitValue]
+	}
+
+	records := make([]Record, len(rows))
+	for i, row := range rows {
+		records[i] = row.record
+	}
+	e.outputCount += int64(len(records))
+	return records, nil
+}
@@ -89,9 +89,18 @@ type Select struct {
 	Expression *SelectExpression `parser:"\"SELECT\" @@"`
 	From       *TableExpression  `parser:"\"FROM\" @@"`
 	Where      *Expression       `parser:"( \"WHERE\" @@ )?"`
+	GroupBy    []*Expression     `parser:"( \"GROUP\" \"BY\" @@ ( \",\" @@ )* )?"`
+	Having     *Expression       `parser:"( \"HAVING\" @@ )?"`
+	OrderBy    []*OrderByTerm    `parser:"( \"ORDER\" \"BY\" @@ ( \",\" @@ )* )?"`
 	Limit      *LitValue         `parser:"( \"LIMIT\" @@ )?"`
 }
 
+// OrderByTerm represents an expression of the ORDER BY clause
+type OrderByTerm struct {
+	Expression *Expression `parser:"@@"`
+	Desc       bool        `parser:"( @\"DESC\" | \"ASC\" )?"`
+}
+
 // SelectExpression represents the items requested in the select
 // statement
 type SelectExpression struct {
@@ -288,8 +297,9 @@ type SimpleArgFunc struct {
 
 // CountFunc represents the COUNT sql function
 type CountFunc struct {
-	StarArg bool        `parser:" \"COUNT\" \"(\" ( @\"*\"?"`
-	ExprArg *Expression `parser:" @@? )! \")\""`
+	StarArg  bool        `parser:" \"COUNT\" \"(\" ( @\"*\"?"`
+	Distinct bool        `parser:" @\"DISTINCT\"?"`
+	ExprArg  *Expression `parser:" @@? )! \")\""`
 }
 
 // CastFunc represents CAST sql function
@@ -352,7 +362,7 @@ type Identifier struct {
 var (
 	sqlLexer = lexer.Must(lexer.Regexp(`(\s+)` +
 		`|(?P<Timeword>(?i)\b(?:YEAR|MONTH|DAY|HOUR|MINUTE|SECOND|TIMEZONE_HOUR|TIMEZONE_MINUTE)\b)` +
-		`|(?P<Keyword>(?i)\b(?:SELECT|FROM|TOP|DISTINCT|ALL|WHERE|GROUP|BY|HAVING|UNION|MINUS|EXCEPT|INTERSECT|ORDER|LIMIT|OFFSET|TRUE|FALSE|NULL|IS|NOT|ANY|SOME|BETWEEN|AND|OR|LIKE|ESCAPE|AS|IN|BOOL|INT|INTEGER|STRING|FLOAT|DECIMAL|NUMERIC|TIMESTAMP|AVG|COUNT|MAX|MIN|SUM|COALESCE|NULLIF|CAST|DATE_ADD|DATE_DIFF|EXTRACT|TO_STRING|TO_TIMESTAMP|UTCNOW|CHAR_LENGTH|CHARACTER_LENGTH|LOWER|SUBSTRING|TRIM|UPPER|LEADING|TRAILING|BOTH|FOR)\b)` +
+		`|(?P<Keyword>(?i)\b(?:SELECT|FROM|TOP|DISTINCT|ALL|WHERE|GROUP|BY|HAVING|UNION|MINUS|EXCEPT|INTERSECT|ORDER|ASC|DESC|LIMIT|OFFSET|TRUE|FALSE|NULL|IS|NOT|ANY|SOME|BETWEEN|AND|OR|LIKE|ESCAPE|AS|IN|BOOL|INT|INTEGER|STRING|FLOAT|DECIMAL|NUMERIC|TIMESTAMP|AVG|COUNT|MAX|MIN|SUM|COALESCE|NULLIF|CAST|DATE_ADD|DATE_DIFF|EXTRACT|TO_STRING|TO_TIMESTAMP|UTCNOW|CHAR_LENGTH|CHARACTER_LENGTH|LOWER|SUBSTRING|TRIM|UPPER|LEADING|TRAILING|BOTH|FOR)\b)` +
 		`|(?P<Ident>[a-zA-Z_][a-zA-Z0-9_]*)` +
 		`|(?P<QuotIdent>"([^"]*("")?)*")` +
 		`|(?P<Float>\d*\.\d+([eE][-+]?\d+)?)` +
@@ -357,6 +357,7 @@ func TestSelectParsing(t *testing.T) {
 		"select * from s3object where name > 2 or value > 1 or word > 2",
 		"select s.word.id + 2 from s3object s",
 		"select 1-2-3 from s3object s limit 1",
+		"select s.a, count(distinct s.b) from s3object s group by s.a having count(*) > 1 order by s.a desc, s.b asc limit 10",
 	}
 	for i, tc := range cases {
 		err := p.ParseString(tc, &s)
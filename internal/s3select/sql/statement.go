@@ -20,6 +20,7 @@ package sql
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/bcicen/jstream"
@@ -50,6 +51,24 @@ type SelectStatement struct {
 
 	// Table alias
 	tableAlias string
+
+	// Aggregation functions of the statement, bound to the state
+	// of the group being processed.
+	aggregates []*FuncExpr
+
+	// Index of the select expression each ORDER BY term refers to
+	// by its alias, or -1.
+	orderAliases []int
+
+	// Groups by their key, and in order of creation.
+	groups     map[string]*group
+	groupOrder []*group
+
+	// Rows to be sorted by the ORDER BY clause.
+	rows []*orderedRow
+
+	// Estimated memory held by groups and rows, and its limit.
+	memoryUsed, memoryLimit int64
 }
 
 // ParseSelectStatement - parses a select query from the given string
@@ -110,6 +129,13 @@ func ParseSelectStatement(s string) (stmt SelectStatement, err error) {
 	err = stmt.selectQProp.err
 	if err != nil {
 		err = errQueryAnalysisFailure(err)
+		return
+	}
+
+	// Analyze group by, having and order by clauses
+	if err = stmt.analyzeGrouping(); err != nil {
+		err = errQueryAnalysisFailure(err)
+		return
 	}
 
 	// Set table alias
@@ -226,27 +252,82 @@ func (e *SelectStatement) EvalFrom(format string, input Record) ([]*Record, erro
 
 // IsAggregated returns if the statement involves SQL aggregation
 func (e *SelectStatement) IsAggregated() bool {
-	return e.selectQProp.isAggregation
+	return e.selectQProp.isAggregation || len(e.selectAST.GroupBy) > 0
 }
 
-// AggregateResult - returns the aggregated result after all input
-// records have been processed. Applies only to aggregation queries.
-func (e *SelectStatement) AggregateResult(output Record) error {
-	for i, expr := range e.selectAST.Expression.Expressions {
-		v, err := expr.evalNode(nil, e.tableAlias)
-		if err != nil {
-			return err
+// Columns - returns the top level columns referenced by the
+// statement, so readers of columnar formats only read these. If all
+// columns may be needed, as for `SELECT *` or a FROM clause with a
+// keypath, nil is returned.
+func (e *SelectStatement) Columns() []string {
+	if e.selectAST.Expression.All || e.selectAST.From.HasKeypath() {
+		return nil
+	}
+
+	alias := e.tableAlias
+	if alias == "" {
+		alias = baseTableName
+	}
+	columns := []string{}
+	all := false
+	walkJSONPaths(reflect.ValueOf(e.selectAST), func(p *JSONPath) {
+		if p == e.selectAST.From.Table {
+			return
 		}
-		if expr.As != "" {
-			output, err = output.Set(expr.As, v)
-		} else {
-			output, err = output.Set(fmt.Sprintf("_%d", i+1), v)
+		pathExpr := p.StripTableAlias(alias)
+		if len(pathExpr) == 0 || pathExpr[0].Key == nil {
+			all = true
+			return
 		}
-		if err != nil {
-			return err
+		columns = append(columns, pathExpr[0].Key.keyString())
+	})
+	if all {
+		return nil
+	}
+	return columns
+}
+
+// Predicates - returns comparisons of top level columns with literals
+// which hold for every row passing the WHERE clause.
+func (e *SelectStatement) Predicates() []Predicate {
+	if e.selectAST.Where == nil || e.selectAST.From.HasKeypath() {
+		return nil
+	}
+
+	alias := e.tableAlias
+	if alias == "" {
+		alias = baseTableName
+	}
+	return e.selectAST.Where.predicates(alias)
+}
+
+var jsonPathType = reflect.TypeOf(&JSONPath{})
+
+// walkJSONPaths - calls fn for every keypath of the AST.
+func walkJSONPaths(v reflect.Value, fn func(*JSONPath)) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		if v.Type() == jsonPathType {
+			fn(v.Interface().(*JSONPath))
+			return
+		}
+		walkJSONPaths(v.Elem(), fn)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			// Unexported fields hold analysis results, not AST nodes.
+			if v.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			walkJSONPaths(v.Field(i), fn)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walkJSONPaths(v.Index(i), fn)
 		}
 	}
-	return nil
 }
 
 func (e *SelectStatement) isPassingWhereClause(input Record) (bool, error) {
@@ -278,13 +359,30 @@ func (e *SelectStatement) AggregateRow(input Record) error {
 		return nil
 	}
 
+	g, err := e.findGroup(input)
+	if err != nil {
+		return err
+	}
+	e.bind(g)
+	distinctSize := g.distinctSize()
+
 	for _, expr := range e.selectAST.Expression.Expressions {
 		err := expr.aggregateRow(input, e.tableAlias)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	if e.selectAST.Having != nil {
+		if err = e.selectAST.Having.aggregateRow(input, e.tableAlias); err != nil {
+			return err
+		}
+	}
+	for _, term := range e.selectAST.OrderBy {
+		if err = term.Expression.aggregateRow(input, e.tableAlias); err != nil {
+			return err
+		}
+	}
+	return e.useMemory(g.distinctSize() - distinctSize)
 }
 
 // Eval - evaluates the Select statement for the given record. It
@@ -297,21 +395,34 @@ func (e *SelectStatement) Eval(input, output Record) (Record, error) {
 		return nil, err
 	}
 
+	output, _, err = e.evalOutput(input, output)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update count of records output.
+	e.outputCount++
+
+	return output, nil
+}
+
+// evalOutput - evaluates the select expressions on the input record
+// and returns the output record along with the values of the
+// expressions.
+func (e *SelectStatement) evalOutput(input, output Record) (Record, []*Value, error) {
 	if e.selectAST.Expression.All {
 		// Return the input record for `SELECT * FROM
 		// .. WHERE ..`
-
-		// Update count of records output.
-		e.outputCount++
-
-		return input.Clone(output), nil
+		return input.Clone(output), nil, nil
 	}
 
+	values := make([]*Value, len(e.selectAST.Expression.Expressions))
 	for i, expr := range e.selectAST.Expression.Expressions {
 		v, err := expr.evalNode(input, e.tableAlias)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		values[i] = v
 
 		// Pick output column names
 		if expr.As != "" {
@@ -322,20 +433,17 @@ func (e *SelectStatement) Eval(input, output Record) (Record, error) {
 			output, err = output.Set(fmt.Sprintf("_%d", i+1), v)
 		}
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
-
-	// Update count of records output.
-	e.outputCount++
-
-	return output, nil
+	return output, values, nil
 }
 
 // LimitReached - returns true if the number of records output has
 // reached the value of the `LIMIT` clause.
 func (e *SelectStatement) LimitReached() bool {
-	if e.limitValue == -1 {
+	if e.limitValue == -1 || e.IsOrdered() {
+		// Sorted rows are limited once all rows are processed.
 		return false
 	}
 	return e.outputCount >= e.limitValue
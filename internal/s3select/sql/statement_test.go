@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSelectStatementColumns(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected []string
+	}{
+		{"SELECT * FROM S3Object", nil},
+		{"SELECT s.* FROM S3Object s", nil},
+		{"SELECT a, b FROM S3Object", []string{"a", "b"}},
+		{"SELECT s.a FROM S3Object s WHERE s.c.d > 10", []string{"a", "c"}},
+		{"SELECT UPPER(name) AS n FROM S3Object WHERE CAST(age AS INT) > 30", []string{"name", "age"}},
+		{"SELECT COUNT(*) FROM S3Object", []string{}},
+		{"SELECT SUM(s.price) FROM S3Object s WHERE s['region'] = 'eu'", []string{"price", "region"}},
+		{"SELECT a FROM S3Object[*].items", nil},
+	}
+
+	for i, testCase := range testCases {
+		stmt, err := ParseSelectStatement(testCase.query)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if got := stmt.Columns(); !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestSelectStatementPredicates(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected []string
+	}{
+		{"SELECT * FROM S3Object", nil},
+		{"SELECT * FROM S3Object WHERE a > 10", []string{"a > 10"}},
+		{"SELECT * FROM S3Object s WHERE 10 <= s.a AND s.b = 'x'", []string{"a >= 10", "b = \"x\""}},
+		{"SELECT * FROM S3Object WHERE a BETWEEN -1.5 AND 2", []string{"a >= -1.5", "a <= 2"}},
+		{"SELECT * FROM S3Object WHERE (a < 5 AND b > 1) AND c LIKE 'x%'", []string{"a < 5", "b > 1"}},
+		{"SELECT * FROM S3Object WHERE a > 10 OR b < 5", nil},
+		{"SELECT * FROM S3Object s WHERE s.a + 1 > 10 AND s.a.b > 1 AND s.a <> 3 AND NOT s.a = 1 AND s.a = s.b", nil},
+	}
+
+	for i, testCase := range testCases {
+		stmt, err := ParseSelectStatement(testCase.query)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		var got []string
+		for _, p := range stmt.Predicates() {
+			got = append(got, p.Column+" "+p.Operator+" "+p.Value.String())
+		}
+		if !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestSelectStatementGrouping(t *testing.T) {
+	testCases := []struct {
+		query string
+		valid bool
+	}{
+		{"SELECT s.a, COUNT(*) FROM S3Object s GROUP BY s.a", true},
+		{"SELECT s.a, s.b, SUM(s.c) FROM S3Object s GROUP BY s.a, s.b HAVING SUM(s.c) > 1 ORDER BY s.b DESC", true},
+		{"SELECT COUNT(DISTINCT s.a) AS n FROM S3Object s ORDER BY n", true},
+		{"SELECT s.a FROM S3Object s ORDER BY s.b LIMIT 3", true},
+		{"SELECT s.a, COUNT(*) FROM S3Object s", false},
+		{"SELECT * FROM S3Object s GROUP BY s.a", false},
+		{"SELECT COUNT(*) FROM S3Object s GROUP BY COUNT(*)", false},
+		{"SELECT s.a FROM S3Object s HAVING s.a > 1", false},
+		{"SELECT COUNT(*) FROM S3Object s HAVING s.a > 1", false},
+		{"SELECT s.a FROM S3Object s ORDER BY COUNT(*)", false},
+		{"SELECT COUNT(*) FROM S3Object s ORDER BY s.a", false},
+		{"SELECT COUNT(DISTINCT *) FROM S3Object s", false},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseSelectStatement(testCase.query)
+		if valid := err == nil; valid != testCase.valid {
+			t.Errorf("Test %d: %s: expected valid %v, got error %v", i+1, testCase.query, testCase.valid, err)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package typed
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/bcicen/jstream"
+	csv "github.com/minio/csvparser"
+	jsonfmt "github.com/minio/minio/internal/s3select/json"
+	"github.com/minio/minio/internal/s3select/sql"
+)
+
+// Column - is a named value of a record. Value is one of nil,
+// bool, int64, float64 or string.
+type Column struct {
+	Name  string
+	Value interface{}
+}
+
+// Record - is a record keeping the type of its values, used by
+// output formats which need a schema like Parquet and Avro.
+type Record struct {
+	Columns []Column
+}
+
+// Get - gets the value for a column name.
+func (r *Record) Get(name string) (*sql.Value, error) {
+	// Get is implemented directly in the sql package.
+	return nil, errors.New("not implemented here")
+}
+
+// Set - sets the value for a column name.
+func (r *Record) Set(name string, value *sql.Value) (sql.Record, error) {
+	var v interface{}
+	if b, ok := value.ToBool(); ok {
+		v = b
+	} else if i, ok := value.ToInt(); ok {
+		v = i
+	} else if f, ok := value.ToFloat(); ok {
+		v = f
+	} else if t, ok := value.ToTimestamp(); ok {
+		v = sql.FormatSQLTimestamp(t)
+	} else if s, ok := value.ToString(); ok {
+		v = s
+	} else if value.IsNull() {
+		v = nil
+	} else if b, ok := value.ToBytes(); ok {
+		v = string(b)
+	} else if arr, ok := value.ToArray(); ok {
+		b, err := json.Marshal(arr)
+		if err != nil {
+			return nil, err
+		}
+		v = string(b)
+	} else {
+		return nil, fmt.Errorf("unsupported sql value %v and type %v", value, value.GetTypeString())
+	}
+
+	r.Columns = append(r.Columns, Column{Name: name, Value: v})
+	return r, nil
+}
+
+// WriteCSV - encodes to CSV data.
+func (r *Record) WriteCSV(writer io.Writer, opts sql.WriteCSVOpts) error {
+	csvRecord := make([]string, 0, len(r.Columns))
+	for _, c := range r.Columns {
+		s, _ := String.convert(c.Value)
+		if s == nil {
+			s = ""
+		}
+		csvRecord = append(csvRecord, s.(string))
+	}
+
+	w := csv.NewWriter(writer)
+	w.Comma = opts.FieldDelimiter
+	w.Quote = opts.Quote
+	w.AlwaysQuote = opts.AlwaysQuote
+	w.QuoteEscape = opts.QuoteEscape
+	if err := w.Write(csvRecord); err != nil {
+		return err
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// WriteJSON - encodes to JSON data.
+func (r *Record) WriteJSON(writer io.Writer) error {
+	kvs := make(jstream.KVS, 0, len(r.Columns))
+	for _, c := range r.Columns {
+		kvs = append(kvs, jstream.KV{Key: c.Name, Value: c.Value})
+	}
+	return json.NewEncoder(writer).Encode(kvs)
+}
+
+// Clone the record and if possible use the destination provided.
+func (r *Record) Clone(dst sql.Record) sql.Record {
+	other, ok := dst.(*Record)
+	if !ok {
+		other = &Record{}
+	}
+	other.Columns = append(other.Columns[:0], r.Columns...)
+	return other
+}
+
+// Reset the record.
+func (r *Record) Reset() {
+	r.Columns = r.Columns[:0]
+}
+
+// Raw - returns the underlying representation.
+func (r *Record) Raw() (sql.SelectObjectFormat, interface{}) {
+	return sql.SelectFmtUnknown, r.Columns
+}
+
+// Replace the underlying columns.
+func (r *Record) Replace(k interface{}) error {
+	columns, ok := k.([]Column)
+	if !ok {
+		return fmt.Errorf("cannot replace internal data in typed record with type %T", k)
+	}
+	r.Columns = columns
+	return nil
+}
+
+// NewRecord - creates new empty typed record.
+func NewRecord() *Record {
+	return &Record{}
+}
+
+// FromRecord - returns the typed columns of any select record. Records
+// returned as is by `SELECT *` keep the representation of their input
+// format and are converted here.
+func FromRecord(rec sql.Record) (*Record, error) {
+	if r, ok := rec.(*Record); ok {
+		return r, nil
+	}
+
+	var kvs jstream.KVS
+	switch format, raw := rec.Raw(); format {
+	case sql.SelectFmtJSON, sql.SelectFmtParquet, sql.SelectFmtORC, sql.SelectFmtAvro:
+		kvs, _ = raw.(jstream.KVS)
+	default:
+		// Other records only expose their values as JSON.
+		var buf bytes.Buffer
+		if err := rec.WriteJSON(&buf); err != nil {
+			return nil, err
+		}
+		v := <-jstream.NewDecoder(&buf, 0).ObjectAsKVS().Stream()
+		if v == nil {
+			return nil, errors.New("unable to decode record")
+		}
+		kvs, _ = v.Value.(jstream.KVS)
+	}
+
+	r := &Record{Columns: make([]Column, 0, len(kvs))}
+	for _, kv := range kvs {
+		var v interface{}
+		switch val := kv.Value.(type) {
+		case nil, bool, int64, float64, string:
+			v = val
+		case jsonfmt.RawJSON:
+			v = string(val)
+		default:
+			b, err := json.Marshal(val)
+			if err != nil {
+				return nil, err
+			}
+			v = string(b)
+		}
+		r.Columns = append(r.Columns, Column{Name: kv.Key, Value: v})
+	}
+	return r, nil
+}
+
+// formatFloat formats floats the same way as JSON output does.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
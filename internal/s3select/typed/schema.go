@@ -0,0 +1,193 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package typed
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Type - is the type of a schema field.
+type Type int
+
+// Supported field types, a field holding values of several types
+// is widened to Float for numbers and to String otherwise.
+const (
+	Null Type = iota
+	Bool
+	Int
+	Float
+	String
+)
+
+func (t Type) String() string {
+	switch t {
+	case Null:
+		return "null"
+	case Bool:
+		return "boolean"
+	case Int:
+		return "int"
+	case Float:
+		return "float"
+	case String:
+		return "string"
+	}
+	return "unknown"
+}
+
+func typeOf(v interface{}) Type {
+	switch v.(type) {
+	case nil:
+		return Null
+	case bool:
+		return Bool
+	case int64:
+		return Int
+	case float64:
+		return Float
+	}
+	return String
+}
+
+// widen - returns a type which can hold values of both types.
+func (t Type) widen(o Type) Type {
+	switch {
+	case t == o || o == Null:
+		return t
+	case t == Null:
+		return o
+	case (t == Int && o == Float) || (t == Float && o == Int):
+		return Float
+	}
+	return String
+}
+
+// convert - converts a column value to this type.
+func (t Type) convert(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch t {
+	case Bool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case Int:
+		if i, ok := v.(int64); ok {
+			return i, nil
+		}
+	case Float:
+		switch x := v.(type) {
+		case float64:
+			return x, nil
+		case int64:
+			return float64(x), nil
+		}
+	case String:
+		switch x := v.(type) {
+		case string:
+			return x, nil
+		case bool:
+			return strconv.FormatBool(x), nil
+		case int64:
+			return strconv.FormatInt(x, 10), nil
+		case float64:
+			return formatFloat(x), nil
+		}
+	}
+	return nil, fmt.Errorf("cannot convert %T value to %s", v, t)
+}
+
+// Field - is a named and typed field of a schema.
+type Field struct {
+	Name string
+	Type Type
+}
+
+// Schema - is the ordered list of fields of the output records.
+type Schema struct {
+	Fields []Field
+	index  map[string]int
+}
+
+// InferSchema - infers the schema from records, fields are kept in
+// the order they first appear. Fields which are always null are
+// typed as strings.
+func InferSchema(records []*Record) Schema {
+	s := Schema{index: make(map[string]int)}
+	for _, r := range records {
+		for _, c := range r.Columns {
+			i, ok := s.index[c.Name]
+			if !ok {
+				i = len(s.Fields)
+				s.index[c.Name] = i
+				s.Fields = append(s.Fields, Field{Name: c.Name})
+			}
+			s.Fields[i].Type = s.Fields[i].Type.widen(typeOf(c.Value))
+		}
+	}
+	for i := range s.Fields {
+		if s.Fields[i].Type == Null {
+			s.Fields[i].Type = String
+		}
+	}
+	return s
+}
+
+// Values - returns the values of a record in schema order, converted
+// to the field types. Fields missing in the record are null.
+func (s Schema) Values(r *Record) ([]interface{}, error) {
+	values := make([]interface{}, len(s.Fields))
+	for _, c := range r.Columns {
+		i, ok := s.index[c.Name]
+		if !ok {
+			return nil, fmt.Errorf("column %s is not part of the output schema", c.Name)
+		}
+		v, err := s.Fields[i].Type.convert(c.Value)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", c.Name, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// FieldName - returns a name only made of letters, digits and
+// underscores, not starting with a digit, as required by the
+// Parquet and Avro schemas.
+func FieldName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteByte('_')
+			}
+		default:
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "_"
+	}
+	return b.String()
+}